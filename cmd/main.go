@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"os"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -15,6 +16,7 @@ import (
 	"spot-assistant/internal/infrastructure/bot"
 	"spot-assistant/internal/infrastructure/chart"
 	"spot-assistant/internal/infrastructure/db/postgresql"
+	policyRepository "spot-assistant/internal/infrastructure/policy/postgresql/sqlc"
 	reservationRepository "spot-assistant/internal/infrastructure/reservation/postgresql/sqlc"
 	spotRepository "spot-assistant/internal/infrastructure/spot/postgresql/sqlc"
 )
@@ -43,13 +45,24 @@ func main() {
 	// Infrastructure
 	reservationRepo := reservationRepository.NewReservationRepository(db)
 	spotRepo := spotRepository.NewSpotRepository(db)
+	policyRepo := policyRepository.NewPolicyRepository(db)
 	charter := chart.NewAdapter()
 
 	// Core
 	summaryService := summary.NewAdapter(charter)
-	bookingService := booking.NewAdapter(spotRepo, reservationRepo)
+	bookingService := booking.NewAdapter(spotRepo, reservationRepo, policyRepo)
 	api := api.NewApplication(reservationRepo, summaryService, bookingService)
 
+	// Administrative subcommands, e.g. "spots import", run instead of the bot
+	if len(os.Args) > 1 {
+		err = runCommand(api, os.Args[1:])
+		if err != nil {
+			logrus.Fatal(err)
+		}
+
+		return
+	}
+
 	// Inverted flow - our port, "input"
 	// (but also an adapter for operations)
 	bot := bot.NewManager(api)
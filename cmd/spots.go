@@ -0,0 +1,102 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/spot"
+	"spot-assistant/internal/ports"
+)
+
+const usage = `usage:
+  spot-assistant-bot spots export [-guild ID] [-format json|csv] [-output FILE]
+  spot-assistant-bot spots import [-guild ID] [-apply] FILE
+
+Spots of the shared catalog are exported and imported, unless guild ID is given.
+Import only shows the changes, unless -apply is given.`
+
+// Runs an administrative subcommand.
+func runCommand(app ports.APIPort, args []string) error {
+	if len(args) < 2 || args[0] != "spots" {
+		return fmt.Errorf("unknown command\n%s", usage)
+	}
+
+	switch args[1] {
+	case "export":
+		return exportSpots(app, args[2:])
+	case "import":
+		return importSpots(app, args[2:])
+	default:
+		return fmt.Errorf("unknown spots subcommand %s\n%s", args[1], usage)
+	}
+}
+
+func exportSpots(app ports.APIPort, args []string) error {
+	flags := flag.NewFlagSet("spots export", flag.ExitOnError)
+	guildID := flags.String("guild", "", "ID of the guild, which spots shall be exported")
+	format := flags.String("format", string(spot.FormatJSON), "format of the catalog, json or csv")
+	output := flags.String("output", "", "catalog file, defaults to the standard output")
+	_ = flags.Parse(args)
+
+	entries, err := app.OnSpotExport(spot.ExportRequest{
+		Guild: &discord.Guild{ID: *guildID},
+	})
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if len(*output) > 0 {
+		f, err := os.Create(*output)
+		if err != nil {
+			return fmt.Errorf("could not create the catalog file: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	return spot.WriteCatalog(w, spot.Format(*format), entries)
+}
+
+func importSpots(app ports.APIPort, args []string) error {
+	flags := flag.NewFlagSet("spots import", flag.ExitOnError)
+	guildID := flags.String("guild", "", "ID of the guild, which spots shall be imported")
+	apply := flags.Bool("apply", false, "apply the changes, instead of only showing them")
+	_ = flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		return fmt.Errorf("catalog file is required\n%s", usage)
+	}
+
+	format, err := spot.FormatFromFileName(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(flags.Arg(0))
+	if err != nil {
+		return fmt.Errorf("could not open the catalog file: %w", err)
+	}
+	defer f.Close()
+
+	entries, err := spot.ReadCatalog(f, format)
+	if err != nil {
+		return err
+	}
+
+	result, err := app.OnSpotImport(spot.ImportRequest{
+		Guild:   &discord.Guild{ID: *guildID},
+		Entries: entries,
+		DryRun:  !*apply,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(result)
+
+	return nil
+}
@@ -1,11 +1,42 @@
 package strings
 
-import "strconv"
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
 
 const DC_TIME_FORMAT = "15:04"
 
 const DC_LONG_TIME_FORMAT = "2006-01-02 15:04"
 
+const DC_DATE_FORMAT = "2006-01-02"
+
+const LONG_TIME_FORMAT = "2006-01-02 15:04 MST"
+
+// FormatDcTime formats time as a Discord timestamp showing hour,
+// which every viewer sees in their own time zone.
+func FormatDcTime(t time.Time) string {
+	return fmt.Sprintf("<t:%d:t>", t.Unix())
+}
+
+// FormatDcLongTime formats time as a Discord timestamp showing date and hour,
+// which every viewer sees in their own time zone.
+func FormatDcLongTime(t time.Time) string {
+	return fmt.Sprintf("<t:%d:f>", t.Unix())
+}
+
+// FormatLongTime formats time as plain text showing date, hour and time zone abbreviation, for places
+// where Discord timestamps are not rendered, such as code blocks.
+func FormatLongTime(t time.Time) string {
+	return t.Format(LONG_TIME_FORMAT)
+}
+
+var weekdaySeparatorRegex = regexp.MustCompile(`[\s,/;]+`)
+
 func StrToInt64(i string) (int64, error) {
 	id, err := strconv.ParseInt(i, 10, 0)
 	if err != nil {
@@ -14,3 +45,120 @@ func StrToInt64(i string) (int64, error) {
 
 	return id, nil
 }
+
+// ParseWeekdays parses a list of weekdays separated by commas, slashes or spaces,
+// such as "Mon/Wed/Fri" or "monday, tuesday". Returned weekdays are unique
+// and ordered from Monday to Sunday.
+func ParseWeekdays(input string) ([]time.Weekday, error) {
+	weekdays := make([]time.Weekday, 0, 7)
+
+	for _, name := range weekdaySeparatorRegex.Split(strings.ToLower(strings.TrimSpace(input)), -1) {
+		if len(name) == 0 {
+			continue
+		}
+
+		weekday, ok := parseWeekday(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown weekday: %s", name)
+		}
+
+		if !slices.Contains(weekdays, weekday) {
+			weekdays = append(weekdays, weekday)
+		}
+	}
+
+	if len(weekdays) == 0 {
+		return nil, fmt.Errorf("at least one weekday is required")
+	}
+
+	slices.SortFunc(weekdays, func(a, b time.Weekday) int {
+		return mondayFirst(a) - mondayFirst(b)
+	})
+
+	return weekdays, nil
+}
+
+// FormatWeekdays formats weekdays using their short names, e.g. "Mon/Wed/Fri".
+func FormatWeekdays(weekdays []time.Weekday) string {
+	names := make([]string, len(weekdays))
+	for i, weekday := range weekdays {
+		names[i] = weekday.String()[:3]
+	}
+
+	return strings.Join(names, "/")
+}
+
+// ParseClock parses time of day in DC_TIME_FORMAT and returns it as an offset from midnight.
+func ParseClock(input string) (time.Duration, error) {
+	clock, err := time.Parse(DC_TIME_FORMAT, input)
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute, nil
+}
+
+// FormatClock formats an offset from midnight using DC_TIME_FORMAT.
+func FormatClock(d time.Duration) string {
+	return time.Time{}.Add(d).Format(DC_TIME_FORMAT)
+}
+
+// FormatDuration formats duration without trailing zero units, e.g. "3h" or "1h30m".
+func FormatDuration(d time.Duration) string {
+	formatted := strings.TrimSuffix(d.String(), "0s")
+	if strings.HasSuffix(formatted, "h0m") {
+		formatted = strings.TrimSuffix(formatted, "0m")
+	}
+
+	if len(formatted) == 0 {
+		return "0s"
+	}
+
+	return formatted
+}
+
+// EditDistance returns the number of single rune insertions, deletions, substitutions
+// and transpositions of adjacent runes needed to turn a into b.
+func EditDistance(a string, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	// Distances for prefixes of a one and two runes shorter than the current one
+	prevPrev := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				curr[j] = min(curr[j], prevPrev[j-2]+1)
+			}
+		}
+		prevPrev, prev, curr = prev, curr, prevPrev
+	}
+
+	return prev[len(rb)]
+}
+
+func parseWeekday(name string) (time.Weekday, bool) {
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		fullName := strings.ToLower(weekday.String())
+		if name == fullName || name == fullName[:3] {
+			return weekday, true
+		}
+	}
+
+	return time.Sunday, false
+}
+
+func mondayFirst(weekday time.Weekday) int {
+	return (int(weekday) + 6) % 7
+}
@@ -2,6 +2,7 @@ package strings
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -30,3 +31,138 @@ func TestStrToInt64WithErrorneousInput(t *testing.T) {
 	// assert
 	assert.NotNil(err)
 }
+
+func TestParseWeekdays(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	input := "Fri/mon, Wednesday friday"
+
+	// when
+	res, err := ParseWeekdays(input)
+
+	// assert
+	assert.Nil(err)
+	assert.Equal([]time.Weekday{time.Monday, time.Wednesday, time.Friday}, res)
+}
+
+func TestParseWeekdaysWithErrorneousInput(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	input := "mon/someday"
+
+	// when
+	_, err := ParseWeekdays(input)
+
+	// assert
+	assert.NotNil(err)
+}
+
+func TestParseWeekdaysWithEmptyInput(t *testing.T) {
+	// given
+	assert := assert.New(t)
+
+	// when
+	_, err := ParseWeekdays(" / ")
+
+	// assert
+	assert.NotNil(err)
+}
+
+func TestFormatWeekdays(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	input := []time.Weekday{time.Monday, time.Wednesday, time.Sunday}
+
+	// when
+	res := FormatWeekdays(input)
+
+	// assert
+	assert.Equal("Mon/Wed/Sun", res)
+}
+
+func TestParseClock(t *testing.T) {
+	// given
+	assert := assert.New(t)
+
+	// when
+	res, err := ParseClock("18:30")
+
+	// assert
+	assert.Nil(err)
+	assert.Equal(18*time.Hour+30*time.Minute, res)
+}
+
+func TestParseClockWithErrorneousInput(t *testing.T) {
+	// given
+	assert := assert.New(t)
+
+	// when
+	_, err := ParseClock("25:00")
+
+	// assert
+	assert.NotNil(err)
+}
+
+func TestFormatClock(t *testing.T) {
+	// given
+	assert := assert.New(t)
+
+	// when
+	res := FormatClock(21*time.Hour + 5*time.Minute)
+
+	// assert
+	assert.Equal("21:05", res)
+}
+
+func TestFormatDuration(t *testing.T) {
+	// given
+	assert := assert.New(t)
+
+	// assert
+	assert.Equal("3h", FormatDuration(3*time.Hour))
+	assert.Equal("1h30m", FormatDuration(90*time.Minute))
+	assert.Equal("45m", FormatDuration(45*time.Minute))
+	assert.Equal("10h", FormatDuration(10*time.Hour))
+}
+
+func TestFormatDcTimestamps(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	input := time.Date(2023, 8, 19, 15, 0, 0, 0, time.UTC)
+
+	// when
+	shortRes := FormatDcTime(input)
+	longRes := FormatDcLongTime(input)
+
+	// assert
+	assert.Equal("<t:1692457200:t>", shortRes)
+	assert.Equal("<t:1692457200:f>", longRes)
+}
+
+func TestFormatLongTime(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	loc, _ := time.LoadLocation("Europe/Warsaw")
+	input := time.Date(2026, 10, 17, 18, 30, 0, 0, loc)
+
+	// when
+	res := FormatLongTime(input)
+
+	// assert
+	assert.Equal("2026-10-17 18:30 CEST", res)
+}
+
+func TestEditDistance(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	inputs := [][2]string{{"asura", "asura"}, {"asrua", "asura"}, {"", "abc"}, {"libary", "library"}, {"kitten", "sitting"}}
+
+	// when
+	res := make([]int, len(inputs))
+	for i, input := range inputs {
+		res[i] = EditDistance(input[0], input[1])
+	}
+
+	// assert
+	assert.Equal([]int{0, 1, 3, 1, 3}, res)
+}
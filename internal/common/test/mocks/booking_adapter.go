@@ -6,27 +6,60 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/policy"
 	"spot-assistant/internal/core/dto/reservation"
+	"spot-assistant/internal/core/dto/spot"
 )
 
+// Role checkers cannot be compared, so they are left out of the recorded arguments.
 type MockBookingService struct {
 	mock.Mock
 }
 
-func (a *MockBookingService) Book(m *discord.Member, g *discord.Guild, spotName string, startAt time.Time, endAt time.Time, overbook bool, hasPermissions bool) ([]*reservation.ClippedOrRemovedReservation, error) {
-	args := a.Called(m, g, spotName, startAt, endAt, overbook, hasPermissions)
+func (a *MockBookingService) Book(m *discord.Member, g *discord.Guild, party []*discord.Member, spotName string, startAt time.Time, endAt time.Time, overbook bool, tier policy.Tier, hasRole policy.RoleChecker) ([]*reservation.ClippedOrRemovedReservation, error) {
+	args := a.Called(m, g, party, spotName, startAt, endAt, overbook, tier)
 
 	return args.Get(0).([]*reservation.ClippedOrRemovedReservation), args.Error(1)
 }
 
-func (a *MockBookingService) FindAvailableSpots(filter string) ([]string, error) {
-	args := a.Called(filter)
+func (a *MockBookingService) FindAvailableSpots(g *discord.Guild, filter string) ([]string, error) {
+	args := a.Called(g, filter)
+
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (a *MockBookingService) FindHiddenSpots(g *discord.Guild, filter string) ([]string, error) {
+	args := a.Called(g, filter)
 
 	return args.Get(0).([]string), args.Error(1)
 }
 
-func (a *MockBookingService) GetSuggestedHours(baseTime time.Time, filter string) []string {
-	args := a.Called(baseTime, filter)
+func (a *MockBookingService) GetSuggestedHours(g *discord.Guild, baseTime time.Time, spotName string, filter string) []string {
+	args := a.Called(g, baseTime, spotName, filter)
+
+	return args.Get(0).([]string)
+}
+
+func (a *MockBookingService) FindAlternatives(g *discord.Guild, spotName string, startAt time.Time, endAt time.Time) ([]*reservation.Alternative, error) {
+	args := a.Called(g, spotName, startAt, endAt)
+
+	return args.Get(0).([]*reservation.Alternative), args.Error(1)
+}
+
+func (a *MockBookingService) GetSpot(g *discord.Guild, spotId int64) (*spot.Spot, error) {
+	args := a.Called(g, spotId)
+
+	return args.Get(0).(*spot.Spot), args.Error(1)
+}
+
+func (a *MockBookingService) FindFreeWindows(g *discord.Guild, spotName string, from time.Time, to time.Time) (*spot.Spot, []*reservation.Window, error) {
+	args := a.Called(g, spotName, from, to)
+
+	return args.Get(0).(*spot.Spot), args.Get(1).([]*reservation.Window), args.Error(2)
+}
+
+func (a *MockBookingService) GetSuggestedDates(g *discord.Guild, baseTime time.Time, filter string) []string {
+	args := a.Called(g, baseTime, filter)
 
 	return args.Get(0).([]string)
 }
@@ -42,3 +75,255 @@ func (a *MockBookingService) Unbook(g *discord.Guild, m *discord.Member, reserva
 
 	return args.Get(0).(*reservation.ReservationWithSpot), args.Error(1)
 }
+
+func (a *MockBookingService) CreateSeries(m *discord.Member, g *discord.Guild, spotName string, weekdays []time.Weekday, startTime time.Duration, endTime time.Duration) (*reservation.SeriesWithSpot, error) {
+	args := a.Called(m, g, spotName, weekdays, startTime, endTime)
+
+	return args.Get(0).(*reservation.SeriesWithSpot), args.Error(1)
+}
+
+func (a *MockBookingService) MaterializeSeries(g *discord.Guild, hasRole policy.RoleChecker) ([]*reservation.Reservation, error) {
+	args := a.Called(g)
+
+	return args.Get(0).([]*reservation.Reservation), args.Error(1)
+}
+
+func (a *MockBookingService) GetSuggestedWeekdays(filter string) []string {
+	args := a.Called(filter)
+
+	return args.Get(0).([]string)
+}
+
+func (a *MockBookingService) FindMemberSeries(g *discord.Guild, m *discord.Member, filter string) ([]*reservation.SeriesWithSpot, error) {
+	args := a.Called(g, m, filter)
+
+	return args.Get(0).([]*reservation.SeriesWithSpot), args.Error(1)
+}
+
+func (a *MockBookingService) CancelSeries(g *discord.Guild, m *discord.Member, seriesId int64) (*reservation.SeriesWithSpot, error) {
+	args := a.Called(g, m, seriesId)
+
+	return args.Get(0).(*reservation.SeriesWithSpot), args.Error(1)
+}
+
+func (a *MockBookingService) Enqueue(m *discord.Member, g *discord.Guild, spotName string, startAt time.Time, endAt time.Time) (*reservation.QueueEntryWithSpot, error) {
+	args := a.Called(m, g, spotName, startAt, endAt)
+
+	return args.Get(0).(*reservation.QueueEntryWithSpot), args.Error(1)
+}
+
+func (a *MockBookingService) ProcessQueue(g *discord.Guild, hasRole policy.RoleChecker) ([]*reservation.QueueEntryWithSpot, error) {
+	args := a.Called(g)
+
+	return args.Get(0).([]*reservation.QueueEntryWithSpot), args.Error(1)
+}
+
+func (a *MockBookingService) Apply(m *discord.Member, g *discord.Guild, spotName string, startAt time.Time, endAt time.Time) (*reservation.LotteryApplicationWithSpot, error) {
+	args := a.Called(m, g, spotName, startAt, endAt)
+
+	return args.Get(0).(*reservation.LotteryApplicationWithSpot), args.Error(1)
+}
+
+func (a *MockBookingService) DrawLotteries(g *discord.Guild, hasRole policy.RoleChecker) ([]*reservation.LotteryApplicationWithSpot, error) {
+	args := a.Called(g)
+
+	return args.Get(0).([]*reservation.LotteryApplicationWithSpot), args.Error(1)
+}
+
+func (a *MockBookingService) GetPolicy(g *discord.Guild) (*policy.Policy, error) {
+	args := a.Called(g)
+
+	return args.Get(0).(*policy.Policy), args.Error(1)
+}
+
+func (a *MockBookingService) SavePolicy(p *policy.Policy) (*policy.Policy, error) {
+	args := a.Called(p)
+
+	return args.Get(0).(*policy.Policy), args.Error(1)
+}
+
+func (a *MockBookingService) GetLocation(g *discord.Guild, m *discord.Member) (*time.Location, error) {
+	args := a.Called(g, m)
+
+	return args.Get(0).(*time.Location), args.Error(1)
+}
+
+func (a *MockBookingService) SetMemberTimeZone(g *discord.Guild, m *discord.Member, timeZone string) (*time.Location, error) {
+	args := a.Called(g, m, timeZone)
+
+	return args.Get(0).(*time.Location), args.Error(1)
+}
+
+func (a *MockBookingService) Rebook(member *discord.Member, guild *discord.Guild, reservationId int64, date *time.Time, startTime *time.Duration, endTime *time.Duration, overbook bool, tier policy.Tier) (*reservation.ReservationWithSpot, []*reservation.ClippedOrRemovedReservation, error) {
+	args := a.Called(member, guild, reservationId, date, startTime, endTime, overbook, tier)
+
+	return args.Get(0).(*reservation.ReservationWithSpot), args.Get(1).([]*reservation.ClippedOrRemovedReservation), args.Error(2)
+}
+
+func (a *MockBookingService) CheckTransfer(guild *discord.Guild, from *discord.Member, to *discord.Member, reservationId int64, hasRole policy.RoleChecker) (*reservation.ReservationWithSpot, error) {
+	args := a.Called(guild, from, to, reservationId)
+
+	return args.Get(0).(*reservation.ReservationWithSpot), args.Error(1)
+}
+
+func (a *MockBookingService) Transfer(guild *discord.Guild, from *discord.Member, to *discord.Member, reservationId int64, hasRole policy.RoleChecker) (*reservation.ReservationWithSpot, error) {
+	args := a.Called(guild, from, to, reservationId)
+
+	return args.Get(0).(*reservation.ReservationWithSpot), args.Error(1)
+}
+
+func (a *MockBookingService) AddPartyMember(guild *discord.Guild, author *discord.Member, reservationId int64, partyMember *discord.Member, hasRole policy.RoleChecker) (*reservation.ReservationWithSpot, error) {
+	args := a.Called(guild, author, reservationId, partyMember)
+
+	return args.Get(0).(*reservation.ReservationWithSpot), args.Error(1)
+}
+
+func (a *MockBookingService) RemovePartyMember(guild *discord.Guild, author *discord.Member, reservationId int64, partyMember *discord.Member) (*reservation.ReservationWithSpot, error) {
+	args := a.Called(guild, author, reservationId, partyMember)
+
+	return args.Get(0).(*reservation.ReservationWithSpot), args.Error(1)
+}
+
+func (a *MockBookingService) CheckIn(guild *discord.Guild, member *discord.Member, reservationId int64) (*reservation.ReservationWithSpot, error) {
+	args := a.Called(guild, member, reservationId)
+
+	return args.Get(0).(*reservation.ReservationWithSpot), args.Error(1)
+}
+
+func (a *MockBookingService) Hold(member *discord.Member, guild *discord.Guild, spot string, startAt time.Time, endAt time.Time, tier policy.Tier) (*reservation.ReservationWithSpot, []*reservation.ClippedOrRemovedReservation, error) {
+	args := a.Called(member, guild, spot, startAt, endAt, tier)
+
+	return args.Get(0).(*reservation.ReservationWithSpot), args.Get(1).([]*reservation.ClippedOrRemovedReservation), args.Error(2)
+}
+
+func (a *MockBookingService) Confirm(guild *discord.Guild, member *discord.Member, reservationId int64, tier policy.Tier) (*reservation.ReservationWithSpot, error) {
+	args := a.Called(guild, member, reservationId, tier)
+
+	return args.Get(0).(*reservation.ReservationWithSpot), args.Error(1)
+}
+
+func (a *MockBookingService) ReleaseExpiredHolds(guild *discord.Guild) ([]*reservation.ReservationWithSpot, error) {
+	args := a.Called(guild)
+
+	return args.Get(0).([]*reservation.ReservationWithSpot), args.Error(1)
+}
+
+func (a *MockBookingService) BookOverbookRequest(member *discord.Member, guild *discord.Guild, party []*discord.Member, request *reservation.OverbookRequestWithSpot, tier policy.Tier, hasRole policy.RoleChecker) ([]*reservation.ClippedOrRemovedReservation, error) {
+	args := a.Called(member, guild, party, request, tier)
+
+	return args.Get(0).([]*reservation.ClippedOrRemovedReservation), args.Error(1)
+}
+
+func (a *MockBookingService) RequestOverbook(member *discord.Member, guild *discord.Guild, party []*discord.Member, spot string, startAt time.Time, endAt time.Time, tier policy.Tier, hasRole policy.RoleChecker) (*reservation.OverbookRequestWithSpot, []*reservation.ClippedOrRemovedReservation, error) {
+	args := a.Called(member, guild, party, spot, startAt, endAt, tier)
+
+	return args.Get(0).(*reservation.OverbookRequestWithSpot), args.Get(1).([]*reservation.ClippedOrRemovedReservation), args.Error(2)
+}
+
+func (a *MockBookingService) AnswerOverbookRequest(guild *discord.Guild, member *discord.Member, requestId int64, accepted bool) (*reservation.OverbookRequestWithSpot, bool, error) {
+	args := a.Called(guild, member, requestId, accepted)
+
+	return args.Get(0).(*reservation.OverbookRequestWithSpot), args.Bool(1), args.Error(2)
+}
+
+func (a *MockBookingService) ResolveExpiredOverbookRequests(guild *discord.Guild) ([]*reservation.OverbookRequestWithSpot, error) {
+	args := a.Called(guild)
+
+	return args.Get(0).([]*reservation.OverbookRequestWithSpot), args.Error(1)
+}
+
+func (a *MockBookingService) ProcessCheckIns(guild *discord.Guild) ([]*reservation.ReservationWithSpot, []*reservation.NoShow, error) {
+	args := a.Called(guild)
+
+	return args.Get(0).([]*reservation.ReservationWithSpot), args.Get(1).([]*reservation.NoShow), args.Error(2)
+}
+
+func (a *MockBookingService) GetReminderPreference(guild *discord.Guild, member *discord.Member) (*policy.ReminderPreference, error) {
+	args := a.Called(guild, member)
+
+	return args.Get(0).(*policy.ReminderPreference), args.Error(1)
+}
+
+func (a *MockBookingService) SaveReminderPreference(p *policy.ReminderPreference) (*policy.ReminderPreference, error) {
+	args := a.Called(p)
+
+	return args.Get(0).(*policy.ReminderPreference), args.Error(1)
+}
+
+func (a *MockBookingService) ProcessReminders(guild *discord.Guild) ([]*reservation.Reminder, error) {
+	args := a.Called(guild)
+
+	return args.Get(0).([]*reservation.Reminder), args.Error(1)
+}
+
+func (a *MockBookingService) AddSpot(g *discord.Guild, name string, parent string) (*spot.Spot, error) {
+	args := a.Called(g, name, parent)
+
+	return args.Get(0).(*spot.Spot), args.Error(1)
+}
+
+func (a *MockBookingService) RenameSpot(g *discord.Guild, name string, newName string) (*spot.Spot, error) {
+	args := a.Called(g, name, newName)
+
+	return args.Get(0).(*spot.Spot), args.Error(1)
+}
+
+func (a *MockBookingService) DescribeSpot(g *discord.Guild, name string, details spot.Details) (*spot.Spot, error) {
+	args := a.Called(g, name, details)
+
+	return args.Get(0).(*spot.Spot), args.Error(1)
+}
+
+func (a *MockBookingService) AddSpotAlias(g *discord.Guild, name string, alias string) (*spot.Spot, error) {
+	args := a.Called(g, name, alias)
+
+	return args.Get(0).(*spot.Spot), args.Error(1)
+}
+
+func (a *MockBookingService) RemoveSpotAlias(g *discord.Guild, name string, alias string) (*spot.Spot, error) {
+	args := a.Called(g, name, alias)
+
+	return args.Get(0).(*spot.Spot), args.Error(1)
+}
+
+func (a *MockBookingService) ImportSpots(g *discord.Guild, entries []*spot.CatalogEntry, dryRun bool) (*spot.ImportResult, error) {
+	args := a.Called(g, entries, dryRun)
+
+	return args.Get(0).(*spot.ImportResult), args.Error(1)
+}
+
+func (a *MockBookingService) ExportSpots(g *discord.Guild) ([]*spot.CatalogEntry, error) {
+	args := a.Called(g)
+
+	return args.Get(0).([]*spot.CatalogEntry), args.Error(1)
+}
+
+func (a *MockBookingService) ArchiveSpot(g *discord.Guild, name string) (*spot.Spot, error) {
+	args := a.Called(g, name)
+
+	return args.Get(0).(*spot.Spot), args.Error(1)
+}
+
+func (a *MockBookingService) HideSpot(g *discord.Guild, name string) (*spot.Spot, error) {
+	args := a.Called(g, name)
+
+	return args.Get(0).(*spot.Spot), args.Error(1)
+}
+
+func (a *MockBookingService) UnhideSpot(g *discord.Guild, name string) (*spot.Spot, error) {
+	args := a.Called(g, name)
+
+	return args.Get(0).(*spot.Spot), args.Error(1)
+}
+
+func (a *MockBookingService) GetQuotaUsage(guild *discord.Guild, member *discord.Member) ([]*reservation.QuotaUsage, error) {
+	args := a.Called(guild, member)
+
+	return args.Get(0).([]*reservation.QuotaUsage), args.Error(1)
+}
+
+func (a *MockBookingService) GetSpotOpenings(guild *discord.Guild, spotNames []string, currTime time.Time) ([]*reservation.SpotOpening, error) {
+	args := a.Called(guild, spotNames, currTime)
+
+	return args.Get(0).([]*reservation.SpotOpening), args.Error(1)
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/reservation"
 	"spot-assistant/internal/core/dto/summary"
 )
 
@@ -32,8 +33,8 @@ func (m *MockBot) FindChannelByName(g *discord.Guild, channelName string) (*disc
 	return args.Get(0).(*discord.Channel), args.Error(1)
 }
 
-func (m *MockBot) EnsureRoles(g *discord.Guild) error {
-	args := m.Called(g)
+func (m *MockBot) EnsureRoles(g *discord.Guild, roleNames ...string) error {
+	args := m.Called(g, roleNames)
 	return args.Error(0)
 }
 
@@ -71,6 +72,21 @@ func (m *MockBot) SendDM(mem *discord.Member, msg string) error {
 	return args.Error(0)
 }
 
+func (m *MockBot) SendCheckInReminder(g *discord.Guild, mem *discord.Member, r *reservation.ReservationWithSpot, msg string) error {
+	args := m.Called(g, mem, r, msg)
+	return args.Error(0)
+}
+
+func (m *MockBot) SendOverbookApprovalRequest(g *discord.Guild, mem *discord.Member, r *reservation.OverbookRequestWithSpot, msg string) error {
+	args := m.Called(g, mem, r, msg)
+	return args.Error(0)
+}
+
+func (m *MockBot) SendChannelMessage(g *discord.Guild, ch *discord.Channel, msg string) error {
+	args := m.Called(g, ch, msg)
+	return args.Error(0)
+}
+
 func (m *MockBot) GetMember(g *discord.Guild, memberID string) (*discord.Member, error) {
 	args := m.Called(g, memberID)
 	return args.Get(0).(*discord.Member), args.Error(1)
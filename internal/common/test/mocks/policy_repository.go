@@ -0,0 +1,48 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	"spot-assistant/internal/core/dto/policy"
+)
+
+type MockPolicyRepo struct {
+	mock.Mock
+}
+
+func (a *MockPolicyRepo) FindGuildPolicy(ctx context.Context, guildId string) (*policy.Policy, error) {
+	args := a.Called(ctx, guildId)
+	return args.Get(0).(*policy.Policy), args.Error(1)
+}
+
+func (a *MockPolicyRepo) SaveGuildPolicy(ctx context.Context, p *policy.Policy) (*policy.Policy, error) {
+	args := a.Called(ctx, p)
+	return args.Get(0).(*policy.Policy), args.Error(1)
+}
+
+func (a *MockPolicyRepo) FindMemberTimeZone(ctx context.Context, guildId string, memberId string) (string, error) {
+	args := a.Called(ctx, guildId, memberId)
+	return args.String(0), args.Error(1)
+}
+
+func (a *MockPolicyRepo) SaveMemberTimeZone(ctx context.Context, guildId string, memberId string, timeZone string) error {
+	args := a.Called(ctx, guildId, memberId, timeZone)
+	return args.Error(0)
+}
+
+func (a *MockPolicyRepo) DeleteMemberTimeZone(ctx context.Context, guildId string, memberId string) error {
+	args := a.Called(ctx, guildId, memberId)
+	return args.Error(0)
+}
+
+func (a *MockPolicyRepo) FindMemberReminderPreference(ctx context.Context, guildId string, memberId string) (*policy.ReminderPreference, error) {
+	args := a.Called(ctx, guildId, memberId)
+	return args.Get(0).(*policy.ReminderPreference), args.Error(1)
+}
+
+func (a *MockPolicyRepo) SaveMemberReminderPreference(ctx context.Context, p *policy.ReminderPreference) (*policy.ReminderPreference, error) {
+	args := a.Called(ctx, p)
+	return args.Get(0).(*policy.ReminderPreference), args.Error(1)
+}
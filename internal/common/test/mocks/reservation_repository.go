@@ -29,19 +29,31 @@ func (a *MockReservationRepo) SelectUpcomingReservationsWithSpot(ctx context.Con
 	return args.Get(0).([]*reservation.ReservationWithSpot), args.Error(1)
 }
 
-func (a *MockReservationRepo) SelectOverlappingReservations(ctx context.Context, spot string, startAt time.Time, endAt time.Time, guildId string) ([]*reservation.Reservation, error) {
-	args := a.Called(ctx, spot, startAt, endAt, guildId)
+func (a *MockReservationRepo) SelectOverlappingReservations(ctx context.Context, spotId int64, startAt time.Time, endAt time.Time, guildId string) ([]*reservation.Reservation, error) {
+	args := a.Called(ctx, spotId, startAt, endAt, guildId)
 
 	return args.Get(0).([]*reservation.Reservation), args.Error(1)
 }
 
-func (a *MockReservationRepo) CreateAndDeleteConflicting(ctx context.Context, member *discord.Member, guild *discord.Guild, conflicts []*reservation.Reservation, spotId int64, startAt time.Time, endAt time.Time) ([]*reservation.ClippedOrRemovedReservation, error) {
-	args := a.Called(ctx, member, guild, conflicts, spotId, startAt, endAt)
+func (a *MockReservationRepo) SelectMemberReservationsWithSpotsBetween(ctx context.Context, guild *discord.Guild, member *discord.Member, from time.Time, to time.Time) ([]*reservation.ReservationWithSpot, error) {
+	args := a.Called(ctx, guild, member, from, to)
+
+	return args.Get(0).([]*reservation.ReservationWithSpot), args.Error(1)
+}
+
+func (a *MockReservationRepo) CreateAndDeleteConflicting(ctx context.Context, member *discord.Member, guild *discord.Guild, party []*discord.Member, conflicts []*reservation.Reservation, spotId int64, startAt time.Time, endAt time.Time, priority int) ([]*reservation.ClippedOrRemovedReservation, error) {
+	args := a.Called(ctx, member, guild, party, conflicts, spotId, startAt, endAt, priority)
 
 	return args.Get(0).([]*reservation.ClippedOrRemovedReservation), args.Error(1)
 
 }
 
+func (a *MockReservationRepo) UpdateAndDeleteConflicting(ctx context.Context, member *discord.Member, guild *discord.Guild, reservationId int64, conflicts []*reservation.Reservation, spotId int64, startAt time.Time, endAt time.Time) ([]*reservation.ClippedOrRemovedReservation, error) {
+	args := a.Called(ctx, member, guild, reservationId, conflicts, spotId, startAt, endAt)
+
+	return args.Get(0).([]*reservation.ClippedOrRemovedReservation), args.Error(1)
+}
+
 func (a *MockReservationRepo) SelectUpcomingMemberReservationsWithSpots(ctx context.Context, guild *discord.Guild, member *discord.Member) ([]*reservation.ReservationWithSpot, error) {
 	args := a.Called(ctx, guild, member)
 
@@ -55,8 +67,212 @@ func (a *MockReservationRepo) DeletePresentMemberReservation(ctx context.Context
 	return args.Error(0)
 }
 
+func (a *MockReservationRepo) TransferPresentMemberReservation(ctx context.Context, g *discord.Guild, from *discord.Member, to *discord.Member, reservationId int64, priority int) error {
+	args := a.Called(ctx, g, from, to, reservationId, priority)
+
+	return args.Error(0)
+}
+
+func (a *MockReservationRepo) CheckInPresentMemberReservation(ctx context.Context, g *discord.Guild, m *discord.Member, reservationId int64) error {
+	args := a.Called(ctx, g, m, reservationId)
+
+	return args.Error(0)
+}
+
+func (a *MockReservationRepo) SelectCheckInPendingReservationsWithSpots(ctx context.Context, guildId string, startedBefore time.Time) ([]*reservation.ReservationWithSpot, error) {
+	args := a.Called(ctx, guildId, startedBefore)
+
+	return args.Get(0).([]*reservation.ReservationWithSpot), args.Error(1)
+}
+
+func (a *MockReservationRepo) MarkCheckInReminded(ctx context.Context, reservationId int64) (bool, error) {
+	args := a.Called(ctx, reservationId)
+
+	return args.Bool(0), args.Error(1)
+}
+
+func (a *MockReservationRepo) MarkNoShow(ctx context.Context, r *reservation.Reservation) (int, error) {
+	args := a.Called(ctx, r)
+
+	return args.Int(0), args.Error(1)
+}
+
+func (a *MockReservationRepo) CreateHold(ctx context.Context, member *discord.Member, guild *discord.Guild, spotId int64, startAt time.Time, endAt time.Time, heldUntil time.Time, priority int) (*reservation.Reservation, error) {
+	args := a.Called(ctx, member, guild, spotId, startAt, endAt, heldUntil, priority)
+
+	return args.Get(0).(*reservation.Reservation), args.Error(1)
+}
+
+func (a *MockReservationRepo) ConfirmPresentMemberHold(ctx context.Context, g *discord.Guild, m *discord.Member, reservationId int64) error {
+	args := a.Called(ctx, g, m, reservationId)
+
+	return args.Error(0)
+}
+
+func (a *MockReservationRepo) SelectExpiredHoldsWithSpots(ctx context.Context, guildId string) ([]*reservation.ReservationWithSpot, error) {
+	args := a.Called(ctx, guildId)
+
+	return args.Get(0).([]*reservation.ReservationWithSpot), args.Error(1)
+}
+
+func (a *MockReservationRepo) ReleaseExpiredHold(ctx context.Context, reservationId int64) (bool, error) {
+	args := a.Called(ctx, reservationId)
+
+	return args.Bool(0), args.Error(1)
+}
+
+func (a *MockReservationRepo) CreateOverbookRequest(ctx context.Context, member *discord.Member, guild *discord.Guild, party []*discord.Member, spotId int64, startAt time.Time, endAt time.Time, expiresAt time.Time, affected []*reservation.Reservation) (*reservation.OverbookRequest, error) {
+	args := a.Called(ctx, member, guild, party, spotId, startAt, endAt, expiresAt, affected)
+
+	return args.Get(0).(*reservation.OverbookRequest), args.Error(1)
+}
+
+func (a *MockReservationRepo) FindOverbookRequestWithSpot(ctx context.Context, guildId string, requestId int64) (*reservation.OverbookRequestWithSpot, error) {
+	args := a.Called(ctx, guildId, requestId)
+
+	return args.Get(0).(*reservation.OverbookRequestWithSpot), args.Error(1)
+}
+
+func (a *MockReservationRepo) AnswerOverbookRequest(ctx context.Context, guildId string, requestId int64, memberId string, accepted bool) (bool, error) {
+	args := a.Called(ctx, guildId, requestId, memberId, accepted)
+
+	return args.Bool(0), args.Error(1)
+}
+
+func (a *MockReservationRepo) SelectExpiredOverbookRequestsWithSpots(ctx context.Context, guildId string) ([]*reservation.OverbookRequestWithSpot, error) {
+	args := a.Called(ctx, guildId)
+
+	return args.Get(0).([]*reservation.OverbookRequestWithSpot), args.Error(1)
+}
+
+func (a *MockReservationRepo) DeleteOverbookRequest(ctx context.Context, requestId int64) (bool, error) {
+	args := a.Called(ctx, requestId)
+
+	return args.Bool(0), args.Error(1)
+}
+
+func (a *MockReservationRepo) ClaimReminder(ctx context.Context, reservationId int64, kind reservation.ReminderKind, eventAt time.Time) (bool, error) {
+	args := a.Called(ctx, reservationId, kind, eventAt)
+
+	return args.Bool(0), args.Error(1)
+}
+
 func (a *MockReservationRepo) FindReservationWithSpot(ctx context.Context, id int64, guildID, authorDiscordID string) (*reservation.ReservationWithSpot, error) {
 	args := a.Called(ctx, id, guildID, authorDiscordID)
 
 	return args.Get(0).(*reservation.ReservationWithSpot), args.Error(1)
 }
+
+func (a *MockReservationRepo) CreateSeries(ctx context.Context, member *discord.Member, guild *discord.Guild, spotId int64, weekdays []time.Weekday, startTime time.Duration, endTime time.Duration) (*reservation.Series, error) {
+	args := a.Called(ctx, member, guild, spotId, weekdays, startTime, endTime)
+
+	return args.Get(0).(*reservation.Series), args.Error(1)
+}
+
+func (a *MockReservationRepo) SelectMemberSeriesWithSpots(ctx context.Context, guild *discord.Guild, member *discord.Member) ([]*reservation.SeriesWithSpot, error) {
+	args := a.Called(ctx, guild, member)
+
+	return args.Get(0).([]*reservation.SeriesWithSpot), args.Error(1)
+}
+
+func (a *MockReservationRepo) FindMemberSeriesWithSpot(ctx context.Context, id int64, guildID, authorDiscordID string) (*reservation.SeriesWithSpot, error) {
+	args := a.Called(ctx, id, guildID, authorDiscordID)
+
+	return args.Get(0).(*reservation.SeriesWithSpot), args.Error(1)
+}
+
+func (a *MockReservationRepo) SelectSeriesToMaterialize(ctx context.Context, guildId string, until time.Time) ([]*reservation.SeriesWithSpot, error) {
+	args := a.Called(ctx, guildId, until)
+
+	return args.Get(0).([]*reservation.SeriesWithSpot), args.Error(1)
+}
+
+func (a *MockReservationRepo) CreateSeriesReservation(ctx context.Context, series *reservation.Series, startAt time.Time, endAt time.Time, priority int) (*reservation.Reservation, error) {
+	args := a.Called(ctx, series, startAt, endAt, priority)
+
+	return args.Get(0).(*reservation.Reservation), args.Error(1)
+}
+
+func (a *MockReservationRepo) UpdateSeriesMaterializedUntil(ctx context.Context, seriesId int64, until time.Time) error {
+	args := a.Called(ctx, seriesId, until)
+
+	return args.Error(0)
+}
+
+func (a *MockReservationRepo) DeleteMemberSeries(ctx context.Context, g *discord.Guild, m *discord.Member, seriesId int64) error {
+	args := a.Called(ctx, g, m, seriesId)
+
+	return args.Error(0)
+}
+
+func (a *MockReservationRepo) CreateQueueEntry(ctx context.Context, member *discord.Member, guild *discord.Guild, spotId int64, startAt time.Time, endAt time.Time) (*reservation.QueueEntry, error) {
+	args := a.Called(ctx, member, guild, spotId, startAt, endAt)
+
+	return args.Get(0).(*reservation.QueueEntry), args.Error(1)
+}
+
+func (a *MockReservationRepo) SelectQueueEntriesWithSpots(ctx context.Context, guildId string) ([]*reservation.QueueEntryWithSpot, error) {
+	args := a.Called(ctx, guildId)
+
+	return args.Get(0).([]*reservation.QueueEntryWithSpot), args.Error(1)
+}
+
+func (a *MockReservationRepo) DeleteExpiredQueueEntries(ctx context.Context, guildId string) error {
+	args := a.Called(ctx, guildId)
+
+	return args.Error(0)
+}
+
+func (a *MockReservationRepo) CreateReservationFromQueueEntry(ctx context.Context, entry *reservation.QueueEntry, priority int) (*reservation.Reservation, error) {
+	args := a.Called(ctx, entry, priority)
+
+	return args.Get(0).(*reservation.Reservation), args.Error(1)
+}
+
+func (a *MockReservationRepo) CreateLotteryApplication(ctx context.Context, member *discord.Member, guild *discord.Guild, spotId int64, startAt time.Time, endAt time.Time, drawAt time.Time) (*reservation.LotteryApplication, error) {
+	args := a.Called(ctx, member, guild, spotId, startAt, endAt, drawAt)
+
+	return args.Get(0).(*reservation.LotteryApplication), args.Error(1)
+}
+
+func (a *MockReservationRepo) SelectPendingLotteryApplicationsWithSpots(ctx context.Context, guildId string) ([]*reservation.LotteryApplicationWithSpot, error) {
+	args := a.Called(ctx, guildId)
+
+	return args.Get(0).([]*reservation.LotteryApplicationWithSpot), args.Error(1)
+}
+
+func (a *MockReservationRepo) CountLotteryWins(ctx context.Context, guildId string, since time.Time) (map[string]int, error) {
+	args := a.Called(ctx, guildId, since)
+
+	return args.Get(0).(map[string]int), args.Error(1)
+}
+
+func (a *MockReservationRepo) ResolveLotteryApplication(ctx context.Context, application *reservation.LotteryApplication, won bool, priority int) (*reservation.Reservation, error) {
+	args := a.Called(ctx, application, won, priority)
+
+	return args.Get(0).(*reservation.Reservation), args.Error(1)
+}
+
+func (a *MockReservationRepo) DeleteLotteryApplicationsDrawnBefore(ctx context.Context, guildId string, before time.Time) error {
+	args := a.Called(ctx, guildId, before)
+
+	return args.Error(0)
+}
+
+func (a *MockReservationRepo) SelectUpcomingMemberPartyReservationsWithSpots(ctx context.Context, guild *discord.Guild, member *discord.Member) ([]*reservation.ReservationWithSpot, error) {
+	args := a.Called(ctx, guild, member)
+
+	return args.Get(0).([]*reservation.ReservationWithSpot), args.Error(1)
+}
+
+func (a *MockReservationRepo) AddReservationPartyMember(ctx context.Context, reservationId int64, member *discord.Member) error {
+	args := a.Called(ctx, reservationId, member)
+
+	return args.Error(0)
+}
+
+func (a *MockReservationRepo) RemoveReservationPartyMember(ctx context.Context, reservationId int64, memberDiscordID string) error {
+	args := a.Called(ctx, reservationId, memberDiscordID)
+
+	return args.Error(0)
+}
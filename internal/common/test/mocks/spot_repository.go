@@ -12,7 +12,52 @@ type MockSpotRepo struct {
 	mock.Mock
 }
 
-func (a *MockSpotRepo) SelectAllSpots(ctx context.Context) ([]*spot.Spot, error) {
-	args := a.Called(ctx)
+func (a *MockSpotRepo) SelectAllSpots(ctx context.Context, guildId string) ([]*spot.Spot, error) {
+	args := a.Called(ctx, guildId)
 	return args.Get(0).([]*spot.Spot), args.Error(1)
 }
+
+func (a *MockSpotRepo) SelectHiddenSpots(ctx context.Context, guildId string) ([]*spot.Spot, error) {
+	args := a.Called(ctx, guildId)
+	return args.Get(0).([]*spot.Spot), args.Error(1)
+}
+
+func (a *MockSpotRepo) CreateSpot(ctx context.Context, guildId string, name string, parentId int64) (*spot.Spot, error) {
+	args := a.Called(ctx, guildId, name, parentId)
+	return args.Get(0).(*spot.Spot), args.Error(1)
+}
+
+func (a *MockSpotRepo) RenameSpot(ctx context.Context, id int64, name string) (*spot.Spot, error) {
+	args := a.Called(ctx, id, name)
+	return args.Get(0).(*spot.Spot), args.Error(1)
+}
+
+func (a *MockSpotRepo) UpdateSpotParent(ctx context.Context, id int64, parentId int64) (*spot.Spot, error) {
+	args := a.Called(ctx, id, parentId)
+	return args.Get(0).(*spot.Spot), args.Error(1)
+}
+
+func (a *MockSpotRepo) UpdateSpotDetails(ctx context.Context, id int64, details spot.Details) (*spot.Spot, error) {
+	args := a.Called(ctx, id, details)
+	return args.Get(0).(*spot.Spot), args.Error(1)
+}
+
+func (a *MockSpotRepo) UpdateSpotAliases(ctx context.Context, id int64, aliases []string) (*spot.Spot, error) {
+	args := a.Called(ctx, id, aliases)
+	return args.Get(0).(*spot.Spot), args.Error(1)
+}
+
+func (a *MockSpotRepo) ArchiveSpot(ctx context.Context, id int64) (*spot.Spot, error) {
+	args := a.Called(ctx, id)
+	return args.Get(0).(*spot.Spot), args.Error(1)
+}
+
+func (a *MockSpotRepo) HideSpot(ctx context.Context, guildId string, spotId int64) error {
+	args := a.Called(ctx, guildId, spotId)
+	return args.Error(0)
+}
+
+func (a *MockSpotRepo) UnhideSpot(ctx context.Context, guildId string, spotId int64) error {
+	args := a.Called(ctx, guildId, spotId)
+	return args.Error(0)
+}
@@ -2,12 +2,15 @@ package api
 
 import (
 	"fmt"
+	"slices"
 	"spot-assistant/internal/common/collections"
 	stringsHelper "spot-assistant/internal/common/strings"
 	"strings"
 	"time"
 
 	"spot-assistant/internal/core/dto/book"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/policy"
 	"spot-assistant/internal/core/dto/reservation"
 	"spot-assistant/internal/ports"
 )
@@ -19,67 +22,257 @@ func (a *Application) OnBook(bot ports.BotPort, request book.BookRequest) (book.
 		EndAt:   request.EndAt,
 	}
 
+	p, err := a.bookingSrv.GetPolicy(request.Guild)
+	if err != nil {
+		return response, err
+	}
+
+	// Booking shortens reservations touching a blackout, reply with the times actually booked
+	if startAt, endAt, err := p.ClipToBlackouts(request.StartAt, request.EndAt); err == nil {
+		response.StartAt, response.EndAt = startAt, endAt
+	}
+
+	tier := memberTier(bot, request.Guild, request.Member, p)
+	if p.OverbookApproval && request.Overbook {
+		pending, rejected, err := a.bookingSrv.RequestOverbook(
+			request.Member,
+			request.Guild,
+			request.Party,
+			request.Spot, request.StartAt,
+			request.EndAt, tier,
+			a.roleChecker(bot, request.Guild),
+		)
+		if err != nil {
+			response.ConflictingReservations = rejected
+			if len(rejected) > 0 {
+				response.Alternatives = a.findAlternatives(request)
+			}
+
+			return response, err
+		}
+
+		if pending != nil {
+			response.OverbookRequest = pending
+			a.askForOverbookApproval(bot, request.Guild, pending)
+
+			return response, nil
+		}
+	}
+
 	conflicting, err := a.bookingSrv.Book(
 		request.Member,
 		request.Guild,
+		request.Party,
 		request.Spot, request.StartAt,
-		request.EndAt, request.Overbook, bot.MemberHasRole(request.Guild, request.Member, "Postman"),
+		request.EndAt, request.Overbook, tier,
+		a.roleChecker(bot, request.Guild),
 	)
 	response.ConflictingReservations = conflicting
 
 	if err != nil {
+		if len(conflicting) > 0 {
+			response.Alternatives = a.findAlternatives(request)
+		}
+
 		return response, err
 	}
-	go a.UpdateGuildSummaryAndLogError(bot, request.Guild)
+	if len(response.ConflictingReservations) > 0 {
+		// Overbooking might have freed slots someone is queued for
+		go a.ProcessQueueAndUpdateGuildSummary(bot, request.Guild)
+	} else {
+		go a.UpdateGuildSummaryAndLogError(bot, request.Guild)
+	}
 
-	// Notify users about overbooking
-	for _, res := range response.ConflictingReservations {
-		go func(res *reservation.ClippedOrRemovedReservation) {
-			member, err := bot.GetMember(request.Guild, res.Original.AuthorDiscordID)
-			if err != nil {
-				a.log.Errorf("error getting member: %s", err)
-				return
-			}
+	a.notifyOverbookedMembers(bot, request.Guild, request.Member, request.Spot, response.ConflictingReservations)
 
-			msgHeader := fmt.Sprintf(
-				"Your reservation was overbooked by %s\n",
-				fmt.Sprintf("<@!%s>", request.Member.ID),
-			)
-
-			var msgBody strings.Builder
-			msgBody.WriteString(fmt.Sprintf("* %s %s ", fmt.Sprintf("<@!%s>", member.ID), request.Spot))
-			if len(res.New) > 0 { // The reservation has been modified, but not entirely removed - lets notify the user!
-				msgBody.WriteString("has been clipped to: ")
-				newClippedRanges := collections.PoorMansMap(res.New, func(r *reservation.Reservation) string {
-					return fmt.Sprintf("%s - %s", r.StartAt.Format(stringsHelper.DC_LONG_TIME_FORMAT), r.EndAt.Format(stringsHelper.DC_LONG_TIME_FORMAT))
-				})
-				msgBody.WriteString(strings.Join(newClippedRanges, ", "))
-			} else {
-				msgBody.WriteString(fmt.Sprintf("has been entirely removed (originally: **%s - %s**)", res.Original.StartAt.Format(stringsHelper.DC_LONG_TIME_FORMAT), res.Original.EndAt.Format(stringsHelper.DC_LONG_TIME_FORMAT)))
-			}
+	return response, nil
+}
 
-			err = bot.SendDM(member, msgHeader+msgBody.String())
-			if err != nil {
-				a.log.Errorf("error sending DM: %s", err)
-			}
-		}(res)
+// Books an alternative slot proposed on conflict, on behalf of the member who has been proposed it.
+// The party of the original request is not carried over.
+func (a *Application) OnBookAlternative(bot ports.BotPort, request book.BookAlternativeRequest) (book.BookResponse, error) {
+	s, err := a.bookingSrv.GetSpot(request.Guild, request.SpotID)
+	if err != nil {
+		return book.BookResponse{}, err
 	}
 
-	return response, nil
+	return a.OnBook(bot, book.BookRequest{
+		Guild:   request.Guild,
+		Member:  request.Member,
+		Party:   []*discord.Member{},
+		Spot:    s.Name,
+		StartAt: request.StartAt,
+		EndAt:   request.EndAt,
+	})
+}
+
+// Returns free slots nearest to the requested one, or none if they cannot be determined.
+func (a *Application) findAlternatives(request book.BookRequest) []*reservation.Alternative {
+	alternatives, err := a.bookingSrv.FindAlternatives(request.Guild, request.Spot, request.StartAt, request.EndAt)
+	if err != nil {
+		a.log.Errorf("could not find alternatives: %s", err)
+
+		return []*reservation.Alternative{}
+	}
+
+	return alternatives
 }
 
 func (a *Application) OnBookAutocomplete(request book.BookAutocompleteRequest) (book.BookAutocompleteResponse, error) {
 	switch request.Field {
 	case book.BookAutocompleteOverbook:
 		// @TODO: make it based on user permissions
-		return []string{"true", "false"}, nil
+		return mapToBookAutocompleteResponse([]string{"true", "false"}), nil
 	case book.BookAutocompleteStartAt:
-		return a.bookingSrv.GetSuggestedHours(time.Now(), request.Value), nil
+		return mapToBookAutocompleteResponse(a.bookingSrv.GetSuggestedHours(request.Guild, a.onChosenDate(a.memberNow(request.Guild, request.Member), request.Date), request.Spot, request.Value)), nil
 	case book.BookAutocompleteEndAt:
-		return a.bookingSrv.GetSuggestedHours(time.Now().Add(2*time.Hour), request.Value), nil
+		return mapToBookAutocompleteResponse(a.bookingSrv.GetSuggestedHours(request.Guild, a.memberNow(request.Guild, request.Member).Add(2*time.Hour), "", request.Value)), nil
+	case book.BookAutocompleteDate:
+		return mapToBookAutocompleteResponse(a.bookingSrv.GetSuggestedDates(request.Guild, a.memberNow(request.Guild, request.Member), request.Value)), nil
 	case book.BookAutocompleteSpot:
-		return a.bookingSrv.FindAvailableSpots(request.Value)
+		return a.suggestSpots(request)
 	default:
-		return []string{}, fmt.Errorf("autocomplete not implemented for %v", request.Field)
+		return book.BookAutocompleteResponse{}, fmt.Errorf("autocomplete not implemented for %v", request.Field)
+	}
+}
+
+// Suggests spots matching the request value. Spots covered by guild booking windows are labeled
+// with how far ahead and since when they can be booked, in member time zone.
+func (a *Application) suggestSpots(request book.BookAutocompleteRequest) (book.BookAutocompleteResponse, error) {
+	names, err := a.bookingSrv.FindAvailableSpots(request.Guild, request.Value)
+	if err != nil {
+		return book.BookAutocompleteResponse{}, err
+	}
+	response := mapToBookAutocompleteResponse(names)
+
+	now := a.memberNow(request.Guild, request.Member)
+	openings, err := a.bookingSrv.GetSpotOpenings(request.Guild, names, now)
+	if err != nil {
+		a.log.Errorf("could not get spot openings: %s", err)
+
+		return response, nil
+	}
+
+	for _, opening := range openings {
+		i := slices.IndexFunc(response, func(c book.BookAutocompleteChoice) bool {
+			return c.Value == opening.Spot
+		})
+		if i != -1 {
+			response[i].Label = formatSpotOpening(opening, now.Location())
+		}
+	}
+
+	return response, nil
+}
+
+func formatSpotOpening(opening *reservation.SpotOpening, loc *time.Location) string {
+	rules := []string{}
+	if opening.Advance > 0 {
+		rules = append(rules, fmt.Sprintf("up to %s ahead", stringsHelper.FormatDuration(opening.Advance)))
+	}
+	if !opening.NextOpeningAt.IsZero() {
+		rules = append(rules, fmt.Sprintf("next day opens %s", opening.NextOpeningAt.In(loc).Format("Mon "+stringsHelper.DC_TIME_FORMAT)))
+	}
+
+	return fmt.Sprintf("%s (%s)", opening.Spot, strings.Join(rules, ", "))
+}
+
+func mapToBookAutocompleteResponse(values []string) book.BookAutocompleteResponse {
+	return collections.PoorMansMap(values, func(v string) book.BookAutocompleteChoice {
+		return book.BookAutocompleteChoice{Value: v}
+	})
+}
+
+// Returns current time in member time zone, or in the server one if it cannot be determined.
+func (a *Application) memberNow(guild *discord.Guild, member *discord.Member) time.Time {
+	loc, err := a.bookingSrv.GetLocation(guild, member)
+	if err != nil {
+		a.log.Error(err)
+
+		return time.Now()
+	}
+
+	return time.Now().In(loc)
+}
+
+// Moves now to the same hour of a later date chosen by member, so that hours suggested
+// for that date can be told free or not. Invalid or past dates leave now intact.
+func (a *Application) onChosenDate(now time.Time, date string) time.Time {
+	chosen, err := time.ParseInLocation(stringsHelper.DC_DATE_FORMAT, date, now.Location())
+	if err != nil || !chosen.After(now) {
+		return now
+	}
+
+	return time.Date(chosen.Year(), chosen.Month(), chosen.Day(), now.Hour(), now.Minute(), now.Second(), 0, now.Location())
+}
+
+// Notifies members about their reservations being overbooked by author,
+// along with members of their parties.
+func (a *Application) notifyOverbookedMembers(bot ports.BotPort, guild *discord.Guild, author *discord.Member, spot string, conflicts []*reservation.ClippedOrRemovedReservation) {
+	for _, res := range conflicts {
+		recipients := []string{res.Original.AuthorDiscordID}
+		for _, partyMember := range res.Original.Party {
+			recipients = append(recipients, partyMember.MemberDiscordID)
+		}
+
+		for _, recipient := range recipients {
+			go func(res *reservation.ClippedOrRemovedReservation, recipient string) {
+				member, err := bot.GetMember(guild, recipient)
+				if err != nil {
+					a.log.Errorf("error getting member: %s", err)
+					return
+				}
+
+				msgHeader := fmt.Sprintf(
+					"Your reservation was overbooked by %s\n",
+					fmt.Sprintf("<@!%s>", author.ID),
+				)
+				if member.ID != res.Original.AuthorDiscordID {
+					msgHeader = fmt.Sprintf(
+						"Reservation you are a party member of was overbooked by %s\n",
+						fmt.Sprintf("<@!%s>", author.ID),
+					)
+				}
+
+				var msgBody strings.Builder
+				msgBody.WriteString(fmt.Sprintf("* %s %s ", fmt.Sprintf("<@!%s>", res.Original.AuthorDiscordID), spot))
+				if len(res.New) > 0 { // The reservation has been modified, but not entirely removed - lets notify the user!
+					msgBody.WriteString("has been clipped to: ")
+					newClippedRanges := collections.PoorMansMap(res.New, func(r *reservation.Reservation) string {
+						return fmt.Sprintf("%s - %s", stringsHelper.FormatDcLongTime(r.StartAt), stringsHelper.FormatDcLongTime(r.EndAt))
+					})
+					msgBody.WriteString(strings.Join(newClippedRanges, ", "))
+				} else {
+					msgBody.WriteString(fmt.Sprintf("has been entirely removed (originally: **%s - %s**)", stringsHelper.FormatDcLongTime(res.Original.StartAt), stringsHelper.FormatDcLongTime(res.Original.EndAt)))
+				}
+
+				err = bot.SendDM(member, msgHeader+msgBody.String())
+				if err != nil {
+					a.log.Errorf("error sending DM: %s", err)
+				}
+			}(res, recipient)
+		}
+	}
+}
+
+// Returns the priority tier of member, based on their Discord roles.
+func memberTier(bot ports.BotPort, guild *discord.Guild, member *discord.Member, p *policy.Policy) policy.Tier {
+	return p.MemberTier(func(role string) bool {
+		return bot.MemberHasRole(guild, member, role)
+	})
+}
+
+// Returns a checker of Discord roles of guild members. Members are fetched first, since reservations,
+// queue entries and the like only know their IDs. Members that cannot be fetched have no roles.
+func (a *Application) roleChecker(bot ports.BotPort, guild *discord.Guild) policy.RoleChecker {
+	return func(member *discord.Member, role string) bool {
+		fetched, err := bot.GetMember(guild, member.ID)
+		if err != nil {
+			a.log.Errorf("error getting member: %s", err)
+
+			return false
+		}
+
+		return bot.MemberHasRole(guild, fetched, role)
 	}
 }
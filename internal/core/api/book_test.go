@@ -1,17 +1,21 @@
 package api
 
 import (
+	"errors"
 	"fmt"
 	stringsHelper "spot-assistant/internal/common/strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 
 	"spot-assistant/internal/common/test/mocks"
 	"spot-assistant/internal/core/dto/book"
 	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/policy"
 	"spot-assistant/internal/core/dto/reservation"
+	"spot-assistant/internal/core/dto/spot"
 	"spot-assistant/internal/core/dto/summary"
 )
 
@@ -33,7 +37,8 @@ func TestOnBookWithoutConflictingReservations(t *testing.T) {
 	endAt := startAt.Add(2 * time.Hour)
 	spotName := "test-spot"
 	bookingSrv := new(mocks.MockBookingService)
-	bookingSrv.On("Book", member, guild, spotName, startAt, endAt, false, false).Return(make([]*reservation.ClippedOrRemovedReservation, 0), nil)
+	bookingSrv.On("GetPolicy", guild).Return(policy.NewDefaultPolicy(guild.ID), nil)
+	bookingSrv.On("Book", member, guild, []*discord.Member(nil), spotName, startAt, endAt, false, policy.Tier{MaximumReservationsTime: policy.DEFAULT_MAXIMUM_RESERVATIONS_TIME}).Return(make([]*reservation.ClippedOrRemovedReservation, 0), nil)
 	defer bookingSrv.AssertExpectations(t)
 	reservationRepo := new(mocks.MockReservationRepo)
 	reservationRepo.On("SelectUpcomingReservationsWithSpot", mocks.ContextMock, guild.ID).Return(make([]*reservation.ReservationWithSpot, 0), nil)
@@ -113,7 +118,9 @@ func TestOnBookWithConflictingReservations(t *testing.T) {
 	}
 	outcomeSummary := &summary.Summary{}
 	bookingSrv := new(mocks.MockBookingService)
-	bookingSrv.On("Book", member, guild, spot.Name, startAt, endAt, false, false).Return(conflictingReservations, nil)
+	bookingSrv.On("GetPolicy", guild).Return(policy.NewDefaultPolicy(guild.ID), nil)
+	bookingSrv.On("Book", member, guild, []*discord.Member(nil), spot.Name, startAt, endAt, false, policy.Tier{MaximumReservationsTime: policy.DEFAULT_MAXIMUM_RESERVATIONS_TIME}).Return(conflictingReservations, nil)
+	bookingSrv.On("ProcessQueue", guild).Return([]*reservation.QueueEntryWithSpot{}, nil)
 	reservationRepo := new(mocks.MockReservationRepo)
 	reservationRepo.On("SelectUpcomingReservationsWithSpot", mocks.ContextMock, guild.ID).Return(finalReservations, nil)
 	defer bookingSrv.AssertExpectations(t)
@@ -122,7 +129,7 @@ func TestOnBookWithConflictingReservations(t *testing.T) {
 	botPort.On("FindChannelByName", guild, "letter-summary").Return(summaryChannel, nil)
 	botPort.On("GetMember", guild, conflictingMember.ID).Return(conflictingMember, nil)
 	botPort.On("SendLetterMessage", guild, summaryChannel, outcomeSummary).Return(nil)
-	botPort.On("SendDM", conflictingMember, fmt.Sprintf("Your reservation was overbooked by <@!test-member-id>\n* <@!test-conflicting-author-id> test-spot has been entirely removed (originally: **%s - %s**)", conflictingReservations[0].Original.StartAt.Format(stringsHelper.DC_LONG_TIME_FORMAT), conflictingReservations[0].Original.EndAt.Format(stringsHelper.DC_LONG_TIME_FORMAT))).Return(nil)
+	botPort.On("SendDM", conflictingMember, fmt.Sprintf("Your reservation was overbooked by <@!test-member-id>\n* <@!test-conflicting-author-id> test-spot has been entirely removed (originally: **%s - %s**)", stringsHelper.FormatDcLongTime(conflictingReservations[0].Original.StartAt), stringsHelper.FormatDcLongTime(conflictingReservations[0].Original.EndAt))).Return(nil)
 	summarySrv := new(mocks.MockSummaryService)
 	summarySrv.On("PrepareSummary", finalReservations).Return(outcomeSummary, nil)
 	adapter := NewApplication(reservationRepo, summarySrv, bookingSrv)
@@ -143,3 +150,148 @@ func TestOnBookWithConflictingReservations(t *testing.T) {
 		return botPort.AssertExpectations(t) && reservationRepo.AssertExpectations(t) && summarySrv.AssertExpectations(t)
 	}, 2*time.Second, 500*time.Millisecond)
 }
+
+func TestOnBookAutocompleteStartAtUsesMemberTimeZone(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{
+		ID: "test-guild-id",
+	}
+	member := &discord.Member{
+		ID: "test-member-id",
+	}
+	loc, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bookingSrv := new(mocks.MockBookingService)
+	bookingSrv.On("GetLocation", guild, member).Return(loc, nil)
+	bookingSrv.On("GetSuggestedHours", guild, mock.MatchedBy(func(baseTime time.Time) bool {
+		return baseTime.Location() == loc
+	}), "", "").Return([]string{"15:30"})
+	defer bookingSrv.AssertExpectations(t)
+	adapter := NewApplication(new(mocks.MockReservationRepo), new(mocks.MockSummaryService), bookingSrv)
+
+	// when
+	res, err := adapter.OnBookAutocomplete(book.BookAutocompleteRequest{
+		Guild:  guild,
+		Member: member,
+		Field:  book.BookAutocompleteStartAt,
+	})
+
+	// assert
+	assert.Nil(err)
+	assert.Equal(book.BookAutocompleteResponse{{Value: "15:30"}}, res)
+}
+
+func TestOnBookAutocompleteSpotShowsOpenings(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{
+		ID: "test-guild-id",
+	}
+	member := &discord.Member{
+		ID: "test-member-id",
+	}
+	loc, err := time.LoadLocation("Europe/Warsaw")
+	if err != nil {
+		t.Fatal(err)
+	}
+	nextOpeningAt := time.Date(2026, 10, 17, 18, 0, 0, 0, time.UTC)
+	bookingSrv := new(mocks.MockBookingService)
+	bookingSrv.On("GetLocation", guild, member).Return(loc, nil)
+	bookingSrv.On("FindAvailableSpots", guild, "so").Return([]string{"Soul War", "Library"}, nil)
+	bookingSrv.On("GetSpotOpenings", guild, []string{"Soul War", "Library"}, mock.Anything).Return([]*reservation.SpotOpening{
+		{Spot: "Soul War", Advance: 48 * time.Hour, NextOpeningAt: nextOpeningAt},
+	}, nil)
+	defer bookingSrv.AssertExpectations(t)
+	adapter := NewApplication(new(mocks.MockReservationRepo), new(mocks.MockSummaryService), bookingSrv)
+
+	// when
+	res, err := adapter.OnBookAutocomplete(book.BookAutocompleteRequest{
+		Guild:  guild,
+		Member: member,
+		Field:  book.BookAutocompleteSpot,
+		Value:  "so",
+	})
+
+	// assert
+	assert.Nil(err)
+	assert.Equal(book.BookAutocompleteResponse{
+		{Value: "Soul War", Label: "Soul War (up to 48h ahead, next day opens Sat 20:00)"},
+		{Value: "Library"},
+	}, res)
+}
+
+func TestOnBookProposesAlternativesOnConflict(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	member := &discord.Member{ID: "test-member-id"}
+	guild := &discord.Guild{ID: "test-guild-id"}
+	startAt := time.Now().Add(time.Hour)
+	endAt := startAt.Add(2 * time.Hour)
+	conflictingReservations := []*reservation.ClippedOrRemovedReservation{
+		{Original: &reservation.Reservation{ID: 1, StartAt: startAt, EndAt: endAt}},
+	}
+	alternatives := []*reservation.Alternative{
+		{
+			Spot:   reservation.Spot{ID: 2, Name: "test-spot -1", ParentID: 1},
+			Window: reservation.Window{StartAt: startAt, EndAt: endAt},
+		},
+	}
+	bookingSrv := new(mocks.MockBookingService)
+	bookingSrv.On("GetPolicy", guild).Return(policy.NewDefaultPolicy(guild.ID), nil)
+	bookingSrv.On("Book", member, guild, []*discord.Member(nil), "test-spot", startAt, endAt, false, policy.Tier{MaximumReservationsTime: policy.DEFAULT_MAXIMUM_RESERVATIONS_TIME}).Return(conflictingReservations, errors.New("conflicting reservations"))
+	bookingSrv.On("FindAlternatives", guild, "test-spot", startAt, endAt).Return(alternatives, nil)
+	defer bookingSrv.AssertExpectations(t)
+	botPort := new(mocks.MockBot)
+	botPort.On("MemberHasRole", guild, member, "Postman").Return(false)
+	adapter := NewApplication(new(mocks.MockReservationRepo), new(mocks.MockSummaryService), bookingSrv)
+
+	// when
+	res, err := adapter.OnBook(botPort, book.BookRequest{
+		Member:  member,
+		Guild:   guild,
+		StartAt: startAt,
+		EndAt:   endAt,
+		Spot:    "test-spot",
+	})
+
+	// assert
+	assert.NotNil(err)
+	assert.Equal(conflictingReservations, res.ConflictingReservations)
+	assert.Equal(alternatives, res.Alternatives)
+}
+
+func TestOnBookAlternative(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	member := &discord.Member{ID: "test-member-id"}
+	guild := &discord.Guild{ID: "test-guild-id"}
+	startAt := time.Now().Add(time.Hour)
+	endAt := startAt.Add(2 * time.Hour)
+	bookingSrv := new(mocks.MockBookingService)
+	bookingSrv.On("GetPolicy", guild).Return(policy.NewDefaultPolicy(guild.ID), nil)
+	bookingSrv.On("GetSpot", guild, int64(2)).Return(&spot.Spot{ID: 2, Name: "test-spot -1"}, nil)
+	bookingSrv.On("Book", member, guild, []*discord.Member{}, "test-spot -1", startAt, endAt, false, policy.Tier{MaximumReservationsTime: policy.DEFAULT_MAXIMUM_RESERVATIONS_TIME}).Return(make([]*reservation.ClippedOrRemovedReservation, 0), nil)
+	defer bookingSrv.AssertExpectations(t)
+	reservationRepo := new(mocks.MockReservationRepo)
+	reservationRepo.On("SelectUpcomingReservationsWithSpot", mocks.ContextMock, guild.ID).Return(make([]*reservation.ReservationWithSpot, 0), nil)
+	botPort := new(mocks.MockBot)
+	botPort.On("MemberHasRole", guild, member, "Postman").Return(false)
+	botPort.On("FindChannelByName", guild, "letter-summary").Return((*discord.Channel)(nil), errors.New("no summary channel"))
+	adapter := NewApplication(reservationRepo, new(mocks.MockSummaryService), bookingSrv)
+
+	// when
+	res, err := adapter.OnBookAlternative(botPort, book.BookAlternativeRequest{
+		Member:  member,
+		Guild:   guild,
+		SpotID:  2,
+		StartAt: startAt,
+		EndAt:   endAt,
+	})
+
+	// assert
+	assert.Nil(err)
+	assert.Equal("test-spot -1", res.Spot)
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"fmt"
+
+	stringsHelper "spot-assistant/internal/common/strings"
+	"spot-assistant/internal/core/dto/book"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/reservation"
+	"spot-assistant/internal/ports"
+)
+
+func (a *Application) OnCheckIn(request book.CheckInRequest) (*reservation.ReservationWithSpot, error) {
+	return a.bookingSrv.CheckIn(request.Guild, request.Member, request.ReservationID)
+}
+
+// Reminds authors of started reservations to check in, and lets authors of no-shows
+// know their reservations can now be taken over by anyone.
+func (a *Application) ProcessCheckIns(bot ports.BotPort, guild *discord.Guild) {
+	reminders, noShows, err := a.bookingSrv.ProcessCheckIns(guild)
+	if err != nil {
+		a.log.Errorf("could not process check-ins: %s", err)
+
+		return
+	}
+
+	for _, res := range reminders {
+		go func(res *reservation.ReservationWithSpot) {
+			member, err := bot.GetMember(guild, res.AuthorDiscordID)
+			if err != nil {
+				a.log.Errorf("error getting member: %s", err)
+				return
+			}
+
+			err = bot.SendCheckInReminder(guild, member, res, fmt.Sprintf(
+				"Your reservation on **%s** (%s - %s) has started. Check in with the button below or /checkin, otherwise anyone will be able to take it over.",
+				res.Spot.Name,
+				stringsHelper.FormatDcLongTime(res.StartAt),
+				stringsHelper.FormatDcLongTime(res.EndAt),
+			))
+			if err != nil {
+				a.log.Errorf("error sending check-in reminder: %s", err)
+			}
+		}(res)
+	}
+
+	for _, noShow := range noShows {
+		go func(noShow *reservation.NoShow) {
+			member, err := bot.GetMember(guild, noShow.AuthorDiscordID)
+			if err != nil {
+				a.log.Errorf("error getting member: %s", err)
+				return
+			}
+
+			err = bot.SendDM(member, fmt.Sprintf(
+				"You have not checked in on your **%s** reservation (%s - %s), so anyone can take it over now. It is your no-show #%d on this server.",
+				noShow.Spot.Name,
+				stringsHelper.FormatDcLongTime(noShow.StartAt),
+				stringsHelper.FormatDcLongTime(noShow.EndAt),
+				noShow.Count,
+			))
+			if err != nil {
+				a.log.Errorf("error sending DM: %s", err)
+			}
+		}(noShow)
+	}
+}
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"spot-assistant/internal/common/test/mocks"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/reservation"
+)
+
+func TestProcessCheckIns(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{
+		ID:   "test-guild-id",
+		Name: "test-guild",
+	}
+	author := &discord.Member{ID: "test-member-id"}
+	noShowAuthor := &discord.Member{ID: "test-no-show-member-id"}
+	reminder := &reservation.ReservationWithSpot{
+		Reservation: reservation.Reservation{ID: 1, AuthorDiscordID: author.ID},
+		Spot:        reservation.Spot{ID: 1, Name: "test-spot"},
+	}
+	noShow := &reservation.NoShow{
+		ReservationWithSpot: &reservation.ReservationWithSpot{
+			Reservation: reservation.Reservation{ID: 2, AuthorDiscordID: noShowAuthor.ID},
+			Spot:        reservation.Spot{ID: 2, Name: "test-other-spot"},
+		},
+		Count: 3,
+	}
+	bot := new(mocks.MockBot)
+	bot.On("GetMember", guild, author.ID).Return(author, nil)
+	bot.On("GetMember", guild, noShowAuthor.ID).Return(noShowAuthor, nil)
+	bot.On("SendCheckInReminder", guild, author, reminder, mock.AnythingOfType("string")).Return(nil)
+	bot.On("SendDM", noShowAuthor, mock.MatchedBy(func(message string) bool {
+		return strings.Contains(message, "no-show #3")
+	})).Return(nil)
+	bookingSrv := new(mocks.MockBookingService)
+	bookingSrv.On("ProcessCheckIns", guild).Return([]*reservation.ReservationWithSpot{reminder}, []*reservation.NoShow{noShow}, nil)
+	adapter := NewApplication(new(mocks.MockReservationRepo), new(mocks.MockSummaryService), bookingSrv)
+
+	// when
+	adapter.ProcessCheckIns(bot, guild)
+
+	// assert
+	assert.Eventually(func() bool {
+		return bot.AssertExpectations(t) && bookingSrv.AssertExpectations(t)
+	}, 5*time.Second, 100*time.Millisecond)
+}
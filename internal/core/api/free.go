@@ -0,0 +1,40 @@
+package api
+
+import (
+	"errors"
+	"time"
+
+	"spot-assistant/internal/core/dto/book"
+)
+
+// Returns free time windows of a spot on a chosen day in member time zone,
+// or within the next 24 hours if no day has been chosen.
+func (a *Application) OnFree(request book.FreeRequest) (book.FreeResponse, error) {
+	// Windows start at a full minute, as that is what can be booked
+	now := a.memberNow(request.Guild, request.Member).Add(time.Minute - time.Nanosecond).Truncate(time.Minute)
+	response := book.FreeResponse{
+		From: now,
+		To:   now.Add(24 * time.Hour),
+	}
+
+	if request.Date != nil {
+		response.From = *request.Date
+		response.To = request.Date.AddDate(0, 0, 1)
+		if !response.To.After(now) {
+			return response, errors.New("cannot look for free time in the past")
+		}
+		if response.From.Before(now) {
+			response.From = now
+		}
+	}
+
+	s, windows, err := a.bookingSrv.FindFreeWindows(request.Guild, request.Spot, response.From, response.To)
+	if err != nil {
+		return response, err
+	}
+
+	response.Spot = s.Name
+	response.Windows = windows
+
+	return response, nil
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"spot-assistant/internal/common/test/mocks"
+	"spot-assistant/internal/core/dto/book"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/reservation"
+	"spot-assistant/internal/core/dto/spot"
+)
+
+func TestOnFreeOnChosenDate(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	member := &discord.Member{ID: "test-member-id"}
+	now := time.Now()
+	date := time.Date(now.Year(), now.Month(), now.Day()+2, 0, 0, 0, 0, time.Local)
+	windows := []*reservation.Window{{StartAt: date, EndAt: date.Add(3 * time.Hour)}}
+	bookingSrv := new(mocks.MockBookingService)
+	bookingSrv.On("GetLocation", guild, member).Return(time.Local, nil)
+	bookingSrv.On("FindFreeWindows", guild, "libby", date, date.AddDate(0, 0, 1)).Return(&spot.Spot{Name: "Library"}, windows, nil)
+	defer bookingSrv.AssertExpectations(t)
+	adapter := NewApplication(new(mocks.MockReservationRepo), new(mocks.MockSummaryService), bookingSrv)
+
+	// when
+	res, err := adapter.OnFree(book.FreeRequest{Guild: guild, Member: member, Spot: "libby", Date: &date})
+
+	// assert
+	assert.Nil(err)
+	assert.Equal("Library", res.Spot)
+	assert.Equal(windows, res.Windows)
+}
+
+func TestOnFreeFailsOnPastDate(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	member := &discord.Member{ID: "test-member-id"}
+	now := time.Now()
+	date := time.Date(now.Year(), now.Month(), now.Day()-1, 0, 0, 0, 0, time.Local)
+	bookingSrv := new(mocks.MockBookingService)
+	bookingSrv.On("GetLocation", guild, member).Return(time.Local, nil)
+	adapter := NewApplication(new(mocks.MockReservationRepo), new(mocks.MockSummaryService), bookingSrv)
+
+	// when
+	_, err := adapter.OnFree(book.FreeRequest{Guild: guild, Member: member, Spot: "libby", Date: &date})
+
+	// assert
+	assert.ErrorContains(err, "in the past")
+	bookingSrv.AssertNotCalled(t, "FindFreeWindows")
+}
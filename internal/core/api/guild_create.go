@@ -24,7 +24,15 @@ func (a *Application) OnGuildCreate(bot ports.BotPort, guild *discord.Guild) {
 		return
 	}
 
-	err = bot.EnsureRoles(guild)
+	// Overbook role is configurable, so it is read from the guild policy
+	p, err := a.bookingSrv.GetPolicy(guild)
+	if err != nil {
+		log.Errorf("could not fetch policy: %s", err)
+
+		return
+	}
+
+	err = bot.EnsureRoles(guild, p.OverbookRole)
 	if err != nil {
 		log.Errorf("could not ensure roles: %s", err)
 
@@ -0,0 +1,32 @@
+package api
+
+import (
+	"errors"
+	"testing"
+
+	"spot-assistant/internal/common/test/mocks"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/policy"
+)
+
+func TestOnGuildCreateEnsuresOverbookRoleOfPolicy(t *testing.T) {
+	// given
+	guild := &discord.Guild{ID: "test-guild-id"}
+	p := policy.NewDefaultPolicy(guild.ID)
+	p.OverbookRole = "Mailman"
+	bookingSrv := new(mocks.MockBookingService)
+	bookingSrv.On("GetPolicy", guild).Return(p, nil)
+	bot := new(mocks.MockBot)
+	bot.On("RegisterCommands", guild).Return(nil)
+	bot.On("EnsureChannel", guild).Return(nil)
+	// Stops guild registration, so that the summary is not sent
+	bot.On("EnsureRoles", guild, []string{"Mailman"}).Return(errors.New("missing permissions"))
+	defer bot.AssertExpectations(t)
+	adapter := NewApplication(new(mocks.MockReservationRepo), new(mocks.MockSummaryService), bookingSrv)
+
+	// when
+	adapter.OnGuildCreate(bot, guild)
+
+	// assert
+	bot.AssertNotCalled(t, "FindChannelByName", guild, "letter-summary")
+}
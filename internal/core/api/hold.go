@@ -0,0 +1,76 @@
+package api
+
+import (
+	"fmt"
+
+	stringsHelper "spot-assistant/internal/common/strings"
+	"spot-assistant/internal/core/dto/book"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/reservation"
+	"spot-assistant/internal/ports"
+)
+
+func (a *Application) OnHold(bot ports.BotPort, request book.HoldRequest) (book.HoldResponse, error) {
+	p, err := a.bookingSrv.GetPolicy(request.Guild)
+	if err != nil {
+		return book.HoldResponse{}, err
+	}
+
+	hold, conflicting, err := a.bookingSrv.Hold(request.Member, request.Guild, request.Spot, request.StartAt, request.EndAt, memberTier(bot, request.Guild, request.Member, p))
+	response := book.HoldResponse{Hold: hold, ConflictingReservations: conflicting}
+	if err != nil {
+		return response, err
+	}
+
+	go a.UpdateGuildSummaryAndLogError(bot, request.Guild)
+
+	return response, nil
+}
+
+func (a *Application) OnConfirm(bot ports.BotPort, request book.ConfirmRequest) (*reservation.ReservationWithSpot, error) {
+	p, err := a.bookingSrv.GetPolicy(request.Guild)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := a.bookingSrv.Confirm(request.Guild, request.Member, request.ReservationID, memberTier(bot, request.Guild, request.Member, p))
+	if err != nil {
+		return nil, err
+	}
+
+	go a.UpdateGuildSummaryAndLogError(bot, request.Guild)
+
+	return res, nil
+}
+
+// Releases holds that have not been confirmed in time and lets their authors know.
+func (a *Application) ReleaseExpiredHolds(bot ports.BotPort, guild *discord.Guild) {
+	released, err := a.bookingSrv.ReleaseExpiredHolds(guild)
+	if err != nil {
+		a.log.Errorf("could not release expired holds: %s", err)
+	}
+
+	if len(released) == 0 {
+		return
+	}
+
+	for _, hold := range released {
+		go func(hold *reservation.ReservationWithSpot) {
+			member, err := bot.GetMember(guild, hold.AuthorDiscordID)
+			if err != nil {
+				a.log.Errorf("error getting member: %s", err)
+				return
+			}
+
+			err = bot.SendDM(member, fmt.Sprintf(
+				"Your hold on **%s** (%s - %s) has not been confirmed in time, so the respawn has been released.",
+				hold.Spot.Name,
+				stringsHelper.FormatDcLongTime(hold.StartAt),
+				stringsHelper.FormatDcLongTime(hold.EndAt),
+			))
+			if err != nil {
+				a.log.Errorf("error sending DM: %s", err)
+			}
+		}(hold)
+	}
+}
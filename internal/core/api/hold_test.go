@@ -0,0 +1,64 @@
+package api
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	stringsHelper "spot-assistant/internal/common/strings"
+	"spot-assistant/internal/common/test/mocks"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/reservation"
+)
+
+func TestReleaseExpiredHolds(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	member := &discord.Member{ID: "test-member-id"}
+	startAt := time.Now().Add(1 * time.Hour)
+	endAt := startAt.Add(2 * time.Hour)
+	released := []*reservation.ReservationWithSpot{
+		{
+			Reservation: reservation.Reservation{ID: 1, AuthorDiscordID: member.ID, StartAt: startAt, EndAt: endAt},
+			Spot:        reservation.Spot{ID: 1, Name: "test-spot"},
+		},
+	}
+	bookingSrv := new(mocks.MockBookingService)
+	bookingSrv.On("ReleaseExpiredHolds", guild).Return(released, nil)
+	reservationRepo := new(mocks.MockReservationRepo)
+	bot := new(mocks.MockBot)
+	bot.On("GetMember", guild, member.ID).Return(member, nil)
+	bot.On("SendDM", member, fmt.Sprintf(
+		"Your hold on **test-spot** (%s - %s) has not been confirmed in time, so the respawn has been released.",
+		stringsHelper.FormatDcLongTime(startAt), stringsHelper.FormatDcLongTime(endAt),
+	)).Return(nil)
+	adapter := NewApplication(reservationRepo, new(mocks.MockSummaryService), bookingSrv)
+
+	// when
+	adapter.ReleaseExpiredHolds(bot, guild)
+
+	// assert
+	assert.Eventually(func() bool {
+		return bot.AssertExpectations(t) && bookingSrv.AssertExpectations(t)
+	}, 5*time.Second, 100*time.Millisecond)
+	bookingSrv.AssertNotCalled(t, "ProcessQueue")
+}
+
+func TestReleaseExpiredHoldsWhenNothingReleased(t *testing.T) {
+	// given
+	guild := &discord.Guild{ID: "test-guild-id"}
+	bookingSrv := new(mocks.MockBookingService)
+	bookingSrv.On("ReleaseExpiredHolds", guild).Return([]*reservation.ReservationWithSpot{}, nil)
+	bot := new(mocks.MockBot)
+	adapter := NewApplication(new(mocks.MockReservationRepo), new(mocks.MockSummaryService), bookingSrv)
+
+	// when
+	adapter.ReleaseExpiredHolds(bot, guild)
+
+	// assert
+	bookingSrv.AssertExpectations(t)
+	bookingSrv.AssertNotCalled(t, "ProcessQueue")
+}
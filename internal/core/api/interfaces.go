@@ -4,7 +4,9 @@ import (
 	"time"
 
 	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/policy"
 	"spot-assistant/internal/core/dto/reservation"
+	"spot-assistant/internal/core/dto/spot"
 	"spot-assistant/internal/core/dto/summary"
 )
 
@@ -13,17 +15,151 @@ type summaryService interface {
 }
 
 type bookingService interface {
-	// Returns available spots based on optional filter, or an error.
-	FindAvailableSpots(filter string) ([]string, error)
+	// Returns spots available to the guild based on optional filter, or an error.
+	FindAvailableSpots(guild *discord.Guild, filter string) ([]string, error)
 
-	// Returns suggested hours based on base time and optional filter.
-	GetSuggestedHours(time.Time, string) []string
+	// Returns shared spots hidden from the guild based on optional filter, or an error.
+	FindHiddenSpots(guild *discord.Guild, filter string) ([]string, error)
 
-	// Returns array of conflicting reservations (or removed reservations)
-	// and an optional error.
-	Book(member *discord.Member, guild *discord.Guild, spot string, startAt time.Time, endAt time.Time, overbook bool, hasPermissions bool) ([]*reservation.ClippedOrRemovedReservation, error)
+	// Adds a new spot owned by the guild.
+	AddSpot(guild *discord.Guild, name string, parent string) (*spot.Spot, error)
+
+	// Changes name of a spot owned by the guild, returns renamed spot.
+	RenameSpot(guild *discord.Guild, name string, newName string) (*spot.Spot, error)
+
+	// Archives a spot owned by the guild, so that it cannot be booked anymore. Returns archived spot.
+	DescribeSpot(guild *discord.Guild, name string, details spot.Details) (*spot.Spot, error)
+	AddSpotAlias(guild *discord.Guild, name string, alias string) (*spot.Spot, error)
+	RemoveSpotAlias(guild *discord.Guild, name string, alias string) (*spot.Spot, error)
+	ImportSpots(guild *discord.Guild, entries []*spot.CatalogEntry, dryRun bool) (*spot.ImportResult, error)
+	ExportSpots(guild *discord.Guild) ([]*spot.CatalogEntry, error)
+	ArchiveSpot(guild *discord.Guild, name string) (*spot.Spot, error)
+
+	// Hides a shared spot from the guild, returns hidden spot.
+	HideSpot(guild *discord.Guild, name string) (*spot.Spot, error)
+
+	// Shows a shared spot hidden from the guild again, returns the spot.
+	UnhideSpot(guild *discord.Guild, name string) (*spot.Spot, error)
+
+	// Returns suggested hours based on guild policy, base time and optional filter.
+	// Hours the spot of a given name is free at come first, unless the name is empty.
+	GetSuggestedHours(guild *discord.Guild, baseTime time.Time, spotName string, filter string) []string
+
+	// Returns free slots as long as the requested one, on the spot or its siblings, nearest first.
+	FindAlternatives(guild *discord.Guild, spotName string, startAt time.Time, endAt time.Time) ([]*reservation.Alternative, error)
+
+	// Returns spot of a given ID, which the guild can book.
+	GetSpot(guild *discord.Guild, spotId int64) (*spot.Spot, error)
+
+	// Returns windows between from and to, in which the spot is free, along with the spot.
+	FindFreeWindows(guild *discord.Guild, spotName string, from time.Time, to time.Time) (*spot.Spot, []*reservation.Window, error)
+
+	// Returns suggested dates within guild booking horizon, based on base time and optional filter.
+	GetSuggestedDates(*discord.Guild, time.Time, string) []string
+
+	GetPolicy(guild *discord.Guild) (*policy.Policy, error)
+
+	// Returns member time zone, falling back to the guild one.
+	GetLocation(guild *discord.Guild, member *discord.Member) (*time.Location, error)
+
+	// Sets member time zone, empty time zone restores the guild one.
+	SetMemberTimeZone(guild *discord.Guild, member *discord.Member, timeZone string) (*time.Location, error)
+
+	// Validates and saves guild policy.
+	SavePolicy(p *policy.Policy) (*policy.Policy, error)
+
+	// Returns member reminder preference, or the default one.
+	GetReminderPreference(guild *discord.Guild, member *discord.Member) (*policy.ReminderPreference, error)
+
+	// Validates and saves member reminder preference.
+	SaveReminderPreference(p *policy.ReminderPreference) (*policy.ReminderPreference, error)
+
+	// Returns reminders about guild reservations that are due, each of them only once.
+	ProcessReminders(guild *discord.Guild) ([]*reservation.Reminder, error)
+
+	// Returns member usage of guild weekly quotas within the current week.
+	GetQuotaUsage(guild *discord.Guild, member *discord.Member) ([]*reservation.QuotaUsage, error)
+
+	// Returns openings of the named spots covered by guild booking windows as of a given time.
+	GetSpotOpenings(guild *discord.Guild, spotNames []string, currTime time.Time) ([]*reservation.SpotOpening, error)
+
+	// Books a spot for member and their party, whose tiers are resolved with hasRole. Returns array of conflicting
+	// reservations (or removed reservations) and an optional error.
+	Book(member *discord.Member, guild *discord.Guild, party []*discord.Member, spot string, startAt time.Time, endAt time.Time, overbook bool, tier policy.Tier, hasRole policy.RoleChecker) ([]*reservation.ClippedOrRemovedReservation, error)
 
 	UnbookAutocomplete(g *discord.Guild, m *discord.Member, filter string) ([]*reservation.ReservationWithSpot, error)
 
 	Unbook(g *discord.Guild, m *discord.Member, reservationId int64) (*reservation.ReservationWithSpot, error)
+
+	// Moves member reservation, nil date, start or end time keep the current ones. Returns
+	// moved reservation, array of conflicting reservations (or removed reservations) and an optional error.
+	Rebook(member *discord.Member, guild *discord.Guild, reservationId int64, date *time.Time, startTime *time.Duration, endTime *time.Duration, overbook bool, tier policy.Tier) (*reservation.ReservationWithSpot, []*reservation.ClippedOrRemovedReservation, error)
+
+	// Adds a co-hunter to member reservation, returns the reservation.
+	AddPartyMember(guild *discord.Guild, author *discord.Member, reservationId int64, partyMember *discord.Member, hasRole policy.RoleChecker) (*reservation.ReservationWithSpot, error)
+
+	// Removes a co-hunter from member reservation, returns the reservation.
+	RemovePartyMember(guild *discord.Guild, author *discord.Member, reservationId int64, partyMember *discord.Member) (*reservation.ReservationWithSpot, error)
+
+	// Returns member reservation if it can be handed over to the recipient, or an error.
+	CheckTransfer(guild *discord.Guild, from *discord.Member, to *discord.Member, reservationId int64, hasRole policy.RoleChecker) (*reservation.ReservationWithSpot, error)
+
+	// Hands member reservation over to the recipient, returns transferred reservation.
+	Transfer(guild *discord.Guild, from *discord.Member, to *discord.Member, reservationId int64, hasRole policy.RoleChecker) (*reservation.ReservationWithSpot, error)
+
+	// Confirms member presence on their reservation, returns the reservation.
+	CheckIn(guild *discord.Guild, member *discord.Member, reservationId int64) (*reservation.ReservationWithSpot, error)
+
+	// Flags guild reservations that have not been checked in on time, returns reservations
+	// whose authors should be reminded to check in, and flagged no-shows.
+	ProcessCheckIns(guild *discord.Guild) ([]*reservation.ReservationWithSpot, []*reservation.NoShow, error)
+
+	// Tentatively reserves a spot until the hold expires, returns the hold, or reservations that prevented it.
+	Hold(member *discord.Member, guild *discord.Guild, spot string, startAt time.Time, endAt time.Time, tier policy.Tier) (*reservation.ReservationWithSpot, []*reservation.ClippedOrRemovedReservation, error)
+
+	// Turns member hold into a regular reservation, as long as it fits within limits of the member tier,
+	// returns the reservation.
+	Confirm(guild *discord.Guild, member *discord.Member, reservationId int64, tier policy.Tier) (*reservation.ReservationWithSpot, error)
+
+	// Releases guild holds that have not been confirmed in time, returns released holds.
+	ReleaseExpiredHolds(guild *discord.Guild) ([]*reservation.ReservationWithSpot, error)
+
+	// Asks authors of reservations an overbook would clip or remove for approval. Returns nil request
+	// if nobody has to approve it, or reservations that prevented it.
+	RequestOverbook(member *discord.Member, guild *discord.Guild, party []*discord.Member, spot string, startAt time.Time, endAt time.Time, tier policy.Tier, hasRole policy.RoleChecker) (*reservation.OverbookRequestWithSpot, []*reservation.ClippedOrRemovedReservation, error)
+
+	// Books an approved overbook request on behalf of its author, overbooking only reservations it has been
+	// approved for. Returns clipped or removed reservations.
+	BookOverbookRequest(member *discord.Member, guild *discord.Guild, party []*discord.Member, request *reservation.OverbookRequestWithSpot, tier policy.Tier, hasRole policy.RoleChecker) ([]*reservation.ClippedOrRemovedReservation, error)
+
+	// Records member answer to an overbook request, returns the request and whether it has been resolved.
+	AnswerOverbookRequest(guild *discord.Guild, member *discord.Member, requestId int64, accepted bool) (*reservation.OverbookRequestWithSpot, bool, error)
+
+	// Resolves guild overbook requests that have not been answered in time, returns resolved requests.
+	ResolveExpiredOverbookRequests(guild *discord.Guild) ([]*reservation.OverbookRequestWithSpot, error)
+
+	// Creates a weekly series, which is later materialized into reservations.
+	CreateSeries(member *discord.Member, guild *discord.Guild, spot string, weekdays []time.Weekday, startTime time.Duration, endTime time.Duration) (*reservation.SeriesWithSpot, error)
+
+	// Materializes guild series into reservations, returns created reservations.
+	MaterializeSeries(guild *discord.Guild, hasRole policy.RoleChecker) ([]*reservation.Reservation, error)
+
+	// Returns suggested weekday combinations based on optional filter.
+	GetSuggestedWeekdays(filter string) []string
+
+	FindMemberSeries(g *discord.Guild, m *discord.Member, filter string) ([]*reservation.SeriesWithSpot, error)
+
+	CancelSeries(g *discord.Guild, m *discord.Member, seriesId int64) (*reservation.SeriesWithSpot, error)
+
+	// Puts member on a waitlist for an occupied spot.
+	Enqueue(member *discord.Member, guild *discord.Guild, spot string, startAt time.Time, endAt time.Time) (*reservation.QueueEntryWithSpot, error)
+
+	// Books queue entries that became free, returns booked entries.
+	ProcessQueue(guild *discord.Guild, hasRole policy.RoleChecker) ([]*reservation.QueueEntryWithSpot, error)
+
+	// Applies for a reservation of a spot handed out by lottery.
+	Apply(member *discord.Member, guild *discord.Guild, spot string, startAt time.Time, endAt time.Time) (*reservation.LotteryApplicationWithSpot, error)
+
+	// Draws lots among guild applications which draw time has come, returns drawn applications.
+	DrawLotteries(guild *discord.Guild, hasRole policy.RoleChecker) ([]*reservation.LotteryApplicationWithSpot, error)
 }
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"fmt"
+
+	stringsHelper "spot-assistant/internal/common/strings"
+	"spot-assistant/internal/core/dto/book"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/reservation"
+	"spot-assistant/internal/ports"
+)
+
+func (a *Application) OnApply(bot ports.BotPort, request book.ApplyRequest) (*reservation.LotteryApplicationWithSpot, error) {
+	return a.bookingSrv.Apply(request.Member, request.Guild, request.Spot, request.StartAt, request.EndAt)
+}
+
+// Draws lots among guild applications which draw time has come, and lets their authors know
+// whether they have won.
+func (a *Application) DrawLotteries(bot ports.BotPort, guild *discord.Guild) {
+	drawn, err := a.bookingSrv.DrawLotteries(guild, a.roleChecker(bot, guild))
+	if err != nil {
+		a.log.Errorf("could not draw lotteries: %s", err)
+	}
+
+	for _, application := range drawn {
+		go func(application *reservation.LotteryApplicationWithSpot) {
+			member, err := bot.GetMember(guild, application.AuthorDiscordID)
+			if err != nil {
+				a.log.Errorf("error getting member: %s", err)
+				return
+			}
+
+			msg := fmt.Sprintf(
+				"You have won the lottery for **%s** between %s and %s, so it has been booked for you.",
+				application.Spot.Name,
+				stringsHelper.FormatDcLongTime(application.StartAt),
+				stringsHelper.FormatDcLongTime(application.EndAt),
+			)
+			if application.Won == nil || !*application.Won {
+				msg = fmt.Sprintf(
+					"You have not won the lottery for **%s** between %s and %s. You can still book any time of it that remains free.",
+					application.Spot.Name,
+					stringsHelper.FormatDcLongTime(application.StartAt),
+					stringsHelper.FormatDcLongTime(application.EndAt),
+				)
+			}
+
+			err = bot.SendDM(member, msg)
+			if err != nil {
+				a.log.Errorf("error sending DM: %s", err)
+			}
+		}(application)
+	}
+}
@@ -1,12 +1,26 @@
 package api
 
 import (
+	"spot-assistant/internal/core/dto/discord"
 	"spot-assistant/internal/ports"
 )
 
 func (a *Application) OnTick(bot ports.BotPort) {
 	guilds := bot.GetGuilds()
 	for _, guild := range guilds {
-		go a.UpdateGuildSummaryAndLogError(bot, guild)
+		go a.SendReminders(bot, guild)
+		go a.runGuildJobs(bot, guild)
 	}
 }
+
+// Runs guild jobs, which book or free reservations, one after another. The queue is processed
+// once they are done, so that it sees their outcome, and guild summary gets refreshed once as well.
+func (a *Application) runGuildJobs(bot ports.BotPort, guild *discord.Guild) {
+	a.ProcessCheckIns(bot, guild)
+	a.MaterializeSeries(bot, guild)
+	a.ReleaseExpiredHolds(bot, guild)
+	a.ResolveExpiredOverbookRequests(bot, guild)
+	// Lottery winners go before the queue
+	a.DrawLotteries(bot, guild)
+	a.ProcessQueueAndUpdateGuildSummary(bot, guild)
+}
@@ -0,0 +1,37 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"spot-assistant/internal/common/test/mocks"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/reservation"
+)
+
+func TestRunGuildJobsProcessesQueueOnce(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	bookingSrv := new(mocks.MockBookingService)
+	bookingSrv.On("ProcessCheckIns", guild).Return([]*reservation.ReservationWithSpot{}, []*reservation.NoShow{}, nil)
+	bookingSrv.On("MaterializeSeries", guild).Return([]*reservation.Reservation{}, nil)
+	bookingSrv.On("ReleaseExpiredHolds", guild).Return([]*reservation.ReservationWithSpot{}, nil)
+	bookingSrv.On("ResolveExpiredOverbookRequests", guild).Return([]*reservation.OverbookRequestWithSpot{}, nil)
+	bookingSrv.On("DrawLotteries", guild).Return([]*reservation.LotteryApplicationWithSpot{}, nil)
+	bookingSrv.On("ProcessQueue", guild).Return([]*reservation.QueueEntryWithSpot{}, nil)
+	reservationRepo := new(mocks.MockReservationRepo)
+	reservationRepo.On("SelectUpcomingReservationsWithSpot", mocks.ContextMock, guild.ID).Return([]*reservation.ReservationWithSpot{}, nil)
+	bot := new(mocks.MockBot)
+	bot.On("FindChannelByName", guild, "letter-summary").Return(&discord.Channel{Name: "letter-summary"}, nil)
+	adapter := NewApplication(reservationRepo, new(mocks.MockSummaryService), bookingSrv)
+
+	// when
+	adapter.runGuildJobs(bot, guild)
+
+	// assert
+	assert.True(bookingSrv.AssertExpectations(t))
+	bookingSrv.AssertNumberOfCalls(t, "ProcessQueue", 1)
+	reservationRepo.AssertNumberOfCalls(t, "SelectUpcomingReservationsWithSpot", 1)
+}
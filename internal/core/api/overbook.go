@@ -0,0 +1,159 @@
+package api
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	stringsHelper "spot-assistant/internal/common/strings"
+	"spot-assistant/internal/core/dto/book"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/reservation"
+	"spot-assistant/internal/ports"
+)
+
+// Channel overbook outcomes are announced in
+const OVERBOOK_OUTCOME_CHANNEL = "letter"
+
+func (a *Application) OnOverbookAnswer(bot ports.BotPort, request book.OverbookAnswerRequest) (*reservation.OverbookRequestWithSpot, error) {
+	res, resolved, err := a.bookingSrv.AnswerOverbookRequest(request.Guild, request.Member, request.RequestID, request.Accepted)
+	if err != nil {
+		return nil, err
+	}
+
+	if resolved {
+		go func() {
+			// Overbooking might have freed slots someone is queued for
+			if a.resolveOverbook(bot, request.Guild, res, false) {
+				a.ProcessQueueAndUpdateGuildSummary(bot, request.Guild)
+			}
+		}()
+	}
+
+	return res, nil
+}
+
+// Carries out overbook requests, which have not been answered in time.
+func (a *Application) ResolveExpiredOverbookRequests(bot ports.BotPort, guild *discord.Guild) {
+	resolved, err := a.bookingSrv.ResolveExpiredOverbookRequests(guild)
+	if err != nil {
+		a.log.Errorf("could not resolve expired overbook requests: %s", err)
+	}
+
+	for _, request := range resolved {
+		a.resolveOverbook(bot, guild, request, true)
+	}
+}
+
+// Sends each affected member a DM with buttons to accept or decline the overbook.
+func (a *Application) askForOverbookApproval(bot ports.BotPort, guild *discord.Guild, request *reservation.OverbookRequestWithSpot) {
+	for _, approver := range request.ApproverDiscordIDs() {
+		go func(approver string) {
+			member, err := bot.GetMember(guild, approver)
+			if err != nil {
+				a.log.Errorf("error getting member: %s", err)
+				return
+			}
+
+			err = bot.SendOverbookApprovalRequest(guild, member, request, fmt.Sprintf(
+				"<@!%s> would like to overbook your reservation on **%s** with %s - %s. If you do not answer until %s, the overbook will be approved.",
+				request.AuthorDiscordID,
+				request.Spot.Name,
+				stringsHelper.FormatDcLongTime(request.StartAt),
+				stringsHelper.FormatDcLongTime(request.EndAt),
+				stringsHelper.FormatDcTime(request.ExpiresAt),
+			))
+			if err != nil {
+				a.log.Errorf("error sending DM: %s", err)
+			}
+		}(approver)
+	}
+}
+
+// Books a resolved overbook request unless it has been declined, and announces the outcome.
+// Returns true if the request has been booked.
+func (a *Application) resolveOverbook(bot ports.BotPort, guild *discord.Guild, request *reservation.OverbookRequestWithSpot, expired bool) bool {
+	timeRange := fmt.Sprintf("%s - %s", stringsHelper.FormatDcLongTime(request.StartAt), stringsHelper.FormatDcLongTime(request.EndAt))
+
+	if request.Declined() {
+		// Approvals are given per reservation, so members with several of them are mentioned once
+		mentions := []string{}
+		for _, approval := range request.Approvals {
+			mention := fmt.Sprintf("<@!%s>", approval.MemberDiscordID)
+			if approval.Accepted != nil && !*approval.Accepted && !slices.Contains(mentions, mention) {
+				mentions = append(mentions, mention)
+			}
+		}
+
+		a.announceOverbookOutcome(bot, guild, fmt.Sprintf(
+			"Overbook of **%s** (%s) requested by <@!%s> has been declined by %s.",
+			request.Spot.Name, timeRange, request.AuthorDiscordID, strings.Join(mentions, ", "),
+		))
+
+		return false
+	}
+
+	conflicts, err := a.bookOverbookRequest(bot, guild, request)
+	if err != nil {
+		a.announceOverbookOutcome(bot, guild, fmt.Sprintf(
+			"Overbook of **%s** (%s) requested by <@!%s> has been approved, but could not be booked: %s",
+			request.Spot.Name, timeRange, request.AuthorDiscordID, err,
+		))
+
+		return false
+	}
+
+	outcome := "approved"
+	if expired {
+		outcome = "approved automatically, as it has not been answered in time"
+	}
+	a.announceOverbookOutcome(bot, guild, fmt.Sprintf(
+		"Overbook of **%s** (%s) requested by <@!%s> has been %s.",
+		request.Spot.Name, timeRange, request.AuthorDiscordID, outcome,
+	))
+
+	author := &discord.Member{ID: request.AuthorDiscordID}
+	a.notifyOverbookedMembers(bot, guild, author, request.Spot.Name, conflicts)
+
+	return true
+}
+
+// Books the request on behalf of its author. The conflicts are checked again against
+// the author's current tier, in case they lost their roles in the meantime, and only
+// the reservations the request has been approved for get overbooked.
+func (a *Application) bookOverbookRequest(bot ports.BotPort, guild *discord.Guild, request *reservation.OverbookRequestWithSpot) ([]*reservation.ClippedOrRemovedReservation, error) {
+	author, err := bot.GetMember(guild, request.AuthorDiscordID)
+	if err != nil {
+		return nil, fmt.Errorf("could not find the author: %w", err)
+	}
+
+	p, err := a.bookingSrv.GetPolicy(guild)
+	if err != nil {
+		return nil, err
+	}
+
+	party := make([]*discord.Member, 0, len(request.PartyMemberDiscordIDs))
+	for _, id := range request.PartyMemberDiscordIDs {
+		partyMember, err := bot.GetMember(guild, id)
+		if err != nil {
+			return nil, fmt.Errorf("could not find party member: %w", err)
+		}
+
+		party = append(party, partyMember)
+	}
+
+	return a.bookingSrv.BookOverbookRequest(author, guild, party, request, memberTier(bot, guild, author, p), a.roleChecker(bot, guild))
+}
+
+func (a *Application) announceOverbookOutcome(bot ports.BotPort, guild *discord.Guild, message string) {
+	channel, err := bot.FindChannelByName(guild, OVERBOOK_OUTCOME_CHANNEL)
+	if err != nil {
+		a.log.Errorf("could not find the %s channel: %s", OVERBOOK_OUTCOME_CHANNEL, err)
+		return
+	}
+
+	err = bot.SendChannelMessage(guild, channel, message)
+	if err != nil {
+		a.log.Errorf("error sending overbook outcome: %s", err)
+	}
+}
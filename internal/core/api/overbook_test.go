@@ -0,0 +1,132 @@
+package api
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"spot-assistant/internal/common/test/mocks"
+	"spot-assistant/internal/core/dto/book"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/policy"
+	"spot-assistant/internal/core/dto/reservation"
+)
+
+func TestOnBookAsksForOverbookApproval(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	member := &discord.Member{ID: "test-member-id"}
+	affected := &discord.Member{ID: "affected-member-id"}
+	startAt := time.Now()
+	endAt := startAt.Add(2 * time.Hour)
+	p := policy.NewDefaultPolicy(guild.ID)
+	p.Blackouts = []policy.Blackout{}
+	p.OverbookApproval = true
+	pending := &reservation.OverbookRequestWithSpot{
+		OverbookRequest: reservation.OverbookRequest{ID: 1, AuthorDiscordID: member.ID, StartAt: startAt, EndAt: endAt, Approvals: []*reservation.OverbookApproval{
+			{ReservationID: 1, MemberDiscordID: affected.ID},
+			{ReservationID: 2, MemberDiscordID: affected.ID},
+		}},
+		Spot: reservation.Spot{ID: 1, Name: "test-spot"},
+	}
+	bookingSrv := new(mocks.MockBookingService)
+	bookingSrv.On("GetPolicy", guild).Return(p, nil)
+	bookingSrv.On("RequestOverbook", member, guild, []*discord.Member(nil), "test-spot", startAt, endAt, policy.Tier{Role: "Postman", Priority: 1, MaximumReservationsTime: policy.DEFAULT_MAXIMUM_RESERVATIONS_TIME}).Return(pending, []*reservation.ClippedOrRemovedReservation(nil), nil)
+	bot := new(mocks.MockBot)
+	bot.On("MemberHasRole", guild, member, "Postman").Return(true)
+	bot.On("GetMember", guild, affected.ID).Return(affected, nil)
+	bot.On("SendOverbookApprovalRequest", guild, affected, pending, mock.AnythingOfType("string")).Return(nil)
+	adapter := NewApplication(new(mocks.MockReservationRepo), new(mocks.MockSummaryService), bookingSrv)
+
+	// when
+	res, err := adapter.OnBook(bot, book.BookRequest{
+		Member:   member,
+		Guild:    guild,
+		Spot:     "test-spot",
+		StartAt:  startAt,
+		EndAt:    endAt,
+		Overbook: true,
+	})
+
+	// assert
+	assert.Nil(err)
+	assert.Equal(pending, res.OverbookRequest)
+	assert.Eventually(func() bool {
+		return bot.AssertExpectations(t)
+	}, 2*time.Second, 100*time.Millisecond)
+	bot.AssertNumberOfCalls(t, "SendOverbookApprovalRequest", 1)
+	bookingSrv.AssertNotCalled(t, "Book")
+}
+
+func TestResolveExpiredOverbookRequestsBooksAndAnnounces(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	author := &discord.Member{ID: "test-member-id"}
+	letter := &discord.Channel{Name: "letter"}
+	startAt := time.Now().Add(1 * time.Hour)
+	endAt := startAt.Add(2 * time.Hour)
+	request := &reservation.OverbookRequestWithSpot{
+		OverbookRequest: reservation.OverbookRequest{ID: 1, AuthorDiscordID: author.ID, StartAt: startAt, EndAt: endAt},
+		Spot:            reservation.Spot{ID: 1, Name: "test-spot"},
+	}
+	bookingSrv := new(mocks.MockBookingService)
+	bookingSrv.On("ResolveExpiredOverbookRequests", guild).Return([]*reservation.OverbookRequestWithSpot{request}, nil)
+	bookingSrv.On("GetPolicy", guild).Return(policy.NewDefaultPolicy(guild.ID), nil)
+	bookingSrv.On("BookOverbookRequest", author, guild, []*discord.Member{}, request, policy.Tier{Role: "Postman", Priority: 1, MaximumReservationsTime: policy.DEFAULT_MAXIMUM_RESERVATIONS_TIME}).Return([]*reservation.ClippedOrRemovedReservation{}, nil)
+	reservationRepo := new(mocks.MockReservationRepo)
+	bot := new(mocks.MockBot)
+	bot.On("GetMember", guild, author.ID).Return(author, nil)
+	bot.On("MemberHasRole", guild, author, "Postman").Return(true)
+	bot.On("FindChannelByName", guild, "letter").Return(letter, nil)
+	bot.On("SendChannelMessage", guild, letter, mock.MatchedBy(func(message string) bool {
+		return strings.Contains(message, "approved automatically")
+	})).Return(nil)
+	adapter := NewApplication(reservationRepo, new(mocks.MockSummaryService), bookingSrv)
+
+	// when
+	adapter.ResolveExpiredOverbookRequests(bot, guild)
+
+	// assert
+	assert.Eventually(func() bool {
+		return bot.AssertExpectations(t) && bookingSrv.AssertExpectations(t)
+	}, 5*time.Second, 100*time.Millisecond)
+}
+
+func TestOnOverbookAnswerAnnouncesDecline(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	declined := false
+	guild := &discord.Guild{ID: "test-guild-id"}
+	member := &discord.Member{ID: "affected-member-id"}
+	letter := &discord.Channel{Name: "letter"}
+	request := &reservation.OverbookRequestWithSpot{
+		OverbookRequest: reservation.OverbookRequest{ID: 1, AuthorDiscordID: "test-member-id", Approvals: []*reservation.OverbookApproval{
+			{ReservationID: 1, MemberDiscordID: member.ID, Accepted: &declined},
+		}},
+		Spot: reservation.Spot{ID: 1, Name: "test-spot"},
+	}
+	bookingSrv := new(mocks.MockBookingService)
+	bookingSrv.On("AnswerOverbookRequest", guild, member, int64(1), false).Return(request, true, nil)
+	bot := new(mocks.MockBot)
+	bot.On("FindChannelByName", guild, "letter").Return(letter, nil)
+	bot.On("SendChannelMessage", guild, letter, mock.MatchedBy(func(message string) bool {
+		return strings.Contains(message, "declined by <@!affected-member-id>")
+	})).Return(nil)
+	adapter := NewApplication(new(mocks.MockReservationRepo), new(mocks.MockSummaryService), bookingSrv)
+
+	// when
+	res, err := adapter.OnOverbookAnswer(bot, book.OverbookAnswerRequest{Guild: guild, Member: member, RequestID: 1, Accepted: false})
+
+	// assert
+	assert.Nil(err)
+	assert.Equal(request, res)
+	assert.Eventually(func() bool {
+		return bot.AssertExpectations(t)
+	}, 2*time.Second, 100*time.Millisecond)
+	bookingSrv.AssertNotCalled(t, "Book")
+}
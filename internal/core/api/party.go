@@ -0,0 +1,42 @@
+package api
+
+import (
+	"fmt"
+
+	stringsHelper "spot-assistant/internal/common/strings"
+	"spot-assistant/internal/core/dto/book"
+	"spot-assistant/internal/core/dto/reservation"
+	"spot-assistant/internal/ports"
+)
+
+func (a *Application) OnPartyAdd(bot ports.BotPort, request book.PartyRequest) (*reservation.ReservationWithSpot, error) {
+	res, err := a.bookingSrv.AddPartyMember(request.Guild, request.Member, request.ReservationID, request.PartyMember, a.roleChecker(bot, request.Guild))
+	if err != nil {
+		return nil, err
+	}
+
+	go a.UpdateGuildSummaryAndLogError(bot, request.Guild)
+
+	a.notifyMember(bot, request.PartyMember, fmt.Sprintf(
+		"<@!%s> added you to the party hunting on **%s** (%s - %s)",
+		request.Member.ID, res.Spot.Name, stringsHelper.FormatDcLongTime(res.StartAt), stringsHelper.FormatDcLongTime(res.EndAt),
+	))
+
+	return res, nil
+}
+
+func (a *Application) OnPartyRemove(bot ports.BotPort, request book.PartyRequest) (*reservation.ReservationWithSpot, error) {
+	res, err := a.bookingSrv.RemovePartyMember(request.Guild, request.Member, request.ReservationID, request.PartyMember)
+	if err != nil {
+		return nil, err
+	}
+
+	go a.UpdateGuildSummaryAndLogError(bot, request.Guild)
+
+	a.notifyMember(bot, request.PartyMember, fmt.Sprintf(
+		"<@!%s> removed you from the party hunting on **%s** (%s - %s)",
+		request.Member.ID, res.Spot.Name, stringsHelper.FormatDcLongTime(res.StartAt), stringsHelper.FormatDcLongTime(res.EndAt),
+	))
+
+	return res, nil
+}
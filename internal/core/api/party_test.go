@@ -0,0 +1,57 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"spot-assistant/internal/common/test/mocks"
+	"spot-assistant/internal/core/dto/book"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/reservation"
+)
+
+func TestOnPartyAdd(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	summarySrv := new(mocks.MockSummaryService)
+	reservationRepo := new(mocks.MockReservationRepo)
+	request := book.PartyRequest{
+		Guild: &discord.Guild{
+			ID:   "test-guild-id",
+			Name: "test-guild",
+		},
+		Member: &discord.Member{
+			ID: "test-member-id",
+		},
+		PartyMember: &discord.Member{
+			ID: "test-party-member-id",
+		},
+		ReservationID: 1,
+	}
+	res := &reservation.ReservationWithSpot{
+		Reservation: reservation.Reservation{ID: 1, AuthorDiscordID: request.Member.ID},
+		Spot:        reservation.Spot{ID: 1, Name: "test-spot"},
+	}
+	bot := new(mocks.MockBot)
+	bot.On("FindChannelByName", request.Guild, "letter-summary").Return(&discord.Channel{Name: "letter-summary"}, nil)
+	bot.On("SendDM", request.PartyMember, mock.AnythingOfType("string")).Return(nil)
+	bookingSrv := new(mocks.MockBookingService)
+	bookingSrv.On("AddPartyMember", request.Guild, request.Member, request.ReservationID, request.PartyMember).Return(res, nil)
+	reservationRepo.On("SelectUpcomingReservationsWithSpot", mocks.ContextMock, request.Guild.ID).Return([]*reservation.ReservationWithSpot{}, nil)
+	adapter := NewApplication(reservationRepo, summarySrv, bookingSrv)
+
+	// when
+	added, err := adapter.OnPartyAdd(bot, request)
+
+	// assert
+	assert.Nil(err)
+	assert.Equal(res, added)
+
+	assert.Eventually(func() bool {
+		return summarySrv.AssertExpectations(t) && bot.AssertExpectations(t) &&
+			reservationRepo.AssertExpectations(t) && bookingSrv.AssertExpectations(t)
+	}, 5*time.Second, 100*time.Millisecond)
+}
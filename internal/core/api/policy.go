@@ -0,0 +1,185 @@
+package api
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"spot-assistant/internal/common/collections"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/policy"
+)
+
+func (a *Application) OnPolicy(guild *discord.Guild) (*policy.Policy, error) {
+	return a.bookingSrv.GetPolicy(guild)
+}
+
+func (a *Application) OnPolicyUpdate(request policy.UpdateRequest) (*policy.Policy, error) {
+	p, err := a.bookingSrv.GetPolicy(request.Guild)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.MaximumReservationsTime != nil {
+		p.MaximumReservationsTime = *request.MaximumReservationsTime
+	}
+
+	if request.MaximumReservationTime != nil {
+		p.MaximumReservationTime = *request.MaximumReservationTime
+	}
+
+	if request.OverbookRole != nil {
+		p.OverbookRole = *request.OverbookRole
+	}
+
+	if request.SuggestionStep != nil {
+		p.SuggestionStep = *request.SuggestionStep
+	}
+
+	if request.BookingHorizon != nil {
+		p.BookingHorizon = *request.BookingHorizon
+	}
+
+	if request.TimeZone != nil {
+		p.TimeZone = *request.TimeZone
+	}
+
+	if request.PartyTimeCounted != nil {
+		p.PartyTimeCounted = *request.PartyTimeCounted
+	}
+
+	if request.CheckInGracePeriod != nil {
+		p.CheckInGracePeriod = *request.CheckInGracePeriod
+	}
+
+	if request.OverbookApproval != nil {
+		p.OverbookApproval = *request.OverbookApproval
+	}
+
+	if request.OverbookApprovalTimeout != nil {
+		p.OverbookApprovalTimeout = *request.OverbookApprovalTimeout
+	}
+
+	if request.AddedBlackout != nil {
+		p.Blackouts = append(p.Blackouts, *request.AddedBlackout)
+	}
+
+	if request.RemovedBlackout != nil {
+		blackouts := slices.DeleteFunc(slices.Clone(p.Blackouts), func(b policy.Blackout) bool {
+			return strings.EqualFold(b.Name, *request.RemovedBlackout)
+		})
+		if len(blackouts) == len(p.Blackouts) {
+			return nil, fmt.Errorf("could not find blackout %s", *request.RemovedBlackout)
+		}
+
+		p.Blackouts = blackouts
+	}
+
+	if request.AddedTier != nil {
+		p.Tiers = append(p.Tiers, *request.AddedTier)
+	}
+
+	if request.RemovedTier != nil {
+		tiers := slices.DeleteFunc(slices.Clone(p.Tiers), func(t policy.Tier) bool {
+			return strings.EqualFold(t.Role, *request.RemovedTier)
+		})
+		if len(tiers) == len(p.Tiers) {
+			return nil, fmt.Errorf("could not find tier of role %s", *request.RemovedTier)
+		}
+
+		p.Tiers = tiers
+	}
+
+	if request.AddedWeeklyQuota != nil {
+		name, err := a.findSpotName(request.Guild, request.AddedWeeklyQuota.Spot)
+		if err != nil {
+			return nil, err
+		}
+
+		p.WeeklyQuotas = append(p.WeeklyQuotas, policy.WeeklyQuota{Spot: name, Limit: request.AddedWeeklyQuota.Limit})
+	}
+
+	if request.RemovedWeeklyQuota != nil {
+		quotas := slices.DeleteFunc(slices.Clone(p.WeeklyQuotas), func(q policy.WeeklyQuota) bool {
+			return strings.EqualFold(q.Spot, *request.RemovedWeeklyQuota)
+		})
+		if len(quotas) == len(p.WeeklyQuotas) {
+			return nil, fmt.Errorf("could not find weekly quota of %s", *request.RemovedWeeklyQuota)
+		}
+
+		p.WeeklyQuotas = quotas
+	}
+
+	if request.AddedBookingWindow != nil {
+		name, err := a.findSpotName(request.Guild, request.AddedBookingWindow.Spot)
+		if err != nil {
+			return nil, err
+		}
+
+		window := *request.AddedBookingWindow
+		window.Spot = name
+		p.BookingWindows = append(p.BookingWindows, window)
+	}
+
+	if request.RemovedBookingWindow != nil {
+		windows := slices.DeleteFunc(slices.Clone(p.BookingWindows), func(w policy.BookingWindow) bool {
+			return strings.EqualFold(w.Spot, *request.RemovedBookingWindow)
+		})
+		if len(windows) == len(p.BookingWindows) {
+			return nil, fmt.Errorf("could not find booking window of %s", *request.RemovedBookingWindow)
+		}
+
+		p.BookingWindows = windows
+	}
+
+	if request.AddedLottery != nil {
+		name, err := a.findSpotName(request.Guild, request.AddedLottery.Spot)
+		if err != nil {
+			return nil, err
+		}
+
+		lottery := *request.AddedLottery
+		lottery.Spot = name
+		p.Lotteries = append(p.Lotteries, lottery)
+	}
+
+	if request.RemovedLottery != nil {
+		lotteries := slices.DeleteFunc(slices.Clone(p.Lotteries), func(l policy.Lottery) bool {
+			return strings.EqualFold(l.Spot, *request.RemovedLottery)
+		})
+		if len(lotteries) == len(p.Lotteries) {
+			return nil, fmt.Errorf("could not find lottery of %s", *request.RemovedLottery)
+		}
+
+		p.Lotteries = lotteries
+	}
+
+	return a.bookingSrv.SavePolicy(p)
+}
+
+// Returns exact name of a spot the guild can book. Quotas, booking windows and lotteries follow
+// spot names, so they have to name an existing spot.
+func (a *Application) findSpotName(guild *discord.Guild, spotName string) (string, error) {
+	names, err := a.bookingSrv.FindAvailableSpots(guild, spotName)
+	if err != nil {
+		return "", err
+	}
+
+	name, index := collections.PoorMansFind(names, func(name string) bool {
+		return strings.EqualFold(name, spotName)
+	})
+	if index == -1 {
+		return "", fmt.Errorf("could not find spot called %s", spotName)
+	}
+
+	return name, nil
+}
+
+func (a *Application) OnLocation(guild *discord.Guild, member *discord.Member) (*time.Location, error) {
+	return a.bookingSrv.GetLocation(guild, member)
+}
+
+func (a *Application) OnTimeZoneUpdate(request policy.TimeZoneRequest) (*time.Location, error) {
+	return a.bookingSrv.SetMemberTimeZone(request.Guild, request.Member, request.TimeZone)
+}
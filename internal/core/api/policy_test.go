@@ -0,0 +1,119 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"spot-assistant/internal/common/test/mocks"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/policy"
+)
+
+func TestOnPolicyUpdate(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{
+		ID: "test-guild-id",
+	}
+	maximumReservationsTime := 5 * time.Hour
+	overbookRole := "Admin"
+	expectedPolicy := policy.NewDefaultPolicy(guild.ID)
+	expectedPolicy.MaximumReservationsTime = maximumReservationsTime
+	expectedPolicy.OverbookRole = overbookRole
+	bookingSrv := new(mocks.MockBookingService)
+	bookingSrv.On("GetPolicy", guild).Return(policy.NewDefaultPolicy(guild.ID), nil)
+	bookingSrv.On("SavePolicy", expectedPolicy).Return(expectedPolicy, nil)
+	defer bookingSrv.AssertExpectations(t)
+	adapter := NewApplication(new(mocks.MockReservationRepo), new(mocks.MockSummaryService), bookingSrv)
+
+	// when
+	res, err := adapter.OnPolicyUpdate(policy.UpdateRequest{
+		Guild:                   guild,
+		MaximumReservationsTime: &maximumReservationsTime,
+		OverbookRole:            &overbookRole,
+	})
+
+	// assert
+	assert.Nil(err)
+	assert.Equal(expectedPolicy, res)
+	assert.Equal(policy.DEFAULT_MAXIMUM_RESERVATION_TIME, res.MaximumReservationTime)
+	assert.Equal(policy.DEFAULT_SUGGESTION_STEP, res.SuggestionStep)
+}
+
+func TestOnPolicyUpdateBlackouts(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	added := policy.Blackout{Name: "Rashid", Start: 20 * time.Hour, Length: time.Hour, TimeZone: "Europe/Warsaw"}
+	removed := "server SAVE"
+	unknown := "Event"
+	expectedPolicy := policy.NewDefaultPolicy(guild.ID)
+	expectedPolicy.Blackouts = []policy.Blackout{added}
+	bookingSrv := new(mocks.MockBookingService)
+	bookingSrv.On("GetPolicy", guild).Return(policy.NewDefaultPolicy(guild.ID), nil)
+	bookingSrv.On("SavePolicy", expectedPolicy).Return(expectedPolicy, nil)
+	adapter := NewApplication(new(mocks.MockReservationRepo), new(mocks.MockSummaryService), bookingSrv)
+
+	// when
+	res, err := adapter.OnPolicyUpdate(policy.UpdateRequest{Guild: guild, AddedBlackout: &added, RemovedBlackout: &removed})
+	_, unknownErr := adapter.OnPolicyUpdate(policy.UpdateRequest{Guild: guild, RemovedBlackout: &unknown})
+
+	// assert
+	assert.Nil(err)
+	assert.Equal(expectedPolicy, res)
+	assert.ErrorContains(unknownErr, "could not find blackout Event")
+}
+
+func TestOnPolicyUpdateWeeklyQuotas(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	added := policy.WeeklyQuota{Spot: "soul war", Limit: 6 * time.Hour}
+	unknown := policy.WeeklyQuota{Spot: "soul", Limit: 6 * time.Hour}
+	expectedPolicy := policy.NewDefaultPolicy(guild.ID)
+	expectedPolicy.WeeklyQuotas = []policy.WeeklyQuota{{Spot: "Soul War", Limit: 6 * time.Hour}}
+	bookingSrv := new(mocks.MockBookingService)
+	bookingSrv.On("GetPolicy", guild).Return(policy.NewDefaultPolicy(guild.ID), nil)
+	bookingSrv.On("FindAvailableSpots", guild, "soul war").Return([]string{"Soul War", "Soul War -1"}, nil)
+	bookingSrv.On("FindAvailableSpots", guild, "soul").Return([]string{"Soul War", "Soul War -1"}, nil)
+	bookingSrv.On("SavePolicy", expectedPolicy).Return(expectedPolicy, nil)
+	adapter := NewApplication(new(mocks.MockReservationRepo), new(mocks.MockSummaryService), bookingSrv)
+
+	// when
+	res, err := adapter.OnPolicyUpdate(policy.UpdateRequest{Guild: guild, AddedWeeklyQuota: &added})
+	_, unknownErr := adapter.OnPolicyUpdate(policy.UpdateRequest{Guild: guild, AddedWeeklyQuota: &unknown})
+
+	// assert
+	assert.Nil(err)
+	assert.Equal(expectedPolicy, res)
+	assert.ErrorContains(unknownErr, "could not find spot called soul")
+	bookingSrv.AssertNumberOfCalls(t, "SavePolicy", 1)
+}
+
+func TestOnPolicyUpdateBookingWindows(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	opensAt := 18 * time.Hour
+	added := policy.BookingWindow{Spot: "soul war", OpensAt: &opensAt}
+	unknown := "library"
+	expectedPolicy := policy.NewDefaultPolicy(guild.ID)
+	expectedPolicy.BookingWindows = []policy.BookingWindow{{Spot: "Soul War", OpensAt: &opensAt}}
+	bookingSrv := new(mocks.MockBookingService)
+	bookingSrv.On("GetPolicy", guild).Return(policy.NewDefaultPolicy(guild.ID), nil)
+	bookingSrv.On("FindAvailableSpots", guild, "soul war").Return([]string{"Soul War", "Soul War -1"}, nil)
+	bookingSrv.On("SavePolicy", expectedPolicy).Return(expectedPolicy, nil)
+	adapter := NewApplication(new(mocks.MockReservationRepo), new(mocks.MockSummaryService), bookingSrv)
+
+	// when
+	res, err := adapter.OnPolicyUpdate(policy.UpdateRequest{Guild: guild, AddedBookingWindow: &added})
+	_, unknownErr := adapter.OnPolicyUpdate(policy.UpdateRequest{Guild: guild, RemovedBookingWindow: &unknown})
+
+	// assert
+	assert.Nil(err)
+	assert.Equal(expectedPolicy, res)
+	assert.ErrorContains(unknownErr, "could not find booking window of library")
+	bookingSrv.AssertNumberOfCalls(t, "SavePolicy", 1)
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"fmt"
+
+	stringsHelper "spot-assistant/internal/common/strings"
+	"spot-assistant/internal/core/dto/book"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/reservation"
+	"spot-assistant/internal/ports"
+)
+
+func (a *Application) OnQueue(bot ports.BotPort, request book.QueueRequest) (*reservation.QueueEntryWithSpot, error) {
+	return a.bookingSrv.Enqueue(request.Member, request.Guild, request.Spot, request.StartAt, request.EndAt)
+}
+
+// Books queue entries that became free, notifies their authors,
+// and refreshes guild summary afterwards.
+func (a *Application) ProcessQueueAndUpdateGuildSummary(bot ports.BotPort, guild *discord.Guild) {
+	booked, err := a.bookingSrv.ProcessQueue(guild, a.roleChecker(bot, guild))
+	if err != nil {
+		a.log.Errorf("could not process queue: %s", err)
+	}
+
+	for _, entry := range booked {
+		go func(entry *reservation.QueueEntryWithSpot) {
+			member, err := bot.GetMember(guild, entry.AuthorDiscordID)
+			if err != nil {
+				a.log.Errorf("error getting member: %s", err)
+				return
+			}
+
+			err = bot.SendDM(member, fmt.Sprintf(
+				"The respawn you have been queued for became free, so you have been booked on **%s** between %s and %s.",
+				entry.Spot.Name,
+				stringsHelper.FormatDcLongTime(entry.StartAt),
+				stringsHelper.FormatDcLongTime(entry.EndAt),
+			))
+			if err != nil {
+				a.log.Errorf("error sending DM: %s", err)
+			}
+		}(entry)
+	}
+
+	a.UpdateGuildSummaryAndLogError(bot, guild)
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	stringsHelper "spot-assistant/internal/common/strings"
+	"spot-assistant/internal/common/test/mocks"
+	"spot-assistant/internal/core/dto/book"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/reservation"
+)
+
+func TestOnQueue(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	request := book.QueueRequest{
+		Guild:   &discord.Guild{ID: "test-guild-id"},
+		Member:  &discord.Member{ID: "test-member-id"},
+		Spot:    "test-spot",
+		StartAt: time.Now(),
+		EndAt:   time.Now().Add(2 * time.Hour),
+	}
+	entry := &reservation.QueueEntryWithSpot{
+		QueueEntry: reservation.QueueEntry{ID: 1, StartAt: request.StartAt, EndAt: request.EndAt},
+		Spot:       reservation.Spot{ID: 1, Name: request.Spot},
+	}
+	bookingSrv := new(mocks.MockBookingService)
+	bookingSrv.On("Enqueue", request.Member, request.Guild, request.Spot, request.StartAt, request.EndAt).Return(entry, nil)
+	defer bookingSrv.AssertExpectations(t)
+	adapter := NewApplication(new(mocks.MockReservationRepo), new(mocks.MockSummaryService), bookingSrv)
+
+	// when
+	res, err := adapter.OnQueue(new(mocks.MockBot), request)
+
+	// assert
+	assert.Nil(err)
+	assert.Equal(entry, res)
+}
+
+func TestProcessQueueAndUpdateGuildSummary(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	member := &discord.Member{ID: "test-member-id"}
+	startAt := time.Now()
+	endAt := startAt.Add(2 * time.Hour)
+	booked := []*reservation.QueueEntryWithSpot{
+		{
+			QueueEntry: reservation.QueueEntry{ID: 1, AuthorDiscordID: member.ID, StartAt: startAt, EndAt: endAt},
+			Spot:       reservation.Spot{ID: 1, Name: "test-spot"},
+		},
+	}
+	bookingSrv := new(mocks.MockBookingService)
+	bookingSrv.On("ProcessQueue", guild).Return(booked, nil)
+	reservationRepo := new(mocks.MockReservationRepo)
+	reservationRepo.On("SelectUpcomingReservationsWithSpot", mocks.ContextMock, guild.ID).Return([]*reservation.ReservationWithSpot{}, nil)
+	bot := new(mocks.MockBot)
+	bot.On("FindChannelByName", guild, "letter-summary").Return(&discord.Channel{Name: "letter-summary"}, nil)
+	bot.On("GetMember", guild, member.ID).Return(member, nil)
+	bot.On("SendDM", member, fmt.Sprintf(
+		"The respawn you have been queued for became free, so you have been booked on **test-spot** between %s and %s.",
+		stringsHelper.FormatDcLongTime(startAt), stringsHelper.FormatDcLongTime(endAt),
+	)).Return(nil)
+	adapter := NewApplication(reservationRepo, new(mocks.MockSummaryService), bookingSrv)
+
+	// when
+	adapter.ProcessQueueAndUpdateGuildSummary(bot, guild)
+
+	// assert
+	assert.Eventually(func() bool {
+		return bot.AssertExpectations(t) && reservationRepo.AssertExpectations(t) && bookingSrv.AssertExpectations(t)
+	}, 5*time.Second, 100*time.Millisecond)
+}
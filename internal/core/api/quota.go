@@ -0,0 +1,10 @@
+package api
+
+import (
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/reservation"
+)
+
+func (a *Application) OnQuota(guild *discord.Guild, member *discord.Member) ([]*reservation.QuotaUsage, error) {
+	return a.bookingSrv.GetQuotaUsage(guild, member)
+}
@@ -0,0 +1,35 @@
+package api
+
+import (
+	"spot-assistant/internal/core/dto/book"
+	"spot-assistant/internal/ports"
+)
+
+func (a *Application) OnRebook(bot ports.BotPort, request book.RebookRequest) (book.RebookResponse, error) {
+	response := book.RebookResponse{}
+
+	p, err := a.bookingSrv.GetPolicy(request.Guild)
+	if err != nil {
+		return response, err
+	}
+
+	res, conflicting, err := a.bookingSrv.Rebook(
+		request.Member,
+		request.Guild,
+		request.ReservationID,
+		request.Date, request.StartTime, request.EndTime,
+		request.Overbook, memberTier(bot, request.Guild, request.Member, p),
+	)
+	response.Reservation = res
+	response.ConflictingReservations = conflicting
+	if err != nil {
+		return response, err
+	}
+
+	// Moving a reservation might have freed slots someone is queued for
+	go a.ProcessQueueAndUpdateGuildSummary(bot, request.Guild)
+
+	a.notifyOverbookedMembers(bot, request.Guild, request.Member, res.Spot.Name, response.ConflictingReservations)
+
+	return response, nil
+}
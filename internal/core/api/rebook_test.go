@@ -0,0 +1,89 @@
+package api
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"spot-assistant/internal/common/test/mocks"
+	"spot-assistant/internal/core/dto/book"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/policy"
+	"spot-assistant/internal/core/dto/reservation"
+)
+
+func TestOnRebook(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	summarySrv := new(mocks.MockSummaryService)
+	reservationRepo := new(mocks.MockReservationRepo)
+	endTime := 23 * time.Hour
+	request := book.RebookRequest{
+		Guild: &discord.Guild{
+			ID:   "test-guild-id",
+			Name: "test-guild",
+		},
+		Member: &discord.Member{
+			ID: "test-member-id",
+		},
+		ReservationID: 1,
+		EndTime:       &endTime,
+	}
+	movedReservation := &reservation.ReservationWithSpot{
+		Reservation: reservation.Reservation{ID: 1},
+		Spot:        reservation.Spot{ID: 1, Name: "test-spot"},
+	}
+	bot := new(mocks.MockBot)
+	bot.On("MemberHasRole", request.Guild, request.Member, "Postman").Return(false)
+	bot.On("FindChannelByName", request.Guild, "letter-summary").Return(&discord.Channel{Name: "letter-summary"}, nil)
+	bookingSrv := new(mocks.MockBookingService)
+	bookingSrv.On("GetPolicy", request.Guild).Return(policy.NewDefaultPolicy(request.Guild.ID), nil)
+	bookingSrv.On("Rebook", request.Member, request.Guild, request.ReservationID, request.Date, request.StartTime, request.EndTime, false, policy.Tier{MaximumReservationsTime: policy.DEFAULT_MAXIMUM_RESERVATIONS_TIME}).
+		Return(movedReservation, []*reservation.ClippedOrRemovedReservation{}, nil)
+	bookingSrv.On("ProcessQueue", request.Guild).Return([]*reservation.QueueEntryWithSpot{}, nil)
+	adapter := NewApplication(reservationRepo, summarySrv, bookingSrv)
+	reservationRepo.On("SelectUpcomingReservationsWithSpot", mocks.ContextMock, request.Guild.ID).Return([]*reservation.ReservationWithSpot{}, nil)
+
+	// when
+	res, err := adapter.OnRebook(bot, request)
+
+	// assert
+	assert.Nil(err)
+	assert.Equal(movedReservation, res.Reservation)
+	assert.Empty(res.ConflictingReservations)
+
+	assert.Eventually(func() bool {
+		return summarySrv.AssertExpectations(t) && bot.AssertExpectations(t) &&
+			reservationRepo.AssertExpectations(t) && bookingSrv.AssertExpectations(t)
+	}, 5*time.Second, 100*time.Millisecond)
+}
+
+func TestOnRebookOnError(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	request := book.RebookRequest{
+		Guild: &discord.Guild{
+			ID: "test-guild-id",
+		},
+		Member: &discord.Member{
+			ID: "test-member-id",
+		},
+		ReservationID: 1,
+	}
+	bot := new(mocks.MockBot)
+	bot.On("MemberHasRole", request.Guild, request.Member, "Postman").Return(false)
+	bookingSrv := new(mocks.MockBookingService)
+	bookingSrv.On("GetPolicy", request.Guild).Return(policy.NewDefaultPolicy(request.Guild.ID), nil)
+	bookingSrv.On("Rebook", request.Member, request.Guild, request.ReservationID, request.Date, request.StartTime, request.EndTime, false, policy.Tier{MaximumReservationsTime: policy.DEFAULT_MAXIMUM_RESERVATIONS_TIME}).
+		Return((*reservation.ReservationWithSpot)(nil), []*reservation.ClippedOrRemovedReservation{}, errors.New("test-error"))
+	defer bookingSrv.AssertExpectations(t)
+	adapter := NewApplication(new(mocks.MockReservationRepo), new(mocks.MockSummaryService), bookingSrv)
+
+	// when
+	_, err := adapter.OnRebook(bot, request)
+
+	// assert
+	assert.NotNil(err)
+}
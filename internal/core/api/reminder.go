@@ -0,0 +1,66 @@
+package api
+
+import (
+	"fmt"
+
+	stringsHelper "spot-assistant/internal/common/strings"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/policy"
+	"spot-assistant/internal/core/dto/reservation"
+	"spot-assistant/internal/ports"
+)
+
+func (a *Application) OnReminderPreference(guild *discord.Guild, member *discord.Member) (*policy.ReminderPreference, error) {
+	return a.bookingSrv.GetReminderPreference(guild, member)
+}
+
+func (a *Application) OnReminderPreferenceUpdate(request policy.ReminderPreferenceRequest) (*policy.ReminderPreference, error) {
+	p, err := a.bookingSrv.GetReminderPreference(request.Guild, request.Member)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Enabled != nil {
+		p.Enabled = *request.Enabled
+	}
+
+	if request.StartLeadTime != nil {
+		p.StartLeadTime = *request.StartLeadTime
+	}
+
+	if request.EndLeadTime != nil {
+		p.EndLeadTime = *request.EndLeadTime
+	}
+
+	return a.bookingSrv.SaveReminderPreference(p)
+}
+
+// Sends DMs reminding members that their reservations are about to start or end.
+func (a *Application) SendReminders(bot ports.BotPort, guild *discord.Guild) {
+	reminders, err := a.bookingSrv.ProcessReminders(guild)
+	if err != nil {
+		a.log.Errorf("could not process reminders: %s", err)
+
+		return
+	}
+
+	for _, reminder := range reminders {
+		a.notifyMember(bot, &discord.Member{ID: reminder.AuthorDiscordID}, formatReminder(reminder))
+	}
+}
+
+func formatReminder(reminder *reservation.Reminder) string {
+	event := "starts"
+	if reminder.Kind == reservation.ReminderEnd {
+		event = "ends"
+	}
+
+	return fmt.Sprintf(
+		"Your reservation on **%s** (%s - %s) %s at %s. You can change or turn off these reminders with /reminders.",
+		reminder.Spot.Name,
+		stringsHelper.FormatDcLongTime(reminder.StartAt),
+		stringsHelper.FormatDcLongTime(reminder.EndAt),
+		event,
+		stringsHelper.FormatDcTime(reminder.EventAt()),
+	)
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"spot-assistant/internal/common/test/mocks"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/policy"
+	"spot-assistant/internal/core/dto/reservation"
+)
+
+func TestOnReminderPreferenceUpdate(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{
+		ID: "test-guild-id",
+	}
+	member := &discord.Member{
+		ID: "test-member-id",
+	}
+	startLeadTime := 30 * time.Minute
+	expectedPreference := policy.NewDefaultReminderPreference(guild.ID, member.ID)
+	expectedPreference.StartLeadTime = startLeadTime
+	bookingSrv := new(mocks.MockBookingService)
+	bookingSrv.On("GetReminderPreference", guild, member).Return(policy.NewDefaultReminderPreference(guild.ID, member.ID), nil)
+	bookingSrv.On("SaveReminderPreference", expectedPreference).Return(expectedPreference, nil)
+	defer bookingSrv.AssertExpectations(t)
+	adapter := NewApplication(new(mocks.MockReservationRepo), new(mocks.MockSummaryService), bookingSrv)
+
+	// when
+	res, err := adapter.OnReminderPreferenceUpdate(policy.ReminderPreferenceRequest{
+		Guild:         guild,
+		Member:        member,
+		StartLeadTime: &startLeadTime,
+	})
+
+	// assert
+	assert.Nil(err)
+	assert.Equal(expectedPreference, res)
+	assert.True(res.Enabled)
+	assert.Equal(policy.DEFAULT_END_REMINDER_LEAD_TIME, res.EndLeadTime)
+}
+
+func TestSendReminders(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{
+		ID: "test-guild-id",
+	}
+	member := &discord.Member{
+		ID: "test-member-id",
+	}
+	reminder := &reservation.Reminder{
+		ReservationWithSpot: &reservation.ReservationWithSpot{
+			Reservation: reservation.Reservation{ID: 1, AuthorDiscordID: member.ID},
+			Spot:        reservation.Spot{ID: 1, Name: "test-spot"},
+		},
+		Kind: reservation.ReminderEnd,
+	}
+	bot := new(mocks.MockBot)
+	bot.On("SendDM", member, mock.MatchedBy(func(message string) bool {
+		return strings.Contains(message, "**test-spot**") && strings.Contains(message, "ends")
+	})).Return(nil)
+	bookingSrv := new(mocks.MockBookingService)
+	bookingSrv.On("ProcessReminders", guild).Return([]*reservation.Reminder{reminder}, nil)
+	adapter := NewApplication(new(mocks.MockReservationRepo), new(mocks.MockSummaryService), bookingSrv)
+
+	// when
+	adapter.SendReminders(bot, guild)
+
+	// assert
+	assert.Eventually(func() bool {
+		return bot.AssertExpectations(t) && bookingSrv.AssertExpectations(t)
+	}, 5*time.Second, 100*time.Millisecond)
+}
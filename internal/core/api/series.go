@@ -0,0 +1,89 @@
+package api
+
+import (
+	"fmt"
+	"time"
+
+	"spot-assistant/internal/common/errors"
+	"spot-assistant/internal/core/dto/book"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/reservation"
+	"spot-assistant/internal/ports"
+)
+
+func (a *Application) OnSeries(bot ports.BotPort, request book.SeriesRequest) (*reservation.SeriesWithSpot, error) {
+	series, err := a.bookingSrv.CreateSeries(
+		request.Member,
+		request.Guild,
+		request.Spot, request.Weekdays,
+		request.StartTime, request.EndTime,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	go a.MaterializeSeriesAndUpdateGuildSummary(bot, request.Guild)
+
+	return series, nil
+}
+
+func (a *Application) OnSeriesAutocomplete(request book.SeriesAutocompleteRequest) (book.SeriesAutocompleteResponse, error) {
+	switch request.Field {
+	case book.SeriesAutocompleteWeekdays:
+		return a.bookingSrv.GetSuggestedWeekdays(request.Value), nil
+	case book.SeriesAutocompleteStartAt:
+		return a.bookingSrv.GetSuggestedHours(request.Guild, a.guildNow(request.Guild), "", request.Value), nil
+	case book.SeriesAutocompleteEndAt:
+		return a.bookingSrv.GetSuggestedHours(request.Guild, a.guildNow(request.Guild).Add(2*time.Hour), "", request.Value), nil
+	case book.SeriesAutocompleteSpot:
+		return a.bookingSrv.FindAvailableSpots(request.Guild, request.Value)
+	default:
+		return []string{}, fmt.Errorf("autocomplete not implemented for %v", request.Field)
+	}
+}
+
+func (a *Application) OnSeriesList(request book.SeriesListRequest) (book.SeriesListResponse, error) {
+	series, err := a.bookingSrv.FindMemberSeries(request.Guild, request.Member, request.Value)
+	if err != nil {
+		return book.SeriesListResponse{}, err
+	}
+
+	return book.SeriesListResponse{
+		Series: series,
+	}, nil
+}
+
+func (a *Application) OnSeriesCancel(bot ports.BotPort, request book.SeriesCancelRequest) (*reservation.SeriesWithSpot, error) {
+	res, err := a.bookingSrv.CancelSeries(request.Guild, request.Member, request.SeriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	go a.ProcessQueueAndUpdateGuildSummary(bot, request.Guild)
+
+	return res, nil
+}
+
+// Materializes guild series, processes the queue, and refreshes guild summary afterwards.
+func (a *Application) MaterializeSeriesAndUpdateGuildSummary(bot ports.BotPort, guild *discord.Guild) {
+	a.MaterializeSeries(bot, guild)
+	a.ProcessQueueAndUpdateGuildSummary(bot, guild)
+}
+
+// Books upcoming occurrences of guild series.
+func (a *Application) MaterializeSeries(bot ports.BotPort, guild *discord.Guild) {
+	_, err := a.bookingSrv.MaterializeSeries(guild, a.roleChecker(bot, guild))
+	errors.LogError(a.log, err)
+}
+
+// Returns current time in guild time zone, series hours are expressed in it.
+func (a *Application) guildNow(guild *discord.Guild) time.Time {
+	p, err := a.bookingSrv.GetPolicy(guild)
+	if err != nil {
+		a.log.Error(err)
+
+		return time.Now()
+	}
+
+	return time.Now().In(p.Location())
+}
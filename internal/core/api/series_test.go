@@ -0,0 +1,167 @@
+package api
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"spot-assistant/internal/common/test/mocks"
+	"spot-assistant/internal/core/dto/book"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/reservation"
+)
+
+func TestOnSeries(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	summarySrv := new(mocks.MockSummaryService)
+	reservationRepo := new(mocks.MockReservationRepo)
+	request := book.SeriesRequest{
+		Guild: &discord.Guild{
+			ID:   "test-guild-id",
+			Name: "test-guild",
+		},
+		Member: &discord.Member{
+			ID: "test-member-id",
+		},
+		Spot:      "test-spot",
+		Weekdays:  []time.Weekday{time.Monday, time.Wednesday},
+		StartTime: 18 * time.Hour,
+		EndTime:   21 * time.Hour,
+	}
+	series := &reservation.SeriesWithSpot{
+		Series: reservation.Series{ID: 1, Weekdays: request.Weekdays, StartTime: request.StartTime, EndTime: request.EndTime},
+		Spot:   reservation.Spot{ID: 1, Name: request.Spot},
+	}
+	bot := new(mocks.MockBot)
+	bot.On("FindChannelByName", request.Guild, "letter-summary").Return(&discord.Channel{Name: "letter-summary"}, nil)
+	bookingSrv := new(mocks.MockBookingService)
+	bookingSrv.On("CreateSeries", request.Member, request.Guild, request.Spot, request.Weekdays, request.StartTime, request.EndTime).Return(series, nil)
+	bookingSrv.On("MaterializeSeries", request.Guild).Return([]*reservation.Reservation{}, nil)
+	bookingSrv.On("ProcessQueue", request.Guild).Return([]*reservation.QueueEntryWithSpot{}, nil)
+	adapter := NewApplication(reservationRepo, summarySrv, bookingSrv)
+	reservationRepo.On("SelectUpcomingReservationsWithSpot", mocks.ContextMock, request.Guild.ID).Return([]*reservation.ReservationWithSpot{}, nil)
+
+	// when
+	res, err := adapter.OnSeries(bot, request)
+
+	// assert
+	assert.Nil(err)
+	assert.Equal(series, res)
+
+	assert.Eventually(func() bool {
+		return summarySrv.AssertExpectations(t) && bot.AssertExpectations(t) &&
+			reservationRepo.AssertExpectations(t) && bookingSrv.AssertExpectations(t)
+	}, 5*time.Second, 100*time.Millisecond)
+}
+
+func TestOnSeriesOnError(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	request := book.SeriesRequest{
+		Guild:  &discord.Guild{ID: "test-guild-id"},
+		Member: &discord.Member{ID: "test-member-id"},
+		Spot:   "test-spot",
+	}
+	bot := new(mocks.MockBot)
+	defer bot.AssertExpectations(t)
+	bookingSrv := new(mocks.MockBookingService)
+	bookingSrv.On("CreateSeries", request.Member, request.Guild, request.Spot, request.Weekdays, request.StartTime, request.EndTime).Return(&reservation.SeriesWithSpot{}, errors.New("test-error"))
+	defer bookingSrv.AssertExpectations(t)
+	adapter := NewApplication(new(mocks.MockReservationRepo), new(mocks.MockSummaryService), bookingSrv)
+
+	// when
+	_, err := adapter.OnSeries(bot, request)
+
+	// assert
+	assert.NotNil(err)
+}
+
+func TestOnSeriesAutocompleteWeekdays(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	bookingSrv := new(mocks.MockBookingService)
+	bookingSrv.On("GetSuggestedWeekdays", "mon").Return([]string{"Mon", "Mon/Wed/Fri"})
+	defer bookingSrv.AssertExpectations(t)
+	adapter := NewApplication(new(mocks.MockReservationRepo), new(mocks.MockSummaryService), bookingSrv)
+
+	// when
+	res, err := adapter.OnSeriesAutocomplete(book.SeriesAutocompleteRequest{
+		Field: book.SeriesAutocompleteWeekdays,
+		Value: "mon",
+	})
+
+	// assert
+	assert.Nil(err)
+	assert.Equal(book.SeriesAutocompleteResponse{"Mon", "Mon/Wed/Fri"}, res)
+}
+
+func TestOnSeriesList(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	member := &discord.Member{ID: "test-member-id"}
+	guild := &discord.Guild{ID: "test-guild-id"}
+	series := []*reservation.SeriesWithSpot{
+		{
+			Series: reservation.Series{ID: 1},
+			Spot:   reservation.Spot{ID: 1, Name: "test-spot"},
+		},
+	}
+	bookingSrv := new(mocks.MockBookingService)
+	bookingSrv.On("FindMemberSeries", guild, member, "test").Return(series, nil)
+	defer bookingSrv.AssertExpectations(t)
+	adapter := NewApplication(new(mocks.MockReservationRepo), new(mocks.MockSummaryService), bookingSrv)
+
+	// when
+	res, err := adapter.OnSeriesList(book.SeriesListRequest{
+		Member: member,
+		Guild:  guild,
+		Value:  "test",
+	})
+
+	// assert
+	assert.Nil(err)
+	assert.Equal(series, res.Series)
+}
+
+func TestOnSeriesCancel(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	summarySrv := new(mocks.MockSummaryService)
+	reservationRepo := new(mocks.MockReservationRepo)
+	request := book.SeriesCancelRequest{
+		Guild: &discord.Guild{
+			ID:   "test-guild-id",
+			Name: "test-guild",
+		},
+		Member: &discord.Member{
+			ID: "test-member-id",
+		},
+		SeriesID: 1,
+	}
+	existingSeries := &reservation.SeriesWithSpot{
+		Series: reservation.Series{ID: 1},
+		Spot:   reservation.Spot{ID: 1},
+	}
+	bot := new(mocks.MockBot)
+	bot.On("FindChannelByName", request.Guild, "letter-summary").Return(&discord.Channel{Name: "letter-summary"}, nil)
+	bookingSrv := new(mocks.MockBookingService)
+	bookingSrv.On("CancelSeries", request.Guild, request.Member, request.SeriesID).Return(existingSeries, nil)
+	bookingSrv.On("ProcessQueue", request.Guild).Return([]*reservation.QueueEntryWithSpot{}, nil)
+	adapter := NewApplication(reservationRepo, summarySrv, bookingSrv)
+	reservationRepo.On("SelectUpcomingReservationsWithSpot", mocks.ContextMock, request.Guild.ID).Return([]*reservation.ReservationWithSpot{}, nil)
+
+	// when
+	res, err := adapter.OnSeriesCancel(bot, request)
+
+	// assert
+	assert.Nil(err)
+	assert.Equal(existingSeries, res)
+
+	assert.Eventually(func() bool {
+		return summarySrv.AssertExpectations(t) && bot.AssertExpectations(t) &&
+			reservationRepo.AssertExpectations(t) && bookingSrv.AssertExpectations(t)
+	}, 5*time.Second, 100*time.Millisecond)
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"spot-assistant/internal/core/dto/spot"
+)
+
+func (a *Application) OnSpotAdd(request spot.AddRequest) (*spot.Spot, error) {
+	return a.bookingSrv.AddSpot(request.Guild, request.Name, request.Parent)
+}
+
+func (a *Application) OnSpotRename(request spot.RenameRequest) (*spot.Spot, error) {
+	return a.bookingSrv.RenameSpot(request.Guild, request.Name, request.NewName)
+}
+
+func (a *Application) OnSpotDescribe(request spot.DescribeRequest) (*spot.Spot, error) {
+	return a.bookingSrv.DescribeSpot(request.Guild, request.Name, request.Details)
+}
+
+func (a *Application) OnSpotAlias(request spot.AliasRequest) (*spot.Spot, error) {
+	return a.bookingSrv.AddSpotAlias(request.Guild, request.Name, request.Alias)
+}
+
+func (a *Application) OnSpotUnalias(request spot.AliasRequest) (*spot.Spot, error) {
+	return a.bookingSrv.RemoveSpotAlias(request.Guild, request.Name, request.Alias)
+}
+
+func (a *Application) OnSpotImport(request spot.ImportRequest) (*spot.ImportResult, error) {
+	return a.bookingSrv.ImportSpots(request.Guild, request.Entries, request.DryRun)
+}
+
+func (a *Application) OnSpotExport(request spot.ExportRequest) ([]*spot.CatalogEntry, error) {
+	return a.bookingSrv.ExportSpots(request.Guild)
+}
+
+func (a *Application) OnSpotArchive(request spot.ArchiveRequest) (*spot.Spot, error) {
+	return a.bookingSrv.ArchiveSpot(request.Guild, request.Name)
+}
+
+func (a *Application) OnSpotHide(request spot.VisibilityRequest) (*spot.Spot, error) {
+	return a.bookingSrv.HideSpot(request.Guild, request.Name)
+}
+
+func (a *Application) OnSpotUnhide(request spot.VisibilityRequest) (*spot.Spot, error) {
+	return a.bookingSrv.UnhideSpot(request.Guild, request.Name)
+}
+
+func (a *Application) OnSpotAutocomplete(request spot.AutocompleteRequest) ([]string, error) {
+	if request.Hidden {
+		return a.bookingSrv.FindHiddenSpots(request.Guild, request.Value)
+	}
+
+	return a.bookingSrv.FindAvailableSpots(request.Guild, request.Value)
+}
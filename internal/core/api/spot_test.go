@@ -0,0 +1,54 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"spot-assistant/internal/common/test/mocks"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/spot"
+)
+
+func TestOnSpotRename(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	expectedSpot := &spot.Spot{ID: 1, Name: "Library -1", OwnerGuildID: guild.ID}
+	bookingSrv := new(mocks.MockBookingService)
+	bookingSrv.On("RenameSpot", guild, "Library", "Library -1").Return(expectedSpot, nil)
+	defer bookingSrv.AssertExpectations(t)
+	adapter := NewApplication(new(mocks.MockReservationRepo), new(mocks.MockSummaryService), bookingSrv)
+
+	// when
+	res, err := adapter.OnSpotRename(spot.RenameRequest{
+		Guild:   guild,
+		Name:    "Library",
+		NewName: "Library -1",
+	})
+
+	// assert
+	assert.Nil(err)
+	assert.Equal(expectedSpot, res)
+}
+
+func TestOnSpotAutocompleteHidden(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	bookingSrv := new(mocks.MockBookingService)
+	bookingSrv.On("FindHiddenSpots", guild, "lib").Return([]string{"Library"}, nil)
+	defer bookingSrv.AssertExpectations(t)
+	adapter := NewApplication(new(mocks.MockReservationRepo), new(mocks.MockSummaryService), bookingSrv)
+
+	// when
+	res, err := adapter.OnSpotAutocomplete(spot.AutocompleteRequest{
+		Guild:  guild,
+		Hidden: true,
+		Value:  "lib",
+	})
+
+	// assert
+	assert.Nil(err)
+	assert.Equal([]string{"Library"}, res)
+}
@@ -5,15 +5,21 @@ import (
 	"fmt"
 	"spot-assistant/internal/core/dto/reservation"
 	"strconv"
+	"time"
 
+	"spot-assistant/internal/common/collections"
 	"spot-assistant/internal/common/errors"
 	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/policy"
 	"spot-assistant/internal/core/dto/summary"
 	"spot-assistant/internal/ports"
 
 	"github.com/sirupsen/logrus"
 )
 
+// Blackouts taking place within this range are marked in the summary
+const SUMMARY_BLACKOUTS_RANGE = 24 * time.Hour
+
 // UpdateGuild makes a full-fledged guild update including summary re-generation.
 func (a *Application) UpdateGuildSummary(bot ports.BotPort, guild *discord.Guild) error {
 	log := a.log.WithFields(logrus.Fields{"guild.ID": guild.ID, "guild.Name": guild.Name, "name": "UpdateGuildSummary"})
@@ -45,6 +51,7 @@ func (a *Application) UpdateGuildSummary(bot ports.BotPort, guild *discord.Guild
 
 		return fmt.Errorf("failed to retrieve upcoming reservations: %s", err)
 	}
+	summary.Blackouts = a.upcomingBlackouts(guild)
 
 	log.Info("updating summary")
 
@@ -68,7 +75,7 @@ func (a *Application) OnPrivateSummary(bot ports.BotPort, request summary.Privat
 
 	res, err := a.fetchUpcomingReservationsWithSpot(request)
 	if res == nil {
-		fmt.Errorf("could not fetch upcoming reservations: %v", err)
+		log.Errorf("could not fetch upcoming reservations: %v", err)
 
 		return nil
 	}
@@ -79,6 +86,7 @@ func (a *Application) OnPrivateSummary(bot ports.BotPort, request summary.Privat
 
 		return fmt.Errorf("could not generate summary: %s", err)
 	}
+	summary.Blackouts = a.upcomingBlackouts(&discord.Guild{ID: strconv.FormatInt(request.GuildID, 10)})
 
 	dmChannel, err := bot.OpenDM(&discord.Member{ID: strconv.FormatInt(request.UserID, 10)})
 	if err != nil {
@@ -95,6 +103,22 @@ func (a *Application) OnPrivateSummary(bot ports.BotPort, request summary.Privat
 	return nil
 }
 
+// Returns guild blackouts taking place within SUMMARY_BLACKOUTS_RANGE, or none if they cannot be determined.
+func (a *Application) upcomingBlackouts(guild *discord.Guild) []summary.Blackout {
+	p, err := a.bookingSrv.GetPolicy(guild)
+	if err != nil {
+		a.log.Errorf("could not fetch guild policy: %s", err)
+
+		return []summary.Blackout{}
+	}
+
+	tNow := time.Now()
+
+	return collections.PoorMansMap(p.BlackoutWindows(tNow, tNow.Add(SUMMARY_BLACKOUTS_RANGE)), func(w policy.BlackoutWindow) summary.Blackout {
+		return summary.Blackout{Name: w.Name, StartAt: w.StartAt, EndAt: w.EndAt}
+	})
+}
+
 func (a *Application) fetchUpcomingReservationsWithSpot(request summary.PrivateSummaryRequest) ([]*reservation.ReservationWithSpot, error) {
 	var res []*reservation.ReservationWithSpot
 	var err error
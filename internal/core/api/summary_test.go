@@ -3,6 +3,7 @@ package api
 import (
 	"spot-assistant/internal/common/test/mocks"
 	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/policy"
 	"spot-assistant/internal/core/dto/reservation"
 	"spot-assistant/internal/core/dto/summary"
 	"strconv"
@@ -45,6 +46,7 @@ func TestUpdateGuildSummary(t *testing.T) {
 	mockSummarySrv := new(mocks.MockSummaryService)
 	mockSummarySrv.On("PrepareSummary", reservations).Return(summary, nil)
 	mockBookingSrv := new(mocks.MockBookingService)
+	mockBookingSrv.On("GetPolicy", guild).Return(policy.NewDefaultPolicy(guild.ID), nil)
 	adapter := NewApplication(mockReservationRepo, mockSummarySrv, mockBookingSrv)
 
 	// when
@@ -52,6 +54,9 @@ func TestUpdateGuildSummary(t *testing.T) {
 
 	// assert
 	assert.Nil(err)
+	if assert.NotEmpty(summary.Blackouts) {
+		assert.Equal(policy.DEFAULT_SERVER_SAVE_NAME, summary.Blackouts[0].Name)
+	}
 	mockReservationRepo.AssertExpectations(t)
 	mockSummarySrv.AssertExpectations(t)
 	mockBot.AssertExpectations(t)
@@ -101,6 +106,7 @@ func TestOnPrivateSummaryWhenRequestContainsSpotNames(t *testing.T) {
 	mockSummarySrv := new(mocks.MockSummaryService)
 	mockSummarySrv.On("PrepareSummary", reservations).Return(summary, nil)
 	mockBookingSrv := new(mocks.MockBookingService)
+	mockBookingSrv.On("GetPolicy", &discord.Guild{ID: "34"}).Return(policy.NewDefaultPolicy("34"), nil)
 	adapter := NewApplication(mockReservationRepo, mockSummarySrv, mockBookingSrv)
 
 	// when
@@ -156,6 +162,7 @@ func TestOnPrivateSummaryWhenRequestDoesntContainsSpotNames(t *testing.T) {
 	mockSummarySrv := new(mocks.MockSummaryService)
 	mockSummarySrv.On("PrepareSummary", reservations).Return(summary, nil)
 	mockBookingSrv := new(mocks.MockBookingService)
+	mockBookingSrv.On("GetPolicy", &discord.Guild{ID: "34"}).Return(policy.NewDefaultPolicy("34"), nil)
 	adapter := NewApplication(mockReservationRepo, mockSummarySrv, mockBookingSrv)
 
 	// when
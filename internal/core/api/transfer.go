@@ -0,0 +1,45 @@
+package api
+
+import (
+	"fmt"
+
+	stringsHelper "spot-assistant/internal/common/strings"
+	"spot-assistant/internal/core/dto/book"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/reservation"
+	"spot-assistant/internal/ports"
+)
+
+// Validates a transfer, so it can be offered to the recipient before it happens.
+func (a *Application) OnTransferOffer(bot ports.BotPort, request book.TransferRequest) (*reservation.ReservationWithSpot, error) {
+	return a.bookingSrv.CheckTransfer(request.Guild, request.Member, request.Recipient, request.ReservationID, a.roleChecker(bot, request.Guild))
+}
+
+func (a *Application) OnTransfer(bot ports.BotPort, request book.TransferRequest) (*reservation.ReservationWithSpot, error) {
+	res, err := a.bookingSrv.Transfer(request.Guild, request.Member, request.Recipient, request.ReservationID, a.roleChecker(bot, request.Guild))
+	if err != nil {
+		return nil, err
+	}
+
+	go a.UpdateGuildSummaryAndLogError(bot, request.Guild)
+
+	timeRange := fmt.Sprintf("%s - %s", stringsHelper.FormatDcLongTime(res.StartAt), stringsHelper.FormatDcLongTime(res.EndAt))
+	a.notifyMember(bot, request.Member, fmt.Sprintf(
+		"Your **%s** reservation (%s) has been transferred to <@!%s>", res.Spot.Name, timeRange, request.Recipient.ID,
+	))
+	a.notifyMember(bot, request.Recipient, fmt.Sprintf(
+		"<@!%s> has transferred you **%s** reservation (%s)", request.Member.ID, res.Spot.Name, timeRange,
+	))
+
+	return res, nil
+}
+
+// Sends a DM to the member in background, logging any error.
+func (a *Application) notifyMember(bot ports.BotPort, member *discord.Member, message string) {
+	go func() {
+		err := bot.SendDM(member, message)
+		if err != nil {
+			a.log.Errorf("error sending DM: %s", err)
+		}
+	}()
+}
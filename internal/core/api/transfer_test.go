@@ -0,0 +1,58 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"spot-assistant/internal/common/test/mocks"
+	"spot-assistant/internal/core/dto/book"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/reservation"
+)
+
+func TestOnTransfer(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	summarySrv := new(mocks.MockSummaryService)
+	reservationRepo := new(mocks.MockReservationRepo)
+	request := book.TransferRequest{
+		Guild: &discord.Guild{
+			ID:   "test-guild-id",
+			Name: "test-guild",
+		},
+		Member: &discord.Member{
+			ID: "test-member-id",
+		},
+		Recipient: &discord.Member{
+			ID: "test-recipient-id",
+		},
+		ReservationID: 1,
+	}
+	transferred := &reservation.ReservationWithSpot{
+		Reservation: reservation.Reservation{ID: 1, AuthorDiscordID: request.Recipient.ID},
+		Spot:        reservation.Spot{ID: 1, Name: "test-spot"},
+	}
+	bot := new(mocks.MockBot)
+	bot.On("FindChannelByName", request.Guild, "letter-summary").Return(&discord.Channel{Name: "letter-summary"}, nil)
+	bot.On("SendDM", request.Member, mock.AnythingOfType("string")).Return(nil)
+	bot.On("SendDM", request.Recipient, mock.AnythingOfType("string")).Return(nil)
+	bookingSrv := new(mocks.MockBookingService)
+	bookingSrv.On("Transfer", request.Guild, request.Member, request.Recipient, request.ReservationID).Return(transferred, nil)
+	reservationRepo.On("SelectUpcomingReservationsWithSpot", mocks.ContextMock, request.Guild.ID).Return([]*reservation.ReservationWithSpot{}, nil)
+	adapter := NewApplication(reservationRepo, summarySrv, bookingSrv)
+
+	// when
+	res, err := adapter.OnTransfer(bot, request)
+
+	// assert
+	assert.Nil(err)
+	assert.Equal(transferred, res)
+
+	assert.Eventually(func() bool {
+		return summarySrv.AssertExpectations(t) && bot.AssertExpectations(t) &&
+			reservationRepo.AssertExpectations(t) && bookingSrv.AssertExpectations(t)
+	}, 5*time.Second, 100*time.Millisecond)
+}
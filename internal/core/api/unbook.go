@@ -23,7 +23,7 @@ func (a *Application) OnUnbook(bot ports.BotPort, request book.UnbookRequest) (*
 		return nil, err
 	}
 
-	go a.UpdateGuildSummaryAndLogError(bot, request.Guild)
+	go a.ProcessQueueAndUpdateGuildSummary(bot, request.Guild)
 
 	return res, nil
 }
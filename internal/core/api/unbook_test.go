@@ -111,6 +111,7 @@ func TestUnbook(t *testing.T) {
 	bot.On("FindChannelByName", request.Guild, "letter-summary").Return(&discord.Channel{Name: "letter-summary"}, nil)
 	bookingSrv := new(mocks.MockBookingService)
 	bookingSrv.On("Unbook", request.Guild, request.Member, request.ReservationID).Return(existingReservation, nil)
+	bookingSrv.On("ProcessQueue", request.Guild).Return([]*reservation.QueueEntryWithSpot{}, nil)
 	adapter := NewApplication(reservationRepo, summarySrv, bookingSrv)
 	reservationRepo.On("SelectUpcomingReservationsWithSpot", mocks.ContextMock, request.Guild.ID).Return([]*reservation.ReservationWithSpot{}, nil)
 
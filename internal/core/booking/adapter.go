@@ -1,21 +1,29 @@
 package booking
 
 import (
+	"sync"
+
 	"spot-assistant/internal/ports"
 
+	cmap "github.com/orcaman/concurrent-map/v2"
 	"github.com/sirupsen/logrus"
 )
 
 type Adapter struct {
 	reservationRepo ports.ReservationRepository
 	spotRepo        ports.SpotRepository
+	policyRepo      ports.PolicyRepository
 	log             *logrus.Entry
+	// Guild queues are processed one at a time, otherwise the same entry could be booked twice
+	queueLocks cmap.ConcurrentMap[string, *sync.Mutex]
 }
 
-func NewAdapter(spotRepo ports.SpotRepository, reservationRepo ports.ReservationRepository) *Adapter {
+func NewAdapter(spotRepo ports.SpotRepository, reservationRepo ports.ReservationRepository, policyRepo ports.PolicyRepository) *Adapter {
 	return &Adapter{
 		log:             logrus.WithFields(logrus.Fields{"type": "core", "name": "booking"}),
 		spotRepo:        spotRepo,
 		reservationRepo: reservationRepo,
+		policyRepo:      policyRepo,
+		queueLocks:      cmap.New[*sync.Mutex](),
 	}
 }
@@ -0,0 +1,138 @@
+package booking
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"spot-assistant/internal/common/collections"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/reservation"
+	"spot-assistant/internal/core/dto/spot"
+)
+
+const (
+	// How far from the conflicting slot alternatives are looked for
+	ALTERNATIVES_SEARCH_RANGE = 24 * time.Hour
+	// Discord fits five buttons in a row
+	MAXIMUM_ALTERNATIVES = 5
+)
+
+// Returns spot of a given ID, which the guild can book.
+func (a *Adapter) GetSpot(guild *discord.Guild, spotId int64) (*spot.Spot, error) {
+	spots, err := a.spotRepo.SelectAllSpots(context.Background(), guild.ID)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch spots: %w", err)
+	}
+
+	s, _ := collections.PoorMansFind(spots, func(s *spot.Spot) bool {
+		return s.ID == spotId
+	})
+	if s == nil {
+		return nil, fmt.Errorf("could not find spot %d", spotId)
+	}
+	if s.Archived() {
+		return nil, fmt.Errorf("respawn %s has been archived and cannot be booked anymore", s.Name)
+	}
+
+	return s, nil
+}
+
+// Returns free slots as long as the one between startAt and endAt, on the spot or on other floors
+// and sides of the same respawn, nearest to the requested slot first. Slots stay clear of guild blackouts.
+func (a *Adapter) FindAlternatives(guild *discord.Guild, spotName string, startAt time.Time, endAt time.Time) ([]*reservation.Alternative, error) {
+	a.log.WithFields(logrus.Fields{"guild": guild.ID, "spot": spotName, "startAt": startAt, "endAt": endAt}).Info("alternatives request")
+
+	p, err := a.GetPolicy(guild)
+	if err != nil {
+		return nil, err
+	}
+
+	requested, err := a.findBookableSpot(guild, spotName)
+	if err != nil {
+		return nil, err
+	}
+
+	spots, err := a.spotRepo.SelectAllSpots(context.Background(), guild.ID)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch spots: %w", err)
+	}
+
+	// Requested spot goes first, so that it wins over its siblings when equally near
+	siblings := collections.PoorMansFilter(spots, func(s *spot.Spot) bool {
+		return !s.Archived() && s.ID != requested.ID && s.SameRespawn(requested)
+	})
+	candidates := append([]*spot.Spot{requested}, siblings...)
+
+	reservations, err := a.reservationRepo.SelectUpcomingReservationsWithSpot(context.Background(), guild.ID)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch upcoming reservations: %w", err)
+	}
+
+	// Alternatives start at a full minute, as that is what can be booked
+	now := time.Now().Truncate(time.Minute).Add(time.Minute)
+	from := startAt.Add(-ALTERNATIVES_SEARCH_RANGE)
+	if from.Before(now) {
+		from = now
+	}
+	to := endAt.Add(ALTERNATIVES_SEARCH_RANGE)
+	if horizon := now.Add(p.BookingHorizon); to.After(horizon) {
+		to = horizon
+	}
+
+	blackouts := p.BlackoutWindows(from, to)
+	length := endAt.Sub(startAt)
+	alternatives := []*reservation.Alternative{}
+	for _, s := range candidates {
+		spotReservations := []*reservation.Reservation{}
+		for _, r := range reservations {
+			if r.Spot.ID == s.ID {
+				spotReservations = append(spotReservations, &r.Reservation)
+			}
+		}
+
+		// Blackouts take up the spot just like reservations do
+		for _, w := range blackouts {
+			spotReservations = append(spotReservations, &reservation.Reservation{StartAt: w.StartAt, EndAt: w.EndAt})
+		}
+
+		// Gaps are not split, as the requested length cannot exceed the maximum reservation time anyway
+		for _, gap := range freeWindows(spotReservations, from, to, to.Sub(from), length) {
+			alternatives = append(alternatives, &reservation.Alternative{
+				Spot:   reservation.Spot{ID: s.ID, Name: s.Name, ParentID: s.ParentID, Details: s.Details},
+				Window: nearestSlot(gap, startAt, length),
+			})
+		}
+	}
+
+	slices.SortStableFunc(alternatives, func(a *reservation.Alternative, b *reservation.Alternative) int {
+		return cmp.Compare(distance(a.StartAt, startAt), distance(b.StartAt, startAt))
+	})
+
+	return collections.Truncate(alternatives, MAXIMUM_ALTERNATIVES), nil
+}
+
+// Returns slot of a given length within the gap, which starts the nearest to startAt.
+func nearestSlot(gap *reservation.Window, startAt time.Time, length time.Duration) reservation.Window {
+	latestStart := gap.EndAt.Add(-length)
+	switch {
+	case startAt.Before(gap.StartAt):
+		startAt = gap.StartAt
+	case startAt.After(latestStart):
+		startAt = latestStart
+	}
+
+	return reservation.Window{StartAt: startAt, EndAt: startAt.Add(length)}
+}
+
+func distance(a time.Time, b time.Time) time.Duration {
+	if a.Before(b) {
+		return b.Sub(a)
+	}
+
+	return a.Sub(b)
+}
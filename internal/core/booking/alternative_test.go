@@ -0,0 +1,97 @@
+package booking
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"spot-assistant/internal/common/test/mocks"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/policy"
+	"spot-assistant/internal/core/dto/reservation"
+	"spot-assistant/internal/core/dto/spot"
+)
+
+func TestFindAlternatives(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	tBase := time.Now().Truncate(time.Hour).Add(24 * time.Hour)
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{
+		{ID: 1, Name: "Library"},
+		{ID: 2, Name: "Library -1", ParentID: 1},
+		{ID: 3, Name: "Library -2", ParentID: 1, ArchivedAt: time.Now()},
+		{ID: 4, Name: "Asura Palace"},
+	}, nil)
+	reservationRepo := new(mocks.MockReservationRepo)
+	reservationRepo.On("SelectUpcomingReservationsWithSpot", mocks.ContextMock, guild.ID).Return([]*reservation.ReservationWithSpot{
+		{
+			Reservation: reservation.Reservation{StartAt: tBase.Add(-4 * time.Hour), EndAt: tBase.Add(3 * time.Hour)},
+			Spot:        reservation.Spot{ID: 1, Name: "Library"},
+		},
+		{
+			Reservation: reservation.Reservation{StartAt: tBase.Add(-24 * time.Hour), EndAt: tBase.Add(1 * time.Hour)},
+			Spot:        reservation.Spot{ID: 2, Name: "Library -1", ParentID: 1},
+		},
+		// Leaves a gap too short for the requested slot
+		{
+			Reservation: reservation.Reservation{StartAt: tBase.Add(2 * time.Hour), EndAt: tBase.Add(24 * time.Hour)},
+			Spot:        reservation.Spot{ID: 2, Name: "Library -1", ParentID: 1},
+		},
+	}, nil)
+	adapter := NewAdapter(spotRepo, reservationRepo, newPolicyRepo())
+
+	// when
+	res, err := adapter.FindAlternatives(guild, "Library", tBase, tBase.Add(2*time.Hour))
+
+	// assert
+	assert.Nil(err)
+	assert.Equal([]*reservation.Alternative{
+		{
+			Spot:   reservation.Spot{ID: 1, Name: "Library"},
+			Window: reservation.Window{StartAt: tBase.Add(3 * time.Hour), EndAt: tBase.Add(5 * time.Hour)},
+		},
+		{
+			Spot:   reservation.Spot{ID: 1, Name: "Library"},
+			Window: reservation.Window{StartAt: tBase.Add(-6 * time.Hour), EndAt: tBase.Add(-4 * time.Hour)},
+		},
+		{
+			Spot:   reservation.Spot{ID: 2, Name: "Library -1", ParentID: 1},
+			Window: reservation.Window{StartAt: tBase.Add(24 * time.Hour), EndAt: tBase.Add(26 * time.Hour)},
+		},
+	}, res)
+}
+
+func TestFindAlternativesAvoidsBlackouts(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	tBase := time.Now().Truncate(time.Hour).Add(24 * time.Hour)
+	blackoutAt := tBase.UTC().Add(3 * time.Hour)
+	p := newTestPolicy(guild.ID)
+	p.Blackouts = []policy.Blackout{{Name: "Server save", Start: time.Duration(blackoutAt.Hour()) * time.Hour, Length: 30 * time.Minute, TimeZone: "UTC"}}
+	policyRepo := new(mocks.MockPolicyRepo)
+	policyRepo.On("FindGuildPolicy", mocks.ContextMock, guild.ID).Return(p, nil)
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{{ID: 1, Name: "Library"}}, nil)
+	reservationRepo := new(mocks.MockReservationRepo)
+	reservationRepo.On("SelectUpcomingReservationsWithSpot", mocks.ContextMock, guild.ID).Return([]*reservation.ReservationWithSpot{
+		{
+			Reservation: reservation.Reservation{StartAt: tBase.Add(-4 * time.Hour), EndAt: tBase.Add(3 * time.Hour)},
+			Spot:        reservation.Spot{ID: 1, Name: "Library"},
+		},
+	}, nil)
+	adapter := NewAdapter(spotRepo, reservationRepo, policyRepo)
+
+	// when
+	res, err := adapter.FindAlternatives(guild, "Library", tBase, tBase.Add(2*time.Hour))
+
+	// assert
+	assert.Nil(err)
+	assert.NotEmpty(res)
+	// Blackout windows are given in their own time zone
+	assert.WithinDuration(tBase.Add(3*time.Hour+30*time.Minute), res[0].StartAt, 0)
+	assert.WithinDuration(tBase.Add(5*time.Hour+30*time.Minute), res[0].EndAt, 0)
+}
@@ -5,34 +5,36 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
 
 	"spot-assistant/internal/common/collections"
 	stringsHelper "spot-assistant/internal/common/strings"
 	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/policy"
 	"spot-assistant/internal/core/dto/reservation"
 	"spot-assistant/internal/core/dto/spot"
 
 	"github.com/sirupsen/logrus"
 )
 
-const MAXIMUM_RESERVATIONS_TIME = 3 * time.Hour
-
-var MAXIMUM_RESERVATIONS_TIME_EXCEEDED_ERROR = errors.New("You can only book 3 hours of reservations within 24 hour window")
 var HourRegex = regexp.MustCompile(`(\d{2}:\d{2})`)
 
-// Returns spots filtered by filter, if non-zero length.
-func (a *Adapter) FindAvailableSpots(filter string) ([]string, error) {
-	spots, err := a.spotRepo.SelectAllSpots(context.Background())
+// Returns spots the guild can book, filtered by filter, if non-zero length, best matches first.
+// Filter may match spot aliases, contain typos or search on spot details, e.g. "feyrist 250 ek".
+func (a *Adapter) FindAvailableSpots(guild *discord.Guild, filter string) ([]string, error) {
+	spots, err := a.spotRepo.SelectAllSpots(context.Background(), guild.ID)
 	if err != nil {
 		return []string{}, fmt.Errorf("could not fetch spots matching your query: %w", err)
 	}
 
+	spots = collections.PoorMansFilter(spots, func(s *spot.Spot) bool {
+		return !s.Archived()
+	})
+
 	if len(filter) > 0 {
-		spots = collections.PoorMansFilter(spots, func(spot *spot.Spot) bool {
-			return strings.Contains(strings.ToLower(spot.Name), strings.ToLower(filter))
-		})
+		spots = rankSpots(spots, filter)
 	}
 
 	spots = collections.Truncate(spots, 15)
@@ -42,28 +44,110 @@ func (a *Adapter) FindAvailableSpots(filter string) ([]string, error) {
 	}), nil
 }
 
-// Returns suggested hours based on requested time. If filter is non-zero length,
-// it will return filtered results.
-func (a *Adapter) GetSuggestedHours(baseTime time.Time, filter string) []string {
+// Returns guild policy.
+func (a *Adapter) GetPolicy(guild *discord.Guild) (*policy.Policy, error) {
+	p, err := a.policyRepo.FindGuildPolicy(context.Background(), guild.ID)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch guild policy: %w", err)
+	}
+
+	return p, nil
+}
+
+// Validates and saves guild policy.
+func (a *Adapter) SavePolicy(p *policy.Policy) (*policy.Policy, error) {
+	err := p.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := a.policyRepo.SaveGuildPolicy(context.Background(), p)
+	if err != nil {
+		return nil, fmt.Errorf("could not save guild policy: %w", err)
+	}
+
+	return res, nil
+}
+
+// Returns time zone of a member, which is either set by the member,
+// or inherited from the guild policy.
+func (a *Adapter) GetLocation(guild *discord.Guild, member *discord.Member) (*time.Location, error) {
+	timeZone, err := a.policyRepo.FindMemberTimeZone(context.Background(), guild.ID, member.ID)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch member time zone: %w", err)
+	}
+
+	if len(timeZone) > 0 {
+		loc, err := time.LoadLocation(timeZone)
+		if err == nil {
+			return loc, nil
+		}
+
+		a.log.Warningf("ignoring unknown member time zone %s: %s", timeZone, err)
+	}
+
+	p, err := a.GetPolicy(guild)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.Location(), nil
+}
+
+// Sets time zone of a member. Empty time zone restores the guild one.
+func (a *Adapter) SetMemberTimeZone(guild *discord.Guild, member *discord.Member, timeZone string) (*time.Location, error) {
+	if len(timeZone) == 0 {
+		err := a.policyRepo.DeleteMemberTimeZone(context.Background(), guild.ID, member.ID)
+		if err != nil {
+			return nil, fmt.Errorf("could not remove member time zone: %w", err)
+		}
+
+		return a.GetLocation(guild, member)
+	}
+
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %s, use names such as Europe/Warsaw or America/Sao_Paulo", timeZone)
+	}
+
+	err = a.policyRepo.SaveMemberTimeZone(context.Background(), guild.ID, member.ID, loc.String())
+	if err != nil {
+		return nil, fmt.Errorf("could not save member time zone: %w", err)
+	}
+
+	return loc, nil
+}
+
+// Returns suggested hours based on requested time, spaced by guild suggestion step.
+// If spot name is non-zero length, hours the spot is free at come first, along with
+// the ones its reservations end at. Hours within guild blackouts are left out. If filter is non-zero length, it will return filtered results.
+func (a *Adapter) GetSuggestedHours(guild *discord.Guild, baseTime time.Time, spotName string, filter string) []string {
 	suggestedHours := make([]time.Time, 0)
 	validatedFilter := HourRegex.FindString(filter)
 
-	roundedMinutes := baseTime.Minute()
-	roundedHour := baseTime.Hour()
-	if roundedMinutes >= 30 {
-		roundedMinutes = 0
-		roundedHour += 1
-	} else {
-		roundedMinutes = 30
+	p, err := a.GetPolicy(guild)
+	if err != nil {
+		a.log.Error(err)
+		p = policy.NewDefaultPolicy(guild.ID)
 	}
+	step := p.SuggestionStep
 
-	baseTimeRounded := time.Date(baseTime.Year(), baseTime.Month(), baseTime.Day(), roundedHour, roundedMinutes, 0, 0, baseTime.Location())
+	// Round up to the next step since midnight
+	midnight := time.Date(baseTime.Year(), baseTime.Month(), baseTime.Day(), 0, 0, 0, 0, baseTime.Location())
+	baseTimeRounded := midnight.Add(baseTime.Sub(midnight).Truncate(step) + step)
 
 	suggestedHours = append(suggestedHours, baseTimeRounded)
 	for x := 1; x <= 7; x++ {
-		suggestedHours = append(suggestedHours, suggestedHours[x-1].Add(30*time.Minute))
+		suggestedHours = append(suggestedHours, suggestedHours[x-1].Add(step))
+	}
+
+	if len(spotName) > 0 {
+		suggestedHours = a.preferFreeHours(p, guild, spotName, suggestedHours)
 	}
 
+	// Nobody can hunt during blackouts, such as the server save
+	suggestedHours = slices.DeleteFunc(suggestedHours, p.InBlackout)
+
 	suggestedOptions := collections.PoorMansMap(suggestedHours, func(hour time.Time) string {
 		return hour.Format(stringsHelper.DC_TIME_FORMAT)
 	})
@@ -82,97 +166,211 @@ func (a *Adapter) GetSuggestedHours(baseTime time.Time, filter string) []string
 	return suggestedOptions
 }
 
-func (a *Adapter) Book(member *discord.Member, guild *discord.Guild, spotName string, startAt time.Time, endAt time.Time, overbook bool, hasPermissions bool) ([]*reservation.ClippedOrRemovedReservation, error) {
+// Adds hours the spot becomes free at to the suggested ones and moves hours the spot is free at
+// to the front, keeping them in chronological order.
+func (a *Adapter) preferFreeHours(p *policy.Policy, guild *discord.Guild, spotName string, hours []time.Time) []time.Time {
+	s, err := a.findBookableSpot(guild, spotName)
+	if err != nil {
+		// Spot name might not have been completed yet
+		return hours
+	}
+
+	last := hours[len(hours)-1]
+	windows, err := a.findFreeWindows(p, guild, s, hours[0], last.Add(p.MaximumReservationTime))
+	if err != nil {
+		a.log.Error(err)
+
+		return hours
+	}
+
+	for i, w := range windows {
+		// Windows following one another come from splitting a single gap
+		continuation := i > 0 && windows[i-1].EndAt.Equal(w.StartAt)
+		if !continuation && !w.StartAt.After(last) && !slices.ContainsFunc(hours, w.StartAt.Equal) {
+			hours = append(hours, w.StartAt)
+		}
+	}
+
+	slices.SortStableFunc(hours, func(a time.Time, b time.Time) int {
+		aFree, bFree := isFree(windows, a), isFree(windows, b)
+		switch {
+		case aFree && !bFree:
+			return -1
+		case !aFree && bFree:
+			return 1
+		default:
+			return a.Compare(b)
+		}
+	})
+
+	return hours
+}
+
+// Returns suggested dates from baseTime up to 7 days ahead, limited by the guild booking horizon.
+// If filter is non-zero length, it will return filtered results.
+func (a *Adapter) GetSuggestedDates(guild *discord.Guild, baseTime time.Time, filter string) []string {
+	horizon := policy.DEFAULT_BOOKING_HORIZON
+	p, err := a.GetPolicy(guild)
+	if err != nil {
+		a.log.Error(err)
+	} else {
+		horizon = p.BookingHorizon
+	}
+
+	suggestedOptions := make([]string, 0, 7)
+	for x := 0; x < 7 && time.Duration(x)*24*time.Hour <= horizon; x++ {
+		suggestedOptions = append(suggestedOptions, baseTime.AddDate(0, 0, x).Format(stringsHelper.DC_DATE_FORMAT))
+	}
+
+	if len(filter) > 0 {
+		suggestedOptions = collections.PoorMansFilter(suggestedOptions, func(d string) bool {
+			return strings.Contains(d, filter)
+		})
+	}
+
+	return suggestedOptions
+}
+
+func (a *Adapter) Book(member *discord.Member, guild *discord.Guild, party []*discord.Member, spotName string, startAt time.Time, endAt time.Time, overbook bool, tier policy.Tier, hasRole policy.RoleChecker) ([]*reservation.ClippedOrRemovedReservation, error) {
+	return a.book(member, guild, party, spotName, startAt, endAt, overbook, tier, hasRole, nil)
+}
+
+// Books the spot, unless checkConflicts, if given, returns an error for reservations the booking would clip or remove.
+func (a *Adapter) book(member *discord.Member, guild *discord.Guild, party []*discord.Member, spotName string, startAt time.Time, endAt time.Time, overbook bool, tier policy.Tier, hasRole policy.RoleChecker, checkConflicts func(p *policy.Policy, conflicts []*reservation.Reservation) error) ([]*reservation.ClippedOrRemovedReservation, error) {
 	currTime := time.Now()
 
 	a.log.WithFields(logrus.Fields{
-		"member":         member,
-		"hasPermissions": hasPermissions,
-		"overbook":       overbook,
-		"startAt":        startAt,
-		"endAt":          endAt,
-		"currTime":       currTime,
+		"member":   member,
+		"party":    party,
+		"tier":     tier,
+		"overbook": overbook,
+		"startAt":  startAt,
+		"endAt":    endAt,
+		"currTime": currTime,
 	}).Info("booking request")
 
-	spots, err := a.spotRepo.SelectAllSpots(context.Background())
+	p, err := a.GetPolicy(guild)
 	if err != nil {
-		return nil, fmt.Errorf("could not fetch spots: %w", err)
+		return nil, err
 	}
 
-	spot, _ := collections.PoorMansFind(spots, func(s *spot.Spot) bool {
-		return s.Name == spotName
-	})
-	if spot == nil {
-		return nil, fmt.Errorf("could not find spot called %s", spotName)
+	startAt, endAt, err = p.ClipToBlackouts(startAt, endAt)
+	if err != nil {
+		return nil, err
 	}
 
-	if endAt.Sub(startAt) > 3*time.Hour {
-		return nil, errors.New("reservation cannot take more than 3 hours")
+	spot, err := a.findBookableSpot(guild, spotName)
+	if err != nil {
+		return nil, err
 	}
 
-	conflictingReservations, err := a.reservationRepo.SelectOverlappingReservations(context.Background(), spotName, startAt, endAt, guild.ID)
+	bookedSpot := reservation.Spot{ID: spot.ID, Name: spot.Name, ParentID: spot.ParentID}
+	conflictingReservations, rejected, err := a.checkBooking(p, member, guild, bookedSpot, startAt, endAt, overbook, tier)
 	if err != nil {
-		return nil, fmt.Errorf("could not select overlapping reservations: %w", err)
+		return rejected, err
 	}
 
+	if checkConflicts != nil {
+		err = checkConflicts(p, conflictingReservations)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, partyMember := range party {
+		err = a.checkPartyMember(p, hasRole, member, guild, partyMember, bookedSpot, startAt, endAt)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	res, err := a.reservationRepo.CreateAndDeleteConflicting(context.Background(), member, guild, party, conflictingReservations, spot.ID, startAt, endAt, p.ReservationPriority(tier))
+	if err != nil {
+		return nil, fmt.Errorf("could not create the reservation: %w", err)
+	}
+
+	return res, nil
+}
+
+// Runs reservation length, booking horizon, booking window, lottery, conflict, daily and weekly quota checks. Member can overbook
+// reservations of lower priority than their tier, and the tier quota applies. Ignored reservations
+// are treated as nonexistent, so that they can be moved. Returns reservations
+// that have to be removed to make room for the new one, or reservations that prevented booking.
+func (a *Adapter) checkBooking(p *policy.Policy, member *discord.Member, guild *discord.Guild, s reservation.Spot, startAt time.Time, endAt time.Time, overbook bool, tier policy.Tier, ignoredReservationIds ...int64) ([]*reservation.Reservation, []*reservation.ClippedOrRemovedReservation, error) {
+	if endAt.Sub(startAt) > p.MaximumReservationTime {
+		return nil, nil, fmt.Errorf("reservation cannot take more than %s", stringsHelper.FormatDuration(p.MaximumReservationTime))
+	}
+
+	err := checkBookingHorizon(p, time.Now(), startAt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = a.checkBookingWindow(p, guild, s, time.Now(), startAt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = a.checkLottery(p, guild, s, time.Now(), startAt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	conflictingReservations, err := a.reservationRepo.SelectOverlappingReservations(context.Background(), s.ID, startAt, endAt, guild.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not select overlapping reservations: %w", err)
+	}
+	conflictingReservations = collections.PoorMansFilter(conflictingReservations, func(r *reservation.Reservation) bool {
+		return !collections.PoorMansContains(ignoredReservationIds, r.ID)
+	})
+
 	authorsConflictingReservations, _ := collections.PoorMansFind(conflictingReservations, func(r *reservation.Reservation) bool {
 		return r.AuthorDiscordID == member.ID
 	})
 
 	if authorsConflictingReservations != nil && overbook {
-		return nil, errors.New("you cannot overbook yourself")
+		return nil, nil, errors.New("you cannot overbook yourself")
 	}
 
 	if len(conflictingReservations) > 0 {
-		switch canDo := overbook && isPotentiallyAbandonedReservation(conflictingReservations) || overbook && hasPermissions; canDo {
+		currTime := time.Now()
+		_, protectedIndex := collections.PoorMansFind(conflictingReservations, func(r *reservation.Reservation) bool {
+			return !canBeTakenOver(p, conflictingReservations, r, currTime) && r.Priority >= tier.Priority
+		})
+
+		switch canDo := overbook && protectedIndex == -1; canDo {
 		case true:
 			break
 		case false:
-			return collections.PoorMansMap(conflictingReservations, func(r *reservation.Reservation) *reservation.ClippedOrRemovedReservation {
+			rejected := collections.PoorMansMap(conflictingReservations, func(r *reservation.Reservation) *reservation.ClippedOrRemovedReservation {
 				return &reservation.ClippedOrRemovedReservation{
 					Original: r,
 					New:      []*reservation.Reservation{r},
 				}
-			}), errors.New("There are conflicting reservation which prevented booking this reservation. If you would like to overbook them, ensure you have a @Postman role, then repeat the command and set 'overbook' parameter to 'true'.")
+			})
+			if len(p.Tiers) == 0 {
+				return nil, rejected, fmt.Errorf("There are conflicting reservation which prevented booking this reservation. If you would like to overbook them, ensure you have a @%s role, then repeat the command and set 'overbook' parameter to 'true'.", p.OverbookRole)
+			}
+
+			return nil, rejected, errors.New("There are conflicting reservation which prevented booking this reservation. You can only overbook reservations of members with a lower priority tier, by repeating the command and setting 'overbook' parameter to 'true'.")
 		}
 	}
 
-	// Check for potentially exceeding maximum hours, with an exception for multi-floor respawns
-	upcomingAuthorReservations, err := a.reservationRepo.SelectUpcomingMemberReservationsWithSpots(context.Background(), guild, member)
+	exceeds, err := a.exceedsMaximumReservationsTime(p, tier.MaximumReservationsTime, guild, member, s, startAt, endAt, ignoredReservationIds...)
 	if err != nil {
-		return nil, fmt.Errorf("could not select upcoming member reservations: %w", err)
+		return nil, nil, err
 	}
 
-	if len(upcomingAuthorReservations) > 0 {
-		tempReservation := reservation.ReservationWithSpot{
-			Reservation: reservation.Reservation{
-				ID:      -1,
-				Author:  member.ID,
-				StartAt: startAt,
-				EndAt:   endAt,
-			},
-			Spot: reservation.Spot{
-				Name: spotName,
-			},
-		}
-		upcomingAuthorReservations = append(upcomingAuthorReservations, &tempReservation)
-
-		reducedReservations := reduceAllAuthorReservationsByLongestPerSpot(upcomingAuthorReservations)
-		totalReservationsTime := collections.PoorMansSum(reducedReservations, func(reservation *reservation.ReservationWithSpot) time.Duration {
-			return reservation.EndAt.Sub(reservation.StartAt)
-		})
-
-		if totalReservationsTime > MAXIMUM_RESERVATIONS_TIME {
-			return nil, MAXIMUM_RESERVATIONS_TIME_EXCEEDED_ERROR
-		}
+	if exceeds {
+		return nil, nil, fmt.Errorf("You can only book %s of reservations within 24 hour window", stringsHelper.FormatDuration(tier.MaximumReservationsTime))
 	}
 
-	res, err := a.reservationRepo.CreateAndDeleteConflicting(context.Background(), member, guild, conflictingReservations, spot.ID, startAt, endAt)
+	err = a.checkWeeklyQuotas(p, guild, member, s, startAt, endAt, ignoredReservationIds...)
 	if err != nil {
-		return nil, fmt.Errorf("could not create the reservation: %w", err)
+		return nil, nil, err
 	}
 
-	return res, nil
+	return conflictingReservations, nil, nil
 }
 
 func (a *Adapter) UnbookAutocomplete(g *discord.Guild, m *discord.Member, filter string) ([]*reservation.ReservationWithSpot, error) {
@@ -183,6 +381,16 @@ func (a *Adapter) UnbookAutocomplete(g *discord.Guild, m *discord.Member, filter
 		return []*reservation.ReservationWithSpot{}, err
 	}
 
+	// Present reservations in member time zone, as that's what they search by
+	loc, err := a.GetLocation(g, m)
+	if err != nil {
+		return []*reservation.ReservationWithSpot{}, err
+	}
+	for _, r := range reservations {
+		r.StartAt = r.StartAt.In(loc)
+		r.EndAt = r.EndAt.In(loc)
+	}
+
 	// If any input value is passed, try to match it with startAt, endAt and spot name
 	if len(filter) > 0 {
 		reservations = collections.PoorMansFilter(reservations, func(r *reservation.ReservationWithSpot) bool {
@@ -216,14 +424,321 @@ func (a *Adapter) Unbook(g *discord.Guild, m *discord.Member, reservationId int6
 	return res, nil
 }
 
-// This is an edge case, where we check:
-// if there is only one overlapping reservation,
-// and if it started,
-// and if it hasn't ended,
-// and it contains our reservation request and time
-func isPotentiallyAbandonedReservation(overlappingReservations []*reservation.Reservation) bool {
-	return len(overlappingReservations) == 1 &&
-		overlappingReservations[0].StartAt.Before(time.Now()) &&
-		(overlappingReservations[0].EndAt.After(time.Now()) ||
-			overlappingReservations[0].EndAt.Equal(time.Now()))
+// Moves one of the upcoming member reservations to a new time range, with the same checks as Book.
+// Date, start and end time are expressed in member time zone, and when nil the current ones are kept.
+// Without an end time, reservation keeps its duration.
+func (a *Adapter) Rebook(member *discord.Member, guild *discord.Guild, reservationId int64, date *time.Time, startTime *time.Duration, endTime *time.Duration, overbook bool, tier policy.Tier) (*reservation.ReservationWithSpot, []*reservation.ClippedOrRemovedReservation, error) {
+	a.log.WithFields(logrus.Fields{
+		"member":        member,
+		"reservationId": reservationId,
+		"tier":          tier,
+		"overbook":      overbook,
+		"date":          date,
+		"startTime":     startTime,
+		"endTime":       endTime,
+	}).Info("rebooking request")
+
+	if date == nil && startTime == nil && endTime == nil {
+		return nil, nil, errors.New("provide a new date, start or end of the reservation")
+	}
+
+	p, err := a.GetPolicy(guild)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res, err := a.reservationRepo.FindReservationWithSpot(context.Background(), reservationId, guild.ID, member.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not find reservation: %w", err)
+	}
+
+	if !res.EndAt.After(time.Now()) {
+		return nil, nil, errors.New("you cannot change a reservation that has already ended")
+	}
+
+	loc, err := a.GetLocation(guild, member)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	startAt, endAt := rebookedTimeRange(res.StartAt.In(loc), res.EndAt.In(loc), date, startTime, endTime)
+	startAt, endAt, err = p.ClipToBlackouts(startAt, endAt)
+	if err != nil {
+		return res, nil, err
+	}
+
+	if startAt.Before(time.Now()) && !startAt.Equal(res.StartAt) {
+		return res, nil, errors.New("reservation cannot start in the past")
+	}
+
+	conflictingReservations, rejected, err := a.checkBooking(p, member, guild, res.Spot, startAt, endAt, overbook, tier, res.Reservation.ID)
+	if err != nil {
+		return res, rejected, err
+	}
+
+	modified, err := a.reservationRepo.UpdateAndDeleteConflicting(context.Background(), member, guild, res.Reservation.ID, conflictingReservations, res.Spot.ID, startAt, endAt)
+	if err != nil {
+		return res, nil, fmt.Errorf("could not update the reservation: %w", err)
+	}
+
+	res.StartAt = startAt
+	res.EndAt = endAt
+
+	return res, modified, nil
+}
+
+// Returns time range of a reservation moved to given date, start and end time.
+// Nil values are replaced with the current ones.
+func rebookedTimeRange(currStartAt time.Time, currEndAt time.Time, date *time.Time, startTime *time.Duration, endTime *time.Duration) (time.Time, time.Time) {
+	day := currStartAt
+	if date != nil {
+		day = *date
+	}
+
+	startAt := time.Date(day.Year(), day.Month(), day.Day(), currStartAt.Hour(), currStartAt.Minute(), 0, 0, currStartAt.Location())
+	if startTime != nil {
+		startAt = time.Date(day.Year(), day.Month(), day.Day(),
+			int(*startTime/time.Hour), int(*startTime%time.Hour/time.Minute), 0, 0, currStartAt.Location())
+	}
+
+	if endTime == nil {
+		return startAt, startAt.Add(currEndAt.Sub(currStartAt))
+	}
+
+	endAt := time.Date(startAt.Year(), startAt.Month(), startAt.Day(),
+		int(*endTime/time.Hour), int(*endTime%time.Hour/time.Minute), 0, 0, currStartAt.Location())
+	if !endAt.After(startAt) {
+		endAt = endAt.AddDate(0, 0, 1)
+	}
+
+	return startAt, endAt
+}
+
+// Checks whether one of the upcoming member reservations can be handed over to the recipient,
+// whose tier reservations time limit and weekly quotas must not be exceeded by it. Returns the reservation.
+func (a *Adapter) CheckTransfer(guild *discord.Guild, from *discord.Member, to *discord.Member, reservationId int64, hasRole policy.RoleChecker) (*reservation.ReservationWithSpot, error) {
+	res, _, err := a.checkTransfer(guild, from, to, reservationId, hasRole)
+
+	return res, err
+}
+
+// Runs CheckTransfer checks. Returns the reservation along with the priority it is stored with
+// once the recipient takes it over.
+func (a *Adapter) checkTransfer(guild *discord.Guild, from *discord.Member, to *discord.Member, reservationId int64, hasRole policy.RoleChecker) (*reservation.ReservationWithSpot, int, error) {
+	if from.ID == to.ID {
+		return nil, 0, errors.New("you cannot transfer a reservation to yourself")
+	}
+
+	p, err := a.GetPolicy(guild)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	res, err := a.reservationRepo.FindReservationWithSpot(context.Background(), reservationId, guild.ID, from.ID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("could not find reservation: %w", err)
+	}
+
+	if !res.EndAt.After(time.Now()) {
+		return nil, 0, errors.New("you cannot transfer a reservation that has already ended")
+	}
+
+	tier := p.TierOf(hasRole, to)
+	exceeds, err := a.exceedsMaximumReservationsTime(p, tier.MaximumReservationsTime, guild, to, res.Spot, res.StartAt, res.EndAt)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if exceeds {
+		return nil, 0, fmt.Errorf("recipient can only book %s of reservations within 24 hour window", stringsHelper.FormatDuration(tier.MaximumReservationsTime))
+	}
+
+	err = a.checkWeeklyQuotas(p, guild, to, res.Spot, res.StartAt, res.EndAt)
+	if err != nil {
+		return nil, 0, fmt.Errorf("recipient cannot take the reservation over. %w", err)
+	}
+
+	return res, p.ReservationPriority(tier), nil
+}
+
+// Hands one of the upcoming member reservations over to the recipient, with the same checks as CheckTransfer.
+// The reservation takes the priority of the recipient tier.
+func (a *Adapter) Transfer(guild *discord.Guild, from *discord.Member, to *discord.Member, reservationId int64, hasRole policy.RoleChecker) (*reservation.ReservationWithSpot, error) {
+	a.log.WithFields(logrus.Fields{
+		"from":          from,
+		"to":            to,
+		"reservationId": reservationId,
+	}).Info("transfer request")
+
+	res, priority, err := a.checkTransfer(guild, from, to, reservationId, hasRole)
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.reservationRepo.TransferPresentMemberReservation(context.Background(), guild, from, to, reservationId, priority)
+	if err != nil {
+		return nil, fmt.Errorf("could not transfer the reservation: %w", err)
+	}
+
+	res.AuthorDiscordID = to.ID
+	res.Priority = priority
+
+	return res, nil
+}
+
+// Adds a co-hunter to one of the upcoming member reservations. When guild counts party time,
+// the co-hunter cannot exceed maximum reservations time of their tier, nor weekly quotas either.
+func (a *Adapter) AddPartyMember(guild *discord.Guild, author *discord.Member, reservationId int64, partyMember *discord.Member, hasRole policy.RoleChecker) (*reservation.ReservationWithSpot, error) {
+	p, err := a.GetPolicy(guild)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := a.reservationRepo.FindReservationWithSpot(context.Background(), reservationId, guild.ID, author.ID)
+	if err != nil {
+		return nil, fmt.Errorf("could not find reservation: %w", err)
+	}
+
+	if !res.EndAt.After(time.Now()) {
+		return nil, errors.New("you cannot change a party of a reservation that has already ended")
+	}
+
+	err = a.checkPartyMember(p, hasRole, author, guild, partyMember, res.Spot, res.StartAt, res.EndAt)
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.reservationRepo.AddReservationPartyMember(context.Background(), res.Reservation.ID, partyMember)
+	if err != nil {
+		return nil, fmt.Errorf("could not add party member: %w", err)
+	}
+
+	return res, nil
+}
+
+// Removes a co-hunter from one of the upcoming member reservations.
+func (a *Adapter) RemovePartyMember(guild *discord.Guild, author *discord.Member, reservationId int64, partyMember *discord.Member) (*reservation.ReservationWithSpot, error) {
+	res, err := a.reservationRepo.FindReservationWithSpot(context.Background(), reservationId, guild.ID, author.ID)
+	if err != nil {
+		return nil, fmt.Errorf("could not find reservation: %w", err)
+	}
+
+	if !res.EndAt.After(time.Now()) {
+		return nil, errors.New("you cannot change a party of a reservation that has already ended")
+	}
+
+	err = a.reservationRepo.RemoveReservationPartyMember(context.Background(), res.Reservation.ID, partyMember.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Returns an error if party member cannot join author reservation on a given spot and time range.
+// Party member tier is resolved with hasRole.
+func (a *Adapter) checkPartyMember(p *policy.Policy, hasRole policy.RoleChecker, author *discord.Member, guild *discord.Guild, partyMember *discord.Member, s reservation.Spot, startAt time.Time, endAt time.Time) error {
+	if partyMember.ID == author.ID {
+		return errors.New("you are already a part of your own reservation")
+	}
+
+	if !p.PartyTimeCounted {
+		return nil
+	}
+
+	tier := p.TierOf(hasRole, partyMember)
+	exceeds, err := a.exceedsMaximumReservationsTime(p, tier.MaximumReservationsTime, guild, partyMember, s, startAt, endAt)
+	if err != nil {
+		return err
+	}
+
+	if exceeds {
+		return fmt.Errorf("%s can only hunt %s within 24 hour window", partyMember.DisplayName(), stringsHelper.FormatDuration(tier.MaximumReservationsTime))
+	}
+
+	err = a.checkWeeklyQuotas(p, guild, partyMember, s, startAt, endAt)
+	if err != nil {
+		return fmt.Errorf("%s cannot join the party. %w", partyMember.DisplayName(), err)
+	}
+
+	return nil
+}
+
+// Checks whether booking a given spot would exceed maximum reservations time within 24 hour window,
+// with an exception for overlapping reservations on floors or sides of the same respawn. Only reservations that could fit in the same 24 hour
+// window as requested reservation, and not ignored, are taken into account. Reservations made by
+// others count as well when member is in their party, and guild policy says so.
+func (a *Adapter) exceedsMaximumReservationsTime(p *policy.Policy, maximum time.Duration, guild *discord.Guild, member *discord.Member, s reservation.Spot, startAt time.Time, endAt time.Time, ignoredReservationIds ...int64) (bool, error) {
+	upcomingAuthorReservations, err := a.reservationRepo.SelectUpcomingMemberReservationsWithSpots(context.Background(), guild, member)
+	if err != nil {
+		return false, fmt.Errorf("could not select upcoming member reservations: %w", err)
+	}
+
+	if p.PartyTimeCounted {
+		upcomingPartyReservations, err := a.reservationRepo.SelectUpcomingMemberPartyReservationsWithSpots(context.Background(), guild, member)
+		if err != nil {
+			return false, fmt.Errorf("could not select upcoming member party reservations: %w", err)
+		}
+		upcomingAuthorReservations = append(upcomingAuthorReservations, upcomingPartyReservations...)
+	}
+
+	// Holds do not count until they are confirmed
+	upcomingAuthorReservations = collections.PoorMansFilter(upcomingAuthorReservations, func(r *reservation.ReservationWithSpot) bool {
+		return !r.Held() && !collections.PoorMansContains(ignoredReservationIds, r.Reservation.ID) && r.EndAt.After(endAt.Add(-24*time.Hour)) && r.StartAt.Before(startAt.Add(24*time.Hour))
+	})
+
+	if len(upcomingAuthorReservations) == 0 {
+		return false, nil
+	}
+
+	tempReservation := reservation.ReservationWithSpot{
+		Reservation: reservation.Reservation{
+			ID:      -1,
+			Author:  member.ID,
+			StartAt: startAt,
+			EndAt:   endAt,
+		},
+		Spot: s,
+	}
+	upcomingAuthorReservations = append(upcomingAuthorReservations, &tempReservation)
+
+	reducedReservations := reduceAllAuthorReservationsByLongestPerSpot(upcomingAuthorReservations)
+	totalReservationsTime := collections.PoorMansSum(reducedReservations, func(reservation *reservation.ReservationWithSpot) time.Duration {
+		return reservation.EndAt.Sub(reservation.StartAt)
+	})
+
+	return totalReservationsTime > maximum, nil
+}
+
+// Returns an error if startAt lies beyond the guild booking horizon.
+func checkBookingHorizon(p *policy.Policy, currTime time.Time, startAt time.Time) error {
+	if startAt.After(currTime.Add(p.BookingHorizon)) {
+		return fmt.Errorf("reservations can be made at most %d days ahead", int(p.BookingHorizon.Hours()/24))
+	}
+
+	return nil
+}
+
+// Returns true if reservation started more than the guild check-in grace period ago,
+// and its author has not checked in, even though they have been asked to.
+func isNoShow(p *policy.Policy, r *reservation.Reservation, currTime time.Time) bool {
+	return r.CheckInRequested() && !r.CheckedIn() && r.StartAt.Add(p.CheckInGracePeriod).Before(currTime)
+}
+
+// Authors who have not been asked to check in within the grace period, e.g. on reservations started before
+// check-ins were introduced, are not no-shows. Whether they are present is unknown, so as it used to be,
+// the only conflicting reservation can be overbooked if it has started and has not ended yet.
+func isPotentiallyAbandonedReservation(p *policy.Policy, overlappingReservations []*reservation.Reservation, currTime time.Time) bool {
+	if len(overlappingReservations) != 1 {
+		return false
+	}
+
+	r := overlappingReservations[0]
+	return !r.CheckInRequested() && !r.CheckedIn() &&
+		r.StartAt.Add(p.CheckInGracePeriod).Before(currTime) && !r.EndAt.Before(currTime)
+}
+
+// Returns true if anyone can overbook the reservation, as its author has not shown up, without asking them.
+func canBeTakenOver(p *policy.Policy, conflicts []*reservation.Reservation, r *reservation.Reservation, currTime time.Time) bool {
+	return isNoShow(p, r, currTime) || isPotentiallyAbandonedReservation(p, conflicts, currTime)
 }
@@ -4,24 +4,57 @@ import (
 	"context"
 	"errors"
 	"regexp"
+	"slices"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 
 	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/policy"
 	"spot-assistant/internal/core/dto/reservation"
 	"spot-assistant/internal/core/dto/spot"
 
 	"spot-assistant/internal/common/test/mocks"
 )
 
+// Returns the default policy without blackouts, as tests book relative to the current time
+// and would otherwise fail around the server save.
+func newTestPolicy(guildId string) *policy.Policy {
+	p := policy.NewDefaultPolicy(guildId)
+	p.Blackouts = []policy.Blackout{}
+
+	return p
+}
+
+// Returns tier of the given priority with the default maximum reservations time.
+func newTestTier(priority int) policy.Tier {
+	return policy.Tier{Priority: priority, MaximumReservationsTime: policy.DEFAULT_MAXIMUM_RESERVATIONS_TIME}
+}
+
+// Returns role checker, which tells every member has the given roles.
+func newTestRoleChecker(roles ...string) policy.RoleChecker {
+	return func(member *discord.Member, role string) bool {
+		return slices.Contains(roles, role)
+	}
+}
+
+func newPolicyRepo() *mocks.MockPolicyRepo {
+	policyRepo := new(mocks.MockPolicyRepo)
+	policyRepo.On("FindGuildPolicy", mocks.ContextMock, mock.Anything).Return(newTestPolicy("test-guild-id"), nil)
+	policyRepo.On("FindMemberTimeZone", mocks.ContextMock, mock.Anything, mock.Anything).Return("", nil)
+
+	return policyRepo
+}
+
 func TestFindAvailableSpotsWithNoFilter(t *testing.T) {
 	// given
 	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
 	mockSpotRepo := new(mocks.MockSpotRepo)
-	adapter := NewAdapter(mockSpotRepo, new(mocks.MockReservationRepo))
+	adapter := NewAdapter(mockSpotRepo, new(mocks.MockReservationRepo), newPolicyRepo())
 	spots := []*spot.Spot{
 		{
 			Name: "test-1",
@@ -30,10 +63,10 @@ func TestFindAvailableSpotsWithNoFilter(t *testing.T) {
 			Name: "test-2",
 		},
 	}
-	mockSpotRepo.On("SelectAllSpots", context.Background()).Return(spots, nil)
+	mockSpotRepo.On("SelectAllSpots", context.Background(), guild.ID).Return(spots, nil)
 
 	// when
-	res, err := adapter.FindAvailableSpots("")
+	res, err := adapter.FindAvailableSpots(guild, "")
 
 	// assert
 	assert.Nil(err)
@@ -47,8 +80,9 @@ func TestFindAvailableSpotsWithNoFilter(t *testing.T) {
 func TestFindAvailableSpotsWithFilter(t *testing.T) {
 	// given
 	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
 	mockSpotRepo := new(mocks.MockSpotRepo)
-	adapter := NewAdapter(mockSpotRepo, new(mocks.MockReservationRepo))
+	adapter := NewAdapter(mockSpotRepo, new(mocks.MockReservationRepo), newPolicyRepo())
 	spots := []*spot.Spot{
 		{
 			Name: "test-1",
@@ -57,10 +91,10 @@ func TestFindAvailableSpotsWithFilter(t *testing.T) {
 			Name: "test-2",
 		},
 	}
-	mockSpotRepo.On("SelectAllSpots", context.Background()).Return(spots, nil)
+	mockSpotRepo.On("SelectAllSpots", context.Background(), guild.ID).Return(spots, nil)
 
 	// when
-	res, err := adapter.FindAvailableSpots("2")
+	res, err := adapter.FindAvailableSpots(guild, "2")
 
 	// assert
 	assert.Nil(err)
@@ -70,14 +104,49 @@ func TestFindAvailableSpotsWithFilter(t *testing.T) {
 	assert.Equal(res[0], spots[1].Name)
 }
 
+func TestFindAvailableSpotsWithDetailsFilter(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	mockSpotRepo := new(mocks.MockSpotRepo)
+	adapter := NewAdapter(mockSpotRepo, new(mocks.MockReservationRepo), newPolicyRepo())
+	spots := []*spot.Spot{
+		{
+			Name:    "Fairy Cave",
+			Details: spot.Details{MinLevel: 150, MaxLevel: 300, Vocations: []string{"knight"}, Area: "Feyrist", Kind: spot.KindSolo},
+		},
+		{
+			Name:    "Dark Fairy Cave",
+			Details: spot.Details{MinLevel: 300, Vocations: []string{"knight", "druid"}, Area: "Feyrist", Kind: spot.KindTeam},
+		},
+		{
+			Name:    "Warzone 4",
+			Details: spot.Details{MinLevel: 250, Area: "Gnomprona", Kind: spot.KindTeam},
+		},
+	}
+	mockSpotRepo.On("SelectAllSpots", context.Background(), guild.ID).Return(spots, nil)
+
+	// when
+	byArea, _ := adapter.FindAvailableSpots(guild, "feyrist")
+	byLevelAndVocation, _ := adapter.FindAvailableSpots(guild, "200 EK")
+	byKindAndName, _ := adapter.FindAvailableSpots(guild, "team cave")
+	byVocationName, _ := adapter.FindAvailableSpots(guild, "druid")
+
+	// assert
+	assert.Equal([]string{"Fairy Cave", "Dark Fairy Cave"}, byArea)
+	assert.Equal([]string{"Fairy Cave"}, byLevelAndVocation)
+	assert.Equal([]string{"Dark Fairy Cave"}, byKindAndName)
+	assert.Equal([]string{"Dark Fairy Cave"}, byVocationName)
+}
+
 func TestGetSuggestedHoursWithNoFilter(t *testing.T) {
 	// given
 	tBase := time.Date(2023, 8, 19, 15, 0, 0, 0, time.Now().Location())
 	assert := assert.New(t)
-	adapter := NewAdapter(new(mocks.MockSpotRepo), new(mocks.MockReservationRepo))
+	adapter := NewAdapter(new(mocks.MockSpotRepo), new(mocks.MockReservationRepo), newPolicyRepo())
 
 	// when
-	res := adapter.GetSuggestedHours(tBase, "")
+	res := adapter.GetSuggestedHours(&discord.Guild{ID: "test-guild-id"}, tBase, "", "")
 
 	// assert
 	assert.NotEmpty(res)
@@ -91,10 +160,10 @@ func TestGetSuggestedHoursWithFilter(t *testing.T) {
 	// given
 	tBase := time.Date(2023, 8, 19, 15, 0, 0, 0, time.Now().Location())
 	assert := assert.New(t)
-	adapter := NewAdapter(new(mocks.MockSpotRepo), new(mocks.MockReservationRepo))
+	adapter := NewAdapter(new(mocks.MockSpotRepo), new(mocks.MockReservationRepo), newPolicyRepo())
 
 	// when
-	res := adapter.GetSuggestedHours(tBase, "30")
+	res := adapter.GetSuggestedHours(&discord.Guild{ID: "test-guild-id"}, tBase, "", "30")
 
 	// assert
 	assert.NotEmpty(res)
@@ -108,10 +177,10 @@ func TestGetSuggestedHoursWithFilterWithSpecificHour(t *testing.T) {
 	// given
 	tBase := time.Date(2023, 8, 19, 15, 0, 0, 0, time.Now().Location())
 	assert := assert.New(t)
-	adapter := NewAdapter(new(mocks.MockSpotRepo), new(mocks.MockReservationRepo))
+	adapter := NewAdapter(new(mocks.MockSpotRepo), new(mocks.MockReservationRepo), newPolicyRepo())
 
 	// when
-	res := adapter.GetSuggestedHours(tBase, "15:20")
+	res := adapter.GetSuggestedHours(&discord.Guild{ID: "test-guild-id"}, tBase, "", "15:20")
 
 	// assert
 	assert.NotEmpty(res)
@@ -121,6 +190,159 @@ func TestGetSuggestedHoursWithFilterWithSpecificHour(t *testing.T) {
 	}
 }
 
+func TestGetSuggestedHoursWithCustomStep(t *testing.T) {
+	// given
+	tBase := time.Date(2023, 8, 19, 15, 5, 0, 0, time.Now().Location())
+	assert := assert.New(t)
+	guildPolicy := newTestPolicy("test-guild-id")
+	guildPolicy.SuggestionStep = 15 * time.Minute
+	policyRepo := new(mocks.MockPolicyRepo)
+	policyRepo.On("FindGuildPolicy", mocks.ContextMock, "test-guild-id").Return(guildPolicy, nil)
+	adapter := NewAdapter(new(mocks.MockSpotRepo), new(mocks.MockReservationRepo), policyRepo)
+
+	// when
+	res := adapter.GetSuggestedHours(&discord.Guild{ID: "test-guild-id"}, tBase, "", "")
+
+	// assert
+	assert.Equal([]string{"15:15", "15:30", "15:45", "16:00", "16:15", "16:30", "16:45", "17:00"}, res)
+}
+
+func TestGetSuggestedDatesLimitedByBookingHorizon(t *testing.T) {
+	// given
+	tBase := time.Date(2023, 8, 19, 15, 0, 0, 0, time.Now().Location())
+	assert := assert.New(t)
+	guildPolicy := newTestPolicy("test-guild-id")
+	guildPolicy.BookingHorizon = 2 * 24 * time.Hour
+	policyRepo := new(mocks.MockPolicyRepo)
+	policyRepo.On("FindGuildPolicy", mocks.ContextMock, "test-guild-id").Return(guildPolicy, nil)
+	adapter := NewAdapter(new(mocks.MockSpotRepo), new(mocks.MockReservationRepo), policyRepo)
+
+	// when
+	res := adapter.GetSuggestedDates(&discord.Guild{ID: "test-guild-id"}, tBase, "")
+
+	// assert
+	assert.Equal([]string{"2023-08-19", "2023-08-20", "2023-08-21"}, res)
+}
+
+func TestGetSuggestedDatesWithFilter(t *testing.T) {
+	// given
+	tBase := time.Date(2023, 8, 19, 15, 0, 0, 0, time.Now().Location())
+	assert := assert.New(t)
+	adapter := NewAdapter(new(mocks.MockSpotRepo), new(mocks.MockReservationRepo), newPolicyRepo())
+
+	// when
+	res := adapter.GetSuggestedDates(&discord.Guild{ID: "test-guild-id"}, tBase, "08-2")
+
+	// assert
+	assert.Equal([]string{"2023-08-20", "2023-08-21", "2023-08-22", "2023-08-23", "2023-08-24", "2023-08-25"}, res)
+}
+
+func TestGetLocationWithMemberTimeZone(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	member := &discord.Member{ID: "test-member-id"}
+	policyRepo := new(mocks.MockPolicyRepo)
+	policyRepo.On("FindMemberTimeZone", mocks.ContextMock, guild.ID, member.ID).Return("America/Sao_Paulo", nil)
+	adapter := NewAdapter(new(mocks.MockSpotRepo), new(mocks.MockReservationRepo), policyRepo)
+
+	// when
+	res, err := adapter.GetLocation(guild, member)
+
+	// assert
+	assert.Nil(err)
+	assert.Equal("America/Sao_Paulo", res.String())
+	policyRepo.AssertNotCalled(t, "FindGuildPolicy", mock.Anything, mock.Anything)
+}
+
+func TestGetLocationFallsBackToGuildTimeZone(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	member := &discord.Member{ID: "test-member-id"}
+	guildPolicy := policy.NewDefaultPolicy(guild.ID)
+	guildPolicy.TimeZone = "Europe/Warsaw"
+	policyRepo := new(mocks.MockPolicyRepo)
+	policyRepo.On("FindMemberTimeZone", mocks.ContextMock, guild.ID, member.ID).Return("", nil)
+	policyRepo.On("FindGuildPolicy", mocks.ContextMock, guild.ID).Return(guildPolicy, nil)
+	adapter := NewAdapter(new(mocks.MockSpotRepo), new(mocks.MockReservationRepo), policyRepo)
+
+	// when
+	res, err := adapter.GetLocation(guild, member)
+
+	// assert
+	assert.Nil(err)
+	assert.Equal("Europe/Warsaw", res.String())
+}
+
+func TestSetMemberTimeZoneWithUnknownTimeZone(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	policyRepo := new(mocks.MockPolicyRepo)
+	adapter := NewAdapter(new(mocks.MockSpotRepo), new(mocks.MockReservationRepo), policyRepo)
+
+	// when
+	res, err := adapter.SetMemberTimeZone(&discord.Guild{ID: "test-guild-id"}, &discord.Member{ID: "test-member-id"}, "Mars/Olympus_Mons")
+
+	// assert
+	assert.Nil(res)
+	assert.ErrorContains(err, "unknown time zone")
+	policyRepo.AssertNotCalled(t, "SaveMemberTimeZone", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSetMemberTimeZone(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	member := &discord.Member{ID: "test-member-id"}
+	policyRepo := new(mocks.MockPolicyRepo)
+	policyRepo.On("SaveMemberTimeZone", mocks.ContextMock, guild.ID, member.ID, "Europe/Warsaw").Return(nil)
+	defer policyRepo.AssertExpectations(t)
+	adapter := NewAdapter(new(mocks.MockSpotRepo), new(mocks.MockReservationRepo), policyRepo)
+
+	// when
+	res, err := adapter.SetMemberTimeZone(guild, member, "Europe/Warsaw")
+
+	// assert
+	assert.Nil(err)
+	assert.Equal("Europe/Warsaw", res.String())
+}
+
+func TestSavePolicyWithInvalidPolicy(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	policyRepo := new(mocks.MockPolicyRepo)
+	adapter := NewAdapter(new(mocks.MockSpotRepo), new(mocks.MockReservationRepo), policyRepo)
+	guildPolicy := policy.NewDefaultPolicy("test-guild-id")
+	guildPolicy.MaximumReservationTime = 4 * time.Hour
+
+	// when
+	res, err := adapter.SavePolicy(guildPolicy)
+
+	// assert
+	assert.Nil(res)
+	assert.NotNil(err)
+	policyRepo.AssertNotCalled(t, "SaveGuildPolicy", mock.Anything, mock.Anything)
+}
+
+func TestSavePolicy(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guildPolicy := policy.NewDefaultPolicy("test-guild-id")
+	guildPolicy.MaximumReservationsTime = 4 * time.Hour
+	policyRepo := new(mocks.MockPolicyRepo)
+	policyRepo.On("SaveGuildPolicy", mocks.ContextMock, guildPolicy).Return(guildPolicy, nil)
+	defer policyRepo.AssertExpectations(t)
+	adapter := NewAdapter(new(mocks.MockSpotRepo), new(mocks.MockReservationRepo), policyRepo)
+
+	// when
+	res, err := adapter.SavePolicy(guildPolicy)
+
+	// assert
+	assert.Nil(err)
+	assert.Equal(guildPolicy, res)
+}
+
 func TestUnbook(t *testing.T) {
 	// given
 	assert := assert.New(t)
@@ -148,7 +370,7 @@ func TestUnbook(t *testing.T) {
 		mocks.ContextMock,
 		reservation.Reservation.ID, guild.ID, member.ID).Return(reservation, nil)
 	reservationService.On("DeletePresentMemberReservation", mocks.ContextMock, guild, member, reservation.Reservation.ID).Return(nil)
-	adapter := NewAdapter(new(mocks.MockSpotRepo), reservationService)
+	adapter := NewAdapter(new(mocks.MockSpotRepo), reservationService, newPolicyRepo())
 
 	// when
 	res, err := adapter.Unbook(guild, member, reservation.Reservation.ID)
@@ -187,7 +409,7 @@ func TestUnbookAutocomplete(t *testing.T) {
 		"SelectUpcomingMemberReservationsWithSpots",
 		mocks.ContextMock,
 		guild, member).Return(reservations, nil)
-	adapter := NewAdapter(new(mocks.MockSpotRepo), reservationService)
+	adapter := NewAdapter(new(mocks.MockSpotRepo), reservationService, newPolicyRepo())
 
 	// when
 	res, err := adapter.UnbookAutocomplete(guild, member, "")
@@ -240,7 +462,7 @@ func TestUnbookAutocompleteWithFilterMatching(t *testing.T) {
 		"SelectUpcomingMemberReservationsWithSpots",
 		mocks.ContextMock,
 		guild, member).Return(reservations, nil)
-	adapter := NewAdapter(new(mocks.MockSpotRepo), reservationService)
+	adapter := NewAdapter(new(mocks.MockSpotRepo), reservationService, newPolicyRepo())
 
 	// when
 	res, err := adapter.UnbookAutocomplete(guild, member, "Library")
@@ -270,21 +492,84 @@ func TestBook(t *testing.T) {
 		CreatedAt: time.Now(),
 	}
 	spotService := new(mocks.MockSpotRepo)
-	spotService.On("SelectAllSpots", mocks.ContextMock).Return([]*spot.Spot{spotInput}, nil)
+	spotService.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{spotInput}, nil)
 	reservationService := new(mocks.MockReservationRepo)
-	reservationService.On("SelectOverlappingReservations", mocks.ContextMock, spotInput.Name, startAt, endAt, guild.ID).Return([]*reservation.Reservation{}, nil)
+	reservationService.On("SelectOverlappingReservations", mocks.ContextMock, spotInput.ID, startAt, endAt, guild.ID).Return([]*reservation.Reservation{}, nil)
 	reservationService.On("SelectUpcomingMemberReservationsWithSpots", mocks.ContextMock, guild, member).Return([]*reservation.ReservationWithSpot{}, nil)
-	reservationService.On("CreateAndDeleteConflicting", mocks.ContextMock, member, guild, []*reservation.Reservation{}, spotInput.ID, startAt, endAt).Return([]*reservation.ClippedOrRemovedReservation{}, nil)
-	adapter := NewAdapter(spotService, reservationService)
+	reservationService.On("CreateAndDeleteConflicting", mocks.ContextMock, member, guild, []*discord.Member{}, []*reservation.Reservation{}, spotInput.ID, startAt, endAt, 0).Return([]*reservation.ClippedOrRemovedReservation{}, nil)
+	adapter := NewAdapter(spotService, reservationService, newPolicyRepo())
 
 	// when
-	res, err := adapter.Book(member, guild, spotInput.Name, startAt, endAt, false, false)
+	res, err := adapter.Book(member, guild, []*discord.Member{}, spotInput.Name, startAt, endAt, false, newTestTier(0), newTestRoleChecker())
 
 	// assert
 	assert.Nil(err)
 	assert.NotNil(res)
 }
 
+func TestBookFailBeyondBookingHorizon(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{
+		ID:   "test-id",
+		Name: "test-guild-name",
+	}
+	member := &discord.Member{
+		ID:   "test-member",
+		Nick: "test-nick",
+	}
+	startAt := time.Now().Add(8 * 24 * time.Hour)
+	endAt := startAt.Add(2 * time.Hour)
+	spotInput := &spot.Spot{
+		Name:      "test-spot",
+		ID:        1,
+		CreatedAt: time.Now(),
+	}
+	spotService := new(mocks.MockSpotRepo)
+	spotService.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{spotInput}, nil)
+	reservationService := new(mocks.MockReservationRepo)
+	adapter := NewAdapter(spotService, reservationService, newPolicyRepo())
+
+	// when
+	res, err := adapter.Book(member, guild, []*discord.Member{}, spotInput.Name, startAt, endAt, false, newTestTier(0), newTestRoleChecker())
+
+	// assert
+	assert.Nil(res)
+	assert.ErrorContains(err, "at most 7 days ahead")
+}
+
+func TestBookClipsToBlackouts(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-id"}
+	member := &discord.Member{ID: "test-member", Nick: "test-nick"}
+	tomorrow := time.Now().UTC().AddDate(0, 0, 1)
+	startAt := time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 8, 0, 0, 0, time.UTC)
+	endAt := startAt.Add(2*time.Hour + 5*time.Minute)
+	clippedEndAt := startAt.Add(2 * time.Hour)
+	p := newTestPolicy(guild.ID)
+	p.Blackouts = []policy.Blackout{{Name: "Server save", Start: 10 * time.Hour, Length: 10 * time.Minute, TimeZone: "UTC"}}
+	policyRepo := new(mocks.MockPolicyRepo)
+	policyRepo.On("FindGuildPolicy", mocks.ContextMock, guild.ID).Return(p, nil)
+	spotInput := &spot.Spot{Name: "test-spot", ID: 1}
+	spotService := new(mocks.MockSpotRepo)
+	spotService.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{spotInput}, nil)
+	reservationService := new(mocks.MockReservationRepo)
+	reservationService.On("SelectOverlappingReservations", mocks.ContextMock, spotInput.ID, startAt, clippedEndAt, guild.ID).Return([]*reservation.Reservation{}, nil)
+	reservationService.On("SelectUpcomingMemberReservationsWithSpots", mocks.ContextMock, guild, member).Return([]*reservation.ReservationWithSpot{}, nil)
+	reservationService.On("CreateAndDeleteConflicting", mocks.ContextMock, member, guild, []*discord.Member{}, []*reservation.Reservation{}, spotInput.ID, startAt, clippedEndAt, 0).Return([]*reservation.ClippedOrRemovedReservation{}, nil)
+	defer reservationService.AssertExpectations(t)
+	adapter := NewAdapter(spotService, reservationService, policyRepo)
+
+	// when
+	_, err := adapter.Book(member, guild, []*discord.Member{}, spotInput.Name, startAt, endAt, false, newTestTier(0), newTestRoleChecker())
+	_, crossingErr := adapter.Book(member, guild, []*discord.Member{}, spotInput.Name, startAt, endAt.Add(time.Hour), false, newTestTier(0), newTestRoleChecker())
+
+	// assert
+	assert.Nil(err)
+	assert.ErrorContains(crossingErr, "cannot cross Server save")
+}
+
 func TestBookFailOnSpotRepo(t *testing.T) {
 	// given
 	assert := assert.New(t)
@@ -304,13 +589,13 @@ func TestBookFailOnSpotRepo(t *testing.T) {
 		CreatedAt: time.Now(),
 	}
 	spotService := new(mocks.MockSpotRepo)
-	spotService.On("SelectAllSpots", mocks.ContextMock).Return([]*spot.Spot{spotInput}, errors.New("test-error"))
+	spotService.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{spotInput}, errors.New("test-error"))
 	reservationService := new(mocks.MockReservationRepo)
 
-	adapter := NewAdapter(spotService, reservationService)
+	adapter := NewAdapter(spotService, reservationService, newPolicyRepo())
 
 	// when
-	_, err := adapter.Book(member, guild, spotInput.Name, startAt, endAt, false, false)
+	_, err := adapter.Book(member, guild, []*discord.Member{}, spotInput.Name, startAt, endAt, false, newTestTier(0), newTestRoleChecker())
 
 	// assert
 	assert.NotNil(err)
@@ -335,12 +620,12 @@ func TestBookFailOnUnknownSpot(t *testing.T) {
 		CreatedAt: time.Now(),
 	}
 	spotService := new(mocks.MockSpotRepo)
-	spotService.On("SelectAllSpots", mocks.ContextMock).Return([]*spot.Spot{spotOutput}, nil)
+	spotService.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{spotOutput}, nil)
 	reservationService := new(mocks.MockReservationRepo)
-	adapter := NewAdapter(spotService, reservationService)
+	adapter := NewAdapter(spotService, reservationService, newPolicyRepo())
 
 	// when
-	res, err := adapter.Book(member, guild, "Library", startAt, endAt, false, false)
+	res, err := adapter.Book(member, guild, []*discord.Member{}, "Library", startAt, endAt, false, newTestTier(0), newTestRoleChecker())
 
 	// assert
 	assert.NotNil(err)
@@ -364,6 +649,7 @@ func TestBookOnMultizoneCase(t *testing.T) {
 		Name:      "Prison -3",
 		ID:        3,
 		CreatedAt: time.Now(),
+		ParentID:  10,
 	}
 	timeNow := time.Now()
 	currentYear := timeNow.Year()
@@ -381,8 +667,9 @@ func TestBookOnMultizoneCase(t *testing.T) {
 				AuthorDiscordID: member.ID,
 			},
 			Spot: reservation.Spot{
-				ID:   2,
-				Name: "Prison -2",
+				ID:       2,
+				Name:     "Prison -2",
+				ParentID: 10,
 			},
 		},
 		{
@@ -404,21 +691,126 @@ func TestBookOnMultizoneCase(t *testing.T) {
 	startAt := time.Date(currentYear, currentMonth, currentDay, 16, 0, 0, 0, time.UTC)
 	endAt := time.Date(currentYear, currentMonth, currentDay, 17, 0, 0, 0, time.UTC)
 	spotService := new(mocks.MockSpotRepo)
-	spotService.On("SelectAllSpots", mocks.ContextMock).Return([]*spot.Spot{spotInput}, nil)
+	spotService.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{spotInput}, nil)
 	reservationService := new(mocks.MockReservationRepo)
-	reservationService.On("SelectOverlappingReservations", mocks.ContextMock, spotInput.Name, startAt, endAt, guild.ID).Return([]*reservation.Reservation{}, nil)
+	reservationService.On("SelectOverlappingReservations", mocks.ContextMock, spotInput.ID, startAt, endAt, guild.ID).Return([]*reservation.Reservation{}, nil)
 	reservationService.On("SelectUpcomingMemberReservationsWithSpots", mocks.ContextMock, guild, member).Return(existingReservations, nil)
-	reservationService.On("CreateAndDeleteConflicting", mocks.ContextMock, member, guild, []*reservation.Reservation{}, spotInput.ID, startAt, endAt).Return([]*reservation.ClippedOrRemovedReservation{}, nil)
-	adapter := NewAdapter(spotService, reservationService)
+	reservationService.On("CreateAndDeleteConflicting", mocks.ContextMock, member, guild, []*discord.Member{}, []*reservation.Reservation{}, spotInput.ID, startAt, endAt, 0).Return([]*reservation.ClippedOrRemovedReservation{}, nil)
+	adapter := NewAdapter(spotService, reservationService, newPolicyRepo())
 
 	// when
-	res, err := adapter.Book(member, guild, spotInput.Name, startAt, endAt, false, false)
+	res, err := adapter.Book(member, guild, []*discord.Member{}, spotInput.Name, startAt, endAt, false, newTestTier(0), newTestRoleChecker())
 
 	// assert
 	assert.Nil(err)
 	assert.NotNil(res)
 }
 
+func TestBookOnOverlappingSidesOfTheSameRespawn(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild"}
+	member := &discord.Member{ID: "test-member", Username: "test-username"}
+	spotInput := &spot.Spot{ID: 12, Name: "Roshamuul -10", ParentID: 10}
+	startAt := time.Now().Add(time.Hour).Truncate(time.Minute)
+	endAt := startAt.Add(2 * time.Hour)
+	existingReservations := []*reservation.ReservationWithSpot{
+		{
+			Reservation: reservation.Reservation{
+				StartAt:         startAt,
+				EndAt:           endAt,
+				SpotID:          11,
+				GuildID:         guild.ID,
+				AuthorDiscordID: member.ID,
+			},
+			Spot: reservation.Spot{ID: 11, Name: "Roshamuul (UPPER)", ParentID: 10},
+		},
+	}
+	spotService := new(mocks.MockSpotRepo)
+	spotService.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{spotInput}, nil)
+	reservationService := new(mocks.MockReservationRepo)
+	reservationService.On("SelectOverlappingReservations", mocks.ContextMock, spotInput.ID, startAt, endAt, guild.ID).Return([]*reservation.Reservation{}, nil)
+	reservationService.On("SelectUpcomingMemberReservationsWithSpots", mocks.ContextMock, guild, member).Return(existingReservations, nil)
+	reservationService.On("CreateAndDeleteConflicting", mocks.ContextMock, member, guild, []*discord.Member{}, []*reservation.Reservation{}, spotInput.ID, startAt, endAt, 0).Return([]*reservation.ClippedOrRemovedReservation{}, nil)
+	adapter := NewAdapter(spotService, reservationService, newPolicyRepo())
+
+	// when
+	_, err := adapter.Book(member, guild, []*discord.Member{}, spotInput.Name, startAt, endAt, false, newTestTier(0), newTestRoleChecker())
+
+	// assert
+	assert.Nil(err)
+}
+
+func TestBookOnOverlappingFloorsOfTheSameRespawnWithoutParent(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild"}
+	member := &discord.Member{ID: "test-member", Username: "test-username"}
+	spotInput := &spot.Spot{ID: 12, Name: "Roshamuul -10"}
+	startAt := time.Now().Add(time.Hour).Truncate(time.Minute)
+	endAt := startAt.Add(2 * time.Hour)
+	existingReservations := []*reservation.ReservationWithSpot{
+		{
+			Reservation: reservation.Reservation{
+				StartAt:         startAt,
+				EndAt:           endAt,
+				SpotID:          1,
+				GuildID:         guild.ID,
+				AuthorDiscordID: member.ID,
+			},
+			Spot: reservation.Spot{ID: 1, Name: "Roshamuul -1"},
+		},
+	}
+	spotService := new(mocks.MockSpotRepo)
+	spotService.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{spotInput}, nil)
+	reservationService := new(mocks.MockReservationRepo)
+	reservationService.On("SelectOverlappingReservations", mocks.ContextMock, spotInput.ID, startAt, endAt, guild.ID).Return([]*reservation.Reservation{}, nil)
+	reservationService.On("SelectUpcomingMemberReservationsWithSpots", mocks.ContextMock, guild, member).Return(existingReservations, nil)
+	reservationService.On("CreateAndDeleteConflicting", mocks.ContextMock, member, guild, []*discord.Member{}, []*reservation.Reservation{}, spotInput.ID, startAt, endAt, 0).Return([]*reservation.ClippedOrRemovedReservation{}, nil)
+	adapter := NewAdapter(spotService, reservationService, newPolicyRepo())
+
+	// when
+	_, err := adapter.Book(member, guild, []*discord.Member{}, spotInput.Name, startAt, endAt, false, newTestTier(0), newTestRoleChecker())
+
+	// assert
+	assert.Nil(err)
+}
+
+func TestBookFailOnOverlappingUnrelatedRespawns(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild"}
+	member := &discord.Member{ID: "test-member", Username: "test-username"}
+	spotInput := &spot.Spot{ID: 12, Name: "Roshamuul -10"}
+	startAt := time.Now().Add(time.Hour).Truncate(time.Minute)
+	endAt := startAt.Add(2 * time.Hour)
+	existingReservations := []*reservation.ReservationWithSpot{
+		{
+			Reservation: reservation.Reservation{
+				StartAt:         startAt,
+				EndAt:           endAt,
+				SpotID:          1,
+				GuildID:         guild.ID,
+				AuthorDiscordID: member.ID,
+			},
+			Spot: reservation.Spot{ID: 1, Name: "Library -1"},
+		},
+	}
+	spotService := new(mocks.MockSpotRepo)
+	spotService.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{spotInput}, nil)
+	reservationService := new(mocks.MockReservationRepo)
+	reservationService.On("SelectOverlappingReservations", mocks.ContextMock, spotInput.ID, startAt, endAt, guild.ID).Return([]*reservation.Reservation{}, nil)
+	reservationService.On("SelectUpcomingMemberReservationsWithSpots", mocks.ContextMock, guild, member).Return(existingReservations, nil)
+	adapter := NewAdapter(spotService, reservationService, newPolicyRepo())
+
+	// when
+	_, err := adapter.Book(member, guild, []*discord.Member{}, spotInput.Name, startAt, endAt, false, newTestTier(0), newTestRoleChecker())
+
+	// assert
+	assert.ErrorContains(err, "within 24 hour window")
+	reservationService.AssertNotCalled(t, "CreateAndDeleteConflicting")
+}
+
 func TestBookFailOnOverbookAuthorsReservation(t *testing.T) {
 	// given
 	assert := assert.New(t)
@@ -449,15 +841,493 @@ func TestBookFailOnOverbookAuthorsReservation(t *testing.T) {
 		},
 	}
 	spotService := new(mocks.MockSpotRepo)
-	spotService.On("SelectAllSpots", mocks.ContextMock).Return([]*spot.Spot{spotInput}, nil)
+	spotService.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{spotInput}, nil)
 	reservationService := new(mocks.MockReservationRepo)
-	reservationService.On("SelectOverlappingReservations", mocks.ContextMock, spotInput.Name, startAt, endAt, guild.ID).Return(conflictingReservations, nil)
-	adapter := NewAdapter(spotService, reservationService)
+	reservationService.On("SelectOverlappingReservations", mocks.ContextMock, spotInput.ID, startAt, endAt, guild.ID).Return(conflictingReservations, nil)
+	adapter := NewAdapter(spotService, reservationService, newPolicyRepo())
 
 	// when
-	res, err := adapter.Book(member, guild, spotInput.Name, startAt, endAt, true, true)
+	res, err := adapter.Book(member, guild, []*discord.Member{}, spotInput.Name, startAt, endAt, true, newTestTier(1), newTestRoleChecker())
 
 	// assert
 	assert.NotNil(err)
 	assert.Empty(res)
 }
+
+func TestBookOverbooksLowerTiersOnly(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	member := &discord.Member{ID: "test-member"}
+	startAt := time.Now().Add(1 * time.Hour)
+	endAt := startAt.Add(1 * time.Hour)
+	spotInput := &spot.Spot{Name: "test-spot", ID: 1}
+	core := policy.Tier{Role: "Core", Priority: 2, MaximumReservationsTime: 3 * time.Hour}
+	trial := &reservation.Reservation{ID: 1, AuthorDiscordID: "trial", StartAt: startAt, EndAt: endAt, CheckedInAt: startAt, Priority: 1}
+	p := newTestPolicy(guild.ID)
+	p.Tiers = []policy.Tier{core, {Role: "Trial", Priority: 1, MaximumReservationsTime: time.Hour}}
+	policyRepo := new(mocks.MockPolicyRepo)
+	policyRepo.On("FindGuildPolicy", mocks.ContextMock, guild.ID).Return(p, nil)
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{spotInput}, nil)
+	reservationRepo := new(mocks.MockReservationRepo)
+	reservationRepo.On("SelectOverlappingReservations", mocks.ContextMock, spotInput.ID, startAt, endAt, guild.ID).Return([]*reservation.Reservation{trial}, nil)
+	reservationRepo.On("SelectUpcomingMemberReservationsWithSpots", mocks.ContextMock, guild, member).Return([]*reservation.ReservationWithSpot{}, nil)
+	reservationRepo.On("CreateAndDeleteConflicting", mocks.ContextMock, member, guild, []*discord.Member{}, []*reservation.Reservation{trial}, spotInput.ID, startAt, endAt, core.Priority).Return([]*reservation.ClippedOrRemovedReservation{}, nil)
+	adapter := NewAdapter(spotRepo, reservationRepo, policyRepo)
+
+	// when
+	_, err := adapter.Book(member, guild, []*discord.Member{}, spotInput.Name, startAt, endAt, true, core, newTestRoleChecker())
+	_, sameTierErr := adapter.Book(member, guild, []*discord.Member{}, spotInput.Name, startAt, endAt, true, policy.Tier{Role: "Trial", Priority: 1, MaximumReservationsTime: time.Hour}, newTestRoleChecker())
+
+	// assert
+	assert.Nil(err)
+	assert.ErrorContains(sameTierErr, "lower priority tier")
+	reservationRepo.AssertNumberOfCalls(t, "CreateAndDeleteConflicting", 1)
+}
+
+func TestBookIgnoresReservationsOutsideOf24HourWindow(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{
+		ID:   "test-id",
+		Name: "test-guild-name",
+	}
+	member := &discord.Member{
+		ID:   "test-member",
+		Nick: "test-nick",
+	}
+	startAt := time.Now().Add(1 * time.Minute)
+	endAt := startAt.Add(2 * time.Hour)
+	spotInput := &spot.Spot{
+		Name:      "test-spot",
+		ID:        1,
+		CreatedAt: time.Now(),
+	}
+	existingReservations := []*reservation.ReservationWithSpot{
+		{
+			Reservation: reservation.Reservation{
+				StartAt: startAt.Add(48 * time.Hour),
+				EndAt:   endAt.Add(48 * time.Hour),
+			},
+			Spot: reservation.Spot{
+				Name: "other-spot",
+			},
+		},
+	}
+	spotService := new(mocks.MockSpotRepo)
+	spotService.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{spotInput}, nil)
+	reservationService := new(mocks.MockReservationRepo)
+	reservationService.On("SelectOverlappingReservations", mocks.ContextMock, spotInput.ID, startAt, endAt, guild.ID).Return([]*reservation.Reservation{}, nil)
+	reservationService.On("SelectUpcomingMemberReservationsWithSpots", mocks.ContextMock, guild, member).Return(existingReservations, nil)
+	reservationService.On("CreateAndDeleteConflicting", mocks.ContextMock, member, guild, []*discord.Member{}, []*reservation.Reservation{}, spotInput.ID, startAt, endAt, 0).Return([]*reservation.ClippedOrRemovedReservation{}, nil)
+	adapter := NewAdapter(spotService, reservationService, newPolicyRepo())
+
+	// when
+	res, err := adapter.Book(member, guild, []*discord.Member{}, spotInput.Name, startAt, endAt, false, newTestTier(0), newTestRoleChecker())
+
+	// assert
+	assert.Nil(err)
+	assert.NotNil(res)
+}
+
+func TestBookFailWhenPartyMemberExceedsReservationsTime(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{
+		ID:   "test-id",
+		Name: "test-guild-name",
+	}
+	member := &discord.Member{
+		ID:   "test-member",
+		Nick: "test-nick",
+	}
+	partyMember := &discord.Member{
+		ID:   "test-party-member",
+		Nick: "test-party-nick",
+	}
+	startAt := time.Now().Add(1 * time.Minute)
+	endAt := startAt.Add(2 * time.Hour)
+	spotInput := &spot.Spot{
+		Name:      "test-spot",
+		ID:        1,
+		CreatedAt: time.Now(),
+	}
+	partyReservations := []*reservation.ReservationWithSpot{
+		{
+			Reservation: reservation.Reservation{
+				ID:      2,
+				StartAt: endAt.Add(1 * time.Hour),
+				EndAt:   endAt.Add(3 * time.Hour),
+			},
+			Spot: reservation.Spot{
+				Name: "other-spot",
+			},
+		},
+	}
+	p := newTestPolicy(guild.ID)
+	p.PartyTimeCounted = true
+	policyRepo := new(mocks.MockPolicyRepo)
+	policyRepo.On("FindGuildPolicy", mocks.ContextMock, guild.ID).Return(p, nil)
+	spotService := new(mocks.MockSpotRepo)
+	spotService.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{spotInput}, nil)
+	reservationService := new(mocks.MockReservationRepo)
+	reservationService.On("SelectOverlappingReservations", mocks.ContextMock, spotInput.ID, startAt, endAt, guild.ID).Return([]*reservation.Reservation{}, nil)
+	reservationService.On("SelectUpcomingMemberReservationsWithSpots", mocks.ContextMock, guild, member).Return([]*reservation.ReservationWithSpot{}, nil)
+	reservationService.On("SelectUpcomingMemberPartyReservationsWithSpots", mocks.ContextMock, guild, member).Return([]*reservation.ReservationWithSpot{}, nil)
+	reservationService.On("SelectUpcomingMemberReservationsWithSpots", mocks.ContextMock, guild, partyMember).Return([]*reservation.ReservationWithSpot{}, nil)
+	reservationService.On("SelectUpcomingMemberPartyReservationsWithSpots", mocks.ContextMock, guild, partyMember).Return(partyReservations, nil)
+	adapter := NewAdapter(spotService, reservationService, policyRepo)
+
+	// when
+	_, err := adapter.Book(member, guild, []*discord.Member{partyMember}, spotInput.Name, startAt, endAt, false, newTestTier(0), newTestRoleChecker())
+
+	// assert
+	assert.NotNil(err)
+	reservationService.AssertNotCalled(t, "CreateAndDeleteConflicting", mocks.ContextMock, member, guild, []*discord.Member{partyMember}, []*reservation.Reservation{}, spotInput.ID, startAt, endAt, 0)
+}
+
+func TestAddPartyMember(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{
+		ID:   "test-id",
+		Name: "test-guild-name",
+	}
+	member := &discord.Member{
+		ID:   "test-member",
+		Nick: "test-nick",
+	}
+	partyMember := &discord.Member{
+		ID:   "test-party-member",
+		Nick: "test-party-nick",
+	}
+	startAt := time.Now().Add(1 * time.Hour)
+	existing := &reservation.ReservationWithSpot{
+		Reservation: reservation.Reservation{
+			ID:              1,
+			AuthorDiscordID: member.ID,
+			StartAt:         startAt,
+			EndAt:           startAt.Add(2 * time.Hour),
+		},
+		Spot: reservation.Spot{
+			ID:   2,
+			Name: "test-spot",
+		},
+	}
+	reservationService := new(mocks.MockReservationRepo)
+	reservationService.On("FindReservationWithSpot", mocks.ContextMock, existing.Reservation.ID, guild.ID, member.ID).Return(existing, nil)
+	reservationService.On("AddReservationPartyMember", mocks.ContextMock, existing.Reservation.ID, partyMember).Return(nil)
+	defer reservationService.AssertExpectations(t)
+	adapter := NewAdapter(new(mocks.MockSpotRepo), reservationService, newPolicyRepo())
+
+	// when
+	res, err := adapter.AddPartyMember(guild, member, existing.Reservation.ID, partyMember, newTestRoleChecker())
+
+	// assert
+	assert.Nil(err)
+	assert.Equal(existing, res)
+}
+
+func TestAddPartyMemberFailOnAuthor(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{
+		ID: "test-id",
+	}
+	member := &discord.Member{
+		ID: "test-member",
+	}
+	existing := &reservation.ReservationWithSpot{
+		Reservation: reservation.Reservation{
+			ID:              1,
+			AuthorDiscordID: member.ID,
+			StartAt:         time.Now().Add(1 * time.Hour),
+			EndAt:           time.Now().Add(3 * time.Hour),
+		},
+	}
+	reservationService := new(mocks.MockReservationRepo)
+	reservationService.On("FindReservationWithSpot", mocks.ContextMock, existing.Reservation.ID, guild.ID, member.ID).Return(existing, nil)
+	adapter := NewAdapter(new(mocks.MockSpotRepo), reservationService, newPolicyRepo())
+
+	// when
+	_, err := adapter.AddPartyMember(guild, member, existing.Reservation.ID, member, newTestRoleChecker())
+
+	// assert
+	assert.NotNil(err)
+}
+
+func TestRebookExtendsReservation(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{
+		ID:   "test-id",
+		Name: "test-guild-name",
+	}
+	member := &discord.Member{
+		ID:   "test-member",
+		Nick: "test-nick",
+	}
+	tomorrow := time.Now().AddDate(0, 0, 1)
+	startAt := time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 20, 0, 0, 0, time.Local)
+	existing := &reservation.ReservationWithSpot{
+		Reservation: reservation.Reservation{
+			ID:              1,
+			Author:          member.Nick,
+			AuthorDiscordID: member.ID,
+			StartAt:         startAt,
+			EndAt:           startAt.Add(2 * time.Hour),
+			GuildID:         guild.ID,
+		},
+		Spot: reservation.Spot{
+			ID:   2,
+			Name: "test-spot",
+		},
+	}
+	endTime := 22*time.Hour + 30*time.Minute
+	expectedEndAt := startAt.Add(2*time.Hour + 30*time.Minute)
+	reservationService := new(mocks.MockReservationRepo)
+	reservationService.On("FindReservationWithSpot", mocks.ContextMock, existing.Reservation.ID, guild.ID, member.ID).Return(existing, nil)
+	reservationService.On("SelectOverlappingReservations", mocks.ContextMock, existing.Spot.ID, startAt, expectedEndAt, guild.ID).Return([]*reservation.Reservation{&existing.Reservation}, nil)
+	reservationService.On("SelectUpcomingMemberReservationsWithSpots", mocks.ContextMock, guild, member).Return([]*reservation.ReservationWithSpot{existing}, nil)
+	reservationService.On("UpdateAndDeleteConflicting", mocks.ContextMock, member, guild, existing.Reservation.ID, []*reservation.Reservation{}, existing.Spot.ID, startAt, expectedEndAt).Return([]*reservation.ClippedOrRemovedReservation{}, nil)
+	defer reservationService.AssertExpectations(t)
+	adapter := NewAdapter(new(mocks.MockSpotRepo), reservationService, newPolicyRepo())
+
+	// when
+	res, conflicts, err := adapter.Rebook(member, guild, existing.Reservation.ID, nil, nil, &endTime, false, newTestTier(0))
+
+	// assert
+	assert.Nil(err)
+	assert.Empty(conflicts)
+	assert.Equal(startAt, res.StartAt)
+	assert.Equal(expectedEndAt, res.EndAt)
+}
+
+func TestRebookFailOnConflictingReservation(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{
+		ID:   "test-id",
+		Name: "test-guild-name",
+	}
+	member := &discord.Member{
+		ID:   "test-member",
+		Nick: "test-nick",
+	}
+	tomorrow := time.Now().AddDate(0, 0, 1)
+	startAt := time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 20, 0, 0, 0, time.Local)
+	existing := &reservation.ReservationWithSpot{
+		Reservation: reservation.Reservation{
+			ID:              1,
+			Author:          member.Nick,
+			AuthorDiscordID: member.ID,
+			StartAt:         startAt,
+			EndAt:           startAt.Add(2 * time.Hour),
+			GuildID:         guild.ID,
+		},
+		Spot: reservation.Spot{
+			ID:   2,
+			Name: "test-spot",
+		},
+	}
+	conflicting := &reservation.Reservation{
+		ID:              3,
+		Author:          "test-other-nick",
+		AuthorDiscordID: "test-other-member",
+		StartAt:         startAt.Add(2 * time.Hour),
+		EndAt:           startAt.Add(4 * time.Hour),
+		GuildID:         guild.ID,
+	}
+	endTime := 23 * time.Hour
+	expectedEndAt := startAt.Add(3 * time.Hour)
+	reservationService := new(mocks.MockReservationRepo)
+	reservationService.On("FindReservationWithSpot", mocks.ContextMock, existing.Reservation.ID, guild.ID, member.ID).Return(existing, nil)
+	reservationService.On("SelectOverlappingReservations", mocks.ContextMock, existing.Spot.ID, startAt, expectedEndAt, guild.ID).Return([]*reservation.Reservation{&existing.Reservation, conflicting}, nil)
+	adapter := NewAdapter(new(mocks.MockSpotRepo), reservationService, newPolicyRepo())
+
+	// when
+	_, conflicts, err := adapter.Rebook(member, guild, existing.Reservation.ID, nil, nil, &endTime, false, newTestTier(0))
+
+	// assert
+	assert.NotNil(err)
+	assert.Len(conflicts, 1)
+	assert.Equal(conflicting, conflicts[0].Original)
+}
+
+func TestTransfer(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{
+		ID:   "test-id",
+		Name: "test-guild-name",
+	}
+	from := &discord.Member{
+		ID:   "test-member",
+		Nick: "test-nick",
+	}
+	to := &discord.Member{
+		ID:   "test-recipient",
+		Nick: "test-recipient-nick",
+	}
+	startAt := time.Now().Add(1 * time.Hour)
+	existing := &reservation.ReservationWithSpot{
+		Reservation: reservation.Reservation{
+			ID:              1,
+			Author:          from.Nick,
+			AuthorDiscordID: from.ID,
+			StartAt:         startAt,
+			EndAt:           startAt.Add(2 * time.Hour),
+			GuildID:         guild.ID,
+		},
+		Spot: reservation.Spot{
+			ID:   2,
+			Name: "test-spot",
+		},
+	}
+	reservationService := new(mocks.MockReservationRepo)
+	reservationService.On("FindReservationWithSpot", mocks.ContextMock, existing.Reservation.ID, guild.ID, from.ID).Return(existing, nil)
+	reservationService.On("SelectUpcomingMemberReservationsWithSpots", mocks.ContextMock, guild, to).Return([]*reservation.ReservationWithSpot{}, nil)
+	reservationService.On("TransferPresentMemberReservation", mocks.ContextMock, guild, from, to, existing.Reservation.ID, 0).Return(nil)
+	defer reservationService.AssertExpectations(t)
+	adapter := NewAdapter(new(mocks.MockSpotRepo), reservationService, newPolicyRepo())
+
+	// when
+	res, err := adapter.Transfer(guild, from, to, existing.Reservation.ID, newTestRoleChecker())
+
+	// assert
+	assert.Nil(err)
+	assert.Equal(to.ID, res.AuthorDiscordID)
+}
+
+func TestTransferTakesRecipientTier(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-id"}
+	from := &discord.Member{ID: "test-member", Nick: "test-nick"}
+	to := &discord.Member{ID: "test-recipient", Nick: "test-recipient-nick"}
+	p := newTestPolicy(guild.ID)
+	p.Tiers = []policy.Tier{{Role: "Core", Priority: 2, MaximumReservationsTime: 6 * time.Hour}}
+	policyRepo := new(mocks.MockPolicyRepo)
+	policyRepo.On("FindGuildPolicy", mocks.ContextMock, guild.ID).Return(p, nil)
+	startAt := time.Now().Add(1 * time.Hour)
+	existing := &reservation.ReservationWithSpot{
+		Reservation: reservation.Reservation{ID: 1, AuthorDiscordID: from.ID, StartAt: startAt, EndAt: startAt.Add(2 * time.Hour), GuildID: guild.ID},
+		Spot:        reservation.Spot{ID: 2, Name: "test-spot"},
+	}
+	// Would exceed the guild maximum reservations time, but not the one of the recipient tier
+	recipientReservations := []*reservation.ReservationWithSpot{
+		{
+			Reservation: reservation.Reservation{ID: 3, AuthorDiscordID: to.ID, StartAt: startAt.Add(3 * time.Hour), EndAt: startAt.Add(6 * time.Hour)},
+			Spot:        reservation.Spot{ID: 4, Name: "other-spot"},
+		},
+	}
+	reservationService := new(mocks.MockReservationRepo)
+	reservationService.On("FindReservationWithSpot", mocks.ContextMock, existing.Reservation.ID, guild.ID, from.ID).Return(existing, nil)
+	reservationService.On("SelectUpcomingMemberReservationsWithSpots", mocks.ContextMock, guild, to).Return(recipientReservations, nil)
+	reservationService.On("TransferPresentMemberReservation", mocks.ContextMock, guild, from, to, existing.Reservation.ID, 2).Return(nil)
+	defer reservationService.AssertExpectations(t)
+	adapter := NewAdapter(new(mocks.MockSpotRepo), reservationService, policyRepo)
+
+	// when
+	res, err := adapter.Transfer(guild, from, to, existing.Reservation.ID, newTestRoleChecker("Core"))
+
+	// assert
+	assert.Nil(err)
+	assert.Equal(2, res.Priority)
+}
+
+func TestTransferFailWhenRecipientExceedsReservationsTime(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{
+		ID:   "test-id",
+		Name: "test-guild-name",
+	}
+	from := &discord.Member{
+		ID:   "test-member",
+		Nick: "test-nick",
+	}
+	to := &discord.Member{
+		ID:   "test-recipient",
+		Nick: "test-recipient-nick",
+	}
+	startAt := time.Now().Add(1 * time.Hour)
+	existing := &reservation.ReservationWithSpot{
+		Reservation: reservation.Reservation{
+			ID:              1,
+			Author:          from.Nick,
+			AuthorDiscordID: from.ID,
+			StartAt:         startAt,
+			EndAt:           startAt.Add(2 * time.Hour),
+			GuildID:         guild.ID,
+		},
+		Spot: reservation.Spot{
+			ID:   2,
+			Name: "test-spot",
+		},
+	}
+	recipientReservations := []*reservation.ReservationWithSpot{
+		{
+			Reservation: reservation.Reservation{
+				ID:              3,
+				AuthorDiscordID: to.ID,
+				StartAt:         startAt.Add(3 * time.Hour),
+				EndAt:           startAt.Add(6 * time.Hour),
+			},
+			Spot: reservation.Spot{
+				Name: "other-spot",
+			},
+		},
+	}
+	reservationService := new(mocks.MockReservationRepo)
+	reservationService.On("FindReservationWithSpot", mocks.ContextMock, existing.Reservation.ID, guild.ID, from.ID).Return(existing, nil)
+	reservationService.On("SelectUpcomingMemberReservationsWithSpots", mocks.ContextMock, guild, to).Return(recipientReservations, nil)
+	defer reservationService.AssertExpectations(t)
+	adapter := NewAdapter(new(mocks.MockSpotRepo), reservationService, newPolicyRepo())
+
+	// when
+	_, err := adapter.Transfer(guild, from, to, existing.Reservation.ID, newTestRoleChecker())
+
+	// assert
+	assert.NotNil(err)
+	reservationService.AssertNotCalled(t, "TransferPresentMemberReservation", mocks.ContextMock, guild, from, to, existing.Reservation.ID, mock.Anything)
+}
+
+func TestTransferFailToYourself(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{
+		ID: "test-id",
+	}
+	member := &discord.Member{
+		ID: "test-member",
+	}
+	adapter := NewAdapter(new(mocks.MockSpotRepo), new(mocks.MockReservationRepo), newPolicyRepo())
+
+	// when
+	_, err := adapter.Transfer(guild, member, member, 1, newTestRoleChecker())
+
+	// assert
+	assert.NotNil(err)
+}
+
+func TestRebookedTimeRange(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	startAt := time.Date(2023, 8, 19, 22, 0, 0, 0, time.UTC)
+	endAt := time.Date(2023, 8, 20, 0, 0, 0, 0, time.UTC)
+	date := time.Date(2023, 8, 21, 0, 0, 0, 0, time.UTC)
+	startTime := 23 * time.Hour
+	endTime := 1 * time.Hour
+
+	// when
+	movedStartAt, movedEndAt := rebookedTimeRange(startAt, endAt, &date, nil, nil)
+	changedStartAt, changedEndAt := rebookedTimeRange(startAt, endAt, nil, &startTime, &endTime)
+
+	// assert
+	assert.Equal(time.Date(2023, 8, 21, 22, 0, 0, 0, time.UTC), movedStartAt)
+	assert.Equal(time.Date(2023, 8, 22, 0, 0, 0, 0, time.UTC), movedEndAt)
+	assert.Equal(time.Date(2023, 8, 19, 23, 0, 0, 0, time.UTC), changedStartAt)
+	assert.Equal(time.Date(2023, 8, 20, 1, 0, 0, 0, time.UTC), changedEndAt)
+}
@@ -0,0 +1,303 @@
+package booking
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"spot-assistant/internal/common/collections"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/spot"
+)
+
+// Returns spots visible to the guild, which have not been archived, as catalog entries.
+// Top-level respawns come first, so that the catalog can be imported back as it is.
+func (a *Adapter) ExportSpots(guild *discord.Guild) ([]*spot.CatalogEntry, error) {
+	spots, err := a.spotRepo.SelectAllSpots(context.Background(), guild.ID)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch spots: %w", err)
+	}
+
+	spots = collections.PoorMansFilter(spots, func(s *spot.Spot) bool {
+		return !s.Archived()
+	})
+	slices.SortStableFunc(spots, func(a *spot.Spot, b *spot.Spot) int {
+		return min(int(a.ParentID), 1) - min(int(b.ParentID), 1)
+	})
+
+	return collections.PoorMansMap(spots, func(s *spot.Spot) *spot.CatalogEntry {
+		entry := &spot.CatalogEntry{
+			Name:      s.Name,
+			Area:      s.Details.Area,
+			MinLevel:  s.Details.MinLevel,
+			MaxLevel:  s.Details.MaxLevel,
+			Vocations: s.Details.Vocations,
+			Kind:      s.Details.Kind,
+			Aliases:   s.Aliases,
+		}
+
+		parent, _ := collections.PoorMansFind(spots, func(p *spot.Spot) bool {
+			return p.ID == s.ParentID
+		})
+		if parent != nil {
+			entry.Parent = parent.Name
+		}
+
+		return entry
+	}), nil
+}
+
+// Adds catalog entries as spots of the guild, or updates spots of the guild called the same.
+// Spots missing from the catalog are kept intact, while shared spots are skipped, unless guild ID
+// is empty, which imports the shared catalog. The whole catalog is validated before anything is
+// changed, and nothing is changed on a dry run.
+func (a *Adapter) ImportSpots(guild *discord.Guild, entries []*spot.CatalogEntry, dryRun bool) (*spot.ImportResult, error) {
+	a.log.WithFields(logrus.Fields{"guild": guild.ID, "entries": len(entries), "dryRun": dryRun}).Info("import spots request")
+
+	if len(entries) == 0 {
+		return nil, errors.New("the catalog does not contain any respawns")
+	}
+
+	spots, err := a.spotRepo.SelectAllSpots(context.Background(), guild.ID)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch spots: %w", err)
+	}
+
+	hiddenSpots, err := a.spotRepo.SelectHiddenSpots(context.Background(), guild.ID)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch hidden spots: %w", err)
+	}
+
+	result, problems := planImport(guild, spots, hiddenSpots, entries)
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("could not import the catalog:\n* %s", strings.Join(problems, "\n* "))
+	}
+
+	result.DryRun = dryRun
+	if dryRun {
+		return result, nil
+	}
+
+	err = a.applyImport(guild, spots, result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Sorts catalog entries into added, updated, unchanged and skipped ones. Returns descriptions
+// of problems preventing the import, such as duplicated names or missing parents.
+func planImport(guild *discord.Guild, spots []*spot.Spot, hiddenSpots []*spot.Spot, entries []*spot.CatalogEntry) (*spot.ImportResult, []string) {
+	result := &spot.ImportResult{
+		Added:     []*spot.CatalogEntry{},
+		Updated:   []*spot.CatalogEntry{},
+		Unchanged: []string{},
+		Skipped:   []string{},
+	}
+	problems := []string{}
+
+	// Names and aliases of the catalog, so that duplicates can be told apart
+	catalogNames := map[string]*spot.CatalogEntry{}
+	for _, entry := range entries {
+		entry.Name = strings.TrimSpace(entry.Name)
+		entry.Parent = strings.TrimSpace(entry.Parent)
+		entry.Area = strings.TrimSpace(entry.Area)
+		entry.Aliases = collections.PoorMansMap(entry.Aliases, strings.TrimSpace)
+
+		if err := spot.ValidateName(entry.Name); err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+		if err := entry.Details().Validate(); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %s", entry.Name, err))
+		}
+
+		for _, name := range append([]string{entry.Name}, entry.Aliases...) {
+			if err := spot.ValidateName(name); err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %s", entry.Name, err))
+				continue
+			}
+
+			if other, ok := catalogNames[strings.ToLower(name)]; ok {
+				problems = append(problems, fmt.Sprintf("%s is used by both %s and %s", name, other.Name, entry.Name))
+				continue
+			}
+			catalogNames[strings.ToLower(name)] = entry
+		}
+	}
+	if len(problems) > 0 {
+		return nil, problems
+	}
+
+	for _, entry := range entries {
+		existing, _ := collections.PoorMansFind(spots, func(s *spot.Spot) bool {
+			return strings.EqualFold(s.Name, entry.Name)
+		})
+
+		// Neither names nor aliases of the entry may belong to another spot
+		for _, name := range append([]string{entry.Name}, entry.Aliases...) {
+			taken, _ := collections.PoorMansFind(append(slices.Clone(spots), hiddenSpots...), func(s *spot.Spot) bool {
+				return s != existing && spotIsCalled(s, name)
+			})
+			if taken != nil {
+				problems = append(problems, fmt.Sprintf("%s is already used by respawn %s", name, taken.Name))
+			}
+		}
+
+		if existing != nil && existing.OwnerGuildID != guild.ID {
+			result.Skipped = append(result.Skipped, existing.Name)
+			continue
+		}
+
+		problems = append(problems, checkImportedParent(spots, catalogNames, entry, existing)...)
+
+		switch {
+		case existing == nil:
+			result.Added = append(result.Added, entry)
+		case importChangesSpot(spots, existing, entry):
+			result.Updated = append(result.Updated, entry)
+		default:
+			result.Unchanged = append(result.Unchanged, entry.Name)
+		}
+	}
+
+	return result, problems
+}
+
+// Returns problems with parent of an imported entry. Parent has to be a top-level respawn,
+// either imported along or visible to the guild already.
+func checkImportedParent(spots []*spot.Spot, catalogNames map[string]*spot.CatalogEntry, entry *spot.CatalogEntry, existing *spot.Spot) []string {
+	if len(entry.Parent) == 0 {
+		return []string{}
+	}
+
+	if strings.EqualFold(entry.Parent, entry.Name) {
+		return []string{fmt.Sprintf("%s cannot be a floor or side of itself", entry.Name)}
+	}
+
+	if existing != nil {
+		child, _ := collections.PoorMansFind(spots, func(s *spot.Spot) bool {
+			return s.ParentID == existing.ID
+		})
+		if child != nil {
+			return []string{fmt.Sprintf("%s has floors or sides of its own, so it cannot be a floor or side of %s", entry.Name, entry.Parent)}
+		}
+	}
+
+	if parentEntry, ok := catalogNames[strings.ToLower(entry.Parent)]; ok {
+		if len(parentEntry.Parent) > 0 {
+			return []string{fmt.Sprintf("%s cannot be a parent of %s, as it is a floor or side of %s", parentEntry.Name, entry.Name, parentEntry.Parent)}
+		}
+
+		return []string{}
+	}
+
+	parent, _ := collections.PoorMansFind(spots, func(s *spot.Spot) bool {
+		return spotIsCalled(s, entry.Parent)
+	})
+	switch {
+	case parent == nil:
+		return []string{fmt.Sprintf("parent %s of %s could not be found", entry.Parent, entry.Name)}
+	case parent.Archived():
+		return []string{fmt.Sprintf("parent %s of %s has been archived", parent.Name, entry.Name)}
+	case parent.ParentID != 0:
+		return []string{fmt.Sprintf("%s cannot be a parent of %s, as it is a floor or side of another respawn", parent.Name, entry.Name)}
+	}
+
+	return []string{}
+}
+
+// Returns true if importing the entry would change the existing spot.
+func importChangesSpot(spots []*spot.Spot, existing *spot.Spot, entry *spot.CatalogEntry) bool {
+	return existing.Name != entry.Name ||
+		!strings.EqualFold(spotName(spots, existing.ParentID), entry.Parent) ||
+		!detailsEqual(existing.Details, entry.Details()) ||
+		!slices.Equal(existing.Aliases, entry.Aliases)
+}
+
+func detailsEqual(a spot.Details, b spot.Details) bool {
+	return a.MinLevel == b.MinLevel && a.MaxLevel == b.MaxLevel && a.Area == b.Area && a.Kind == b.Kind &&
+		slices.Equal(a.Vocations, b.Vocations)
+}
+
+// Returns name of a spot with a given ID, or an empty string if there is no such spot.
+func spotName(spots []*spot.Spot, id int64) string {
+	s, _ := collections.PoorMansFind(spots, func(s *spot.Spot) bool {
+		return s.ID == id
+	})
+	if s == nil {
+		return ""
+	}
+
+	return s.Name
+}
+
+// Creates added spots and updates changed ones, parents first.
+func (a *Adapter) applyImport(guild *discord.Guild, spots []*spot.Spot, result *spot.ImportResult) error {
+	ctx := context.Background()
+	idsByName := map[string]int64{}
+	for _, s := range spots {
+		idsByName[strings.ToLower(s.Name)] = s.ID
+		for _, alias := range s.Aliases {
+			idsByName[strings.ToLower(alias)] = s.ID
+		}
+	}
+
+	changed := append(slices.Clone(result.Added), result.Updated...)
+	slices.SortStableFunc(changed, func(a *spot.CatalogEntry, b *spot.CatalogEntry) int {
+		return min(len(a.Parent), 1) - min(len(b.Parent), 1)
+	})
+
+	for _, entry := range changed {
+		var parentId int64
+		if len(entry.Parent) > 0 {
+			parentId = idsByName[strings.ToLower(entry.Parent)]
+		}
+
+		id, ok := idsByName[strings.ToLower(entry.Name)]
+		if !ok {
+			created, err := a.spotRepo.CreateSpot(ctx, guild.ID, entry.Name, parentId)
+			if err != nil {
+				return fmt.Errorf("could not add respawn %s: %w", entry.Name, err)
+			}
+			id = created.ID
+			for _, name := range append([]string{entry.Name}, entry.Aliases...) {
+				idsByName[strings.ToLower(name)] = id
+			}
+		} else {
+			existing, _ := collections.PoorMansFind(spots, func(s *spot.Spot) bool {
+				return s.ID == id
+			})
+			if existing.Name != entry.Name {
+				_, err := a.spotRepo.RenameSpot(ctx, id, entry.Name)
+				if err != nil {
+					return fmt.Errorf("could not rename respawn %s: %w", existing.Name, err)
+				}
+			}
+
+			if existing.ParentID != parentId {
+				_, err := a.spotRepo.UpdateSpotParent(ctx, id, parentId)
+				if err != nil {
+					return fmt.Errorf("could not change parent of respawn %s: %w", entry.Name, err)
+				}
+			}
+		}
+
+		_, err := a.spotRepo.UpdateSpotDetails(ctx, id, entry.Details())
+		if err != nil {
+			return fmt.Errorf("could not describe respawn %s: %w", entry.Name, err)
+		}
+
+		_, err = a.spotRepo.UpdateSpotAliases(ctx, id, entry.Aliases)
+		if err != nil {
+			return fmt.Errorf("could not change aliases of respawn %s: %w", entry.Name, err)
+		}
+	}
+
+	return nil
+}
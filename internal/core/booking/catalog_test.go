@@ -0,0 +1,119 @@
+package booking
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"spot-assistant/internal/common/test/mocks"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/spot"
+)
+
+func TestExportSpots(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{
+		{ID: 2, Name: "Library -1", ParentID: 1, OwnerGuildID: guild.ID},
+		{ID: 1, Name: "Library", Aliases: []string{"libby"}, Details: spot.Details{Area: "Oramond", MinLevel: 200}},
+	}, nil)
+	adapter := NewAdapter(spotRepo, new(mocks.MockReservationRepo), newPolicyRepo())
+
+	// when
+	res, err := adapter.ExportSpots(guild)
+
+	// assert
+	assert.Nil(err)
+	assert.Equal([]*spot.CatalogEntry{
+		{Name: "Library", Aliases: []string{"libby"}, Area: "Oramond", MinLevel: 200},
+		{Name: "Library -1", Parent: "Library"},
+	}, res)
+}
+
+func TestImportSpotsDryRun(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{
+		{ID: 1, Name: "Library", Aliases: []string{}},
+		{ID: 2, Name: "Asura Palace", OwnerGuildID: guild.ID, Aliases: []string{}},
+		{ID: 3, Name: "Roshamuul", OwnerGuildID: guild.ID, Aliases: []string{}},
+	}, nil)
+	spotRepo.On("SelectHiddenSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{}, nil)
+	adapter := NewAdapter(spotRepo, new(mocks.MockReservationRepo), newPolicyRepo())
+	entries := []*spot.CatalogEntry{
+		{Name: "Library"},
+		{Name: "Asura Palace", Area: "Asura"},
+		{Name: "Roshamuul"},
+		{Name: "Library -1", Parent: "Library"},
+	}
+
+	// when
+	res, err := adapter.ImportSpots(guild, entries, true)
+
+	// assert
+	assert.Nil(err)
+	assert.True(res.DryRun)
+	assert.Equal([]*spot.CatalogEntry{entries[3]}, res.Added)
+	assert.Equal([]*spot.CatalogEntry{entries[1]}, res.Updated)
+	assert.Equal([]string{"Roshamuul"}, res.Unchanged)
+	assert.Equal([]string{"Library"}, res.Skipped)
+	spotRepo.AssertNotCalled(t, "CreateSpot")
+	spotRepo.AssertNotCalled(t, "UpdateSpotDetails")
+}
+
+func TestImportSpotsFailOnDuplicates(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{{ID: 1, Name: "Secret Library", Aliases: []string{"libby"}}}, nil)
+	spotRepo.On("SelectHiddenSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{}, nil)
+	adapter := NewAdapter(spotRepo, new(mocks.MockReservationRepo), newPolicyRepo())
+
+	// when
+	_, duplicateInCatalogErr := adapter.ImportSpots(guild, []*spot.CatalogEntry{
+		{Name: "Library"},
+		{Name: "Oramond", Aliases: []string{"LIBRARY"}},
+	}, true)
+	_, duplicateInDatabaseErr := adapter.ImportSpots(guild, []*spot.CatalogEntry{
+		{Name: "Libby"},
+		{Name: "Oramond -1", Parent: "Oramond"},
+	}, true)
+
+	// assert
+	assert.ErrorContains(duplicateInCatalogErr, "LIBRARY is used by both Library and Oramond")
+	assert.ErrorContains(duplicateInDatabaseErr, "Libby is already used by respawn Secret Library")
+	assert.ErrorContains(duplicateInDatabaseErr, "parent Oramond of Oramond -1 could not be found")
+}
+
+func TestImportSpotsApply(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{}, nil)
+	spotRepo.On("SelectHiddenSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{}, nil)
+	spotRepo.On("CreateSpot", mocks.ContextMock, guild.ID, "Library", int64(0)).Return(&spot.Spot{ID: 1, Name: "Library"}, nil).Once()
+	spotRepo.On("CreateSpot", mocks.ContextMock, guild.ID, "Library -1", int64(1)).Return(&spot.Spot{ID: 2, Name: "Library -1", ParentID: 1}, nil).Once()
+	spotRepo.On("UpdateSpotDetails", mocks.ContextMock, int64(1), spot.Details{Area: "Oramond"}).Return(&spot.Spot{ID: 1}, nil)
+	spotRepo.On("UpdateSpotDetails", mocks.ContextMock, int64(2), spot.Details{}).Return(&spot.Spot{ID: 2}, nil)
+	spotRepo.On("UpdateSpotAliases", mocks.ContextMock, int64(1), []string{"libby"}).Return(&spot.Spot{ID: 1}, nil)
+	spotRepo.On("UpdateSpotAliases", mocks.ContextMock, int64(2), []string{}).Return(&spot.Spot{ID: 2}, nil)
+	defer spotRepo.AssertExpectations(t)
+	adapter := NewAdapter(spotRepo, new(mocks.MockReservationRepo), newPolicyRepo())
+
+	// when
+	res, err := adapter.ImportSpots(guild, []*spot.CatalogEntry{
+		{Name: "Library -1", Parent: "libby"},
+		{Name: "Library", Area: "Oramond", Aliases: []string{"libby"}},
+	}, false)
+
+	// assert
+	assert.Nil(err)
+	assert.False(res.DryRun)
+	assert.Len(res.Added, 2)
+}
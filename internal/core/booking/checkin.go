@@ -0,0 +1,108 @@
+package booking
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	stringsHelper "spot-assistant/internal/common/strings"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/reservation"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Confirms member presence on one of their reservations. Members can check in
+// from a grace period before the reservation starts, until it ends.
+func (a *Adapter) CheckIn(guild *discord.Guild, member *discord.Member, reservationId int64) (*reservation.ReservationWithSpot, error) {
+	a.log.WithFields(logrus.Fields{
+		"member":        member,
+		"reservationId": reservationId,
+	}).Info("check-in request")
+
+	p, err := a.GetPolicy(guild)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := a.reservationRepo.FindReservationWithSpot(context.Background(), reservationId, guild.ID, member.ID)
+	if err != nil {
+		return nil, fmt.Errorf("could not find reservation: %w", err)
+	}
+
+	currTime := time.Now()
+	if !res.EndAt.After(currTime) {
+		return nil, errors.New("you cannot check in on a reservation that has already ended")
+	}
+
+	if res.StartAt.Add(-p.CheckInGracePeriod).After(currTime) {
+		return nil, fmt.Errorf("you can check in at most %s before the reservation starts", stringsHelper.FormatDuration(p.CheckInGracePeriod))
+	}
+
+	if res.CheckedIn() {
+		return res, nil
+	}
+
+	err = a.reservationRepo.CheckInPresentMemberReservation(context.Background(), guild, member, res.Reservation.ID)
+	if err != nil {
+		return nil, fmt.Errorf("could not check in: %w", err)
+	}
+
+	res.CheckedInAt = currTime
+
+	return res, nil
+}
+
+// Goes through started guild reservations that have not been checked in yet. Authors within
+// the grace period are due a reminder, and the ones past it are flagged as no-shows. Authors
+// who have not been reminded within the grace period, e.g. on reservations started before
+// check-ins were introduced, are neither reminded nor flagged.
+// Each reservation is returned at most once as a reminder, and at most once as a no-show.
+func (a *Adapter) ProcessCheckIns(guild *discord.Guild) ([]*reservation.ReservationWithSpot, []*reservation.NoShow, error) {
+	p, err := a.GetPolicy(guild)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	currTime := time.Now()
+	pending, err := a.reservationRepo.SelectCheckInPendingReservationsWithSpots(context.Background(), guild.ID, currTime)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not select reservations pending check-in: %w", err)
+	}
+
+	reminders := make([]*reservation.ReservationWithSpot, 0)
+	noShows := make([]*reservation.NoShow, 0)
+	for _, res := range pending {
+		log := a.log.WithFields(logrus.Fields{"reservation.ID": res.Reservation.ID, "guild.ID": guild.ID})
+
+		if isNoShow(p, &res.Reservation, currTime) {
+			count, err := a.reservationRepo.MarkNoShow(context.Background(), &res.Reservation)
+			if err != nil {
+				log.Errorf("could not flag reservation as a no-show: %s", err)
+				continue
+			}
+
+			if count > 0 {
+				noShows = append(noShows, &reservation.NoShow{ReservationWithSpot: res, Count: count})
+			}
+			continue
+		}
+
+		if !res.StartAt.Add(p.CheckInGracePeriod).After(currTime) {
+			continue
+		}
+
+		reminded, err := a.reservationRepo.MarkCheckInReminded(context.Background(), res.Reservation.ID)
+		if err != nil {
+			log.Errorf("could not mark reservation as reminded of check-in: %s", err)
+			continue
+		}
+
+		if reminded {
+			reminders = append(reminders, res)
+		}
+	}
+
+	return reminders, noShows, nil
+}
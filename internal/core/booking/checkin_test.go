@@ -0,0 +1,292 @@
+package booking
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"spot-assistant/internal/common/test/mocks"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/reservation"
+	"spot-assistant/internal/core/dto/spot"
+)
+
+func TestCheckIn(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{
+		ID:   "test-id",
+		Name: "test-guild-name",
+	}
+	member := &discord.Member{
+		ID:   "test-member",
+		Nick: "test-nick",
+	}
+	existingReservation := &reservation.ReservationWithSpot{
+		Reservation: reservation.Reservation{
+			ID:              1,
+			AuthorDiscordID: member.ID,
+			StartAt:         time.Now().Add(-5 * time.Minute),
+			EndAt:           time.Now().Add(2 * time.Hour),
+			GuildID:         guild.ID,
+		},
+		Spot: reservation.Spot{Name: "test-spot"},
+	}
+	reservationService := new(mocks.MockReservationRepo)
+	reservationService.On("FindReservationWithSpot", mocks.ContextMock, existingReservation.Reservation.ID, guild.ID, member.ID).Return(existingReservation, nil)
+	reservationService.On("CheckInPresentMemberReservation", mocks.ContextMock, guild, member, existingReservation.Reservation.ID).Return(nil)
+	adapter := NewAdapter(new(mocks.MockSpotRepo), reservationService, newPolicyRepo())
+
+	// when
+	res, err := adapter.CheckIn(guild, member, existingReservation.Reservation.ID)
+
+	// assert
+	assert.Nil(err)
+	assert.True(res.CheckedIn())
+	reservationService.AssertExpectations(t)
+}
+
+func TestCheckInFailTooEarly(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{
+		ID:   "test-id",
+		Name: "test-guild-name",
+	}
+	member := &discord.Member{
+		ID:   "test-member",
+		Nick: "test-nick",
+	}
+	existingReservation := &reservation.ReservationWithSpot{
+		Reservation: reservation.Reservation{
+			ID:              1,
+			AuthorDiscordID: member.ID,
+			StartAt:         time.Now().Add(2 * time.Hour),
+			EndAt:           time.Now().Add(4 * time.Hour),
+			GuildID:         guild.ID,
+		},
+		Spot: reservation.Spot{Name: "test-spot"},
+	}
+	reservationService := new(mocks.MockReservationRepo)
+	reservationService.On("FindReservationWithSpot", mocks.ContextMock, existingReservation.Reservation.ID, guild.ID, member.ID).Return(existingReservation, nil)
+	adapter := NewAdapter(new(mocks.MockSpotRepo), reservationService, newPolicyRepo())
+
+	// when
+	res, err := adapter.CheckIn(guild, member, existingReservation.Reservation.ID)
+
+	// assert
+	assert.NotNil(err)
+	assert.Nil(res)
+	reservationService.AssertNotCalled(t, "CheckInPresentMemberReservation", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestProcessCheckIns(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{
+		ID:   "test-id",
+		Name: "test-guild-name",
+	}
+	justStarted := &reservation.ReservationWithSpot{
+		Reservation: reservation.Reservation{
+			ID:              1,
+			AuthorDiscordID: "test-member",
+			StartAt:         time.Now().Add(-1 * time.Minute),
+			EndAt:           time.Now().Add(2 * time.Hour),
+			GuildID:         guild.ID,
+		},
+	}
+	alreadyReminded := &reservation.ReservationWithSpot{
+		Reservation: reservation.Reservation{
+			ID:                2,
+			AuthorDiscordID:   "test-member-2",
+			StartAt:           time.Now().Add(-5 * time.Minute),
+			EndAt:             time.Now().Add(2 * time.Hour),
+			GuildID:           guild.ID,
+			CheckInRemindedAt: time.Now().Add(-4 * time.Minute),
+		},
+	}
+	abandoned := &reservation.ReservationWithSpot{
+		Reservation: reservation.Reservation{
+			ID:                3,
+			AuthorDiscordID:   "test-member-3",
+			StartAt:           time.Now().Add(-30 * time.Minute),
+			EndAt:             time.Now().Add(1 * time.Hour),
+			GuildID:           guild.ID,
+			CheckInRemindedAt: time.Now().Add(-29 * time.Minute),
+		},
+	}
+	// Started before check-ins were introduced, so its author has never been reminded
+	neverReminded := &reservation.ReservationWithSpot{
+		Reservation: reservation.Reservation{
+			ID:              4,
+			AuthorDiscordID: "test-member-4",
+			StartAt:         time.Now().Add(-30 * time.Minute),
+			EndAt:           time.Now().Add(1 * time.Hour),
+			GuildID:         guild.ID,
+		},
+	}
+	reservationService := new(mocks.MockReservationRepo)
+	reservationService.On("SelectCheckInPendingReservationsWithSpots", mocks.ContextMock, guild.ID, mock.Anything).Return(
+		[]*reservation.ReservationWithSpot{abandoned, neverReminded, alreadyReminded, justStarted}, nil)
+	reservationService.On("MarkNoShow", mocks.ContextMock, &abandoned.Reservation).Return(2, nil)
+	reservationService.On("MarkCheckInReminded", mocks.ContextMock, alreadyReminded.Reservation.ID).Return(false, nil)
+	reservationService.On("MarkCheckInReminded", mocks.ContextMock, justStarted.Reservation.ID).Return(true, nil)
+	adapter := NewAdapter(new(mocks.MockSpotRepo), reservationService, newPolicyRepo())
+
+	// when
+	reminders, noShows, err := adapter.ProcessCheckIns(guild)
+
+	// assert
+	assert.Nil(err)
+	assert.Equal([]*reservation.ReservationWithSpot{justStarted}, reminders)
+	assert.Equal([]*reservation.NoShow{{ReservationWithSpot: abandoned, Count: 2}}, noShows)
+	reservationService.AssertNotCalled(t, "MarkNoShow", mocks.ContextMock, &neverReminded.Reservation)
+	reservationService.AssertNotCalled(t, "MarkCheckInReminded", mocks.ContextMock, neverReminded.Reservation.ID)
+}
+
+func TestBookOverbookNoShowWithoutPermissions(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{
+		ID:   "test-id",
+		Name: "test-guild-name",
+	}
+	member := &discord.Member{
+		ID:   "test-member",
+		Nick: "test-nick",
+	}
+	startAt := time.Now().Add(1 * time.Minute)
+	endAt := startAt.Add(1 * time.Hour)
+	spotInput := &spot.Spot{
+		Name:      "test-spot",
+		ID:        1,
+		CreatedAt: time.Now(),
+	}
+	conflictingReservations := []*reservation.Reservation{
+		{
+			ID:                2,
+			StartAt:           time.Now().Add(-30 * time.Minute),
+			EndAt:             time.Now().Add(30 * time.Minute),
+			SpotID:            spotInput.ID,
+			GuildID:           guild.ID,
+			AuthorDiscordID:   "test-other-member",
+			CheckInRemindedAt: time.Now().Add(-29 * time.Minute),
+		},
+	}
+	spotService := new(mocks.MockSpotRepo)
+	spotService.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{spotInput}, nil)
+	reservationService := new(mocks.MockReservationRepo)
+	reservationService.On("SelectOverlappingReservations", mocks.ContextMock, spotInput.ID, startAt, endAt, guild.ID).Return(conflictingReservations, nil)
+	reservationService.On("SelectUpcomingMemberReservationsWithSpots", mocks.ContextMock, guild, member).Return([]*reservation.ReservationWithSpot{}, nil)
+	reservationService.On("CreateAndDeleteConflicting", mocks.ContextMock, member, guild, []*discord.Member{}, conflictingReservations, spotInput.ID, startAt, endAt, 0).Return([]*reservation.ClippedOrRemovedReservation{}, nil)
+	adapter := NewAdapter(spotService, reservationService, newPolicyRepo())
+
+	// when
+	res, err := adapter.Book(member, guild, []*discord.Member{}, spotInput.Name, startAt, endAt, true, newTestTier(0), newTestRoleChecker())
+
+	// assert
+	assert.Nil(err)
+	assert.NotNil(res)
+}
+
+func TestBookOverbookNeverRemindedReservationWithoutPermissions(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-id"}
+	member := &discord.Member{ID: "test-member"}
+	startAt := time.Now().Add(1 * time.Minute)
+	endAt := startAt.Add(1 * time.Hour)
+	spotInput := &spot.Spot{Name: "test-spot", ID: 1}
+	// Started before check-ins were introduced, so whether its author is present is unknown
+	conflictingReservations := []*reservation.Reservation{
+		{ID: 2, StartAt: time.Now().Add(-30 * time.Minute), EndAt: time.Now().Add(30 * time.Minute), SpotID: spotInput.ID, GuildID: guild.ID, AuthorDiscordID: "test-other-member"},
+	}
+	spotService := new(mocks.MockSpotRepo)
+	spotService.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{spotInput}, nil)
+	reservationService := new(mocks.MockReservationRepo)
+	reservationService.On("SelectOverlappingReservations", mocks.ContextMock, spotInput.ID, startAt, endAt, guild.ID).Return(conflictingReservations, nil)
+	reservationService.On("SelectUpcomingMemberReservationsWithSpots", mocks.ContextMock, guild, member).Return([]*reservation.ReservationWithSpot{}, nil)
+	reservationService.On("CreateAndDeleteConflicting", mocks.ContextMock, member, guild, []*discord.Member{}, conflictingReservations, spotInput.ID, startAt, endAt, 0).Return([]*reservation.ClippedOrRemovedReservation{}, nil)
+	adapter := NewAdapter(spotService, reservationService, newPolicyRepo())
+
+	// when
+	res, err := adapter.Book(member, guild, []*discord.Member{}, spotInput.Name, startAt, endAt, true, newTestTier(0), newTestRoleChecker())
+
+	// assert
+	assert.Nil(err)
+	assert.NotNil(res)
+}
+
+func TestBookFailOnOverbookNeverRemindedReservationsWithoutPermissions(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-id"}
+	member := &discord.Member{ID: "test-member"}
+	startAt := time.Now().Add(1 * time.Minute)
+	endAt := startAt.Add(1 * time.Hour)
+	spotInput := &spot.Spot{Name: "test-spot", ID: 1}
+	conflictingReservations := []*reservation.Reservation{
+		{ID: 2, StartAt: time.Now().Add(-30 * time.Minute), EndAt: time.Now().Add(30 * time.Minute), SpotID: spotInput.ID, GuildID: guild.ID, AuthorDiscordID: "test-other-member"},
+		{ID: 3, StartAt: time.Now().Add(30 * time.Minute), EndAt: time.Now().Add(90 * time.Minute), SpotID: spotInput.ID, GuildID: guild.ID, AuthorDiscordID: "test-another-member"},
+	}
+	spotService := new(mocks.MockSpotRepo)
+	spotService.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{spotInput}, nil)
+	reservationService := new(mocks.MockReservationRepo)
+	reservationService.On("SelectOverlappingReservations", mocks.ContextMock, spotInput.ID, startAt, endAt, guild.ID).Return(conflictingReservations, nil)
+	adapter := NewAdapter(spotService, reservationService, newPolicyRepo())
+
+	// when
+	res, err := adapter.Book(member, guild, []*discord.Member{}, spotInput.Name, startAt, endAt, true, newTestTier(0), newTestRoleChecker())
+
+	// assert
+	assert.NotNil(err)
+	assert.Len(res, 2)
+	reservationService.AssertNotCalled(t, "CreateAndDeleteConflicting", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestBookFailOnOverbookCheckedInReservationWithoutPermissions(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{
+		ID:   "test-id",
+		Name: "test-guild-name",
+	}
+	member := &discord.Member{
+		ID:   "test-member",
+		Nick: "test-nick",
+	}
+	startAt := time.Now().Add(1 * time.Minute)
+	endAt := startAt.Add(1 * time.Hour)
+	spotInput := &spot.Spot{
+		Name:      "test-spot",
+		ID:        1,
+		CreatedAt: time.Now(),
+	}
+	conflictingReservations := []*reservation.Reservation{
+		{
+			ID:              2,
+			StartAt:         time.Now().Add(-30 * time.Minute),
+			EndAt:           time.Now().Add(30 * time.Minute),
+			SpotID:          spotInput.ID,
+			GuildID:         guild.ID,
+			AuthorDiscordID: "test-other-member",
+			CheckedInAt:     time.Now().Add(-25 * time.Minute),
+		},
+	}
+	spotService := new(mocks.MockSpotRepo)
+	spotService.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{spotInput}, nil)
+	reservationService := new(mocks.MockReservationRepo)
+	reservationService.On("SelectOverlappingReservations", mocks.ContextMock, spotInput.ID, startAt, endAt, guild.ID).Return(conflictingReservations, nil)
+	adapter := NewAdapter(spotService, reservationService, newPolicyRepo())
+
+	// when
+	res, err := adapter.Book(member, guild, []*discord.Member{}, spotInput.Name, startAt, endAt, true, newTestTier(0), newTestRoleChecker())
+
+	// assert
+	assert.NotNil(err)
+	assert.Len(res, 1)
+	reservationService.AssertNotCalled(t, "CreateAndDeleteConflicting", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
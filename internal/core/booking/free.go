@@ -0,0 +1,118 @@
+package booking
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/policy"
+	"spot-assistant/internal/core/dto/reservation"
+	"spot-assistant/internal/core/dto/spot"
+)
+
+// Returns windows between from and to, in which the spot has no reservations and no guild blackout
+// takes place. Windows are no longer
+// than the maximum reservation time and do not reach beyond the booking horizon.
+func (a *Adapter) FindFreeWindows(guild *discord.Guild, spotName string, from time.Time, to time.Time) (*spot.Spot, []*reservation.Window, error) {
+	a.log.WithFields(logrus.Fields{"guild": guild.ID, "spot": spotName, "from": from, "to": to}).Info("free windows request")
+
+	p, err := a.GetPolicy(guild)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s, err := a.findBookableSpot(guild, spotName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	windows, err := a.findFreeWindows(p, guild, s, from, to)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s, windows, nil
+}
+
+func (a *Adapter) findFreeWindows(p *policy.Policy, guild *discord.Guild, s *spot.Spot, from time.Time, to time.Time) ([]*reservation.Window, error) {
+	if horizon := time.Now().Add(p.BookingHorizon); to.After(horizon) {
+		to = horizon
+	}
+
+	reservations, err := a.reservationRepo.SelectUpcomingReservationsWithSpot(context.Background(), guild.ID)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch upcoming reservations: %w", err)
+	}
+
+	spotReservations := []*reservation.Reservation{}
+	for _, r := range reservations {
+		if r.Spot.ID == s.ID {
+			spotReservations = append(spotReservations, &r.Reservation)
+		}
+	}
+
+	// Blackouts take up the spot just like reservations do
+	for _, w := range p.BlackoutWindows(from, to) {
+		spotReservations = append(spotReservations, &reservation.Reservation{StartAt: w.StartAt, EndAt: w.EndAt})
+	}
+
+	return freeWindows(spotReservations, from, to, p.MaximumReservationTime, p.SuggestionStep), nil
+}
+
+// Returns gaps between reservations within from and to, split into windows no longer than maxLength.
+// Gaps shorter than minLength are left out, as nobody would be able to hunt in them.
+func freeWindows(reservations []*reservation.Reservation, from time.Time, to time.Time, maxLength time.Duration, minLength time.Duration) []*reservation.Window {
+	reservations = slices.Clone(reservations)
+	slices.SortFunc(reservations, func(a *reservation.Reservation, b *reservation.Reservation) int {
+		return a.StartAt.Compare(b.StartAt)
+	})
+
+	windows := []*reservation.Window{}
+	addGap := func(startAt time.Time, endAt time.Time) {
+		if endAt.Sub(startAt) < minLength {
+			return
+		}
+
+		for startAt.Before(endAt) {
+			windowEnd := startAt.Add(maxLength)
+			if windowEnd.After(endAt) {
+				windowEnd = endAt
+			}
+
+			windows = append(windows, &reservation.Window{StartAt: startAt, EndAt: windowEnd})
+			startAt = windowEnd
+		}
+	}
+
+	cursor := from
+	for _, r := range reservations {
+		if !r.StartAt.Before(to) {
+			break
+		}
+		if !r.EndAt.After(cursor) {
+			continue
+		}
+
+		if r.StartAt.After(cursor) {
+			addGap(cursor, r.StartAt)
+		}
+		cursor = r.EndAt
+	}
+
+	if cursor.Before(to) {
+		addGap(cursor, to)
+	}
+
+	return windows
+}
+
+// Returns true if t falls within one of the windows.
+func isFree(windows []*reservation.Window, t time.Time) bool {
+	return slices.ContainsFunc(windows, func(w *reservation.Window) bool {
+		return !t.Before(w.StartAt) && t.Before(w.EndAt)
+	})
+}
@@ -0,0 +1,127 @@
+package booking
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"spot-assistant/internal/common/test/mocks"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/policy"
+	"spot-assistant/internal/core/dto/reservation"
+	"spot-assistant/internal/core/dto/spot"
+)
+
+func TestFreeWindows(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	tBase := time.Date(2023, 8, 19, 10, 0, 0, 0, time.UTC)
+	reservations := []*reservation.Reservation{
+		{StartAt: tBase.Add(9 * time.Hour), EndAt: tBase.Add(10 * time.Hour)},
+		// Reservations might overlap, if one of them was booked on a sibling floor before grouping it
+		{StartAt: tBase.Add(1 * time.Hour), EndAt: tBase.Add(3 * time.Hour)},
+		{StartAt: tBase.Add(2 * time.Hour), EndAt: tBase.Add(4 * time.Hour)},
+		// Leaves a gap too short to hunt in
+		{StartAt: tBase.Add(4*time.Hour + 10*time.Minute), EndAt: tBase.Add(5 * time.Hour)},
+	}
+
+	// when
+	res := freeWindows(reservations, tBase, tBase.Add(12*time.Hour), 3*time.Hour, 30*time.Minute)
+
+	// assert
+	assert.Equal([]*reservation.Window{
+		{StartAt: tBase, EndAt: tBase.Add(1 * time.Hour)},
+		{StartAt: tBase.Add(5 * time.Hour), EndAt: tBase.Add(8 * time.Hour)},
+		{StartAt: tBase.Add(8 * time.Hour), EndAt: tBase.Add(9 * time.Hour)},
+		{StartAt: tBase.Add(10 * time.Hour), EndAt: tBase.Add(12 * time.Hour)},
+	}, res)
+}
+
+func TestFindFreeWindows(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	tBase := time.Now().Truncate(time.Hour).Add(24 * time.Hour)
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{
+		{ID: 1, Name: "Library", Aliases: []string{"libby"}},
+	}, nil)
+	reservationRepo := new(mocks.MockReservationRepo)
+	reservationRepo.On("SelectUpcomingReservationsWithSpot", mocks.ContextMock, guild.ID).Return([]*reservation.ReservationWithSpot{
+		{
+			Reservation: reservation.Reservation{StartAt: tBase.Add(1 * time.Hour), EndAt: tBase.Add(2 * time.Hour)},
+			Spot:        reservation.Spot{ID: 1, Name: "Library"},
+		},
+		{
+			Reservation: reservation.Reservation{StartAt: tBase, EndAt: tBase.Add(4 * time.Hour)},
+			Spot:        reservation.Spot{ID: 2, Name: "Asura Palace"},
+		},
+	}, nil)
+	adapter := NewAdapter(spotRepo, reservationRepo, newPolicyRepo())
+
+	// when
+	s, res, err := adapter.FindFreeWindows(guild, "libby", tBase, tBase.Add(4*time.Hour))
+
+	// assert
+	assert.Nil(err)
+	assert.Equal("Library", s.Name)
+	assert.Equal([]*reservation.Window{
+		{StartAt: tBase, EndAt: tBase.Add(1 * time.Hour)},
+		{StartAt: tBase.Add(2 * time.Hour), EndAt: tBase.Add(4 * time.Hour)},
+	}, res)
+}
+
+func TestGetSuggestedHoursPrefersFreeHours(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	tBase := time.Now().Truncate(24 * time.Hour).Add(24*time.Hour + 15*time.Hour)
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{{ID: 1, Name: "Library"}}, nil)
+	reservationRepo := new(mocks.MockReservationRepo)
+	reservationRepo.On("SelectUpcomingReservationsWithSpot", mocks.ContextMock, guild.ID).Return([]*reservation.ReservationWithSpot{
+		{
+			Reservation: reservation.Reservation{StartAt: tBase, EndAt: tBase.Add(2*time.Hour + 15*time.Minute)},
+			Spot:        reservation.Spot{ID: 1, Name: "Library"},
+		},
+	}, nil)
+	adapter := NewAdapter(spotRepo, reservationRepo, newPolicyRepo())
+
+	// when
+	res := adapter.GetSuggestedHours(guild, tBase.In(time.UTC), "Library", "")
+	unknownSpot := adapter.GetSuggestedHours(guild, tBase.In(time.UTC), "Libr", "")
+
+	// assert
+	assert.Equal([]string{"17:15", "17:30", "18:00", "18:30", "19:00", "15:30", "16:00", "16:30", "17:00"}, res)
+	assert.Equal([]string{"15:30", "16:00", "16:30", "17:00", "17:30", "18:00", "18:30", "19:00"}, unknownSpot)
+}
+
+func TestFindFreeWindowsSkipsBlackouts(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	tomorrow := time.Now().UTC().AddDate(0, 0, 1)
+	tBase := time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 9, 0, 0, 0, time.UTC)
+	p := newTestPolicy(guild.ID)
+	p.Blackouts = []policy.Blackout{{Name: "Server save", Start: 10 * time.Hour, Length: 10 * time.Minute, TimeZone: "UTC"}}
+	policyRepo := new(mocks.MockPolicyRepo)
+	policyRepo.On("FindGuildPolicy", mocks.ContextMock, guild.ID).Return(p, nil)
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{{ID: 1, Name: "Library"}}, nil)
+	reservationRepo := new(mocks.MockReservationRepo)
+	reservationRepo.On("SelectUpcomingReservationsWithSpot", mocks.ContextMock, guild.ID).Return([]*reservation.ReservationWithSpot{}, nil)
+	adapter := NewAdapter(spotRepo, reservationRepo, policyRepo)
+
+	// when
+	_, res, err := adapter.FindFreeWindows(guild, "Library", tBase, tBase.Add(3*time.Hour))
+	hours := adapter.GetSuggestedHours(guild, tBase, "", "")
+
+	// assert
+	assert.Nil(err)
+	assert.Equal([]*reservation.Window{
+		{StartAt: tBase, EndAt: tBase.Add(1 * time.Hour)},
+		{StartAt: tBase.Add(1*time.Hour + 10*time.Minute), EndAt: tBase.Add(3 * time.Hour)},
+	}, res)
+	assert.Equal([]string{"09:30", "10:30", "11:00", "11:30", "12:00", "12:30", "13:00"}, hours)
+}
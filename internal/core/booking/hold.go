@@ -0,0 +1,132 @@
+package booking
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	stringsHelper "spot-assistant/internal/common/strings"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/policy"
+	"spot-assistant/internal/core/dto/reservation"
+)
+
+// How long a hold keeps the respawn for its author, before it gets released unless confirmed
+const HOLD_DURATION = 15 * time.Minute
+
+// Tentatively reserves a spot for HOLD_DURATION, e.g. while party leader gathers people.
+// Holds go through the same checks as regular reservations of the member tier, but cannot overbook anyone.
+func (a *Adapter) Hold(member *discord.Member, guild *discord.Guild, spotName string, startAt time.Time, endAt time.Time, tier policy.Tier) (*reservation.ReservationWithSpot, []*reservation.ClippedOrRemovedReservation, error) {
+	a.log.WithFields(logrus.Fields{
+		"member":  member,
+		"tier":    tier,
+		"startAt": startAt,
+		"endAt":   endAt,
+	}).Info("hold request")
+
+	p, err := a.GetPolicy(guild)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	startAt, endAt, err = p.ClipToBlackouts(startAt, endAt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	spot, err := a.findBookableSpot(guild, spotName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	heldSpot := reservation.Spot{ID: spot.ID, Name: spot.Name, ParentID: spot.ParentID}
+	_, rejected, err := a.checkBooking(p, member, guild, heldSpot, startAt, endAt, false, tier)
+	if err != nil {
+		return nil, rejected, err
+	}
+
+	res, err := a.reservationRepo.CreateHold(context.Background(), member, guild, spot.ID, startAt, endAt, time.Now().Add(HOLD_DURATION), p.ReservationPriority(tier))
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not create the hold: %w", err)
+	}
+
+	return &reservation.ReservationWithSpot{Reservation: *res, Spot: heldSpot}, nil, nil
+}
+
+// Turns one of the member holds into a regular reservation, as long as it has not expired yet. Holds do not count
+// towards maximum reservations time and weekly quotas, so they are checked again with the member tier.
+func (a *Adapter) Confirm(guild *discord.Guild, member *discord.Member, reservationId int64, tier policy.Tier) (*reservation.ReservationWithSpot, error) {
+	a.log.WithFields(logrus.Fields{
+		"member":        member,
+		"reservationId": reservationId,
+		"tier":          tier,
+	}).Info("confirm request")
+
+	p, err := a.GetPolicy(guild)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := a.reservationRepo.FindReservationWithSpot(context.Background(), reservationId, guild.ID, member.ID)
+	if err != nil {
+		return nil, fmt.Errorf("could not find reservation: %w", err)
+	}
+
+	if !res.Held() {
+		return nil, errors.New("this reservation is already confirmed")
+	}
+
+	if !res.HeldUntil.After(time.Now()) {
+		return nil, errors.New("this hold has already expired, book the respawn again")
+	}
+
+	exceeds, err := a.exceedsMaximumReservationsTime(p, tier.MaximumReservationsTime, guild, member, res.Spot, res.StartAt, res.EndAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if exceeds {
+		return nil, fmt.Errorf("You can only book %s of reservations within 24 hour window", stringsHelper.FormatDuration(tier.MaximumReservationsTime))
+	}
+
+	err = a.checkWeeklyQuotas(p, guild, member, res.Spot, res.StartAt, res.EndAt)
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.reservationRepo.ConfirmPresentMemberHold(context.Background(), guild, member, res.Reservation.ID)
+	if err != nil {
+		return nil, fmt.Errorf("could not confirm: %w", err)
+	}
+
+	res.HeldUntil = time.Time{}
+
+	return res, nil
+}
+
+// Releases guild holds, which have not been confirmed in time. Each hold is returned
+// at most once, even if the releasing runs concurrently.
+func (a *Adapter) ReleaseExpiredHolds(guild *discord.Guild) ([]*reservation.ReservationWithSpot, error) {
+	released := make([]*reservation.ReservationWithSpot, 0)
+
+	holds, err := a.reservationRepo.SelectExpiredHoldsWithSpots(context.Background(), guild.ID)
+	if err != nil {
+		return released, fmt.Errorf("could not select expired holds: %w", err)
+	}
+
+	for _, hold := range holds {
+		ok, err := a.reservationRepo.ReleaseExpiredHold(context.Background(), hold.Reservation.ID)
+		if err != nil {
+			return released, fmt.Errorf("could not release hold: %w", err)
+		}
+
+		if ok {
+			released = append(released, hold)
+		}
+	}
+
+	return released, nil
+}
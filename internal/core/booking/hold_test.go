@@ -0,0 +1,151 @@
+package booking
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"spot-assistant/internal/common/test/mocks"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/reservation"
+	"spot-assistant/internal/core/dto/spot"
+)
+
+func TestHold(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	member := &discord.Member{ID: "test-member"}
+	startAt := time.Now().Add(1 * time.Minute)
+	endAt := startAt.Add(2 * time.Hour)
+	spotInput := &spot.Spot{ID: 1, Name: "test-spot"}
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{spotInput}, nil)
+	reservationRepo := new(mocks.MockReservationRepo)
+	reservationRepo.On("SelectOverlappingReservations", mocks.ContextMock, spotInput.ID, startAt, endAt, guild.ID).Return([]*reservation.Reservation{}, nil)
+	reservationRepo.On("SelectUpcomingMemberReservationsWithSpots", mocks.ContextMock, guild, member).Return([]*reservation.ReservationWithSpot{}, nil)
+	reservationRepo.On("CreateHold", mocks.ContextMock, member, guild, spotInput.ID, startAt, endAt, mock.MatchedBy(func(heldUntil time.Time) bool {
+		return heldUntil.After(time.Now().Add(HOLD_DURATION - time.Minute))
+	}), 0).Return(&reservation.Reservation{ID: 7, StartAt: startAt, EndAt: endAt, HeldUntil: time.Now().Add(HOLD_DURATION)}, nil)
+	defer reservationRepo.AssertExpectations(t)
+	adapter := NewAdapter(spotRepo, reservationRepo, newPolicyRepo())
+
+	// when
+	res, conflicting, err := adapter.Hold(member, guild, spotInput.Name, startAt, endAt, newTestTier(0))
+
+	// assert
+	assert.Nil(err)
+	assert.Empty(conflicting)
+	assert.True(res.Held())
+	assert.Equal(spotInput.Name, res.Spot.Name)
+}
+
+func TestHoldFailsOnOverlappingReservation(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	member := &discord.Member{ID: "test-member"}
+	startAt := time.Now().Add(1 * time.Minute)
+	endAt := startAt.Add(2 * time.Hour)
+	spotInput := &spot.Spot{ID: 1, Name: "test-spot"}
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{spotInput}, nil)
+	reservationRepo := new(mocks.MockReservationRepo)
+	reservationRepo.On("SelectOverlappingReservations", mocks.ContextMock, spotInput.ID, startAt, endAt, guild.ID).Return([]*reservation.Reservation{
+		{ID: 3, Author: "other", AuthorDiscordID: "other-id", StartAt: startAt, EndAt: endAt, SpotID: spotInput.ID},
+	}, nil)
+	reservationRepo.On("SelectUpcomingMemberReservationsWithSpots", mocks.ContextMock, guild, member).Return([]*reservation.ReservationWithSpot{}, nil)
+	adapter := NewAdapter(spotRepo, reservationRepo, newPolicyRepo())
+
+	// when
+	_, _, err := adapter.Hold(member, guild, spotInput.Name, startAt, endAt, newTestTier(0))
+
+	// assert
+	assert.NotNil(err)
+	reservationRepo.AssertNotCalled(t, "CreateHold")
+}
+
+func TestConfirm(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	member := &discord.Member{ID: "test-member"}
+	reservationRepo := new(mocks.MockReservationRepo)
+	reservationRepo.On("FindReservationWithSpot", mocks.ContextMock, int64(1), guild.ID, member.ID).Return(&reservation.ReservationWithSpot{
+		Reservation: reservation.Reservation{ID: 1, HeldUntil: time.Now().Add(5 * time.Minute)},
+	}, nil)
+	reservationRepo.On("FindReservationWithSpot", mocks.ContextMock, int64(2), guild.ID, member.ID).Return(&reservation.ReservationWithSpot{
+		Reservation: reservation.Reservation{ID: 2, HeldUntil: time.Now().Add(-1 * time.Minute)},
+	}, nil)
+	reservationRepo.On("FindReservationWithSpot", mocks.ContextMock, int64(3), guild.ID, member.ID).Return(&reservation.ReservationWithSpot{
+		Reservation: reservation.Reservation{ID: 3},
+	}, nil)
+	reservationRepo.On("SelectUpcomingMemberReservationsWithSpots", mocks.ContextMock, guild, member).Return([]*reservation.ReservationWithSpot{}, nil)
+	reservationRepo.On("ConfirmPresentMemberHold", mocks.ContextMock, guild, member, int64(1)).Return(nil)
+	adapter := NewAdapter(new(mocks.MockSpotRepo), reservationRepo, newPolicyRepo())
+
+	// when
+	res, err := adapter.Confirm(guild, member, 1, newTestTier(0))
+	_, expiredErr := adapter.Confirm(guild, member, 2, newTestTier(0))
+	_, confirmedErr := adapter.Confirm(guild, member, 3, newTestTier(0))
+
+	// assert
+	assert.Nil(err)
+	assert.False(res.Held())
+	assert.ErrorContains(expiredErr, "expired")
+	assert.ErrorContains(confirmedErr, "already confirmed")
+	reservationRepo.AssertNumberOfCalls(t, "ConfirmPresentMemberHold", 1)
+}
+
+func TestConfirmExceedingMaximumReservationsTime(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	member := &discord.Member{ID: "test-member"}
+	startAt := time.Now().Add(1 * time.Hour)
+	hold := &reservation.ReservationWithSpot{
+		Reservation: reservation.Reservation{ID: 1, StartAt: startAt, EndAt: startAt.Add(2 * time.Hour), HeldUntil: time.Now().Add(5 * time.Minute)},
+		Spot:        reservation.Spot{ID: 1, Name: "test-spot"},
+	}
+	// Booked after the hold had been made, as holds do not count towards maximum reservations time
+	other := &reservation.ReservationWithSpot{
+		Reservation: reservation.Reservation{ID: 2, StartAt: startAt.Add(3 * time.Hour), EndAt: startAt.Add(5 * time.Hour)},
+		Spot:        reservation.Spot{ID: 2, Name: "other-spot"},
+	}
+	reservationRepo := new(mocks.MockReservationRepo)
+	reservationRepo.On("FindReservationWithSpot", mocks.ContextMock, hold.Reservation.ID, guild.ID, member.ID).Return(hold, nil)
+	reservationRepo.On("SelectUpcomingMemberReservationsWithSpots", mocks.ContextMock, guild, member).Return([]*reservation.ReservationWithSpot{hold, other}, nil)
+	adapter := NewAdapter(new(mocks.MockSpotRepo), reservationRepo, newPolicyRepo())
+
+	// when
+	_, err := adapter.Confirm(guild, member, hold.Reservation.ID, newTestTier(0))
+
+	// assert
+	assert.ErrorContains(err, "You can only book 3h of reservations within 24 hour window")
+	reservationRepo.AssertNotCalled(t, "ConfirmPresentMemberHold", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestReleaseExpiredHoldsSkipsClaimedOnes(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	holds := []*reservation.ReservationWithSpot{
+		{Reservation: reservation.Reservation{ID: 1}},
+		{Reservation: reservation.Reservation{ID: 2}},
+	}
+	reservationRepo := new(mocks.MockReservationRepo)
+	reservationRepo.On("SelectExpiredHoldsWithSpots", mocks.ContextMock, guild.ID).Return(holds, nil)
+	reservationRepo.On("ReleaseExpiredHold", mocks.ContextMock, int64(1)).Return(true, nil)
+	// Confirmed or released by another tick in the meantime
+	reservationRepo.On("ReleaseExpiredHold", mocks.ContextMock, int64(2)).Return(false, nil)
+	adapter := NewAdapter(new(mocks.MockSpotRepo), reservationRepo, newPolicyRepo())
+
+	// when
+	res, err := adapter.ReleaseExpiredHolds(guild)
+
+	// assert
+	assert.Nil(err)
+	assert.Equal([]*reservation.ReservationWithSpot{holds[0]}, res)
+}
@@ -0,0 +1,275 @@
+package booking
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"spot-assistant/internal/common/collections"
+	stringsHelper "spot-assistant/internal/common/strings"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/policy"
+	"spot-assistant/internal/core/dto/reservation"
+	"spot-assistant/internal/core/dto/spot"
+)
+
+// How long lottery wins lower chances of members in weighted lotteries
+const LOTTERY_WINS_PERIOD = 30 * 24 * time.Hour
+
+// Lottery along with the spot it has been set on.
+type resolvedLottery struct {
+	policy.Lottery
+	spot *spot.Spot
+}
+
+// Applies for a reservation of a spot handed out by lottery, until lots for the time range are drawn.
+func (a *Adapter) Apply(member *discord.Member, guild *discord.Guild, spotName string, startAt time.Time, endAt time.Time) (*reservation.LotteryApplicationWithSpot, error) {
+	currTime := time.Now()
+
+	a.log.WithFields(logrus.Fields{
+		"member":   member,
+		"startAt":  startAt,
+		"endAt":    endAt,
+		"currTime": currTime,
+	}).Info("lottery application")
+
+	p, err := a.GetPolicy(guild)
+	if err != nil {
+		return nil, err
+	}
+
+	startAt, endAt, err = p.ClipToBlackouts(startAt, endAt)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := a.findBookableSpot(guild, spotName)
+	if err != nil {
+		return nil, err
+	}
+
+	spots, err := a.spotRepo.SelectAllSpots(context.Background(), guild.ID)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch spots: %w", err)
+	}
+
+	l := findLottery(a.resolveLotteries(p, guild, spots), s.ID, s.ParentID)
+	if l == nil {
+		return nil, fmt.Errorf("%s is not handed out by lottery, you can book it right away", s.Name)
+	}
+
+	if endAt.Sub(startAt) > p.MaximumReservationTime {
+		return nil, fmt.Errorf("reservation cannot take more than %s", stringsHelper.FormatDuration(p.MaximumReservationTime))
+	}
+
+	err = checkBookingHorizon(p, currTime, startAt)
+	if err != nil {
+		return nil, err
+	}
+
+	drawAt := l.DrawTime(p.Location(), startAt)
+	if !currTime.Before(drawAt) {
+		return nil, fmt.Errorf("Lots for %s starting %s have been drawn %s, you can book it if it is still free", s.Name, stringsHelper.FormatLongTime(startAt.In(p.Location())), stringsHelper.FormatLongTime(drawAt.In(p.Location())))
+	}
+
+	pending, err := a.reservationRepo.SelectPendingLotteryApplicationsWithSpots(context.Background(), guild.ID)
+	if err != nil {
+		return nil, fmt.Errorf("could not select lottery applications: %w", err)
+	}
+
+	authorsApplication, _ := collections.PoorMansFind(pending, func(application *reservation.LotteryApplicationWithSpot) bool {
+		return application.AuthorDiscordID == member.ID && application.Spot.ID == s.ID &&
+			application.StartAt.Before(endAt) && application.EndAt.After(startAt)
+	})
+	if authorsApplication != nil {
+		return nil, errors.New("you have already applied for this respawn within this time range")
+	}
+
+	application, err := a.reservationRepo.CreateLotteryApplication(context.Background(), member, guild, s.ID, startAt, endAt, drawAt)
+	if err != nil {
+		return nil, fmt.Errorf("could not apply: %w", err)
+	}
+
+	return &reservation.LotteryApplicationWithSpot{
+		LotteryApplication: *application,
+		Spot: reservation.Spot{
+			ID:       s.ID,
+			Name:     s.Name,
+			ParentID: s.ParentID,
+		},
+	}, nil
+}
+
+// Draws lots among guild applications, which draw time has come. Applications are drawn in a random
+// order, weighted against members who have won recently in weighted lotteries. Each application wins,
+// unless its time has been taken by then, its author has already won the respawn in the same draw,
+// or would exceed maximum reservations time of their tier, which is resolved with hasRole, or weekly quotas. Winning
+// applications are booked with the priority of the tier. Returns drawn applications along with their results.
+func (a *Adapter) DrawLotteries(guild *discord.Guild, hasRole policy.RoleChecker) ([]*reservation.LotteryApplicationWithSpot, error) {
+	drawn := make([]*reservation.LotteryApplicationWithSpot, 0)
+	currTime := time.Now()
+
+	err := a.reservationRepo.DeleteLotteryApplicationsDrawnBefore(context.Background(), guild.ID, currTime.Add(-LOTTERY_WINS_PERIOD))
+	if err != nil {
+		return drawn, fmt.Errorf("could not delete past lottery applications: %w", err)
+	}
+
+	pending, err := a.reservationRepo.SelectPendingLotteryApplicationsWithSpots(context.Background(), guild.ID)
+	if err != nil {
+		return drawn, fmt.Errorf("could not select lottery applications: %w", err)
+	}
+
+	due := collections.PoorMansFilter(pending, func(application *reservation.LotteryApplicationWithSpot) bool {
+		return !application.DrawAt.After(currTime)
+	})
+	if len(due) == 0 {
+		return drawn, nil
+	}
+
+	p, err := a.GetPolicy(guild)
+	if err != nil {
+		return drawn, err
+	}
+
+	wins, err := a.reservationRepo.CountLotteryWins(context.Background(), guild.ID, currTime.Add(-LOTTERY_WINS_PERIOD))
+	if err != nil {
+		return drawn, fmt.Errorf("could not count lottery wins: %w", err)
+	}
+
+	spots, err := a.spotRepo.SelectAllSpots(context.Background(), guild.ID)
+	if err != nil {
+		return drawn, fmt.Errorf("could not fetch spots: %w", err)
+	}
+	lotteries := a.resolveLotteries(p, guild, spots)
+
+	// Weighted random order, in which each application is drawn with a key of u^(1/weight)
+	keys := make(map[int64]float64, len(due))
+	for _, application := range due {
+		weight := 1.0
+		if l := findLottery(lotteries, application.Spot.ID, application.Spot.ParentID); l != nil && l.Weighted {
+			weight = 1 / float64(1+wins[application.AuthorDiscordID])
+		}
+
+		keys[application.ID] = math.Pow(rand.Float64(), 1/weight)
+	}
+	slices.SortFunc(due, func(a *reservation.LotteryApplicationWithSpot, b *reservation.LotteryApplicationWithSpot) int {
+		return cmp.Compare(keys[b.ID], keys[a.ID])
+	})
+
+	// Members win a reservation of each respawn at most once per draw
+	winners := make([]*reservation.LotteryApplicationWithSpot, 0)
+	for _, application := range due {
+		member := &discord.Member{ID: application.AuthorDiscordID, Nick: application.Author}
+		tier := p.TierOf(hasRole, member)
+		won := !slices.ContainsFunc(winners, func(w *reservation.LotteryApplicationWithSpot) bool {
+			return w.AuthorDiscordID == application.AuthorDiscordID && w.DrawAt.Equal(application.DrawAt) && w.Spot.SameRespawn(application.Spot)
+		})
+		if won {
+			won, err = a.canWinLottery(p, guild, member, tier, application)
+			if err != nil {
+				return drawn, err
+			}
+		}
+
+		_, err = a.reservationRepo.ResolveLotteryApplication(context.Background(), &application.LotteryApplication, won, p.ReservationPriority(tier))
+		if err != nil {
+			return drawn, fmt.Errorf("could not resolve lottery application: %w", err)
+		}
+
+		if won {
+			winners = append(winners, application)
+		}
+		application.Won = &won
+		drawn = append(drawn, application)
+	}
+
+	return drawn, nil
+}
+
+// Returns true if the application time range is still free, and booking it would not exceed
+// maximum reservations time of its author tier, nor weekly quotas.
+func (a *Adapter) canWinLottery(p *policy.Policy, guild *discord.Guild, member *discord.Member, tier policy.Tier, application *reservation.LotteryApplicationWithSpot) (bool, error) {
+	conflicts, err := a.reservationRepo.SelectOverlappingReservations(context.Background(), application.Spot.ID, application.StartAt, application.EndAt, guild.ID)
+	if err != nil {
+		return false, fmt.Errorf("could not select overlapping reservations: %w", err)
+	}
+
+	if len(conflicts) > 0 {
+		return false, nil
+	}
+
+	exceeds, err := a.exceedsMaximumReservationsTime(p, tier.MaximumReservationsTime, guild, member, application.Spot, application.StartAt, application.EndAt)
+	if err != nil {
+		return false, err
+	}
+
+	if exceeds {
+		return false, nil
+	}
+
+	err = a.checkWeeklyQuotas(p, guild, member, application.Spot, application.StartAt, application.EndAt)
+	if err != nil {
+		a.log.WithFields(logrus.Fields{"application.ID": application.ID}).Infof("lottery application cannot win: %s", err)
+
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// Returns an error if the spot is handed out by lottery for startAt, and lots have not been drawn yet as of currTime.
+func (a *Adapter) checkLottery(p *policy.Policy, guild *discord.Guild, s reservation.Spot, currTime time.Time, startAt time.Time) error {
+	if len(p.Lotteries) == 0 {
+		return nil
+	}
+
+	spots, err := a.spotRepo.SelectAllSpots(context.Background(), guild.ID)
+	if err != nil {
+		return fmt.Errorf("could not fetch spots: %w", err)
+	}
+
+	l := findLottery(a.resolveLotteries(p, guild, spots), s.ID, s.ParentID)
+	if l == nil {
+		return nil
+	}
+
+	drawAt := l.DrawTime(p.Location(), startAt)
+	if currTime.Before(drawAt) {
+		return fmt.Errorf("%s starting %s is handed out by lottery drawn %s, apply for it with /apply instead", s.Name, stringsHelper.FormatLongTime(startAt.In(p.Location())), stringsHelper.FormatLongTime(drawAt.In(p.Location())))
+	}
+
+	return nil
+}
+
+// Finds spots of guild lotteries. Lotteries of spots that no longer exist are skipped.
+func (a *Adapter) resolveLotteries(p *policy.Policy, guild *discord.Guild, spots []*spot.Spot) []resolvedLottery {
+	lotteries := make([]resolvedLottery, 0, len(p.Lotteries))
+	for _, l := range p.Lotteries {
+		s, _ := collections.PoorMansFind(spots, func(s *spot.Spot) bool {
+			return spotIsCalled(s, l.Spot)
+		})
+		if s == nil {
+			a.log.WithFields(logrus.Fields{"guild": guild.ID, "spot": l.Spot}).Warn("lottery of unknown spot")
+			continue
+		}
+
+		lotteries = append(lotteries, resolvedLottery{Lottery: l, spot: s})
+	}
+
+	return lotteries
+}
+
+// Returns lottery of the spot of a given ID, falling back to the one of the respawn it is a floor
+// or side of, or nil if there is none.
+func findLottery(lotteries []resolvedLottery, spotID int64, parentID int64) *resolvedLottery {
+	return findSpotRule(lotteries, func(l resolvedLottery) *spot.Spot {
+		return l.spot
+	}, spotID, parentID)
+}
@@ -0,0 +1,222 @@
+package booking
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"spot-assistant/internal/common/test/mocks"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/policy"
+	"spot-assistant/internal/core/dto/reservation"
+	"spot-assistant/internal/core/dto/spot"
+)
+
+func newLotteryPolicyRepo(guildId string) (*mocks.MockPolicyRepo, *policy.Policy) {
+	p := newTestPolicy(guildId)
+	p.Lotteries = []policy.Lottery{{Spot: "soul war", DrawAt: 18 * time.Hour, Weighted: true}}
+	policyRepo := new(mocks.MockPolicyRepo)
+	policyRepo.On("FindGuildPolicy", mocks.ContextMock, guildId).Return(p, nil)
+
+	return policyRepo, p
+}
+
+func TestApplyForFloorOfLotteryRespawn(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	member := &discord.Member{ID: "test-member"}
+	policyRepo, p := newLotteryPolicyRepo(guild.ID)
+	respawn := &spot.Spot{ID: 1, Name: "Soul War"}
+	floor := &spot.Spot{ID: 2, Name: "Soul War -1", ParentID: respawn.ID}
+	startAt := time.Now().Add(72 * time.Hour).Truncate(time.Minute)
+	endAt := startAt.Add(2 * time.Hour)
+	local := startAt.In(p.Location())
+	drawAt := time.Date(local.Year(), local.Month(), local.Day()-1, 18, 0, 0, 0, p.Location())
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{respawn, floor}, nil)
+	reservationRepo := new(mocks.MockReservationRepo)
+	reservationRepo.On("SelectPendingLotteryApplicationsWithSpots", mocks.ContextMock, guild.ID).Return([]*reservation.LotteryApplicationWithSpot{}, nil)
+	reservationRepo.On("CreateLotteryApplication", mocks.ContextMock, member, guild, floor.ID, startAt, endAt, drawAt).Return(&reservation.LotteryApplication{
+		ID: 1, AuthorDiscordID: member.ID, SpotID: floor.ID, StartAt: startAt, EndAt: endAt, DrawAt: drawAt,
+	}, nil)
+	adapter := NewAdapter(spotRepo, reservationRepo, policyRepo)
+
+	// when
+	res, err := adapter.Apply(member, guild, floor.Name, startAt, endAt)
+
+	// assert
+	assert.Nil(err)
+	assert.Equal(drawAt, res.DrawAt)
+	assert.Equal(floor.Name, res.Spot.Name)
+	reservationRepo.AssertExpectations(t)
+}
+
+func TestBookFailsBeforeLotteryIsDrawn(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	member := &discord.Member{ID: "test-member"}
+	policyRepo, p := newLotteryPolicyRepo(guild.ID)
+	respawn := &spot.Spot{ID: 1, Name: "Soul War"}
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{respawn}, nil)
+	reservationRepo := new(mocks.MockReservationRepo)
+	adapter := NewAdapter(spotRepo, reservationRepo, policyRepo)
+	startAt := time.Now().Add(72 * time.Hour).Truncate(time.Minute)
+	local := startAt.In(p.Location())
+	drawAt := time.Date(local.Year(), local.Month(), local.Day()-1, 18, 0, 0, 0, p.Location())
+
+	// when
+	_, err := adapter.Book(member, guild, []*discord.Member{}, respawn.Name, startAt, startAt.Add(2*time.Hour), false, newTestTier(0), newTestRoleChecker())
+
+	// assert
+	// Discord timestamps are not rendered in error messages
+	assert.EqualError(err, fmt.Sprintf(
+		"Soul War starting %s is handed out by lottery drawn %s, apply for it with /apply instead",
+		local.Format("2006-01-02 15:04 MST"),
+		drawAt.Format("2006-01-02 15:04 MST"),
+	))
+	reservationRepo.AssertNotCalled(t, "CreateAndDeleteConflicting")
+}
+
+func TestDrawLotteriesHandsOutEachRespawnOncePerMember(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	policyRepo, _ := newLotteryPolicyRepo(guild.ID)
+	respawn := &spot.Spot{ID: 1, Name: "Soul War"}
+	floor := &spot.Spot{ID: 2, Name: "Soul War -1", ParentID: respawn.ID}
+	drawAt := time.Now().Add(-time.Minute)
+	startAt := drawAt.Add(12 * time.Hour)
+	newApplication := func(id int64, memberId string, s *spot.Spot) *reservation.LotteryApplicationWithSpot {
+		return &reservation.LotteryApplicationWithSpot{
+			LotteryApplication: reservation.LotteryApplication{
+				ID: id, AuthorDiscordID: memberId, SpotID: s.ID, StartAt: startAt, EndAt: startAt.Add(2 * time.Hour), DrawAt: drawAt,
+			},
+			Spot: reservation.Spot{ID: s.ID, Name: s.Name, ParentID: s.ParentID},
+		}
+	}
+	pending := []*reservation.LotteryApplicationWithSpot{
+		newApplication(1, "first-member", respawn),
+		newApplication(2, "first-member", floor),
+		newApplication(3, "second-member", floor),
+		// Not drawn yet
+		{
+			LotteryApplication: reservation.LotteryApplication{ID: 4, AuthorDiscordID: "third-member", DrawAt: drawAt.Add(time.Hour)},
+			Spot:               reservation.Spot{ID: respawn.ID, Name: respawn.Name},
+		},
+	}
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{respawn, floor}, nil)
+	reservationRepo := new(mocks.MockReservationRepo)
+	reservationRepo.On("DeleteLotteryApplicationsDrawnBefore", mocks.ContextMock, guild.ID, mock.Anything).Return(nil)
+	reservationRepo.On("SelectPendingLotteryApplicationsWithSpots", mocks.ContextMock, guild.ID).Return(pending, nil)
+	reservationRepo.On("CountLotteryWins", mocks.ContextMock, guild.ID, mock.Anything).Return(map[string]int{"second-member": 2}, nil)
+	// Each spot is free until it is won once
+	reservationRepo.On("SelectOverlappingReservations", mocks.ContextMock, respawn.ID, mock.Anything, mock.Anything, guild.ID).Return([]*reservation.Reservation{}, nil)
+	reservationRepo.On("SelectOverlappingReservations", mocks.ContextMock, floor.ID, mock.Anything, mock.Anything, guild.ID).Return([]*reservation.Reservation{}, nil).Once()
+	reservationRepo.On("SelectOverlappingReservations", mocks.ContextMock, floor.ID, mock.Anything, mock.Anything, guild.ID).Return([]*reservation.Reservation{{ID: 10}}, nil)
+	reservationRepo.On("SelectUpcomingMemberReservationsWithSpots", mocks.ContextMock, guild, mock.Anything).Return([]*reservation.ReservationWithSpot{}, nil)
+	reservationRepo.On("ResolveLotteryApplication", mocks.ContextMock, mock.Anything, true, 0).Return(&reservation.Reservation{}, nil)
+	reservationRepo.On("ResolveLotteryApplication", mocks.ContextMock, mock.Anything, false, 0).Return((*reservation.Reservation)(nil), nil)
+	adapter := NewAdapter(spotRepo, reservationRepo, policyRepo)
+
+	// when
+	res, err := adapter.DrawLotteries(guild, newTestRoleChecker())
+
+	// assert
+	assert.Nil(err)
+	assert.Len(res, 3)
+	wins := map[string]int{}
+	for _, application := range res {
+		assert.NotEqual(int64(4), application.ID)
+		if *application.Won {
+			wins[application.AuthorDiscordID]++
+		}
+	}
+	// Whichever application of the floor is drawn first takes it
+	assert.Equal(1, wins["first-member"])
+	assert.LessOrEqual(wins["second-member"], 1)
+	reservationRepo.AssertNumberOfCalls(t, "ResolveLotteryApplication", 3)
+}
+
+func TestMaterializeSeriesPostponesOccurrencesBeforeLotteryIsDrawn(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	policyRepo, p := newLotteryPolicyRepo(guild.ID)
+	respawn := &spot.Spot{ID: 1, Name: "Soul War"}
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{respawn}, nil)
+	series := &reservation.SeriesWithSpot{
+		Series: reservation.Series{
+			ID:              1,
+			AuthorDiscordID: "test-member-id",
+			Weekdays:        []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday},
+			StartTime:       20 * time.Hour,
+			EndTime:         22 * time.Hour,
+		},
+		Spot: reservation.Spot{ID: respawn.ID, Name: respawn.Name},
+	}
+	// Occurrences are booked up to the first one, which lots have not been drawn for yet
+	tNow := time.Now().In(p.Location())
+	drawn := 0
+	var postponedAt time.Time
+	for day := 0; postponedAt.IsZero(); day++ {
+		startAt := time.Date(tNow.Year(), tNow.Month(), tNow.Day()+day, 20, 0, 0, 0, p.Location())
+		switch {
+		case !startAt.After(tNow):
+			continue
+		case p.Lotteries[0].DrawTime(p.Location(), startAt).After(tNow):
+			postponedAt = startAt
+		default:
+			drawn++
+		}
+	}
+	reservationRepo := new(mocks.MockReservationRepo)
+	reservationRepo.On("SelectSeriesToMaterialize", mocks.ContextMock, guild.ID, mock.Anything).Return([]*reservation.SeriesWithSpot{series}, nil)
+	reservationRepo.On("SelectOverlappingReservations", mocks.ContextMock, respawn.ID, mock.Anything, mock.Anything, guild.ID).Return([]*reservation.Reservation{}, nil)
+	reservationRepo.On("SelectUpcomingMemberReservationsWithSpots", mocks.ContextMock, guild, mock.Anything).Return([]*reservation.ReservationWithSpot{}, nil)
+	reservationRepo.On("CreateSeriesReservation", mocks.ContextMock, &series.Series, mock.Anything, mock.Anything, 0).Return(&reservation.Reservation{}, nil)
+	reservationRepo.On("UpdateSeriesMaterializedUntil", mocks.ContextMock, series.Series.ID, postponedAt.Add(-time.Second)).Return(nil)
+	defer reservationRepo.AssertExpectations(t)
+	adapter := NewAdapter(spotRepo, reservationRepo, policyRepo)
+
+	// when
+	res, err := adapter.MaterializeSeries(guild, newTestRoleChecker())
+
+	// assert
+	assert.Nil(err)
+	assert.Len(res, drawn)
+}
+
+func TestProcessQueueLeavesEntriesBeforeLotteryIsDrawn(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	policyRepo, _ := newLotteryPolicyRepo(guild.ID)
+	respawn := &spot.Spot{ID: 1, Name: "Soul War"}
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{respawn}, nil)
+	startAt := time.Now().Add(72 * time.Hour)
+	entry := &reservation.QueueEntryWithSpot{
+		QueueEntry: reservation.QueueEntry{ID: 1, AuthorDiscordID: "test-member-id", StartAt: startAt, EndAt: startAt.Add(2 * time.Hour)},
+		Spot:       reservation.Spot{ID: respawn.ID, Name: respawn.Name},
+	}
+	reservationRepo := new(mocks.MockReservationRepo)
+	reservationRepo.On("DeleteExpiredQueueEntries", mocks.ContextMock, guild.ID).Return(nil)
+	reservationRepo.On("SelectQueueEntriesWithSpots", mocks.ContextMock, guild.ID).Return([]*reservation.QueueEntryWithSpot{entry}, nil)
+	adapter := NewAdapter(spotRepo, reservationRepo, policyRepo)
+
+	// when
+	res, err := adapter.ProcessQueue(guild, newTestRoleChecker())
+
+	// assert
+	assert.Nil(err)
+	assert.Empty(res)
+	reservationRepo.AssertNotCalled(t, "CreateReservationFromQueueEntry", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,140 @@
+package booking
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	stringsHelper "spot-assistant/internal/common/strings"
+	"spot-assistant/internal/core/dto/spot"
+)
+
+// Ranks of a spot matching a filter, from the best one.
+const (
+	rankPrefix = iota
+	rankWordStart
+	rankSubstring
+	rankDetails
+	// Edit distance between filter and the closest word is added to this one
+	rankTypo
+
+	rankNone = -1
+)
+
+// Returns spots matching filter, ordered from the best match. Spots matching equally well
+// keep their original order.
+func rankSpots(spots []*spot.Spot, filter string) []*spot.Spot {
+	type rankedSpot struct {
+		spot *spot.Spot
+		rank int
+	}
+
+	ranked := make([]rankedSpot, 0, len(spots))
+	for _, s := range spots {
+		rank := rankSpot(s, filter)
+		if rank != rankNone {
+			ranked = append(ranked, rankedSpot{spot: s, rank: rank})
+		}
+	}
+
+	slices.SortStableFunc(ranked, func(a rankedSpot, b rankedSpot) int {
+		return a.rank - b.rank
+	})
+
+	res := make([]*spot.Spot, len(ranked))
+	for i, r := range ranked {
+		res[i] = r.spot
+	}
+
+	return res
+}
+
+// Returns how well filter matches spot name or one of its aliases, the lower the better,
+// or rankNone if it does not match at all. Prefix matches beat matches at the start
+// of a word, which beat plain substrings, then spot details and finally typos.
+func rankSpot(s *spot.Spot, filter string) int {
+	filter = strings.ToLower(strings.TrimSpace(filter))
+	if len(filter) == 0 {
+		return rankPrefix
+	}
+
+	names := append([]string{s.Name}, s.Aliases...)
+	best := rankNone
+	for _, name := range names {
+		rank := rankName(strings.ToLower(name), filter)
+		if rank != rankNone && (best == rankNone || rank < best) {
+			best = rank
+		}
+	}
+
+	if best != rankNone && best < rankDetails {
+		return best
+	}
+
+	if spotMatchesFilter(s, filter) {
+		return rankDetails
+	}
+
+	return best
+}
+
+func rankName(name string, filter string) int {
+	if strings.HasPrefix(name, filter) {
+		return rankPrefix
+	}
+
+	for i, r := range name {
+		if i > 0 && isWordStart(name, i, r) && strings.HasPrefix(name[i:], filter) {
+			return rankWordStart
+		}
+	}
+
+	if strings.Contains(name, filter) {
+		return rankSubstring
+	}
+
+	allowedTypos := maxTypos(filter)
+	if allowedTypos == 0 {
+		return rankNone
+	}
+
+	filterLength := utf8.RuneCountInString(filter)
+	distance := allowedTypos + 1
+	for _, word := range append(strings.FieldsFunc(name, isSeparator), name) {
+		// Compare against the beginning of the word only, so that unfinished words match as well
+		runes := []rune(word)
+		if len(runes) > filterLength+allowedTypos {
+			runes = runes[:filterLength]
+		}
+		distance = min(distance, stringsHelper.EditDistance(string(runes), filter))
+	}
+
+	if distance > allowedTypos {
+		return rankNone
+	}
+
+	return rankTypo + distance
+}
+
+// Returns how many typos are tolerated in filter, the longer it is the more.
+func maxTypos(filter string) int {
+	switch length := utf8.RuneCountInString(filter); {
+	case length >= 8:
+		return 2
+	case length >= 4:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func isWordStart(name string, i int, r rune) bool {
+	prev, _ := utf8.DecodeLastRuneInString(name[:i])
+
+	return isSeparator(prev) && !isSeparator(r)
+}
+
+func isSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
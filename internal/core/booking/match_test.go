@@ -0,0 +1,51 @@
+package booking
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"spot-assistant/internal/common/collections"
+	"spot-assistant/internal/core/dto/spot"
+)
+
+func TestRankSpots(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	spots := []*spot.Spot{
+		{Name: "Flimsy Lost Souls"},
+		{Name: "Palace of Asura"},
+		{Name: "Asura Mirror"},
+		{Name: "Secret Library (FIRE)", Aliases: []string{"libby"}},
+		{Name: "Gnomprona Asura Vaults"},
+	}
+	names := func(spots []*spot.Spot) []string {
+		return collections.PoorMansMap(spots, func(s *spot.Spot) string {
+			return s.Name
+		})
+	}
+
+	// when
+	byPrefixAndWordStart := rankSpots(spots, "asura")
+	byAlias := rankSpots(spots, "libby")
+	bySubstring := rankSpots(spots, "ibrar")
+	byTypo := rankSpots(spots, "asrua")
+
+	// assert
+	assert.Equal([]string{"Asura Mirror", "Palace of Asura", "Gnomprona Asura Vaults"}, names(byPrefixAndWordStart))
+	assert.Equal([]string{"Secret Library (FIRE)"}, names(byAlias))
+	assert.Equal([]string{"Secret Library (FIRE)"}, names(bySubstring))
+	assert.Equal([]string{"Palace of Asura", "Asura Mirror", "Gnomprona Asura Vaults"}, names(byTypo))
+}
+
+func TestRankSpotsIgnoresTyposInShortFilters(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	spots := []*spot.Spot{{Name: "Cobra Bastion"}, {Name: "Oramond West"}}
+
+	// when
+	res := rankSpots(spots, "orb")
+
+	// assert
+	assert.Empty(res)
+}
@@ -0,0 +1,154 @@
+package booking
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"spot-assistant/internal/common/collections"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/policy"
+	"spot-assistant/internal/core/dto/reservation"
+)
+
+// Runs the same checks as an overbooking Book, but instead of clipping or removing conflicting
+// reservations, asks their authors for approval. Authors who have not shown up, e.g. no-shows
+// who did not check in on time, are not asked. Returns nil request if nobody has to approve the overbook, in which case
+// it can be booked right away.
+func (a *Adapter) RequestOverbook(member *discord.Member, guild *discord.Guild, party []*discord.Member, spotName string, startAt time.Time, endAt time.Time, tier policy.Tier, hasRole policy.RoleChecker) (*reservation.OverbookRequestWithSpot, []*reservation.ClippedOrRemovedReservation, error) {
+	a.log.WithFields(logrus.Fields{
+		"member":  member,
+		"party":   party,
+		"tier":    tier,
+		"startAt": startAt,
+		"endAt":   endAt,
+	}).Info("overbook request")
+
+	p, err := a.GetPolicy(guild)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	startAt, endAt, err = p.ClipToBlackouts(startAt, endAt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	spot, err := a.findBookableSpot(guild, spotName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	requestedSpot := reservation.Spot{ID: spot.ID, Name: spot.Name, ParentID: spot.ParentID}
+	conflictingReservations, rejected, err := a.checkBooking(p, member, guild, requestedSpot, startAt, endAt, true, tier)
+	if err != nil {
+		return nil, rejected, err
+	}
+
+	for _, partyMember := range party {
+		err = a.checkPartyMember(p, hasRole, member, guild, partyMember, requestedSpot, startAt, endAt)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	currTime := time.Now()
+	affected := collections.PoorMansFilter(conflictingReservations, func(r *reservation.Reservation) bool {
+		return !canBeTakenOver(p, conflictingReservations, r, currTime)
+	})
+	if len(affected) == 0 {
+		return nil, nil, nil
+	}
+
+	request, err := a.reservationRepo.CreateOverbookRequest(context.Background(), member, guild, party, spot.ID, startAt, endAt, currTime.Add(p.OverbookApprovalTimeout), affected)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not create the overbook request: %w", err)
+	}
+
+	return &reservation.OverbookRequestWithSpot{OverbookRequest: *request, Spot: requestedSpot}, nil, nil
+}
+
+// Books an approved overbook request on behalf of its author. Only reservations the request has been
+// approved for can be overbooked, as well as ones of authors who have not shown up, which need no
+// approval. If other reservations have been made over its time range since it was requested, it has
+// to be requested again.
+func (a *Adapter) BookOverbookRequest(member *discord.Member, guild *discord.Guild, party []*discord.Member, request *reservation.OverbookRequestWithSpot, tier policy.Tier, hasRole policy.RoleChecker) ([]*reservation.ClippedOrRemovedReservation, error) {
+	return a.book(member, guild, party, request.Spot.Name, request.StartAt, request.EndAt, true, tier, hasRole, func(p *policy.Policy, conflicts []*reservation.Reservation) error {
+		currTime := time.Now()
+		for _, r := range conflicts {
+			approved := slices.ContainsFunc(request.Approvals, func(approval *reservation.OverbookApproval) bool {
+				return approval.ReservationID == r.ID
+			})
+			if !approved && !canBeTakenOver(p, conflicts, r, currTime) {
+				return errors.New("the respawn has been booked by someone else in the meantime, request the overbook again")
+			}
+		}
+
+		return nil
+	})
+}
+
+// Records member answer to an overbook request. Returns the request along with true, once
+// it has been declined by anyone or accepted by everyone, so that it can be carried out.
+func (a *Adapter) AnswerOverbookRequest(guild *discord.Guild, member *discord.Member, requestId int64, accepted bool) (*reservation.OverbookRequestWithSpot, bool, error) {
+	a.log.WithFields(logrus.Fields{
+		"member":    member,
+		"requestId": requestId,
+		"accepted":  accepted,
+	}).Info("overbook answer")
+
+	ok, err := a.reservationRepo.AnswerOverbookRequest(context.Background(), guild.ID, requestId, member.ID, accepted)
+	if err != nil {
+		return nil, false, fmt.Errorf("could not answer the overbook request: %w", err)
+	}
+
+	if !ok {
+		return nil, false, errors.New("this overbook request has already been resolved, or does not wait for your answer")
+	}
+
+	request, err := a.reservationRepo.FindOverbookRequestWithSpot(context.Background(), guild.ID, requestId)
+	if err != nil {
+		return nil, false, fmt.Errorf("could not find the overbook request: %w", err)
+	}
+
+	if !request.Declined() && !request.Accepted() {
+		return request, false, nil
+	}
+
+	// Only one of the last answers gets to resolve the request
+	resolved, err := a.reservationRepo.DeleteOverbookRequest(context.Background(), requestId)
+	if err != nil {
+		return nil, false, fmt.Errorf("could not resolve the overbook request: %w", err)
+	}
+
+	return request, resolved, nil
+}
+
+// Resolves guild overbook requests, which have not been answered in time. Such requests count
+// as approved, as nobody declined them. Each request is returned at most once, even if resolving
+// runs concurrently.
+func (a *Adapter) ResolveExpiredOverbookRequests(guild *discord.Guild) ([]*reservation.OverbookRequestWithSpot, error) {
+	resolved := make([]*reservation.OverbookRequestWithSpot, 0)
+
+	requests, err := a.reservationRepo.SelectExpiredOverbookRequestsWithSpots(context.Background(), guild.ID)
+	if err != nil {
+		return resolved, fmt.Errorf("could not select expired overbook requests: %w", err)
+	}
+
+	for _, request := range requests {
+		ok, err := a.reservationRepo.DeleteOverbookRequest(context.Background(), request.OverbookRequest.ID)
+		if err != nil {
+			return resolved, fmt.Errorf("could not resolve overbook request: %w", err)
+		}
+
+		if ok {
+			resolved = append(resolved, request)
+		}
+	}
+
+	return resolved, nil
+}
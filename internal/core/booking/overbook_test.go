@@ -0,0 +1,199 @@
+package booking
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"spot-assistant/internal/common/test/mocks"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/reservation"
+	"spot-assistant/internal/core/dto/spot"
+)
+
+func TestRequestOverbookAsksAttendedReservationsOnly(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	member := &discord.Member{ID: "test-member"}
+	startAt := time.Now().Add(-1 * time.Hour)
+	endAt := startAt.Add(2 * time.Hour)
+	spotInput := &spot.Spot{ID: 1, Name: "test-spot"}
+	attended := &reservation.Reservation{ID: 1, AuthorDiscordID: "attended", StartAt: startAt, EndAt: startAt.Add(30 * time.Minute), CheckedInAt: startAt}
+	noShow := &reservation.Reservation{ID: 2, AuthorDiscordID: "no-show", StartAt: startAt.Add(30 * time.Minute), EndAt: endAt, CheckInRemindedAt: startAt.Add(30 * time.Minute)}
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{spotInput}, nil)
+	reservationRepo := new(mocks.MockReservationRepo)
+	reservationRepo.On("SelectOverlappingReservations", mocks.ContextMock, spotInput.ID, startAt, endAt, guild.ID).Return([]*reservation.Reservation{attended, noShow}, nil)
+	reservationRepo.On("SelectUpcomingMemberReservationsWithSpots", mocks.ContextMock, guild, member).Return([]*reservation.ReservationWithSpot{}, nil)
+	reservationRepo.On("CreateOverbookRequest", mocks.ContextMock, member, guild, []*discord.Member{}, spotInput.ID, startAt, endAt, mock.AnythingOfType("time.Time"), []*reservation.Reservation{attended}).Return(&reservation.OverbookRequest{
+		ID:        5,
+		Approvals: []*reservation.OverbookApproval{{ReservationID: attended.ID, MemberDiscordID: attended.AuthorDiscordID}},
+	}, nil)
+	defer reservationRepo.AssertExpectations(t)
+	adapter := NewAdapter(spotRepo, reservationRepo, newPolicyRepo())
+
+	// when
+	res, rejected, err := adapter.RequestOverbook(member, guild, []*discord.Member{}, spotInput.Name, startAt, endAt, newTestTier(1), newTestRoleChecker())
+
+	// assert
+	assert.Nil(err)
+	assert.Empty(rejected)
+	assert.Equal(int64(5), res.OverbookRequest.ID)
+	assert.Equal([]string{"attended"}, res.ApproverDiscordIDs())
+}
+
+func TestRequestOverbookSkipsApprovalOfNoShows(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	member := &discord.Member{ID: "test-member"}
+	startAt := time.Now().Add(-1 * time.Hour)
+	endAt := startAt.Add(2 * time.Hour)
+	spotInput := &spot.Spot{ID: 1, Name: "test-spot"}
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{spotInput}, nil)
+	reservationRepo := new(mocks.MockReservationRepo)
+	reservationRepo.On("SelectOverlappingReservations", mocks.ContextMock, spotInput.ID, startAt, endAt, guild.ID).Return([]*reservation.Reservation{
+		{ID: 2, AuthorDiscordID: "no-show", StartAt: startAt, EndAt: endAt, CheckInRemindedAt: startAt},
+	}, nil)
+	reservationRepo.On("SelectUpcomingMemberReservationsWithSpots", mocks.ContextMock, guild, member).Return([]*reservation.ReservationWithSpot{}, nil)
+	adapter := NewAdapter(spotRepo, reservationRepo, newPolicyRepo())
+
+	// when
+	res, _, err := adapter.RequestOverbook(member, guild, []*discord.Member{}, spotInput.Name, startAt, endAt, newTestTier(0), newTestRoleChecker())
+
+	// assert
+	assert.Nil(err)
+	assert.Nil(res)
+	reservationRepo.AssertNotCalled(t, "CreateOverbookRequest")
+}
+
+func TestBookOverbookRequestOverbooksApprovedReservationsAndNoShows(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	member := &discord.Member{ID: "test-member"}
+	startAt := time.Now().Add(-1 * time.Hour)
+	endAt := startAt.Add(2 * time.Hour)
+	spotInput := &spot.Spot{ID: 1, Name: "test-spot"}
+	attended := &reservation.Reservation{ID: 1, AuthorDiscordID: "attended", StartAt: startAt, EndAt: startAt.Add(30 * time.Minute), CheckedInAt: startAt}
+	noShow := &reservation.Reservation{ID: 2, AuthorDiscordID: "no-show", StartAt: startAt.Add(30 * time.Minute), EndAt: endAt, CheckInRemindedAt: startAt.Add(30 * time.Minute)}
+	request := &reservation.OverbookRequestWithSpot{
+		OverbookRequest: reservation.OverbookRequest{
+			ID: 5, AuthorDiscordID: member.ID, StartAt: startAt, EndAt: endAt,
+			Approvals: []*reservation.OverbookApproval{{ReservationID: attended.ID, MemberDiscordID: attended.AuthorDiscordID}},
+		},
+		Spot: reservation.Spot{ID: spotInput.ID, Name: spotInput.Name},
+	}
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{spotInput}, nil)
+	reservationRepo := new(mocks.MockReservationRepo)
+	reservationRepo.On("SelectOverlappingReservations", mocks.ContextMock, spotInput.ID, startAt, endAt, guild.ID).Return([]*reservation.Reservation{attended, noShow}, nil)
+	reservationRepo.On("SelectUpcomingMemberReservationsWithSpots", mocks.ContextMock, guild, member).Return([]*reservation.ReservationWithSpot{}, nil)
+	reservationRepo.On("CreateAndDeleteConflicting", mocks.ContextMock, member, guild, []*discord.Member{}, []*reservation.Reservation{attended, noShow}, spotInput.ID, startAt, endAt, 0).Return([]*reservation.ClippedOrRemovedReservation{}, nil)
+	defer reservationRepo.AssertExpectations(t)
+	adapter := NewAdapter(spotRepo, reservationRepo, newPolicyRepo())
+
+	// when
+	_, err := adapter.BookOverbookRequest(member, guild, []*discord.Member{}, request, newTestTier(1), newTestRoleChecker())
+
+	// assert
+	assert.Nil(err)
+}
+
+func TestBookOverbookRequestFailsOnNewConflicts(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	member := &discord.Member{ID: "test-member"}
+	startAt := time.Now().Add(1 * time.Hour)
+	endAt := startAt.Add(2 * time.Hour)
+	spotInput := &spot.Spot{ID: 1, Name: "test-spot"}
+	approved := &reservation.Reservation{ID: 1, AuthorDiscordID: "approved", StartAt: startAt, EndAt: startAt.Add(30 * time.Minute)}
+	booked := &reservation.Reservation{ID: 3, AuthorDiscordID: "booked-in-the-meantime", StartAt: startAt.Add(30 * time.Minute), EndAt: endAt}
+	request := &reservation.OverbookRequestWithSpot{
+		OverbookRequest: reservation.OverbookRequest{
+			ID: 5, AuthorDiscordID: member.ID, StartAt: startAt, EndAt: endAt,
+			Approvals: []*reservation.OverbookApproval{{ReservationID: approved.ID, MemberDiscordID: approved.AuthorDiscordID}},
+		},
+		Spot: reservation.Spot{ID: spotInput.ID, Name: spotInput.Name},
+	}
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{spotInput}, nil)
+	reservationRepo := new(mocks.MockReservationRepo)
+	reservationRepo.On("SelectOverlappingReservations", mocks.ContextMock, spotInput.ID, startAt, endAt, guild.ID).Return([]*reservation.Reservation{approved, booked}, nil)
+	reservationRepo.On("SelectUpcomingMemberReservationsWithSpots", mocks.ContextMock, guild, member).Return([]*reservation.ReservationWithSpot{}, nil)
+	adapter := NewAdapter(spotRepo, reservationRepo, newPolicyRepo())
+
+	// when
+	_, err := adapter.BookOverbookRequest(member, guild, []*discord.Member{}, request, newTestTier(1), newTestRoleChecker())
+
+	// assert
+	assert.ErrorContains(err, "request the overbook again")
+	reservationRepo.AssertNotCalled(t, "CreateAndDeleteConflicting")
+}
+
+func TestAnswerOverbookRequest(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	accepted, declined := true, false
+	guild := &discord.Guild{ID: "test-guild-id"}
+	first := &discord.Member{ID: "first"}
+	second := &discord.Member{ID: "second"}
+	pending := &reservation.OverbookRequestWithSpot{OverbookRequest: reservation.OverbookRequest{ID: 1, Approvals: []*reservation.OverbookApproval{
+		{ReservationID: 1, MemberDiscordID: first.ID, Accepted: &accepted},
+		{ReservationID: 2, MemberDiscordID: second.ID},
+	}}}
+	rejected := &reservation.OverbookRequestWithSpot{OverbookRequest: reservation.OverbookRequest{ID: 2, Approvals: []*reservation.OverbookApproval{
+		{ReservationID: 3, MemberDiscordID: first.ID, Accepted: &accepted},
+		{ReservationID: 4, MemberDiscordID: second.ID, Accepted: &declined},
+	}}}
+	reservationRepo := new(mocks.MockReservationRepo)
+	reservationRepo.On("AnswerOverbookRequest", mocks.ContextMock, guild.ID, int64(1), first.ID, true).Return(true, nil)
+	reservationRepo.On("AnswerOverbookRequest", mocks.ContextMock, guild.ID, int64(2), second.ID, false).Return(true, nil)
+	reservationRepo.On("AnswerOverbookRequest", mocks.ContextMock, guild.ID, int64(3), first.ID, true).Return(false, nil)
+	reservationRepo.On("FindOverbookRequestWithSpot", mocks.ContextMock, guild.ID, int64(1)).Return(pending, nil)
+	reservationRepo.On("FindOverbookRequestWithSpot", mocks.ContextMock, guild.ID, int64(2)).Return(rejected, nil)
+	reservationRepo.On("DeleteOverbookRequest", mocks.ContextMock, int64(2)).Return(true, nil)
+	adapter := NewAdapter(new(mocks.MockSpotRepo), reservationRepo, newPolicyRepo())
+
+	// when
+	_, pendingResolved, pendingErr := adapter.AnswerOverbookRequest(guild, first, 1, true)
+	res, rejectedResolved, rejectedErr := adapter.AnswerOverbookRequest(guild, second, 2, false)
+	_, _, resolvedErr := adapter.AnswerOverbookRequest(guild, first, 3, true)
+
+	// assert
+	assert.Nil(pendingErr)
+	assert.False(pendingResolved)
+	assert.Nil(rejectedErr)
+	assert.True(rejectedResolved)
+	assert.True(res.Declined())
+	assert.ErrorContains(resolvedErr, "already been resolved")
+	reservationRepo.AssertNumberOfCalls(t, "DeleteOverbookRequest", 1)
+}
+
+func TestResolveExpiredOverbookRequestsSkipsClaimedOnes(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	requests := []*reservation.OverbookRequestWithSpot{
+		{OverbookRequest: reservation.OverbookRequest{ID: 1}},
+		{OverbookRequest: reservation.OverbookRequest{ID: 2}},
+	}
+	reservationRepo := new(mocks.MockReservationRepo)
+	reservationRepo.On("SelectExpiredOverbookRequestsWithSpots", mocks.ContextMock, guild.ID).Return(requests, nil)
+	reservationRepo.On("DeleteOverbookRequest", mocks.ContextMock, int64(1)).Return(true, nil)
+	// Resolved by the last answer or another tick in the meantime
+	reservationRepo.On("DeleteOverbookRequest", mocks.ContextMock, int64(2)).Return(false, nil)
+	adapter := NewAdapter(new(mocks.MockSpotRepo), reservationRepo, newPolicyRepo())
+
+	// when
+	res, err := adapter.ResolveExpiredOverbookRequests(guild)
+
+	// assert
+	assert.Nil(err)
+	assert.Equal([]*reservation.OverbookRequestWithSpot{requests[0]}, res)
+}
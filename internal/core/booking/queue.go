@@ -0,0 +1,189 @@
+package booking
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"spot-assistant/internal/common/collections"
+	stringsHelper "spot-assistant/internal/common/strings"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/policy"
+	"spot-assistant/internal/core/dto/reservation"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Puts member on a waitlist for a given spot and time range, clipped to guild blackouts. Only occupied
+// spots can be queued for.
+func (a *Adapter) Enqueue(member *discord.Member, guild *discord.Guild, spotName string, startAt time.Time, endAt time.Time) (*reservation.QueueEntryWithSpot, error) {
+	a.log.WithFields(logrus.Fields{
+		"member":  member,
+		"startAt": startAt,
+		"endAt":   endAt,
+	}).Info("queue request")
+
+	p, err := a.GetPolicy(guild)
+	if err != nil {
+		return nil, err
+	}
+
+	spot, err := a.findBookableSpot(guild, spotName)
+	if err != nil {
+		return nil, err
+	}
+
+	if endAt.Sub(startAt) > p.MaximumReservationTime {
+		return nil, fmt.Errorf("reservation cannot take more than %s", stringsHelper.FormatDuration(p.MaximumReservationTime))
+	}
+
+	err = checkBookingHorizon(p, time.Now(), startAt)
+	if err != nil {
+		return nil, err
+	}
+
+	startAt, endAt, err = p.ClipToBlackouts(startAt, endAt)
+	if err != nil {
+		return nil, err
+	}
+
+	// Queue would book lottery slots, which become free before the draw
+	err = a.checkLottery(p, guild, reservation.Spot{ID: spot.ID, Name: spot.Name, ParentID: spot.ParentID}, time.Now(), startAt)
+	if err != nil {
+		return nil, err
+	}
+
+	conflictingReservations, err := a.reservationRepo.SelectOverlappingReservations(context.Background(), spot.ID, startAt, endAt, guild.ID)
+	if err != nil {
+		return nil, fmt.Errorf("could not select overlapping reservations: %w", err)
+	}
+
+	if len(conflictingReservations) == 0 {
+		return nil, errors.New("the respawn is free at that time, you can book it right away")
+	}
+
+	authorsConflictingReservation, _ := collections.PoorMansFind(conflictingReservations, func(r *reservation.Reservation) bool {
+		return r.AuthorDiscordID == member.ID
+	})
+	if authorsConflictingReservation != nil {
+		return nil, errors.New("you already have a reservation overlapping with this time range")
+	}
+
+	entries, err := a.reservationRepo.SelectQueueEntriesWithSpots(context.Background(), guild.ID)
+	if err != nil {
+		return nil, fmt.Errorf("could not select queue entries: %w", err)
+	}
+
+	authorsQueueEntry, _ := collections.PoorMansFind(entries, func(e *reservation.QueueEntryWithSpot) bool {
+		return e.AuthorDiscordID == member.ID && e.Spot.ID == spot.ID &&
+			e.StartAt.Before(endAt) && e.EndAt.After(startAt)
+	})
+	if authorsQueueEntry != nil {
+		return nil, errors.New("you are already queued for this respawn within this time range")
+	}
+
+	entry, err := a.reservationRepo.CreateQueueEntry(context.Background(), member, guild, spot.ID, startAt, endAt)
+	if err != nil {
+		return nil, fmt.Errorf("could not queue: %w", err)
+	}
+
+	return &reservation.QueueEntryWithSpot{
+		QueueEntry: *entry,
+		Spot: reservation.Spot{
+			ID:       spot.ID,
+			Name:     spot.Name,
+			ParentID: spot.ParentID,
+		},
+	}, nil
+}
+
+// Books queue entries which time range became free, in the order they were queued in, with the priority
+// of the queued member tier, which is resolved with hasRole. Entries are clipped to guild blackouts, which
+// might have been added since they were queued. Entries crossing a blackout, exceeding maximum reservations
+// time of the tier or weekly quotas, which booking window has not opened yet, or which are handed out by
+// lottery not drawn yet, are left in the queue, as well as ones which could not be booked. Guild queue
+// is processed by one caller at a time. Returns entries which have been booked.
+func (a *Adapter) ProcessQueue(guild *discord.Guild, hasRole policy.RoleChecker) ([]*reservation.QueueEntryWithSpot, error) {
+	booked := make([]*reservation.QueueEntryWithSpot, 0)
+
+	mutex := a.queueLocks.Upsert(guild.ID, nil, func(exists bool, mutex *sync.Mutex, _ *sync.Mutex) *sync.Mutex {
+		if exists {
+			return mutex
+		}
+
+		return &sync.Mutex{}
+	})
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	p, err := a.GetPolicy(guild)
+	if err != nil {
+		return booked, err
+	}
+
+	err = a.reservationRepo.DeleteExpiredQueueEntries(context.Background(), guild.ID)
+	if err != nil {
+		return booked, fmt.Errorf("could not delete expired queue entries: %w", err)
+	}
+
+	entries, err := a.reservationRepo.SelectQueueEntriesWithSpots(context.Background(), guild.ID)
+	if err != nil {
+		return booked, fmt.Errorf("could not select queue entries: %w", err)
+	}
+
+	for _, entry := range entries {
+		err = a.checkBookingWindow(p, guild, entry.Spot, time.Now(), entry.StartAt)
+		if err == nil {
+			err = a.checkLottery(p, guild, entry.Spot, time.Now(), entry.StartAt)
+		}
+		if err != nil {
+			a.log.WithFields(logrus.Fields{"entry.ID": entry.QueueEntry.ID}).Infof("leaving queue entry in the queue: %s", err)
+			continue
+		}
+
+		entry.StartAt, entry.EndAt, err = p.ClipToBlackouts(entry.StartAt, entry.EndAt)
+		if err != nil {
+			a.log.WithFields(logrus.Fields{"entry.ID": entry.QueueEntry.ID}).Infof("leaving queue entry in the queue: %s", err)
+			continue
+		}
+
+		conflicts, err := a.reservationRepo.SelectOverlappingReservations(context.Background(), entry.Spot.ID, entry.StartAt, entry.EndAt, guild.ID)
+		if err != nil {
+			return booked, fmt.Errorf("could not select overlapping reservations: %w", err)
+		}
+
+		if len(conflicts) > 0 {
+			continue
+		}
+
+		member := &discord.Member{ID: entry.AuthorDiscordID, Nick: entry.Author}
+		tier := p.TierOf(hasRole, member)
+		exceeds, err := a.exceedsMaximumReservationsTime(p, tier.MaximumReservationsTime, guild, member, entry.Spot, entry.StartAt, entry.EndAt)
+		if err != nil {
+			return booked, err
+		}
+
+		if exceeds {
+			continue
+		}
+
+		err = a.checkWeeklyQuotas(p, guild, member, entry.Spot, entry.StartAt, entry.EndAt)
+		if err != nil {
+			a.log.WithFields(logrus.Fields{"entry.ID": entry.QueueEntry.ID}).Infof("leaving queue entry in the queue: %s", err)
+			continue
+		}
+
+		// A single entry failing to book must not keep the ones queued after it waiting
+		_, err = a.reservationRepo.CreateReservationFromQueueEntry(context.Background(), &entry.QueueEntry, p.ReservationPriority(tier))
+		if err != nil {
+			a.log.WithFields(logrus.Fields{"entry.ID": entry.QueueEntry.ID}).Errorf("could not book queue entry: %s", err)
+			continue
+		}
+
+		booked = append(booked, entry)
+	}
+
+	return booked, nil
+}
@@ -0,0 +1,320 @@
+package booking
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"spot-assistant/internal/common/test/mocks"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/policy"
+	"spot-assistant/internal/core/dto/reservation"
+	"spot-assistant/internal/core/dto/spot"
+)
+
+func TestEnqueue(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	member := &discord.Member{ID: "test-member-id"}
+	startAt := time.Now().Add(1 * time.Hour)
+	endAt := startAt.Add(2 * time.Hour)
+	spotInput := &spot.Spot{Name: "test-spot", ID: 1}
+	conflicts := []*reservation.Reservation{{ID: 1, AuthorDiscordID: "other-member-id", StartAt: startAt, EndAt: endAt}}
+	entry := &reservation.QueueEntry{ID: 1, SpotID: spotInput.ID, StartAt: startAt, EndAt: endAt}
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{spotInput}, nil)
+	reservationRepo := new(mocks.MockReservationRepo)
+	reservationRepo.On("SelectOverlappingReservations", mocks.ContextMock, spotInput.ID, startAt, endAt, guild.ID).Return(conflicts, nil)
+	reservationRepo.On("SelectQueueEntriesWithSpots", mocks.ContextMock, guild.ID).Return([]*reservation.QueueEntryWithSpot{}, nil)
+	reservationRepo.On("CreateQueueEntry", mocks.ContextMock, member, guild, spotInput.ID, startAt, endAt).Return(entry, nil)
+	defer reservationRepo.AssertExpectations(t)
+	adapter := NewAdapter(spotRepo, reservationRepo, newPolicyRepo())
+
+	// when
+	res, err := adapter.Enqueue(member, guild, spotInput.Name, startAt, endAt)
+
+	// assert
+	assert.Nil(err)
+	assert.Equal(*entry, res.QueueEntry)
+	assert.Equal(spotInput.Name, res.Spot.Name)
+}
+
+func TestEnqueueForFreeSpot(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	member := &discord.Member{ID: "test-member-id"}
+	startAt := time.Now().Add(1 * time.Hour)
+	endAt := startAt.Add(2 * time.Hour)
+	spotInput := &spot.Spot{Name: "test-spot", ID: 1}
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{spotInput}, nil)
+	reservationRepo := new(mocks.MockReservationRepo)
+	reservationRepo.On("SelectOverlappingReservations", mocks.ContextMock, spotInput.ID, startAt, endAt, guild.ID).Return([]*reservation.Reservation{}, nil)
+	adapter := NewAdapter(spotRepo, reservationRepo, newPolicyRepo())
+
+	// when
+	_, err := adapter.Enqueue(member, guild, spotInput.Name, startAt, endAt)
+
+	// assert
+	assert.NotNil(err)
+	reservationRepo.AssertNotCalled(t, "CreateQueueEntry", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestEnqueueTwice(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	member := &discord.Member{ID: "test-member-id"}
+	startAt := time.Now().Add(1 * time.Hour)
+	endAt := startAt.Add(2 * time.Hour)
+	spotInput := &spot.Spot{Name: "test-spot", ID: 1}
+	conflicts := []*reservation.Reservation{{ID: 1, AuthorDiscordID: "other-member-id", StartAt: startAt, EndAt: endAt}}
+	entries := []*reservation.QueueEntryWithSpot{
+		{
+			QueueEntry: reservation.QueueEntry{ID: 1, AuthorDiscordID: member.ID, StartAt: startAt.Add(1 * time.Hour), EndAt: endAt.Add(1 * time.Hour)},
+			Spot:       reservation.Spot{ID: spotInput.ID, Name: spotInput.Name},
+		},
+	}
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{spotInput}, nil)
+	reservationRepo := new(mocks.MockReservationRepo)
+	reservationRepo.On("SelectOverlappingReservations", mocks.ContextMock, spotInput.ID, startAt, endAt, guild.ID).Return(conflicts, nil)
+	reservationRepo.On("SelectQueueEntriesWithSpots", mocks.ContextMock, guild.ID).Return(entries, nil)
+	adapter := NewAdapter(spotRepo, reservationRepo, newPolicyRepo())
+
+	// when
+	_, err := adapter.Enqueue(member, guild, spotInput.Name, startAt, endAt)
+
+	// assert
+	assert.NotNil(err)
+	reservationRepo.AssertNotCalled(t, "CreateQueueEntry", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestProcessQueue(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	startAt := time.Now().Add(1 * time.Hour)
+	endAt := startAt.Add(2 * time.Hour)
+	freeEntry := &reservation.QueueEntryWithSpot{
+		QueueEntry: reservation.QueueEntry{ID: 1, AuthorDiscordID: "first-member-id", StartAt: startAt, EndAt: endAt},
+		Spot:       reservation.Spot{ID: 1, Name: "free-spot"},
+	}
+	occupiedEntry := &reservation.QueueEntryWithSpot{
+		QueueEntry: reservation.QueueEntry{ID: 2, AuthorDiscordID: "second-member-id", StartAt: startAt, EndAt: endAt},
+		Spot:       reservation.Spot{ID: 2, Name: "occupied-spot"},
+	}
+	reservationRepo := new(mocks.MockReservationRepo)
+	reservationRepo.On("DeleteExpiredQueueEntries", mocks.ContextMock, guild.ID).Return(nil)
+	reservationRepo.On("SelectQueueEntriesWithSpots", mocks.ContextMock, guild.ID).Return([]*reservation.QueueEntryWithSpot{freeEntry, occupiedEntry}, nil)
+	reservationRepo.On("SelectOverlappingReservations", mocks.ContextMock, freeEntry.Spot.ID, startAt, endAt, guild.ID).Return([]*reservation.Reservation{}, nil)
+	reservationRepo.On("SelectOverlappingReservations", mocks.ContextMock, occupiedEntry.Spot.ID, startAt, endAt, guild.ID).Return([]*reservation.Reservation{{ID: 3}}, nil)
+	reservationRepo.On("SelectUpcomingMemberReservationsWithSpots", mocks.ContextMock, guild, mock.Anything).Return([]*reservation.ReservationWithSpot{}, nil)
+	reservationRepo.On("CreateReservationFromQueueEntry", mocks.ContextMock, &freeEntry.QueueEntry, 0).Return(&reservation.Reservation{ID: 4}, nil)
+	defer reservationRepo.AssertExpectations(t)
+	adapter := NewAdapter(new(mocks.MockSpotRepo), reservationRepo, newPolicyRepo())
+
+	// when
+	res, err := adapter.ProcessQueue(guild, newTestRoleChecker())
+
+	// assert
+	assert.Nil(err)
+	assert.Equal([]*reservation.QueueEntryWithSpot{freeEntry}, res)
+}
+
+func TestProcessQueueContinuesAfterFailedEntry(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	startAt := time.Now().Add(1 * time.Hour)
+	endAt := startAt.Add(2 * time.Hour)
+	failingEntry := &reservation.QueueEntryWithSpot{
+		QueueEntry: reservation.QueueEntry{ID: 1, AuthorDiscordID: "first-member-id", StartAt: startAt, EndAt: endAt},
+		Spot:       reservation.Spot{ID: 1, Name: "first-spot"},
+	}
+	entry := &reservation.QueueEntryWithSpot{
+		QueueEntry: reservation.QueueEntry{ID: 2, AuthorDiscordID: "second-member-id", StartAt: startAt, EndAt: endAt},
+		Spot:       reservation.Spot{ID: 2, Name: "second-spot"},
+	}
+	reservationRepo := new(mocks.MockReservationRepo)
+	reservationRepo.On("DeleteExpiredQueueEntries", mocks.ContextMock, guild.ID).Return(nil)
+	reservationRepo.On("SelectQueueEntriesWithSpots", mocks.ContextMock, guild.ID).Return([]*reservation.QueueEntryWithSpot{failingEntry, entry}, nil)
+	reservationRepo.On("SelectOverlappingReservations", mocks.ContextMock, mock.Anything, startAt, endAt, guild.ID).Return([]*reservation.Reservation{}, nil)
+	reservationRepo.On("SelectUpcomingMemberReservationsWithSpots", mocks.ContextMock, guild, mock.Anything).Return([]*reservation.ReservationWithSpot{}, nil)
+	reservationRepo.On("CreateReservationFromQueueEntry", mocks.ContextMock, &failingEntry.QueueEntry, 0).Return((*reservation.Reservation)(nil), errors.New("test-error"))
+	reservationRepo.On("CreateReservationFromQueueEntry", mocks.ContextMock, &entry.QueueEntry, 0).Return(&reservation.Reservation{ID: 3}, nil)
+	defer reservationRepo.AssertExpectations(t)
+	adapter := NewAdapter(new(mocks.MockSpotRepo), reservationRepo, newPolicyRepo())
+
+	// when
+	res, err := adapter.ProcessQueue(guild, newTestRoleChecker())
+
+	// assert
+	assert.Nil(err)
+	assert.Equal([]*reservation.QueueEntryWithSpot{entry}, res)
+}
+
+func TestProcessQueueOneCallerAtATime(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	var inProgress, maxInProgress atomic.Int32
+	reservationRepo := new(mocks.MockReservationRepo)
+	reservationRepo.On("DeleteExpiredQueueEntries", mocks.ContextMock, guild.ID).Return(nil).Run(func(args mock.Arguments) {
+		n := inProgress.Add(1)
+		if n > maxInProgress.Load() {
+			maxInProgress.Store(n)
+		}
+		time.Sleep(10 * time.Millisecond)
+	})
+	reservationRepo.On("SelectQueueEntriesWithSpots", mocks.ContextMock, guild.ID).Return([]*reservation.QueueEntryWithSpot{}, nil).Run(func(args mock.Arguments) {
+		inProgress.Add(-1)
+	})
+	adapter := NewAdapter(new(mocks.MockSpotRepo), reservationRepo, newPolicyRepo())
+
+	// when
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = adapter.ProcessQueue(guild, newTestRoleChecker())
+		}()
+	}
+	wg.Wait()
+
+	// assert
+	assert.Equal(int32(1), maxInProgress.Load())
+}
+
+func TestProcessQueueBooksWithQueuedMemberTier(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	startAt := time.Now().Add(1 * time.Hour)
+	endAt := startAt.Add(2 * time.Hour)
+	entry := &reservation.QueueEntryWithSpot{
+		QueueEntry: reservation.QueueEntry{ID: 1, AuthorDiscordID: "test-member-id", StartAt: startAt, EndAt: endAt},
+		Spot:       reservation.Spot{ID: 1, Name: "free-spot"},
+	}
+	p := newTestPolicy(guild.ID)
+	p.Tiers = []policy.Tier{{Role: "Core", Priority: 2, MaximumReservationsTime: 6 * time.Hour}}
+	policyRepo := new(mocks.MockPolicyRepo)
+	policyRepo.On("FindGuildPolicy", mocks.ContextMock, guild.ID).Return(p, nil)
+	// Would exceed the guild maximum reservations time, but not the one of the member tier
+	memberReservations := []*reservation.ReservationWithSpot{
+		{
+			Reservation: reservation.Reservation{ID: 2, AuthorDiscordID: "test-member-id", StartAt: endAt, EndAt: endAt.Add(3 * time.Hour)},
+			Spot:        reservation.Spot{ID: 2, Name: "other-spot"},
+		},
+	}
+	reservationRepo := new(mocks.MockReservationRepo)
+	reservationRepo.On("DeleteExpiredQueueEntries", mocks.ContextMock, guild.ID).Return(nil)
+	reservationRepo.On("SelectQueueEntriesWithSpots", mocks.ContextMock, guild.ID).Return([]*reservation.QueueEntryWithSpot{entry}, nil)
+	reservationRepo.On("SelectOverlappingReservations", mocks.ContextMock, entry.Spot.ID, startAt, endAt, guild.ID).Return([]*reservation.Reservation{}, nil)
+	reservationRepo.On("SelectUpcomingMemberReservationsWithSpots", mocks.ContextMock, guild, mock.Anything).Return(memberReservations, nil)
+	reservationRepo.On("CreateReservationFromQueueEntry", mocks.ContextMock, &entry.QueueEntry, 2).Return(&reservation.Reservation{ID: 3, Priority: 2}, nil)
+	defer reservationRepo.AssertExpectations(t)
+	adapter := NewAdapter(new(mocks.MockSpotRepo), reservationRepo, policyRepo)
+
+	// when
+	res, err := adapter.ProcessQueue(guild, newTestRoleChecker("Core"))
+
+	// assert
+	assert.Nil(err)
+	assert.Equal([]*reservation.QueueEntryWithSpot{entry}, res)
+}
+
+func TestEnqueueWithinBlackout(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	member := &discord.Member{ID: "test-member-id"}
+	startAt := time.Now().Truncate(time.Hour).Add(2 * time.Hour)
+	endAt := startAt.Add(20 * time.Minute)
+	p := newTestPolicy(guild.ID)
+	p.Blackouts = []policy.Blackout{{Name: "Server save", Start: time.Duration(startAt.UTC().Hour()) * time.Hour, Length: 30 * time.Minute, TimeZone: "UTC"}}
+	policyRepo := new(mocks.MockPolicyRepo)
+	policyRepo.On("FindGuildPolicy", mocks.ContextMock, guild.ID).Return(p, nil)
+	spotInput := &spot.Spot{Name: "test-spot", ID: 1}
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{spotInput}, nil)
+	reservationRepo := new(mocks.MockReservationRepo)
+	adapter := NewAdapter(spotRepo, reservationRepo, policyRepo)
+
+	// when
+	_, err := adapter.Enqueue(member, guild, spotInput.Name, startAt, endAt)
+
+	// assert
+	assert.ErrorContains(err, "Server save")
+	reservationRepo.AssertNotCalled(t, "CreateQueueEntry", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestProcessQueueClipsEntriesToBlackouts(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	startAt := time.Now().Truncate(time.Hour).Add(2 * time.Hour)
+	endAt := startAt.Add(2 * time.Hour)
+	p := newTestPolicy(guild.ID)
+	// Added after the entry has been queued
+	p.Blackouts = []policy.Blackout{{Name: "Server save", Start: time.Duration(startAt.UTC().Hour()) * time.Hour, Length: 30 * time.Minute, TimeZone: "UTC"}}
+	policyRepo := new(mocks.MockPolicyRepo)
+	policyRepo.On("FindGuildPolicy", mocks.ContextMock, guild.ID).Return(p, nil)
+	entry := &reservation.QueueEntryWithSpot{
+		QueueEntry: reservation.QueueEntry{ID: 1, AuthorDiscordID: "test-member-id", StartAt: startAt, EndAt: endAt},
+		Spot:       reservation.Spot{ID: 1, Name: "free-spot"},
+	}
+	clippedStartAt := startAt.Add(30 * time.Minute)
+	reservationRepo := new(mocks.MockReservationRepo)
+	reservationRepo.On("DeleteExpiredQueueEntries", mocks.ContextMock, guild.ID).Return(nil)
+	reservationRepo.On("SelectQueueEntriesWithSpots", mocks.ContextMock, guild.ID).Return([]*reservation.QueueEntryWithSpot{entry}, nil)
+	reservationRepo.On("SelectOverlappingReservations", mocks.ContextMock, entry.Spot.ID, mock.MatchedBy(clippedStartAt.Equal), endAt, guild.ID).Return([]*reservation.Reservation{}, nil)
+	reservationRepo.On("SelectUpcomingMemberReservationsWithSpots", mocks.ContextMock, guild, mock.Anything).Return([]*reservation.ReservationWithSpot{}, nil)
+	reservationRepo.On("CreateReservationFromQueueEntry", mocks.ContextMock, mock.MatchedBy(func(e *reservation.QueueEntry) bool {
+		return e.StartAt.Equal(clippedStartAt) && e.EndAt.Equal(endAt)
+	}), 0).Return(&reservation.Reservation{ID: 2}, nil)
+	defer reservationRepo.AssertExpectations(t)
+	adapter := NewAdapter(new(mocks.MockSpotRepo), reservationRepo, policyRepo)
+
+	// when
+	res, err := adapter.ProcessQueue(guild, newTestRoleChecker())
+
+	// assert
+	assert.Nil(err)
+	assert.Len(res, 1)
+}
+
+func TestEnqueueByAlias(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	member := &discord.Member{ID: "test-member-id"}
+	startAt := time.Now().Add(1 * time.Hour)
+	endAt := startAt.Add(2 * time.Hour)
+	spotInput := &spot.Spot{Name: "Library", ID: 1, Aliases: []string{"lib"}}
+	conflicts := []*reservation.Reservation{{ID: 1, AuthorDiscordID: "other-member-id", StartAt: startAt, EndAt: endAt}}
+	entry := &reservation.QueueEntry{ID: 1, SpotID: spotInput.ID, StartAt: startAt, EndAt: endAt}
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{spotInput}, nil)
+	reservationRepo := new(mocks.MockReservationRepo)
+	reservationRepo.On("SelectOverlappingReservations", mocks.ContextMock, spotInput.ID, startAt, endAt, guild.ID).Return(conflicts, nil)
+	reservationRepo.On("SelectQueueEntriesWithSpots", mocks.ContextMock, guild.ID).Return([]*reservation.QueueEntryWithSpot{}, nil)
+	reservationRepo.On("CreateQueueEntry", mocks.ContextMock, member, guild, spotInput.ID, startAt, endAt).Return(entry, nil)
+	defer reservationRepo.AssertExpectations(t)
+	adapter := NewAdapter(spotRepo, reservationRepo, newPolicyRepo())
+
+	// when
+	res, err := adapter.Enqueue(member, guild, "LIB", startAt, endAt)
+
+	// assert
+	assert.Nil(err)
+	assert.Equal(spotInput.Name, res.Spot.Name)
+}
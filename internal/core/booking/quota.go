@@ -0,0 +1,153 @@
+package booking
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"spot-assistant/internal/common/collections"
+	stringsHelper "spot-assistant/internal/common/strings"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/policy"
+	"spot-assistant/internal/core/dto/reservation"
+	"spot-assistant/internal/core/dto/spot"
+)
+
+// Weekly quota along with the spot it has been set on.
+type resolvedQuota struct {
+	policy.WeeklyQuota
+	spot *spot.Spot
+}
+
+// Covers returns true if reservations of s count toward the quota, which is the case for the quota
+// spot itself, and floors or sides of it.
+func (q resolvedQuota) Covers(s reservation.Spot) bool {
+	return s.ID == q.spot.ID || s.ParentID == q.spot.ID
+}
+
+// Returns member usage of each guild weekly quota within the current week.
+func (a *Adapter) GetQuotaUsage(guild *discord.Guild, member *discord.Member) ([]*reservation.QuotaUsage, error) {
+	p, err := a.GetPolicy(guild)
+	if err != nil {
+		return nil, err
+	}
+
+	quotas, err := a.resolveWeeklyQuotas(p, guild)
+	if err != nil {
+		return nil, err
+	}
+
+	weekStartAt, weekEndAt := p.Week(time.Now())
+	reservations, err := a.reservationRepo.SelectMemberReservationsWithSpotsBetween(context.Background(), guild, member, weekStartAt, weekEndAt)
+	if err != nil {
+		return nil, fmt.Errorf("could not select member reservations: %w", err)
+	}
+
+	return collections.PoorMansMap(quotas, func(q resolvedQuota) *reservation.QuotaUsage {
+		return &reservation.QuotaUsage{
+			Spot:     q.spot.Name,
+			Limit:    q.Limit,
+			Used:     quotaUsage(q, reservations, weekStartAt, weekEndAt),
+			ResetsAt: weekEndAt,
+		}
+	}), nil
+}
+
+// Returns an error if booking a given spot would exceed any of the weekly quotas covering it, in
+// any of the weeks the reservation falls within. Ignored reservations do not count, so that they can be moved.
+func (a *Adapter) checkWeeklyQuotas(p *policy.Policy, guild *discord.Guild, member *discord.Member, s reservation.Spot, startAt time.Time, endAt time.Time, ignoredReservationIds ...int64) error {
+	quotas, err := a.resolveWeeklyQuotas(p, guild)
+	if err != nil {
+		return err
+	}
+
+	quotas = collections.PoorMansFilter(quotas, func(q resolvedQuota) bool {
+		return q.Covers(s)
+	})
+	if len(quotas) == 0 {
+		return nil
+	}
+
+	for weekStartAt, weekEndAt := p.Week(startAt); weekStartAt.Before(endAt); weekStartAt, weekEndAt = weekEndAt, weekEndAt.AddDate(0, 0, 7) {
+		reservations, err := a.reservationRepo.SelectMemberReservationsWithSpotsBetween(context.Background(), guild, member, weekStartAt, weekEndAt)
+		if err != nil {
+			return fmt.Errorf("could not select member reservations: %w", err)
+		}
+
+		reservations = collections.PoorMansFilter(reservations, func(r *reservation.ReservationWithSpot) bool {
+			return !collections.PoorMansContains(ignoredReservationIds, r.Reservation.ID)
+		})
+
+		booked := overlap(startAt, endAt, weekStartAt, weekEndAt)
+		for _, q := range quotas {
+			used := quotaUsage(q, reservations, weekStartAt, weekEndAt)
+			if used+booked > q.Limit {
+				return fmt.Errorf(
+					"You can only book %s of %s per week, and have %s left until the quota renews %s",
+					stringsHelper.FormatDuration(q.Limit),
+					q.spot.Name,
+					stringsHelper.FormatDuration(max(q.Limit-used, 0)),
+					stringsHelper.FormatLongTime(weekEndAt.In(p.Location())),
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Finds spots of guild weekly quotas. Quotas of spots that no longer exist are skipped.
+func (a *Adapter) resolveWeeklyQuotas(p *policy.Policy, guild *discord.Guild) ([]resolvedQuota, error) {
+	if len(p.WeeklyQuotas) == 0 {
+		return []resolvedQuota{}, nil
+	}
+
+	spots, err := a.spotRepo.SelectAllSpots(context.Background(), guild.ID)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch spots: %w", err)
+	}
+
+	quotas := make([]resolvedQuota, 0, len(p.WeeklyQuotas))
+	for _, q := range p.WeeklyQuotas {
+		s, _ := collections.PoorMansFind(spots, func(s *spot.Spot) bool {
+			return spotIsCalled(s, q.Spot)
+		})
+		if s == nil {
+			a.log.WithFields(logrus.Fields{"guild": guild.ID, "spot": q.Spot}).Warn("weekly quota of unknown spot")
+			continue
+		}
+
+		quotas = append(quotas, resolvedQuota{WeeklyQuota: q, spot: s})
+	}
+
+	return quotas, nil
+}
+
+// Sums time of reservations covered by the quota, which falls within the week. Holds do not count
+// until they are confirmed.
+func quotaUsage(q resolvedQuota, reservations []*reservation.ReservationWithSpot, weekStartAt time.Time, weekEndAt time.Time) time.Duration {
+	covered := collections.PoorMansFilter(reservations, func(r *reservation.ReservationWithSpot) bool {
+		return !r.Held() && q.Covers(r.Spot)
+	})
+
+	return collections.PoorMansSum(covered, func(r *reservation.ReservationWithSpot) time.Duration {
+		return overlap(r.StartAt, r.EndAt, weekStartAt, weekEndAt)
+	})
+}
+
+// Returns how long the two time ranges overlap.
+func overlap(startAt time.Time, endAt time.Time, otherStartAt time.Time, otherEndAt time.Time) time.Duration {
+	from := startAt
+	if otherStartAt.After(from) {
+		from = otherStartAt
+	}
+
+	to := endAt
+	if otherEndAt.Before(to) {
+		to = otherEndAt
+	}
+
+	return max(to.Sub(from), 0)
+}
@@ -0,0 +1,188 @@
+package booking
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"spot-assistant/internal/common/test/mocks"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/policy"
+	"spot-assistant/internal/core/dto/reservation"
+	"spot-assistant/internal/core/dto/spot"
+)
+
+func newQuotaPolicyRepo(guildId string) (*mocks.MockPolicyRepo, *policy.Policy) {
+	p := newTestPolicy(guildId)
+	p.WeeklyQuotas = []policy.WeeklyQuota{{Spot: "soul war", Limit: 6 * time.Hour}}
+	policyRepo := new(mocks.MockPolicyRepo)
+	policyRepo.On("FindGuildPolicy", mocks.ContextMock, guildId).Return(p, nil)
+
+	return policyRepo, p
+}
+
+func TestBookFailsWhenWeeklyQuotaOfRespawnIsExceeded(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	member := &discord.Member{ID: "test-member"}
+	policyRepo, p := newQuotaPolicyRepo(guild.ID)
+	respawn := &spot.Spot{ID: 1, Name: "Soul War"}
+	floor := &spot.Spot{ID: 2, Name: "Soul War -1", ParentID: respawn.ID}
+	startAt := time.Now().Add(time.Hour)
+	// Keep the reservation within a single week
+	if _, weekEndAt := p.Week(startAt); weekEndAt.Before(startAt.Add(2 * time.Hour)) {
+		startAt = weekEndAt
+	}
+	endAt := startAt.Add(2 * time.Hour)
+	weekStartAt, _ := p.Week(startAt)
+	history := []*reservation.ReservationWithSpot{
+		{
+			Reservation: reservation.Reservation{ID: 1, StartAt: weekStartAt.Add(time.Hour), EndAt: weekStartAt.Add(6 * time.Hour)},
+			Spot:        reservation.Spot{ID: respawn.ID, Name: respawn.Name},
+		},
+	}
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{respawn, floor}, nil)
+	reservationRepo := new(mocks.MockReservationRepo)
+	reservationRepo.On("SelectOverlappingReservations", mocks.ContextMock, floor.ID, startAt, endAt, guild.ID).Return([]*reservation.Reservation{}, nil)
+	reservationRepo.On("SelectUpcomingMemberReservationsWithSpots", mocks.ContextMock, guild, member).Return([]*reservation.ReservationWithSpot{}, nil)
+	reservationRepo.On("SelectMemberReservationsWithSpotsBetween", mocks.ContextMock, guild, member, mock.Anything, mock.Anything).Return(history, nil)
+	adapter := NewAdapter(spotRepo, reservationRepo, policyRepo)
+
+	// when
+	_, err := adapter.Book(member, guild, []*discord.Member{}, floor.Name, startAt, endAt, false, newTestTier(0), newTestRoleChecker())
+
+	// assert
+	_, weekEndAt := p.Week(startAt)
+	assert.EqualError(err, "You can only book 6h of Soul War per week, and have 1h left until the quota renews "+weekEndAt.In(p.Location()).Format("2006-01-02 15:04 MST"))
+	reservationRepo.AssertNotCalled(t, "CreateAndDeleteConflicting")
+}
+
+func TestGetQuotaUsage(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	member := &discord.Member{ID: "test-member"}
+	policyRepo, p := newQuotaPolicyRepo(guild.ID)
+	respawn := &spot.Spot{ID: 1, Name: "Soul War"}
+	floor := &spot.Spot{ID: 2, Name: "Soul War -1", ParentID: respawn.ID}
+	other := &spot.Spot{ID: 3, Name: "Library"}
+	weekStartAt, weekEndAt := p.Week(time.Now())
+	history := []*reservation.ReservationWithSpot{
+		{
+			// Started in the previous week, so only its part within this one counts
+			Reservation: reservation.Reservation{ID: 1, StartAt: weekStartAt.Add(-time.Hour), EndAt: weekStartAt.Add(time.Hour)},
+			Spot:        reservation.Spot{ID: floor.ID, Name: floor.Name, ParentID: respawn.ID},
+		},
+		{
+			Reservation: reservation.Reservation{ID: 2, StartAt: weekStartAt.Add(2 * time.Hour), EndAt: weekStartAt.Add(4 * time.Hour)},
+			Spot:        reservation.Spot{ID: other.ID, Name: other.Name},
+		},
+		{
+			// Holds do not count until they are confirmed
+			Reservation: reservation.Reservation{ID: 3, StartAt: weekStartAt.Add(5 * time.Hour), EndAt: weekStartAt.Add(7 * time.Hour), HeldUntil: time.Now().Add(5 * time.Minute)},
+			Spot:        reservation.Spot{ID: respawn.ID, Name: respawn.Name},
+		},
+	}
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{respawn, floor, other}, nil)
+	reservationRepo := new(mocks.MockReservationRepo)
+	reservationRepo.On("SelectMemberReservationsWithSpotsBetween", mocks.ContextMock, guild, member, weekStartAt, weekEndAt).Return(history, nil)
+	adapter := NewAdapter(spotRepo, reservationRepo, policyRepo)
+
+	// when
+	res, err := adapter.GetQuotaUsage(guild, member)
+
+	// assert
+	assert.Nil(err)
+	assert.Equal([]*reservation.QuotaUsage{
+		{Spot: "Soul War", Limit: 6 * time.Hour, Used: time.Hour, ResetsAt: weekEndAt},
+	}, res)
+	assert.Equal(5*time.Hour, res[0].Remaining())
+}
+
+func TestTransferFailsWhenRecipientExceedsWeeklyQuota(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	from := &discord.Member{ID: "test-member"}
+	to := &discord.Member{ID: "test-recipient"}
+	policyRepo, p := newQuotaPolicyRepo(guild.ID)
+	respawn := &spot.Spot{ID: 1, Name: "Soul War"}
+	startAt := time.Now().Add(time.Hour)
+	// Keep the reservation within a single week
+	if _, weekEndAt := p.Week(startAt); weekEndAt.Before(startAt.Add(2 * time.Hour)) {
+		startAt = weekEndAt
+	}
+	weekStartAt, _ := p.Week(startAt)
+	existing := &reservation.ReservationWithSpot{
+		Reservation: reservation.Reservation{ID: 1, AuthorDiscordID: from.ID, StartAt: startAt, EndAt: startAt.Add(2 * time.Hour)},
+		Spot:        reservation.Spot{ID: respawn.ID, Name: respawn.Name},
+	}
+	history := []*reservation.ReservationWithSpot{
+		{
+			Reservation: reservation.Reservation{ID: 2, AuthorDiscordID: to.ID, StartAt: weekStartAt, EndAt: weekStartAt.Add(5 * time.Hour)},
+			Spot:        reservation.Spot{ID: respawn.ID, Name: respawn.Name},
+		},
+	}
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{respawn}, nil)
+	reservationRepo := new(mocks.MockReservationRepo)
+	reservationRepo.On("FindReservationWithSpot", mocks.ContextMock, existing.Reservation.ID, guild.ID, from.ID).Return(existing, nil)
+	reservationRepo.On("SelectUpcomingMemberReservationsWithSpots", mocks.ContextMock, guild, to).Return([]*reservation.ReservationWithSpot{}, nil)
+	reservationRepo.On("SelectMemberReservationsWithSpotsBetween", mocks.ContextMock, guild, to, mock.Anything, mock.Anything).Return(history, nil)
+	adapter := NewAdapter(spotRepo, reservationRepo, policyRepo)
+
+	// when
+	_, err := adapter.Transfer(guild, from, to, existing.Reservation.ID, newTestRoleChecker())
+
+	// assert
+	assert.ErrorContains(err, "You can only book 6h of Soul War per week, and have 1h left")
+	reservationRepo.AssertNotCalled(t, "TransferPresentMemberReservation", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestProcessQueueLeavesEntriesExceedingWeeklyQuota(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	member := &discord.Member{ID: "test-member"}
+	policyRepo, p := newQuotaPolicyRepo(guild.ID)
+	respawn := &spot.Spot{ID: 1, Name: "Soul War"}
+	startAt := time.Now().Add(time.Hour)
+	// Keep the entry within a single week
+	if _, weekEndAt := p.Week(startAt); weekEndAt.Before(startAt.Add(2 * time.Hour)) {
+		startAt = weekEndAt
+	}
+	endAt := startAt.Add(2 * time.Hour)
+	weekStartAt, _ := p.Week(startAt)
+	entry := &reservation.QueueEntryWithSpot{
+		QueueEntry: reservation.QueueEntry{ID: 1, AuthorDiscordID: member.ID, StartAt: startAt, EndAt: endAt},
+		Spot:       reservation.Spot{ID: respawn.ID, Name: respawn.Name},
+	}
+	history := []*reservation.ReservationWithSpot{
+		{
+			Reservation: reservation.Reservation{ID: 2, AuthorDiscordID: member.ID, StartAt: weekStartAt, EndAt: weekStartAt.Add(5 * time.Hour)},
+			Spot:        reservation.Spot{ID: respawn.ID, Name: respawn.Name},
+		},
+	}
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{respawn}, nil)
+	reservationRepo := new(mocks.MockReservationRepo)
+	reservationRepo.On("DeleteExpiredQueueEntries", mocks.ContextMock, guild.ID).Return(nil)
+	reservationRepo.On("SelectQueueEntriesWithSpots", mocks.ContextMock, guild.ID).Return([]*reservation.QueueEntryWithSpot{entry}, nil)
+	reservationRepo.On("SelectOverlappingReservations", mocks.ContextMock, respawn.ID, startAt, endAt, guild.ID).Return([]*reservation.Reservation{}, nil)
+	reservationRepo.On("SelectUpcomingMemberReservationsWithSpots", mocks.ContextMock, guild, mock.Anything).Return([]*reservation.ReservationWithSpot{}, nil)
+	reservationRepo.On("SelectMemberReservationsWithSpotsBetween", mocks.ContextMock, guild, mock.Anything, mock.Anything, mock.Anything).Return(history, nil)
+	adapter := NewAdapter(spotRepo, reservationRepo, policyRepo)
+
+	// when
+	res, err := adapter.ProcessQueue(guild, newTestRoleChecker())
+
+	// assert
+	assert.Nil(err)
+	assert.Empty(res)
+	reservationRepo.AssertNotCalled(t, "CreateReservationFromQueueEntry", mock.Anything, mock.Anything, mock.Anything)
+}
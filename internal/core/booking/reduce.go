@@ -1,39 +1,19 @@
 package booking
 
 import (
-	"strings"
-
 	"spot-assistant/internal/core/dto/reservation"
 )
 
 /* 
-	This function takes a slice of reservations and merges some of them when they overlap and have the same spot (with different side or floor) 
+	This function takes a slice of reservations and merges some of them when they overlap and have the same respawn (with different side or floor) 
 */ 
 func reduceAllAuthorReservationsByLongestPerSpot(reservations []*reservation.ReservationWithSpot) []*reservation.ReservationWithSpot {
 	reducedReservations := []*reservation.ReservationWithSpot{reservations[0]}
 
 	for _, r := range reservations[1:] {
-		replacer := strings.NewReplacer(
-			"(NORTH)", "",
-			"(EAST)", "",
-			"(SOUTH)", "",
-			"(WEST)", "",
-			"(RIGHT)", "",
-			"(LEFT)", "",
-			"-1", "",
-			"-2", "",
-			"-3", "",
-			"-4", "",
-			"-5", "",
-			"-6", "",
-			"-7", "",
-			"-8", "",
-		)
-		spotNameWithoutLevelOrSide := strings.TrimSpace(replacer.Replace(r.Spot.Name))
-
 		for i, re := range reducedReservations {
-			// Spot is already present in one of reservations; add time to it
-			if strings.Contains(re.Spot.Name, spotNameWithoutLevelOrSide) {
+			// Spot or another floor or side of its respawn is already present in one of reservations; add time to it
+			if re.Spot.SameRespawn(r.Spot) {
 				// Reservation start and end time of processed reservation do not equal with the one that already exist in reduced slice
 				if !(re.StartAt.Equal(r.StartAt) && re.EndAt.Equal(r.EndAt)) {
 					var firstOne *reservation.ReservationWithSpot
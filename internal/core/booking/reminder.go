@@ -0,0 +1,109 @@
+package booking
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/policy"
+	"spot-assistant/internal/core/dto/reservation"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Returns member reminder preference, or the default one.
+func (a *Adapter) GetReminderPreference(guild *discord.Guild, member *discord.Member) (*policy.ReminderPreference, error) {
+	p, err := a.policyRepo.FindMemberReminderPreference(context.Background(), guild.ID, member.ID)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch reminder preference: %w", err)
+	}
+
+	return p, nil
+}
+
+// Validates and saves member reminder preference.
+func (a *Adapter) SaveReminderPreference(p *policy.ReminderPreference) (*policy.ReminderPreference, error) {
+	err := p.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := a.policyRepo.SaveMemberReminderPreference(context.Background(), p)
+	if err != nil {
+		return nil, fmt.Errorf("could not save reminder preference: %w", err)
+	}
+
+	return res, nil
+}
+
+// Returns reminders about upcoming guild reservations that are due according to their authors
+// preferences. Every returned reminder is recorded as sent, so it is never returned again,
+// even after a restart.
+func (a *Adapter) ProcessReminders(guild *discord.Guild) ([]*reservation.Reminder, error) {
+	reservations, err := a.reservationRepo.SelectUpcomingReservationsWithSpot(context.Background(), guild.ID)
+	if err != nil {
+		return nil, fmt.Errorf("could not select upcoming reservations: %w", err)
+	}
+
+	currTime := time.Now()
+	preferences := make(map[string]*policy.ReminderPreference)
+	reminders := make([]*reservation.Reminder, 0)
+	for _, res := range reservations {
+		// Holds might never be confirmed, their authors are notified once they are released
+		if res.Held() {
+			continue
+		}
+
+		log := a.log.WithFields(logrus.Fields{"reservation.ID": res.Reservation.ID, "guild.ID": guild.ID})
+
+		preference, ok := preferences[res.AuthorDiscordID]
+		if !ok {
+			preference, err = a.policyRepo.FindMemberReminderPreference(context.Background(), guild.ID, res.AuthorDiscordID)
+			if err != nil {
+				log.Errorf("could not fetch reminder preference: %s", err)
+				continue
+			}
+			preferences[res.AuthorDiscordID] = preference
+		}
+
+		for _, reminder := range dueReminders(preference, res, currTime) {
+			claimed, err := a.reservationRepo.ClaimReminder(context.Background(), res.Reservation.ID, reminder.Kind, reminder.EventAt())
+			if err != nil {
+				log.Errorf("could not record reminder: %s", err)
+				continue
+			}
+
+			if claimed {
+				reminders = append(reminders, reminder)
+			}
+		}
+	}
+
+	return reminders, nil
+}
+
+// Returns reminders of a reservation, which lead time has come. Reservations made after
+// their reminder time are not reminded of, as their authors have just booked them.
+func dueReminders(p *policy.ReminderPreference, res *reservation.ReservationWithSpot, currTime time.Time) []*reservation.Reminder {
+	reminders := make([]*reservation.Reminder, 0, 2)
+	if !p.Enabled {
+		return reminders
+	}
+
+	leadTimes := map[reservation.ReminderKind]time.Duration{
+		reservation.ReminderStart: p.StartLeadTime,
+		reservation.ReminderEnd:   p.EndLeadTime,
+	}
+	for _, kind := range []reservation.ReminderKind{reservation.ReminderStart, reservation.ReminderEnd} {
+		reminder := &reservation.Reminder{ReservationWithSpot: res, Kind: kind}
+		eventAt := reminder.EventAt()
+		remindAt := eventAt.Add(-leadTimes[kind])
+
+		if leadTimes[kind] > 0 && !currTime.Before(remindAt) && currTime.Before(eventAt) && res.CreatedAt.Before(remindAt) {
+			reminders = append(reminders, reminder)
+		}
+	}
+
+	return reminders
+}
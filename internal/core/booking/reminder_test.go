@@ -0,0 +1,140 @@
+package booking
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"spot-assistant/internal/common/test/mocks"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/policy"
+	"spot-assistant/internal/core/dto/reservation"
+)
+
+func TestProcessReminders(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{
+		ID:   "test-id",
+		Name: "test-guild-name",
+	}
+	startingSoon := &reservation.ReservationWithSpot{
+		Reservation: reservation.Reservation{
+			ID:              1,
+			AuthorDiscordID: "test-member",
+			CreatedAt:       time.Now().Add(-24 * time.Hour),
+			StartAt:         time.Now().Add(10 * time.Minute),
+			EndAt:           time.Now().Add(2 * time.Hour),
+		},
+	}
+	endingSoon := &reservation.ReservationWithSpot{
+		Reservation: reservation.Reservation{
+			ID:              2,
+			AuthorDiscordID: "test-member",
+			CreatedAt:       time.Now().Add(-24 * time.Hour),
+			StartAt:         time.Now().Add(-2 * time.Hour),
+			EndAt:           time.Now().Add(5 * time.Minute),
+		},
+	}
+	alreadyReminded := &reservation.ReservationWithSpot{
+		Reservation: reservation.Reservation{
+			ID:              3,
+			AuthorDiscordID: "test-member",
+			CreatedAt:       time.Now().Add(-24 * time.Hour),
+			StartAt:         time.Now().Add(5 * time.Minute),
+			EndAt:           time.Now().Add(1 * time.Hour),
+		},
+	}
+	optedOut := &reservation.ReservationWithSpot{
+		Reservation: reservation.Reservation{
+			ID:              4,
+			AuthorDiscordID: "test-member-2",
+			CreatedAt:       time.Now().Add(-24 * time.Hour),
+			StartAt:         time.Now().Add(5 * time.Minute),
+			EndAt:           time.Now().Add(1 * time.Hour),
+		},
+	}
+	disabledPreference := policy.NewDefaultReminderPreference(guild.ID, "test-member-2")
+	disabledPreference.Enabled = false
+	reservationService := new(mocks.MockReservationRepo)
+	reservationService.On("SelectUpcomingReservationsWithSpot", mocks.ContextMock, guild.ID).Return(
+		[]*reservation.ReservationWithSpot{startingSoon, endingSoon, alreadyReminded, optedOut}, nil)
+	reservationService.On("ClaimReminder", mocks.ContextMock, startingSoon.Reservation.ID, reservation.ReminderStart, startingSoon.StartAt).Return(true, nil)
+	reservationService.On("ClaimReminder", mocks.ContextMock, endingSoon.Reservation.ID, reservation.ReminderEnd, endingSoon.EndAt).Return(true, nil)
+	reservationService.On("ClaimReminder", mocks.ContextMock, alreadyReminded.Reservation.ID, reservation.ReminderStart, alreadyReminded.StartAt).Return(false, nil)
+	policyRepo := new(mocks.MockPolicyRepo)
+	policyRepo.On("FindMemberReminderPreference", mocks.ContextMock, guild.ID, "test-member").Return(policy.NewDefaultReminderPreference(guild.ID, "test-member"), nil).Once()
+	policyRepo.On("FindMemberReminderPreference", mocks.ContextMock, guild.ID, "test-member-2").Return(disabledPreference, nil).Once()
+	adapter := NewAdapter(new(mocks.MockSpotRepo), reservationService, policyRepo)
+
+	// when
+	reminders, err := adapter.ProcessReminders(guild)
+
+	// assert
+	assert.Nil(err)
+	assert.Equal([]*reservation.Reminder{
+		{ReservationWithSpot: startingSoon, Kind: reservation.ReminderStart},
+		{ReservationWithSpot: endingSoon, Kind: reservation.ReminderEnd},
+	}, reminders)
+	reservationService.AssertNotCalled(t, "ClaimReminder", mock.Anything, optedOut.Reservation.ID, mock.Anything, mock.Anything)
+	policyRepo.AssertExpectations(t)
+}
+
+func TestDueRemindersSkipsJustBookedReservations(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	preference := policy.NewDefaultReminderPreference("test-id", "test-member")
+	res := &reservation.ReservationWithSpot{
+		Reservation: reservation.Reservation{
+			CreatedAt: time.Now().Add(-1 * time.Minute),
+			StartAt:   time.Now().Add(10 * time.Minute),
+			EndAt:     time.Now().Add(2 * time.Hour),
+		},
+	}
+
+	// when
+	reminders := dueReminders(preference, res, time.Now())
+
+	// assert
+	assert.Empty(reminders)
+}
+
+func TestDueRemindersWithDisabledLeadTime(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	preference := policy.NewDefaultReminderPreference("test-id", "test-member")
+	preference.StartLeadTime = 0
+	res := &reservation.ReservationWithSpot{
+		Reservation: reservation.Reservation{
+			CreatedAt: time.Now().Add(-24 * time.Hour),
+			StartAt:   time.Now(),
+			EndAt:     time.Now().Add(5 * time.Minute),
+		},
+	}
+
+	// when
+	reminders := dueReminders(preference, res, time.Now())
+
+	// assert
+	assert.Len(reminders, 1)
+	assert.Equal(reservation.ReminderEnd, reminders[0].Kind)
+}
+
+func TestSaveReminderPreferenceWithInvalidPreference(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	policyRepo := new(mocks.MockPolicyRepo)
+	adapter := NewAdapter(new(mocks.MockSpotRepo), new(mocks.MockReservationRepo), policyRepo)
+	preference := policy.NewDefaultReminderPreference("test-id", "test-member")
+	preference.EndLeadTime = -1 * time.Minute
+
+	// when
+	res, err := adapter.SaveReminderPreference(preference)
+
+	// assert
+	assert.NotNil(err)
+	assert.Nil(res)
+	policyRepo.AssertNotCalled(t, "SaveMemberReminderPreference", mock.Anything, mock.Anything)
+}
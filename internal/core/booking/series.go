@@ -0,0 +1,293 @@
+package booking
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"spot-assistant/internal/common/collections"
+	stringsHelper "spot-assistant/internal/common/strings"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/policy"
+	"spot-assistant/internal/core/dto/reservation"
+
+	"github.com/sirupsen/logrus"
+)
+
+// How far ahead series are materialized into concrete reservations, unless the guild booking horizon is shorter.
+const SERIES_MATERIALIZATION_HORIZON = 7 * 24 * time.Hour
+
+var suggestedWeekdays = []string{
+	"Mon/Wed/Fri",
+	"Tue/Thu",
+	"Sat/Sun",
+	"Mon/Tue/Wed/Thu/Fri",
+	"Mon/Tue/Wed/Thu/Fri/Sat/Sun",
+}
+
+type occurrence struct {
+	StartAt time.Time
+	EndAt   time.Time
+}
+
+// Creates a new weekly series. Reservations are not created here,
+// but during series materialization.
+func (a *Adapter) CreateSeries(member *discord.Member, guild *discord.Guild, spotName string, weekdays []time.Weekday, startTime time.Duration, endTime time.Duration) (*reservation.SeriesWithSpot, error) {
+	a.log.WithFields(logrus.Fields{
+		"member":    member,
+		"weekdays":  weekdays,
+		"startTime": startTime,
+		"endTime":   endTime,
+	}).Info("series request")
+
+	if len(weekdays) == 0 {
+		return nil, errors.New("series has to occur on at least one weekday")
+	}
+
+	p, err := a.GetPolicy(guild)
+	if err != nil {
+		return nil, err
+	}
+
+	if seriesDuration(startTime, endTime) > p.MaximumReservationTime {
+		return nil, fmt.Errorf("reservation cannot take more than %s", stringsHelper.FormatDuration(p.MaximumReservationTime))
+	}
+
+	err = checkSeriesOccurrences(p, reservation.Series{Weekdays: weekdays, StartTime: startTime, EndTime: endTime}, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	spot, err := a.findBookableSpot(guild, spotName)
+	if err != nil {
+		return nil, err
+	}
+
+	series, err := a.reservationRepo.CreateSeries(context.Background(), member, guild, spot.ID, weekdays, startTime, endTime)
+	if err != nil {
+		return nil, fmt.Errorf("could not create the series: %w", err)
+	}
+
+	return &reservation.SeriesWithSpot{
+		Series: *series,
+		Spot: reservation.Spot{
+			ID:       spot.ID,
+			Name:     spot.Name,
+			ParentID: spot.ParentID,
+		},
+	}, nil
+}
+
+// Creates reservations for guild series occurrences up to SERIES_MATERIALIZATION_HORIZON or the guild booking
+// horizon, whichever is shorter, with the priority
+// of series author tier, which is resolved with hasRole. Occurrences are clipped to guild blackouts. Occurrences
+// crossing a blackout, conflicting with existing reservations, exceeding maximum reservations time of
+// the tier or weekly quotas are skipped. Occurrences which booking window has not opened yet, or which are
+// handed out by lottery not drawn yet, are postponed along with the following ones until they can be booked.
+// Returns created reservations.
+func (a *Adapter) MaterializeSeries(guild *discord.Guild, hasRole policy.RoleChecker) ([]*reservation.Reservation, error) {
+	tNow := time.Now()
+	created := make([]*reservation.Reservation, 0)
+
+	p, err := a.GetPolicy(guild)
+	if err != nil {
+		return created, err
+	}
+
+	until := tNow.Add(min(p.BookingHorizon, SERIES_MATERIALIZATION_HORIZON))
+
+	seriesToMaterialize, err := a.reservationRepo.SelectSeriesToMaterialize(context.Background(), guild.ID, until)
+	if err != nil {
+		return created, fmt.Errorf("could not select series to materialize: %w", err)
+	}
+
+	for _, series := range seriesToMaterialize {
+		from := series.MaterializedUntil
+		if from.Before(tNow) {
+			from = tNow
+		}
+
+		// Series hours are expressed in the guild time zone
+		member := &discord.Member{ID: series.AuthorDiscordID, Nick: series.Author}
+		tier := p.TierOf(hasRole, member)
+		materializedUntil := until
+		for _, o := range seriesOccurrences(series.Series, from.In(p.Location()), until) {
+			log := a.log.WithFields(logrus.Fields{"series.ID": series.Series.ID, "startAt": o.StartAt, "endAt": o.EndAt})
+
+			// Booking of the following occurrences opens even later
+			err = a.checkBookingWindow(p, guild, series.Spot, tNow, o.StartAt)
+			if err == nil {
+				err = a.checkLottery(p, guild, series.Spot, tNow, o.StartAt)
+			}
+			if err != nil {
+				log.Infof("postponing series occurrence: %s", err)
+				materializedUntil = o.StartAt.Add(-time.Second)
+				break
+			}
+
+			o.StartAt, o.EndAt, err = p.ClipToBlackouts(o.StartAt, o.EndAt)
+			if err != nil {
+				log.Infof("skipping series occurrence: %s", err)
+				continue
+			}
+
+			conflicts, err := a.reservationRepo.SelectOverlappingReservations(context.Background(), series.Spot.ID, o.StartAt, o.EndAt, guild.ID)
+			if err != nil {
+				return created, fmt.Errorf("could not select overlapping reservations: %w", err)
+			}
+
+			if len(conflicts) > 0 {
+				log.Info("skipping series occurrence, as it conflicts with existing reservations")
+				continue
+			}
+
+			exceeds, err := a.exceedsMaximumReservationsTime(p, tier.MaximumReservationsTime, guild, member, series.Spot, o.StartAt, o.EndAt)
+			if err != nil {
+				return created, err
+			}
+
+			if exceeds {
+				log.Info("skipping series occurrence, as it exceeds maximum reservations time")
+				continue
+			}
+
+			err = a.checkWeeklyQuotas(p, guild, member, series.Spot, o.StartAt, o.EndAt)
+			if err != nil {
+				log.Infof("skipping series occurrence: %s", err)
+				continue
+			}
+
+			res, err := a.reservationRepo.CreateSeriesReservation(context.Background(), &series.Series, o.StartAt, o.EndAt, p.ReservationPriority(tier))
+			if err != nil {
+				return created, fmt.Errorf("could not create series reservation: %w", err)
+			}
+
+			created = append(created, res)
+		}
+
+		err = a.reservationRepo.UpdateSeriesMaterializedUntil(context.Background(), series.Series.ID, materializedUntil)
+		if err != nil {
+			return created, fmt.Errorf("could not update series: %w", err)
+		}
+	}
+
+	return created, nil
+}
+
+// Returns suggested weekday combinations. If filter is a valid list of weekdays,
+// it is suggested as well.
+func (a *Adapter) GetSuggestedWeekdays(filter string) []string {
+	suggestedOptions := suggestedWeekdays
+	if len(filter) == 0 {
+		return suggestedOptions
+	}
+
+	suggestedOptions = collections.PoorMansFilter(suggestedOptions, func(w string) bool {
+		return strings.Contains(strings.ToLower(w), strings.ToLower(filter))
+	})
+
+	// Add user input, if it's valid
+	weekdays, err := stringsHelper.ParseWeekdays(filter)
+	if err == nil {
+		formattedWeekdays := stringsHelper.FormatWeekdays(weekdays)
+		if !collections.PoorMansContains(suggestedOptions, formattedWeekdays) {
+			suggestedOptions = append([]string{formattedWeekdays}, suggestedOptions...)
+		}
+	}
+
+	return suggestedOptions
+}
+
+func (a *Adapter) FindMemberSeries(g *discord.Guild, m *discord.Member, filter string) ([]*reservation.SeriesWithSpot, error) {
+	series, err := a.reservationRepo.SelectMemberSeriesWithSpots(context.Background(), g, m)
+	if err != nil {
+		return []*reservation.SeriesWithSpot{}, err
+	}
+
+	// If any input value is passed, try to match it with weekdays, hours and spot name
+	if len(filter) > 0 {
+		series = collections.PoorMansFilter(series, func(s *reservation.SeriesWithSpot) bool {
+			searchableString := strings.Join([]string{
+				stringsHelper.FormatWeekdays(s.Weekdays),
+				stringsHelper.FormatClock(s.StartTime),
+				stringsHelper.FormatClock(s.EndTime),
+				s.Spot.Name}, "")
+			return strings.Contains(strings.ToLower(searchableString), strings.ToLower(filter))
+		})
+	}
+
+	return series, nil
+}
+
+// Removes member series along with its upcoming reservations.
+func (a *Adapter) CancelSeries(g *discord.Guild, m *discord.Member, seriesId int64) (*reservation.SeriesWithSpot, error) {
+	series, err := a.reservationRepo.FindMemberSeriesWithSpot(context.Background(), seriesId, g.ID, m.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.reservationRepo.DeleteMemberSeries(context.Background(), g, m, series.Series.ID)
+	if err != nil {
+		return series, err
+	}
+
+	return series, nil
+}
+
+// Returns series occurrences starting after from, and no later than until.
+func seriesOccurrences(series reservation.Series, from time.Time, until time.Time) []occurrence {
+	occurrences := make([]occurrence, 0)
+	duration := seriesDuration(series.StartTime, series.EndTime)
+
+	// Start a day earlier, so that the day boundary is not a concern
+	day := time.Date(from.Year(), from.Month(), from.Day()-1, 0, 0, 0, 0, from.Location())
+	for ; !day.After(until); day = day.AddDate(0, 0, 1) {
+		if !series.OccursOn(day.Weekday()) {
+			continue
+		}
+
+		startAt := time.Date(day.Year(), day.Month(), day.Day(),
+			int(series.StartTime/time.Hour), int(series.StartTime%time.Hour/time.Minute), 0, 0, day.Location())
+		if !startAt.After(from) || startAt.After(until) {
+			continue
+		}
+
+		occurrences = append(occurrences, occurrence{
+			StartAt: startAt,
+			EndAt:   startAt.Add(duration),
+		})
+	}
+
+	return occurrences
+}
+
+// Returns an error if series occurrences during the following week would cross guild blackouts or fall
+// within them, or if none of them could be materialized right away, as they are beyond the booking horizon.
+func checkSeriesOccurrences(p *policy.Policy, series reservation.Series, currTime time.Time) error {
+	from := currTime.In(p.Location())
+	for _, o := range seriesOccurrences(series, from, from.AddDate(0, 0, 7)) {
+		_, _, err := p.ClipToBlackouts(o.StartAt, o.EndAt)
+		if err != nil {
+			return fmt.Errorf("series cannot be booked on %s: %w", o.StartAt.Weekday(), err)
+		}
+	}
+
+	horizon := min(p.BookingHorizon, SERIES_MATERIALIZATION_HORIZON)
+	if len(seriesOccurrences(series, from, from.Add(horizon))) == 0 {
+		return fmt.Errorf("reservations can be made at most %d days ahead, and the series does not occur until then, create it closer to its first occurrence", int(horizon.Hours()/24))
+	}
+
+	return nil
+}
+
+// Returns duration between start and end time, where end time lower
+// or equal to start time means the next day.
+func seriesDuration(startTime time.Duration, endTime time.Duration) time.Duration {
+	if endTime <= startTime {
+		return endTime + 24*time.Hour - startTime
+	}
+
+	return endTime - startTime
+}
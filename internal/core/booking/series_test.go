@@ -0,0 +1,328 @@
+package booking
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"spot-assistant/internal/common/test/mocks"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/policy"
+	"spot-assistant/internal/core/dto/reservation"
+	"spot-assistant/internal/core/dto/spot"
+)
+
+func TestSeriesOccurrences(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	series := reservation.Series{
+		Weekdays:  []time.Weekday{time.Monday, time.Wednesday},
+		StartTime: 18 * time.Hour,
+		EndTime:   21 * time.Hour,
+	}
+	from := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC) // Monday
+	until := from.Add(7 * 24 * time.Hour)
+
+	// when
+	res := seriesOccurrences(series, from, until)
+
+	// assert
+	assert.Len(res, 2)
+	assert.Equal(time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC), res[0].StartAt)
+	assert.Equal(time.Date(2024, 1, 1, 21, 0, 0, 0, time.UTC), res[0].EndAt)
+	assert.Equal(time.Date(2024, 1, 3, 18, 0, 0, 0, time.UTC), res[1].StartAt)
+	assert.Equal(time.Date(2024, 1, 3, 21, 0, 0, 0, time.UTC), res[1].EndAt)
+}
+
+func TestSeriesOccurrencesEndingOnTheNextDay(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	series := reservation.Series{
+		Weekdays:  []time.Weekday{time.Sunday},
+		StartTime: 23 * time.Hour,
+		EndTime:   1 * time.Hour,
+	}
+	from := time.Date(2024, 1, 7, 23, 30, 0, 0, time.UTC) // Sunday, after the occurrence started
+	until := from.Add(7 * 24 * time.Hour)
+
+	// when
+	res := seriesOccurrences(series, from, until)
+
+	// assert
+	assert.Len(res, 1)
+	assert.Equal(time.Date(2024, 1, 14, 23, 0, 0, 0, time.UTC), res[0].StartAt)
+	assert.Equal(time.Date(2024, 1, 15, 1, 0, 0, 0, time.UTC), res[0].EndAt)
+}
+
+func TestCreateSeries(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	member := &discord.Member{ID: "test-member-id"}
+	weekdays := []time.Weekday{time.Monday, time.Friday}
+	spotInput := &spot.Spot{Name: "test-spot", ID: 1}
+	series := &reservation.Series{ID: 1, SpotID: spotInput.ID, Weekdays: weekdays, StartTime: 18 * time.Hour, EndTime: 21 * time.Hour}
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{spotInput}, nil)
+	reservationRepo := new(mocks.MockReservationRepo)
+	reservationRepo.On("CreateSeries", mocks.ContextMock, member, guild, spotInput.ID, weekdays, series.StartTime, series.EndTime).Return(series, nil)
+	adapter := NewAdapter(spotRepo, reservationRepo, newPolicyRepo())
+
+	// when
+	res, err := adapter.CreateSeries(member, guild, spotInput.Name, weekdays, series.StartTime, series.EndTime)
+
+	// assert
+	assert.Nil(err)
+	assert.Equal(*series, res.Series)
+	assert.Equal(spotInput.Name, res.Spot.Name)
+}
+
+func TestCreateSeriesExceedingMaximumReservationTime(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	adapter := NewAdapter(new(mocks.MockSpotRepo), new(mocks.MockReservationRepo), newPolicyRepo())
+
+	// when
+	_, err := adapter.CreateSeries(&discord.Member{}, &discord.Guild{}, "test-spot", []time.Weekday{time.Monday}, 22*time.Hour, 2*time.Hour)
+
+	// assert
+	assert.NotNil(err)
+}
+
+func TestCreateSeriesCrossingBlackout(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	p := newTestPolicy(guild.ID)
+	p.Blackouts = []policy.Blackout{{Name: "Server save", Start: 10 * time.Hour, Length: 10 * time.Minute, TimeZone: "UTC"}}
+	policyRepo := new(mocks.MockPolicyRepo)
+	policyRepo.On("FindGuildPolicy", mocks.ContextMock, guild.ID).Return(p, nil)
+	reservationRepo := new(mocks.MockReservationRepo)
+	adapter := NewAdapter(new(mocks.MockSpotRepo), reservationRepo, policyRepo)
+
+	// when
+	_, err := adapter.CreateSeries(&discord.Member{}, guild, "test-spot", []time.Weekday{time.Monday, time.Thursday}, 9*time.Hour, 11*time.Hour)
+
+	// assert
+	assert.ErrorContains(err, "Server save")
+	reservationRepo.AssertNotCalled(t, "CreateSeries", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateSeriesBeyondBookingHorizon(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	p := newTestPolicy(guild.ID)
+	p.BookingHorizon = 24 * time.Hour
+	policyRepo := new(mocks.MockPolicyRepo)
+	policyRepo.On("FindGuildPolicy", mocks.ContextMock, guild.ID).Return(p, nil)
+	reservationRepo := new(mocks.MockReservationRepo)
+	adapter := NewAdapter(new(mocks.MockSpotRepo), reservationRepo, policyRepo)
+	weekday := time.Now().In(p.Location()).AddDate(0, 0, 3).Weekday()
+
+	// when
+	_, err := adapter.CreateSeries(&discord.Member{}, guild, "test-spot", []time.Weekday{weekday}, 18*time.Hour, 20*time.Hour)
+
+	// assert
+	assert.ErrorContains(err, "at most 1 days ahead")
+	reservationRepo.AssertNotCalled(t, "CreateSeries", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateSeriesWithoutWeekdays(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	adapter := NewAdapter(new(mocks.MockSpotRepo), new(mocks.MockReservationRepo), newPolicyRepo())
+
+	// when
+	_, err := adapter.CreateSeries(&discord.Member{}, &discord.Guild{}, "test-spot", []time.Weekday{}, 18*time.Hour, 20*time.Hour)
+
+	// assert
+	assert.NotNil(err)
+}
+
+func TestMaterializeSeries(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	series := &reservation.SeriesWithSpot{
+		Series: reservation.Series{
+			ID:              1,
+			AuthorDiscordID: "test-member-id",
+			Weekdays:        []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday},
+			StartTime:       18 * time.Hour,
+			EndTime:         20 * time.Hour,
+		},
+		Spot: reservation.Spot{ID: 1, Name: "test-spot"},
+	}
+	reservationRepo := new(mocks.MockReservationRepo)
+	reservationRepo.On("SelectSeriesToMaterialize", mocks.ContextMock, guild.ID, mock.Anything).Return([]*reservation.SeriesWithSpot{series}, nil)
+	reservationRepo.On("SelectOverlappingReservations", mocks.ContextMock, series.Spot.ID, mock.Anything, mock.Anything, guild.ID).Return([]*reservation.Reservation{}, nil)
+	reservationRepo.On("SelectUpcomingMemberReservationsWithSpots", mocks.ContextMock, guild, mock.Anything).Return([]*reservation.ReservationWithSpot{}, nil)
+	reservationRepo.On("CreateSeriesReservation", mocks.ContextMock, &series.Series, mock.Anything, mock.Anything, 0).Return(&reservation.Reservation{}, nil)
+	reservationRepo.On("UpdateSeriesMaterializedUntil", mocks.ContextMock, series.Series.ID, mock.Anything).Return(nil)
+	defer reservationRepo.AssertExpectations(t)
+	adapter := NewAdapter(new(mocks.MockSpotRepo), reservationRepo, newPolicyRepo())
+
+	// when
+	res, err := adapter.MaterializeSeries(guild, newTestRoleChecker())
+
+	// assert
+	assert.Nil(err)
+	assert.Len(res, 7)
+}
+
+func TestMaterializeSeriesWithinBookingHorizon(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	p := newTestPolicy(guild.ID)
+	p.BookingHorizon = 72 * time.Hour
+	policyRepo := new(mocks.MockPolicyRepo)
+	policyRepo.On("FindGuildPolicy", mocks.ContextMock, guild.ID).Return(p, nil)
+	series := &reservation.SeriesWithSpot{
+		Series: reservation.Series{
+			ID:              1,
+			AuthorDiscordID: "test-member-id",
+			Weekdays:        []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday},
+			StartTime:       18 * time.Hour,
+			EndTime:         20 * time.Hour,
+		},
+		Spot: reservation.Spot{ID: 1, Name: "test-spot"},
+	}
+	horizon := time.Now().Add(p.BookingHorizon)
+	reservationRepo := new(mocks.MockReservationRepo)
+	reservationRepo.On("SelectSeriesToMaterialize", mocks.ContextMock, guild.ID, mock.Anything).Return([]*reservation.SeriesWithSpot{series}, nil)
+	reservationRepo.On("SelectOverlappingReservations", mocks.ContextMock, series.Spot.ID, mock.Anything, mock.Anything, guild.ID).Return([]*reservation.Reservation{}, nil)
+	reservationRepo.On("SelectUpcomingMemberReservationsWithSpots", mocks.ContextMock, guild, mock.Anything).Return([]*reservation.ReservationWithSpot{}, nil)
+	reservationRepo.On("CreateSeriesReservation", mocks.ContextMock, &series.Series, mock.Anything, mock.Anything, 0).Return(&reservation.Reservation{}, nil)
+	reservationRepo.On("UpdateSeriesMaterializedUntil", mocks.ContextMock, series.Series.ID, mock.MatchedBy(func(until time.Time) bool {
+		return !until.Before(horizon) && until.Before(horizon.Add(time.Minute))
+	})).Return(nil)
+	defer reservationRepo.AssertExpectations(t)
+	adapter := NewAdapter(new(mocks.MockSpotRepo), reservationRepo, policyRepo)
+
+	// when
+	res, err := adapter.MaterializeSeries(guild, newTestRoleChecker())
+
+	// assert
+	assert.Nil(err)
+	assert.Len(res, 3)
+}
+
+func TestMaterializeSeriesSkipsConflictingOccurrences(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	series := &reservation.SeriesWithSpot{
+		Series: reservation.Series{
+			ID:              1,
+			AuthorDiscordID: "test-member-id",
+			Weekdays:        []time.Weekday{time.Monday, time.Thursday},
+			StartTime:       18 * time.Hour,
+			EndTime:         20 * time.Hour,
+		},
+		Spot: reservation.Spot{ID: 1, Name: "test-spot"},
+	}
+	conflicts := []*reservation.Reservation{{ID: 2, AuthorDiscordID: "other-member-id"}}
+	reservationRepo := new(mocks.MockReservationRepo)
+	reservationRepo.On("SelectSeriesToMaterialize", mocks.ContextMock, guild.ID, mock.Anything).Return([]*reservation.SeriesWithSpot{series}, nil)
+	reservationRepo.On("SelectOverlappingReservations", mocks.ContextMock, series.Spot.ID, mock.Anything, mock.Anything, guild.ID).Return(conflicts, nil)
+	reservationRepo.On("UpdateSeriesMaterializedUntil", mocks.ContextMock, series.Series.ID, mock.Anything).Return(nil)
+	adapter := NewAdapter(new(mocks.MockSpotRepo), reservationRepo, newPolicyRepo())
+
+	// when
+	res, err := adapter.MaterializeSeries(guild, newTestRoleChecker())
+
+	// assert
+	assert.Nil(err)
+	assert.Empty(res)
+	reservationRepo.AssertNotCalled(t, "CreateSeriesReservation", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	reservationRepo.AssertExpectations(t)
+}
+
+func TestMaterializeSeriesSkipsOccurrencesExceedingMaximumReservationsTime(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	series := &reservation.SeriesWithSpot{
+		Series: reservation.Series{
+			ID:              1,
+			AuthorDiscordID: "test-member-id",
+			Weekdays:        []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday},
+			StartTime:       18 * time.Hour,
+			EndTime:         20 * time.Hour,
+		},
+		Spot: reservation.Spot{ID: 1, Name: "test-spot"},
+	}
+	// Reservation that spans across whole materialization horizon
+	upcoming := func() []*reservation.ReservationWithSpot {
+		return []*reservation.ReservationWithSpot{{
+			Reservation: reservation.Reservation{StartAt: time.Now(), EndAt: time.Now().Add(SERIES_MATERIALIZATION_HORIZON + 48*time.Hour)},
+			Spot:        reservation.Spot{Name: "other-spot"},
+		}}
+	}
+	reservationRepo := new(mocks.MockReservationRepo)
+	reservationRepo.On("SelectSeriesToMaterialize", mocks.ContextMock, guild.ID, mock.Anything).Return([]*reservation.SeriesWithSpot{series}, nil)
+	reservationRepo.On("SelectOverlappingReservations", mocks.ContextMock, series.Spot.ID, mock.Anything, mock.Anything, guild.ID).Return([]*reservation.Reservation{}, nil)
+	reservationRepo.On("SelectUpcomingMemberReservationsWithSpots", mocks.ContextMock, guild, mock.Anything).Return(upcoming(), nil)
+	reservationRepo.On("UpdateSeriesMaterializedUntil", mocks.ContextMock, series.Series.ID, mock.Anything).Return(nil)
+	adapter := NewAdapter(new(mocks.MockSpotRepo), reservationRepo, newPolicyRepo())
+
+	// when
+	res, err := adapter.MaterializeSeries(guild, newTestRoleChecker())
+
+	// assert
+	assert.Nil(err)
+	assert.Empty(res)
+	reservationRepo.AssertNotCalled(t, "CreateSeriesReservation", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetSuggestedWeekdaysWithValidFilter(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	adapter := NewAdapter(new(mocks.MockSpotRepo), new(mocks.MockReservationRepo), newPolicyRepo())
+
+	// when
+	res := adapter.GetSuggestedWeekdays("fri mon")
+
+	// assert
+	assert.Equal([]string{"Mon/Fri"}, res)
+}
+
+func TestGetSuggestedWeekdaysWithPartialFilter(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	adapter := NewAdapter(new(mocks.MockSpotRepo), new(mocks.MockReservationRepo), newPolicyRepo())
+
+	// when
+	res := adapter.GetSuggestedWeekdays("Sat")
+
+	// assert
+	assert.Contains(res, "Sat")
+	assert.Contains(res, "Sat/Sun")
+}
+
+func TestCancelSeries(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	member := &discord.Member{ID: "test-member-id"}
+	series := &reservation.SeriesWithSpot{
+		Series: reservation.Series{ID: 1, GuildID: guild.ID, AuthorDiscordID: member.ID},
+		Spot:   reservation.Spot{ID: 1, Name: "test-spot"},
+	}
+	reservationRepo := new(mocks.MockReservationRepo)
+	reservationRepo.On("FindMemberSeriesWithSpot", mocks.ContextMock, series.Series.ID, guild.ID, member.ID).Return(series, nil)
+	reservationRepo.On("DeleteMemberSeries", mocks.ContextMock, guild, member, series.Series.ID).Return(nil)
+	defer reservationRepo.AssertExpectations(t)
+	adapter := NewAdapter(new(mocks.MockSpotRepo), reservationRepo, newPolicyRepo())
+
+	// when
+	res, err := adapter.CancelSeries(guild, member, series.Series.ID)
+
+	// assert
+	assert.Nil(err)
+	assert.Equal(series, res)
+}
@@ -0,0 +1,349 @@
+package booking
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"spot-assistant/internal/common/collections"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/spot"
+)
+
+// Adds a new spot owned by the guild. Spot names are unique within the guild regardless of their case.
+// Non-empty parent makes the spot a floor or side of a top-level respawn visible to the guild.
+func (a *Adapter) AddSpot(guild *discord.Guild, name string, parent string) (*spot.Spot, error) {
+	a.log.WithFields(logrus.Fields{"guild": guild.ID, "name": name, "parent": parent}).Info("add spot request")
+
+	name = strings.TrimSpace(name)
+	err := spot.ValidateName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.checkSpotNameAvailable(guild, name, -1)
+	if err != nil {
+		return nil, err
+	}
+
+	var parentId int64
+	if len(parent) > 0 {
+		p, err := a.findBookableSpot(guild, parent)
+		if err != nil {
+			return nil, err
+		}
+
+		if p.ParentID != 0 {
+			return nil, fmt.Errorf("respawn %s is already a floor or side of another respawn", p.Name)
+		}
+		parentId = p.ID
+	}
+
+	res, err := a.spotRepo.CreateSpot(context.Background(), guild.ID, name, parentId)
+	if err != nil {
+		return nil, fmt.Errorf("could not add the respawn: %w", err)
+	}
+
+	return res, nil
+}
+
+// Changes name of a spot owned by the guild. Reservations made on it follow the new name.
+func (a *Adapter) RenameSpot(guild *discord.Guild, name string, newName string) (*spot.Spot, error) {
+	a.log.WithFields(logrus.Fields{"guild": guild.ID, "name": name, "newName": newName}).Info("rename spot request")
+
+	newName = strings.TrimSpace(newName)
+	err := spot.ValidateName(newName)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := a.findOwnedSpot(guild, name)
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.checkSpotNameAvailable(guild, newName, s.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := a.spotRepo.RenameSpot(context.Background(), s.ID, newName)
+	if err != nil {
+		return nil, fmt.Errorf("could not rename the respawn: %w", err)
+	}
+
+	return res, nil
+}
+
+// Archives a spot owned by the guild, so that it cannot be booked anymore. Its reservations are kept.
+func (a *Adapter) ArchiveSpot(guild *discord.Guild, name string) (*spot.Spot, error) {
+	a.log.WithFields(logrus.Fields{"guild": guild.ID, "name": name}).Info("archive spot request")
+
+	s, err := a.findOwnedSpot(guild, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.Archived() {
+		return nil, fmt.Errorf("respawn %s has already been archived", s.Name)
+	}
+
+	res, err := a.spotRepo.ArchiveSpot(context.Background(), s.ID)
+	if err != nil {
+		return nil, fmt.Errorf("could not archive the respawn: %w", err)
+	}
+
+	return res, nil
+}
+
+// Replaces details of a spot owned by the guild, such as its level range or area.
+func (a *Adapter) DescribeSpot(guild *discord.Guild, name string, details spot.Details) (*spot.Spot, error) {
+	a.log.WithFields(logrus.Fields{"guild": guild.ID, "name": name, "details": details}).Info("describe spot request")
+
+	err := details.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := a.findSpot(guild, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.OwnerGuildID != guild.ID {
+		return nil, fmt.Errorf("respawn %s is shared by all servers and cannot be described by this one", s.Name)
+	}
+
+	res, err := a.spotRepo.UpdateSpotDetails(context.Background(), s.ID, details)
+	if err != nil {
+		return nil, fmt.Errorf("could not describe the respawn: %w", err)
+	}
+
+	return res, nil
+}
+
+// Adds an alternative name members can refer to a spot owned by the guild with.
+// Aliases are unique among names and aliases of spots visible to the guild.
+func (a *Adapter) AddSpotAlias(guild *discord.Guild, name string, alias string) (*spot.Spot, error) {
+	a.log.WithFields(logrus.Fields{"guild": guild.ID, "name": name, "alias": alias}).Info("add spot alias request")
+
+	alias = strings.TrimSpace(alias)
+	err := spot.ValidateName(alias)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := a.findAliasableSpot(guild, name)
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.checkSpotNameAvailable(guild, alias, -1)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := a.spotRepo.UpdateSpotAliases(context.Background(), s.ID, append(slices.Clone(s.Aliases), alias))
+	if err != nil {
+		return nil, fmt.Errorf("could not add the alias: %w", err)
+	}
+
+	return res, nil
+}
+
+// Removes an alternative name of a spot owned by the guild.
+func (a *Adapter) RemoveSpotAlias(guild *discord.Guild, name string, alias string) (*spot.Spot, error) {
+	a.log.WithFields(logrus.Fields{"guild": guild.ID, "name": name, "alias": alias}).Info("remove spot alias request")
+
+	s, err := a.findAliasableSpot(guild, name)
+	if err != nil {
+		return nil, err
+	}
+
+	aliases := slices.DeleteFunc(slices.Clone(s.Aliases), func(existing string) bool {
+		return strings.EqualFold(existing, strings.TrimSpace(alias))
+	})
+	if len(aliases) == len(s.Aliases) {
+		return nil, fmt.Errorf("respawn %s has no alias called %s", s.Name, alias)
+	}
+
+	res, err := a.spotRepo.UpdateSpotAliases(context.Background(), s.ID, aliases)
+	if err != nil {
+		return nil, fmt.Errorf("could not remove the alias: %w", err)
+	}
+
+	return res, nil
+}
+
+// Hides a shared spot from the guild, so that its members can neither see nor book it.
+func (a *Adapter) HideSpot(guild *discord.Guild, name string) (*spot.Spot, error) {
+	a.log.WithFields(logrus.Fields{"guild": guild.ID, "name": name}).Info("hide spot request")
+
+	s, err := a.findSpot(guild, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.Shared() {
+		return nil, fmt.Errorf("respawn %s has been added by this server, archive it instead", s.Name)
+	}
+
+	err = a.spotRepo.HideSpot(context.Background(), guild.ID, s.ID)
+	if err != nil {
+		return nil, fmt.Errorf("could not hide the respawn: %w", err)
+	}
+
+	return s, nil
+}
+
+// Shows a shared spot hidden from the guild again.
+func (a *Adapter) UnhideSpot(guild *discord.Guild, name string) (*spot.Spot, error) {
+	a.log.WithFields(logrus.Fields{"guild": guild.ID, "name": name}).Info("unhide spot request")
+
+	spots, err := a.spotRepo.SelectHiddenSpots(context.Background(), guild.ID)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch hidden spots: %w", err)
+	}
+
+	s, _ := collections.PoorMansFind(spots, func(s *spot.Spot) bool {
+		return s.Name == name
+	})
+	if s == nil {
+		return nil, fmt.Errorf("could not find hidden spot called %s", name)
+	}
+
+	err = a.spotRepo.UnhideSpot(context.Background(), guild.ID, s.ID)
+	if err != nil {
+		return nil, fmt.Errorf("could not show the respawn again: %w", err)
+	}
+
+	return s, nil
+}
+
+// Returns names of shared spots hidden from the guild, filtered by filter, if non-zero length.
+func (a *Adapter) FindHiddenSpots(guild *discord.Guild, filter string) ([]string, error) {
+	spots, err := a.spotRepo.SelectHiddenSpots(context.Background(), guild.ID)
+	if err != nil {
+		return []string{}, fmt.Errorf("could not fetch hidden spots: %w", err)
+	}
+
+	if len(filter) > 0 {
+		spots = rankSpots(spots, filter)
+	}
+
+	spots = collections.Truncate(spots, 15)
+
+	return collections.PoorMansMap(spots, func(s *spot.Spot) string {
+		return s.Name
+	}), nil
+}
+
+// Returns spot called spotName visible to the guild, including archived ones. Names differing
+// in case only and aliases resolve to the spot as well, unless some spot is called exactly spotName.
+func (a *Adapter) findSpot(guild *discord.Guild, spotName string) (*spot.Spot, error) {
+	spots, err := a.spotRepo.SelectAllSpots(context.Background(), guild.ID)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch spots: %w", err)
+	}
+
+	s, _ := collections.PoorMansFind(spots, func(s *spot.Spot) bool {
+		return s.Name == spotName
+	})
+	if s == nil {
+		s, _ = collections.PoorMansFind(spots, func(s *spot.Spot) bool {
+			return spotIsCalled(s, spotName)
+		})
+	}
+	if s == nil {
+		return nil, fmt.Errorf("could not find spot called %s", spotName)
+	}
+
+	return s, nil
+}
+
+// Returns spot called spotName visible to the guild, unless it has been archived.
+func (a *Adapter) findBookableSpot(guild *discord.Guild, spotName string) (*spot.Spot, error) {
+	s, err := a.findSpot(guild, spotName)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.Archived() {
+		return nil, fmt.Errorf("respawn %s has been archived and cannot be booked anymore", s.Name)
+	}
+
+	return s, nil
+}
+
+// Returns spot called spotName, which has been added by the guild. Shared spots can only be hidden.
+func (a *Adapter) findOwnedSpot(guild *discord.Guild, spotName string) (*spot.Spot, error) {
+	s, err := a.findSpot(guild, spotName)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.OwnerGuildID != guild.ID {
+		return nil, fmt.Errorf("respawn %s is shared by all servers, hide it instead", s.Name)
+	}
+
+	return s, nil
+}
+
+// Returns spot called spotName, which aliases can be managed by the guild.
+func (a *Adapter) findAliasableSpot(guild *discord.Guild, spotName string) (*spot.Spot, error) {
+	s, err := a.findSpot(guild, spotName)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.OwnerGuildID != guild.ID {
+		return nil, fmt.Errorf("respawn %s is shared by all servers and its aliases cannot be changed by this one", s.Name)
+	}
+
+	return s, nil
+}
+
+// Returns an error if name is already taken by a spot of the guild other than the ignored one, either
+// as its name or one of its aliases, including shared spots the guild has hidden.
+func (a *Adapter) checkSpotNameAvailable(guild *discord.Guild, name string, ignoredSpotId int64) error {
+	spots, err := a.spotRepo.SelectAllSpots(context.Background(), guild.ID)
+	if err != nil {
+		return fmt.Errorf("could not fetch spots: %w", err)
+	}
+
+	hiddenSpots, err := a.spotRepo.SelectHiddenSpots(context.Background(), guild.ID)
+	if err != nil {
+		return fmt.Errorf("could not fetch hidden spots: %w", err)
+	}
+
+	duplicate, _ := collections.PoorMansFind(append(spots, hiddenSpots...), func(s *spot.Spot) bool {
+		return s.ID != ignoredSpotId && spotIsCalled(s, name)
+	})
+	if duplicate != nil {
+		return fmt.Errorf("respawn called %s already exists", duplicate.Name)
+	}
+
+	return nil
+}
+
+// Returns true if every term of the filter can be found either in the spot name, its aliases or its details.
+func spotMatchesFilter(s *spot.Spot, filter string) bool {
+	names := strings.ToLower(strings.Join(append([]string{s.Name}, s.Aliases...), "\n"))
+	for _, term := range strings.Fields(strings.ToLower(filter)) {
+		if !strings.Contains(names, term) && !s.Details.Matches(term) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Returns true if name equals spot name or one of its aliases, regardless of their case.
+func spotIsCalled(s *spot.Spot, name string) bool {
+	return strings.EqualFold(s.Name, name) || slices.ContainsFunc(s.Aliases, func(alias string) bool {
+		return strings.EqualFold(alias, name)
+	})
+}
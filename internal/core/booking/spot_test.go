@@ -0,0 +1,392 @@
+package booking
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"spot-assistant/internal/common/test/mocks"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/reservation"
+	"spot-assistant/internal/core/dto/spot"
+)
+
+func TestFindAvailableSpotsSkipsArchived(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{
+		{ID: 1, Name: "test-1"},
+		{ID: 2, Name: "test-2", ArchivedAt: time.Now()},
+	}, nil)
+	adapter := NewAdapter(spotRepo, new(mocks.MockReservationRepo), newPolicyRepo())
+
+	// when
+	res, err := adapter.FindAvailableSpots(guild, "test")
+
+	// assert
+	assert.Nil(err)
+	assert.Equal([]string{"test-1"}, res)
+}
+
+func TestAddSpot(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	expectedSpot := &spot.Spot{ID: 2, Name: "Library", OwnerGuildID: guild.ID}
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{{ID: 1, Name: "Asura Palace"}}, nil)
+	spotRepo.On("SelectHiddenSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{}, nil)
+	spotRepo.On("CreateSpot", mocks.ContextMock, guild.ID, "Library", int64(0)).Return(expectedSpot, nil)
+	defer spotRepo.AssertExpectations(t)
+	adapter := NewAdapter(spotRepo, new(mocks.MockReservationRepo), newPolicyRepo())
+
+	// when
+	res, err := adapter.AddSpot(guild, "  Library ", "")
+
+	// assert
+	assert.Nil(err)
+	assert.Equal(expectedSpot, res)
+}
+
+func TestAddSpotAsFloorOfRespawn(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	expectedSpot := &spot.Spot{ID: 2, Name: "Library -1", OwnerGuildID: guild.ID, ParentID: 1}
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{{ID: 1, Name: "Library"}}, nil)
+	spotRepo.On("SelectHiddenSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{}, nil)
+	spotRepo.On("CreateSpot", mocks.ContextMock, guild.ID, "Library -1", int64(1)).Return(expectedSpot, nil)
+	defer spotRepo.AssertExpectations(t)
+	adapter := NewAdapter(spotRepo, new(mocks.MockReservationRepo), newPolicyRepo())
+
+	// when
+	res, err := adapter.AddSpot(guild, "Library -1", "Library")
+
+	// assert
+	assert.Nil(err)
+	assert.Equal(int64(1), res.GroupID())
+}
+
+func TestAddSpotFailOnNestedParent(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{{ID: 1, Name: "Library"}, {ID: 2, Name: "Library -1", ParentID: 1}}, nil)
+	spotRepo.On("SelectHiddenSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{}, nil)
+	adapter := NewAdapter(spotRepo, new(mocks.MockReservationRepo), newPolicyRepo())
+
+	// when
+	res, err := adapter.AddSpot(guild, "Library -1 (NORTH)", "Library -1")
+
+	// assert
+	assert.Nil(res)
+	assert.ErrorContains(err, "already a floor or side")
+	spotRepo.AssertNotCalled(t, "CreateSpot")
+}
+
+func TestAddSpotFailOnDuplicateName(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{{ID: 1, Name: "Library", ArchivedAt: time.Now()}}, nil)
+	spotRepo.On("SelectHiddenSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{}, nil)
+	adapter := NewAdapter(spotRepo, new(mocks.MockReservationRepo), newPolicyRepo())
+
+	// when
+	res, err := adapter.AddSpot(guild, "library", "")
+
+	// assert
+	assert.Nil(res)
+	assert.ErrorContains(err, "respawn called Library already exists")
+	spotRepo.AssertNotCalled(t, "CreateSpot")
+}
+
+func TestAddSpotFailOnHiddenSharedName(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{}, nil)
+	spotRepo.On("SelectHiddenSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{{ID: 1, Name: "Library"}}, nil)
+	adapter := NewAdapter(spotRepo, new(mocks.MockReservationRepo), newPolicyRepo())
+
+	// when
+	res, err := adapter.AddSpot(guild, "Library", "")
+
+	// assert
+	assert.Nil(res)
+	assert.ErrorContains(err, "already exists")
+}
+
+func TestAddSpotFailOnEmptyName(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	spotRepo := new(mocks.MockSpotRepo)
+	adapter := NewAdapter(spotRepo, new(mocks.MockReservationRepo), newPolicyRepo())
+
+	// when
+	res, err := adapter.AddSpot(guild, " ", "")
+
+	// assert
+	assert.Nil(res)
+	assert.NotNil(err)
+}
+
+func TestRenameSpotChangingItsCase(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	expectedSpot := &spot.Spot{ID: 1, Name: "LIBRARY", OwnerGuildID: guild.ID}
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{{ID: 1, Name: "Library", OwnerGuildID: guild.ID}}, nil)
+	spotRepo.On("SelectHiddenSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{}, nil)
+	spotRepo.On("RenameSpot", mocks.ContextMock, int64(1), "LIBRARY").Return(expectedSpot, nil)
+	defer spotRepo.AssertExpectations(t)
+	adapter := NewAdapter(spotRepo, new(mocks.MockReservationRepo), newPolicyRepo())
+
+	// when
+	res, err := adapter.RenameSpot(guild, "Library", "LIBRARY")
+
+	// assert
+	assert.Nil(err)
+	assert.Equal(expectedSpot, res)
+}
+
+func TestRenameSpotFailOnSharedSpot(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{{ID: 1, Name: "Library"}}, nil)
+	adapter := NewAdapter(spotRepo, new(mocks.MockReservationRepo), newPolicyRepo())
+
+	// when
+	res, err := adapter.RenameSpot(guild, "Library", "Library -1")
+
+	// assert
+	assert.Nil(res)
+	assert.ErrorContains(err, "hide it instead")
+	spotRepo.AssertNotCalled(t, "RenameSpot")
+}
+
+func TestArchiveSpot(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	expectedSpot := &spot.Spot{ID: 1, Name: "Library", OwnerGuildID: guild.ID, ArchivedAt: time.Now()}
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{{ID: 1, Name: "Library", OwnerGuildID: guild.ID}}, nil)
+	spotRepo.On("ArchiveSpot", mocks.ContextMock, int64(1)).Return(expectedSpot, nil)
+	defer spotRepo.AssertExpectations(t)
+	adapter := NewAdapter(spotRepo, new(mocks.MockReservationRepo), newPolicyRepo())
+
+	// when
+	res, err := adapter.ArchiveSpot(guild, "Library")
+
+	// assert
+	assert.Nil(err)
+	assert.True(res.Archived())
+}
+
+func TestDescribeSpot(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	details := spot.Details{MinLevel: 200, MaxLevel: 400, Area: "Oramond", Kind: spot.KindTeam}
+	expectedSpot := &spot.Spot{ID: 1, Name: "Library", OwnerGuildID: guild.ID, Details: details}
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{{ID: 1, Name: "Library", OwnerGuildID: guild.ID}}, nil)
+	spotRepo.On("UpdateSpotDetails", mocks.ContextMock, int64(1), details).Return(expectedSpot, nil)
+	defer spotRepo.AssertExpectations(t)
+	adapter := NewAdapter(spotRepo, new(mocks.MockReservationRepo), newPolicyRepo())
+
+	// when
+	res, err := adapter.DescribeSpot(guild, "Library", details)
+
+	// assert
+	assert.Nil(err)
+	assert.Equal(expectedSpot, res)
+}
+
+func TestDescribeSpotFailOnInvalidLevelRange(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	spotRepo := new(mocks.MockSpotRepo)
+	adapter := NewAdapter(spotRepo, new(mocks.MockReservationRepo), newPolicyRepo())
+
+	// when
+	res, err := adapter.DescribeSpot(guild, "Library", spot.Details{MinLevel: 400, MaxLevel: 200})
+
+	// assert
+	assert.Nil(res)
+	assert.ErrorContains(err, "minimum level cannot be greater than maximum level")
+	spotRepo.AssertNotCalled(t, "UpdateSpotDetails")
+}
+
+func TestHideSpot(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	sharedSpot := &spot.Spot{ID: 1, Name: "Library"}
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{sharedSpot}, nil)
+	spotRepo.On("HideSpot", mocks.ContextMock, guild.ID, sharedSpot.ID).Return(nil)
+	defer spotRepo.AssertExpectations(t)
+	adapter := NewAdapter(spotRepo, new(mocks.MockReservationRepo), newPolicyRepo())
+
+	// when
+	res, err := adapter.HideSpot(guild, "Library")
+
+	// assert
+	assert.Nil(err)
+	assert.Equal(sharedSpot, res)
+}
+
+func TestHideSpotFailOnOwnedSpot(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{{ID: 1, Name: "Library", OwnerGuildID: guild.ID}}, nil)
+	adapter := NewAdapter(spotRepo, new(mocks.MockReservationRepo), newPolicyRepo())
+
+	// when
+	res, err := adapter.HideSpot(guild, "Library")
+
+	// assert
+	assert.Nil(res)
+	assert.ErrorContains(err, "archive it instead")
+	spotRepo.AssertNotCalled(t, "HideSpot")
+}
+
+func TestUnhideSpot(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	hiddenSpot := &spot.Spot{ID: 1, Name: "Library"}
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectHiddenSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{hiddenSpot}, nil)
+	spotRepo.On("UnhideSpot", mocks.ContextMock, guild.ID, hiddenSpot.ID).Return(nil)
+	defer spotRepo.AssertExpectations(t)
+	adapter := NewAdapter(spotRepo, new(mocks.MockReservationRepo), newPolicyRepo())
+
+	// when
+	res, err := adapter.UnhideSpot(guild, "Library")
+
+	// assert
+	assert.Nil(err)
+	assert.Equal(hiddenSpot, res)
+}
+
+func TestBookFailOnArchivedSpot(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	member := &discord.Member{ID: "test-member"}
+	startAt := time.Now().Add(1 * time.Minute)
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{{ID: 1, Name: "Library", ArchivedAt: time.Now()}}, nil)
+	adapter := NewAdapter(spotRepo, new(mocks.MockReservationRepo), newPolicyRepo())
+
+	// when
+	res, err := adapter.Book(member, guild, []*discord.Member{}, "Library", startAt, startAt.Add(time.Hour), false, newTestTier(0), newTestRoleChecker())
+
+	// assert
+	assert.Nil(res)
+	assert.ErrorContains(err, "has been archived")
+}
+
+func TestBookByAlias(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	member := &discord.Member{ID: "test-member"}
+	library := &spot.Spot{ID: 1, Name: "Secret Library", Aliases: []string{"libby"}}
+	startAt := time.Now().Add(time.Hour).Truncate(time.Minute)
+	endAt := startAt.Add(time.Hour)
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{{ID: 2, Name: "Library"}, library}, nil)
+	reservationRepo := new(mocks.MockReservationRepo)
+	reservationRepo.On("SelectOverlappingReservations", mocks.ContextMock, library.ID, startAt, endAt, guild.ID).Return([]*reservation.Reservation{}, nil)
+	reservationRepo.On("SelectUpcomingMemberReservationsWithSpots", mocks.ContextMock, guild, member).Return([]*reservation.ReservationWithSpot{}, nil)
+	reservationRepo.On("CreateAndDeleteConflicting", mocks.ContextMock, member, guild, []*discord.Member{}, []*reservation.Reservation{}, library.ID, startAt, endAt, 0).Return([]*reservation.ClippedOrRemovedReservation{}, nil)
+	defer reservationRepo.AssertExpectations(t)
+	adapter := NewAdapter(spotRepo, reservationRepo, newPolicyRepo())
+
+	// when
+	_, err := adapter.Book(member, guild, []*discord.Member{}, "LIBBY", startAt, endAt, false, newTestTier(0), newTestRoleChecker())
+
+	// assert
+	assert.Nil(err)
+}
+
+func TestAddSpotAlias(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	library := &spot.Spot{ID: 1, Name: "Secret Library", OwnerGuildID: guild.ID, Aliases: []string{"sl"}}
+	expectedSpot := &spot.Spot{ID: 1, Name: "Secret Library", OwnerGuildID: guild.ID, Aliases: []string{"sl", "libby"}}
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{library}, nil)
+	spotRepo.On("SelectHiddenSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{}, nil)
+	spotRepo.On("UpdateSpotAliases", mocks.ContextMock, library.ID, []string{"sl", "libby"}).Return(expectedSpot, nil)
+	defer spotRepo.AssertExpectations(t)
+	adapter := NewAdapter(spotRepo, new(mocks.MockReservationRepo), newPolicyRepo())
+
+	// when
+	res, err := adapter.AddSpotAlias(guild, "Secret Library", " libby ")
+
+	// assert
+	assert.Nil(err)
+	assert.Equal(expectedSpot, res)
+}
+
+func TestAddSpotAliasFailOnNameOfAnotherSpot(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{
+		{ID: 1, Name: "Secret Library", OwnerGuildID: guild.ID},
+		{ID: 2, Name: "Library", Aliases: []string{"libby"}},
+	}, nil)
+	spotRepo.On("SelectHiddenSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{}, nil)
+	adapter := NewAdapter(spotRepo, new(mocks.MockReservationRepo), newPolicyRepo())
+
+	// when
+	res, err := adapter.AddSpotAlias(guild, "Secret Library", "Libby")
+
+	// assert
+	assert.Nil(res)
+	assert.ErrorContains(err, "respawn called Library already exists")
+	spotRepo.AssertNotCalled(t, "UpdateSpotAliases")
+}
+
+func TestRemoveSpotAlias(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	library := &spot.Spot{ID: 1, Name: "Secret Library", OwnerGuildID: guild.ID, Aliases: []string{"sl", "libby"}}
+	expectedSpot := &spot.Spot{ID: 1, Name: "Secret Library", OwnerGuildID: guild.ID, Aliases: []string{"sl"}}
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{library}, nil)
+	spotRepo.On("UpdateSpotAliases", mocks.ContextMock, library.ID, []string{"sl"}).Return(expectedSpot, nil)
+	defer spotRepo.AssertExpectations(t)
+	adapter := NewAdapter(spotRepo, new(mocks.MockReservationRepo), newPolicyRepo())
+
+	// when
+	res, err := adapter.RemoveSpotAlias(guild, "Secret Library", "LIBBY")
+
+	// assert
+	assert.Nil(err)
+	assert.Equal(expectedSpot, res)
+	assert.Equal([]string{"sl", "libby"}, library.Aliases)
+}
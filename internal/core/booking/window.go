@@ -0,0 +1,137 @@
+package booking
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"spot-assistant/internal/common/collections"
+	stringsHelper "spot-assistant/internal/common/strings"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/policy"
+	"spot-assistant/internal/core/dto/reservation"
+	"spot-assistant/internal/core/dto/spot"
+)
+
+// Booking window along with the spot it has been set on.
+type resolvedWindow struct {
+	policy.BookingWindow
+	spot *spot.Spot
+}
+
+// Returns openings of the named spots covered by guild booking windows as of currTime,
+// in the order of names. Spots without a window are left out.
+func (a *Adapter) GetSpotOpenings(guild *discord.Guild, spotNames []string, currTime time.Time) ([]*reservation.SpotOpening, error) {
+	p, err := a.GetPolicy(guild)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(p.BookingWindows) == 0 {
+		return []*reservation.SpotOpening{}, nil
+	}
+
+	spots, err := a.spotRepo.SelectAllSpots(context.Background(), guild.ID)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch spots: %w", err)
+	}
+	windows := a.resolveBookingWindows(p, guild, spots)
+
+	openings := make([]*reservation.SpotOpening, 0, len(spotNames))
+	for _, name := range spotNames {
+		s, _ := collections.PoorMansFind(spots, func(s *spot.Spot) bool {
+			return s.Name == name
+		})
+		if s == nil {
+			continue
+		}
+
+		w := findBookingWindow(windows, s.ID, s.ParentID)
+		if w == nil {
+			continue
+		}
+
+		openings = append(openings, &reservation.SpotOpening{
+			Spot:          s.Name,
+			Advance:       w.Advance,
+			NextOpeningAt: w.NextOpening(p.Location(), currTime),
+		})
+	}
+
+	return openings, nil
+}
+
+// Returns an error if booking of the spot for startAt has not opened yet as of currTime.
+func (a *Adapter) checkBookingWindow(p *policy.Policy, guild *discord.Guild, s reservation.Spot, currTime time.Time, startAt time.Time) error {
+	if len(p.BookingWindows) == 0 {
+		return nil
+	}
+
+	spots, err := a.spotRepo.SelectAllSpots(context.Background(), guild.ID)
+	if err != nil {
+		return fmt.Errorf("could not fetch spots: %w", err)
+	}
+
+	w := findBookingWindow(a.resolveBookingWindows(p, guild, spots), s.ID, s.ParentID)
+	if w == nil {
+		return nil
+	}
+
+	openAt := w.OpenAt(p.Location(), startAt)
+	if currTime.Before(openAt) {
+		return fmt.Errorf(
+			"Booking of %s starting %s opens %s",
+			s.Name,
+			stringsHelper.FormatLongTime(startAt.In(p.Location())),
+			stringsHelper.FormatLongTime(openAt.In(p.Location())),
+		)
+	}
+
+	return nil
+}
+
+// Finds spots of guild booking windows. Windows of spots that no longer exist are skipped.
+func (a *Adapter) resolveBookingWindows(p *policy.Policy, guild *discord.Guild, spots []*spot.Spot) []resolvedWindow {
+	windows := make([]resolvedWindow, 0, len(p.BookingWindows))
+	for _, w := range p.BookingWindows {
+		s, _ := collections.PoorMansFind(spots, func(s *spot.Spot) bool {
+			return spotIsCalled(s, w.Spot)
+		})
+		if s == nil {
+			a.log.WithFields(logrus.Fields{"guild": guild.ID, "spot": w.Spot}).Warn("booking window of unknown spot")
+			continue
+		}
+
+		windows = append(windows, resolvedWindow{BookingWindow: w, spot: s})
+	}
+
+	return windows
+}
+
+// Returns window of the spot of a given ID, falling back to the one of the respawn it is a floor
+// or side of, or nil if there is none.
+func findBookingWindow(windows []resolvedWindow, spotID int64, parentID int64) *resolvedWindow {
+	return findSpotRule(windows, func(w resolvedWindow) *spot.Spot {
+		return w.spot
+	}, spotID, parentID)
+}
+
+// Returns rule set on the spot of a given ID, falling back to the one set on the respawn it is
+// a floor or side of, or nil if there is none.
+func findSpotRule[T any](rules []T, ruleSpot func(T) *spot.Spot, spotID int64, parentID int64) *T {
+	_, index := collections.PoorMansFind(rules, func(r T) bool {
+		return ruleSpot(r).ID == spotID
+	})
+	if index == -1 && parentID != 0 {
+		_, index = collections.PoorMansFind(rules, func(r T) bool {
+			return ruleSpot(r).ID == parentID
+		})
+	}
+	if index == -1 {
+		return nil
+	}
+
+	return &rules[index]
+}
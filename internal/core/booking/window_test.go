@@ -0,0 +1,152 @@
+package booking
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"spot-assistant/internal/common/test/mocks"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/policy"
+	"spot-assistant/internal/core/dto/reservation"
+	"spot-assistant/internal/core/dto/spot"
+)
+
+func TestBookFailsBeforeBookingWindowOfRespawnOpens(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	member := &discord.Member{ID: "test-member"}
+	p := newTestPolicy(guild.ID)
+	p.BookingWindows = []policy.BookingWindow{{Spot: "soul war", Advance: 24 * time.Hour}}
+	policyRepo := new(mocks.MockPolicyRepo)
+	policyRepo.On("FindGuildPolicy", mocks.ContextMock, guild.ID).Return(p, nil)
+	respawn := &spot.Spot{ID: 1, Name: "Soul War"}
+	floor := &spot.Spot{ID: 2, Name: "Soul War -1", ParentID: respawn.ID}
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{respawn, floor}, nil)
+	reservationRepo := new(mocks.MockReservationRepo)
+	adapter := NewAdapter(spotRepo, reservationRepo, policyRepo)
+	startAt := time.Now().Add(48 * time.Hour).Truncate(time.Minute)
+
+	// when
+	_, err := adapter.Book(member, guild, []*discord.Member{}, floor.Name, startAt, startAt.Add(2*time.Hour), false, newTestTier(0), newTestRoleChecker())
+
+	// assert
+	assert.EqualError(err, fmt.Sprintf(
+		"Booking of Soul War -1 starting %s opens %s",
+		startAt.In(p.Location()).Format("2006-01-02 15:04 MST"),
+		startAt.Add(-24*time.Hour).In(p.Location()).Format("2006-01-02 15:04 MST"),
+	))
+	reservationRepo.AssertNotCalled(t, "CreateAndDeleteConflicting")
+}
+
+func TestGetSpotOpeningsPrefersOwnWindowOfFloor(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	opensAt := 18 * time.Hour
+	p := newTestPolicy(guild.ID)
+	p.TimeZone = "Europe/Warsaw"
+	p.BookingWindows = []policy.BookingWindow{
+		{Spot: "Soul War", Advance: 48 * time.Hour},
+		{Spot: "Soul War -2", OpensAt: &opensAt},
+	}
+	policyRepo := new(mocks.MockPolicyRepo)
+	policyRepo.On("FindGuildPolicy", mocks.ContextMock, guild.ID).Return(p, nil)
+	respawn := &spot.Spot{ID: 1, Name: "Soul War"}
+	firstFloor := &spot.Spot{ID: 2, Name: "Soul War -1", ParentID: respawn.ID}
+	secondFloor := &spot.Spot{ID: 3, Name: "Soul War -2", ParentID: respawn.ID}
+	other := &spot.Spot{ID: 4, Name: "Library"}
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{respawn, firstFloor, secondFloor, other}, nil)
+	adapter := NewAdapter(spotRepo, new(mocks.MockReservationRepo), policyRepo)
+	currTime := time.Date(2026, 10, 17, 19, 0, 0, 0, p.Location())
+
+	// when
+	res, err := adapter.GetSpotOpenings(guild, []string{firstFloor.Name, secondFloor.Name, other.Name}, currTime)
+
+	// assert
+	assert.Nil(err)
+	assert.Equal([]*reservation.SpotOpening{
+		{Spot: firstFloor.Name, Advance: 48 * time.Hour},
+		{Spot: secondFloor.Name, NextOpeningAt: time.Date(2026, 10, 18, 18, 0, 0, 0, p.Location())},
+	}, res)
+}
+
+func TestMaterializeSeriesPostponesOccurrencesBeforeBookingWindowOpens(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	p := newTestPolicy(guild.ID)
+	p.BookingWindows = []policy.BookingWindow{{Spot: "soul war", Advance: 48 * time.Hour}}
+	policyRepo := new(mocks.MockPolicyRepo)
+	policyRepo.On("FindGuildPolicy", mocks.ContextMock, guild.ID).Return(p, nil)
+	respawn := &spot.Spot{ID: 1, Name: "Soul War"}
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{respawn}, nil)
+	series := &reservation.SeriesWithSpot{
+		Series: reservation.Series{
+			ID:              1,
+			AuthorDiscordID: "test-member-id",
+			Weekdays:        []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday},
+			StartTime:       18 * time.Hour,
+			EndTime:         20 * time.Hour,
+		},
+		Spot: reservation.Spot{ID: respawn.ID, Name: respawn.Name},
+	}
+	opensUntil := time.Now().Add(48 * time.Hour)
+	reservationRepo := new(mocks.MockReservationRepo)
+	reservationRepo.On("SelectSeriesToMaterialize", mocks.ContextMock, guild.ID, mock.Anything).Return([]*reservation.SeriesWithSpot{series}, nil)
+	reservationRepo.On("SelectOverlappingReservations", mocks.ContextMock, respawn.ID, mock.Anything, mock.Anything, guild.ID).Return([]*reservation.Reservation{}, nil)
+	reservationRepo.On("SelectUpcomingMemberReservationsWithSpots", mocks.ContextMock, guild, mock.Anything).Return([]*reservation.ReservationWithSpot{}, nil)
+	reservationRepo.On("CreateSeriesReservation", mocks.ContextMock, &series.Series, mock.MatchedBy(func(startAt time.Time) bool {
+		return startAt.Before(opensUntil)
+	}), mock.Anything, 0).Return(&reservation.Reservation{}, nil)
+	// Next occurrence is materialized once its booking opens
+	reservationRepo.On("UpdateSeriesMaterializedUntil", mocks.ContextMock, series.Series.ID, mock.MatchedBy(func(until time.Time) bool {
+		return until.After(opensUntil.Add(-time.Second)) && until.Before(opensUntil.Add(24*time.Hour))
+	})).Return(nil)
+	defer reservationRepo.AssertExpectations(t)
+	adapter := NewAdapter(spotRepo, reservationRepo, policyRepo)
+
+	// when
+	res, err := adapter.MaterializeSeries(guild, newTestRoleChecker())
+
+	// assert
+	assert.Nil(err)
+	assert.Len(res, 2)
+}
+
+func TestProcessQueueLeavesEntriesBeforeBookingWindowOpens(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	p := newTestPolicy(guild.ID)
+	p.BookingWindows = []policy.BookingWindow{{Spot: "soul war", Advance: 24 * time.Hour}}
+	policyRepo := new(mocks.MockPolicyRepo)
+	policyRepo.On("FindGuildPolicy", mocks.ContextMock, guild.ID).Return(p, nil)
+	respawn := &spot.Spot{ID: 1, Name: "Soul War"}
+	spotRepo := new(mocks.MockSpotRepo)
+	spotRepo.On("SelectAllSpots", mocks.ContextMock, guild.ID).Return([]*spot.Spot{respawn}, nil)
+	startAt := time.Now().Add(48 * time.Hour)
+	entry := &reservation.QueueEntryWithSpot{
+		QueueEntry: reservation.QueueEntry{ID: 1, AuthorDiscordID: "test-member-id", StartAt: startAt, EndAt: startAt.Add(2 * time.Hour)},
+		Spot:       reservation.Spot{ID: respawn.ID, Name: respawn.Name},
+	}
+	reservationRepo := new(mocks.MockReservationRepo)
+	reservationRepo.On("DeleteExpiredQueueEntries", mocks.ContextMock, guild.ID).Return(nil)
+	reservationRepo.On("SelectQueueEntriesWithSpots", mocks.ContextMock, guild.ID).Return([]*reservation.QueueEntryWithSpot{entry}, nil)
+	adapter := NewAdapter(spotRepo, reservationRepo, policyRepo)
+
+	// when
+	res, err := adapter.ProcessQueue(guild, newTestRoleChecker())
+
+	// assert
+	assert.Nil(err)
+	assert.Empty(res)
+	reservationRepo.AssertNotCalled(t, "CreateReservationFromQueueEntry", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -14,22 +14,37 @@ const (
 	BookAutocompleteStartAt
 	BookAutocompleteEndAt
 	BookAutocompleteOverbook
+	BookAutocompleteDate
 )
 
 // Request for autocompletion during Booking process
 type BookAutocompleteRequest struct {
-	Field BookAutocompleteFocus
+	Guild  *discord.Guild
+	Member *discord.Member
+	Field  BookAutocompleteFocus
+	Value  string
+
+	// Respawn and date chosen so far, if any, so that free hours can be suggested first
+	Spot string
+	Date string
+}
+
+// Choice suggested during booking process. Label is shown to member in place of the value, if set.
+type BookAutocompleteChoice struct {
 	Value string
+	Label string
 }
 
 // Response for autocompletion during booking process
-type BookAutocompleteResponse []string
+type BookAutocompleteResponse []BookAutocompleteChoice
 
 // Booking request
 type BookRequest struct {
 	*discord.Guild
 	*discord.Member
 
+	// Co-hunters taking part in the reservation
+	Party    []*discord.Member
 	Spot     string
 	StartAt  time.Time
 	EndAt    time.Time
@@ -42,4 +57,21 @@ type BookResponse struct {
 	EndAt   time.Time
 
 	ConflictingReservations []*reservation.ClippedOrRemovedReservation
+
+	// Free slots proposed when conflicting reservations prevented booking
+	Alternatives []*reservation.Alternative
+
+	// Overbook waiting for approval of the affected members, instead of being booked right away
+	OverbookRequest *reservation.OverbookRequestWithSpot
+}
+
+// Request to book an alternative slot proposed on conflict. Spot is referred to by its ID,
+// as alternatives are carried by message components, which cannot hold long names.
+type BookAlternativeRequest struct {
+	*discord.Guild
+	*discord.Member
+
+	SpotID  int64
+	StartAt time.Time
+	EndAt   time.Time
 }
@@ -0,0 +1,11 @@
+package book
+
+import (
+	"spot-assistant/internal/core/dto/discord"
+)
+
+type CheckInRequest struct {
+	Member        *discord.Member
+	Guild         *discord.Guild
+	ReservationID int64
+}
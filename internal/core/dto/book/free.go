@@ -0,0 +1,25 @@
+package book
+
+import (
+	"time"
+
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/reservation"
+)
+
+// Request for free time windows of a spot. Without a date, windows within the next 24 hours are returned.
+type FreeRequest struct {
+	*discord.Guild
+	*discord.Member
+
+	Spot string
+	// Midnight of the day in member time zone, nil if not specified
+	Date *time.Time
+}
+
+type FreeResponse struct {
+	Spot    string
+	From    time.Time
+	To      time.Time
+	Windows []*reservation.Window
+}
@@ -0,0 +1,32 @@
+package book
+
+import (
+	"time"
+
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/reservation"
+)
+
+// Request to tentatively reserve a spot, e.g. while gathering a party
+type HoldRequest struct {
+	*discord.Guild
+	*discord.Member
+
+	Spot    string
+	StartAt time.Time
+	EndAt   time.Time
+}
+
+type HoldResponse struct {
+	Hold *reservation.ReservationWithSpot
+
+	// Reservations that prevented holding the spot
+	ConflictingReservations []*reservation.ClippedOrRemovedReservation
+}
+
+// Request to turn a hold into a regular reservation
+type ConfirmRequest struct {
+	Member        *discord.Member
+	Guild         *discord.Guild
+	ReservationID int64
+}
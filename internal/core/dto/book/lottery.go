@@ -0,0 +1,17 @@
+package book
+
+import (
+	"time"
+
+	"spot-assistant/internal/core/dto/discord"
+)
+
+// Request to apply for a reservation of a spot handed out by lottery
+type ApplyRequest struct {
+	*discord.Guild
+	*discord.Member
+
+	Spot    string
+	StartAt time.Time
+	EndAt   time.Time
+}
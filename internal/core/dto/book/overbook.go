@@ -0,0 +1,13 @@
+package book
+
+import (
+	"spot-assistant/internal/core/dto/discord"
+)
+
+// Answer of a member, whose reservation would be clipped or removed by an overbook.
+type OverbookAnswerRequest struct {
+	Guild     *discord.Guild
+	Member    *discord.Member
+	RequestID int64
+	Accepted  bool
+}
@@ -0,0 +1,13 @@
+package book
+
+import (
+	"spot-assistant/internal/core/dto/discord"
+)
+
+// Request to add or remove a co-hunter from member reservation
+type PartyRequest struct {
+	Member        *discord.Member
+	Guild         *discord.Guild
+	PartyMember   *discord.Member
+	ReservationID int64
+}
@@ -0,0 +1,17 @@
+package book
+
+import (
+	"time"
+
+	"spot-assistant/internal/core/dto/discord"
+)
+
+// Request to be put on a waitlist for an occupied spot
+type QueueRequest struct {
+	*discord.Guild
+	*discord.Member
+
+	Spot    string
+	StartAt time.Time
+	EndAt   time.Time
+}
@@ -0,0 +1,27 @@
+package book
+
+import (
+	"time"
+
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/reservation"
+)
+
+// Request to move an existing reservation. Nil fields keep their current values,
+// StartTime and EndTime are offsets from midnight in member time zone.
+type RebookRequest struct {
+	*discord.Guild
+	*discord.Member
+
+	ReservationID int64
+	Date          *time.Time
+	StartTime     *time.Duration
+	EndTime       *time.Duration
+	Overbook      bool
+}
+
+type RebookResponse struct {
+	Reservation *reservation.ReservationWithSpot
+
+	ConflictingReservations []*reservation.ClippedOrRemovedReservation
+}
@@ -0,0 +1,54 @@
+package book
+
+import (
+	"time"
+
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/reservation"
+)
+
+type SeriesAutocompleteFocus int
+
+const (
+	SeriesAutocompleteSpot SeriesAutocompleteFocus = iota
+	SeriesAutocompleteWeekdays
+	SeriesAutocompleteStartAt
+	SeriesAutocompleteEndAt
+)
+
+// Request for autocompletion during series creation
+type SeriesAutocompleteRequest struct {
+	Guild *discord.Guild
+	Field SeriesAutocompleteFocus
+	Value string
+}
+
+// Response for autocompletion during series creation
+type SeriesAutocompleteResponse []string
+
+// Weekly series request. StartTime and EndTime are offsets from midnight.
+type SeriesRequest struct {
+	*discord.Guild
+	*discord.Member
+
+	Spot      string
+	Weekdays  []time.Weekday
+	StartTime time.Duration
+	EndTime   time.Duration
+}
+
+type SeriesListRequest struct {
+	Member *discord.Member
+	Guild  *discord.Guild
+	Value  string
+}
+
+type SeriesListResponse struct {
+	Series []*reservation.SeriesWithSpot
+}
+
+type SeriesCancelRequest struct {
+	Member   *discord.Member
+	Guild    *discord.Guild
+	SeriesID int64
+}
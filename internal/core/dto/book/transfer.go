@@ -0,0 +1,12 @@
+package book
+
+import (
+	"spot-assistant/internal/core/dto/discord"
+)
+
+type TransferRequest struct {
+	Member        *discord.Member
+	Guild         *discord.Guild
+	Recipient     *discord.Member
+	ReservationID int64
+}
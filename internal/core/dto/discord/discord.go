@@ -69,6 +69,15 @@ type Member struct {
 	Permissions int64 `json:"permissions,string"`
 }
 
+// DisplayName returns member nickname, or username when there's none.
+func (m *Member) DisplayName() string {
+	if len(m.Nick) > 0 {
+		return m.Nick
+	}
+
+	return m.Username
+}
+
 type Message struct {
 	// The ID of the message.
 	ID string `json:"id"`
@@ -0,0 +1,143 @@
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// Tibia saves its servers daily at 10:00 CET, which makes every hunt crossing it meaningless
+	DEFAULT_SERVER_SAVE_NAME      = "Server save"
+	DEFAULT_SERVER_SAVE_START     = 10 * time.Hour
+	DEFAULT_SERVER_SAVE_LENGTH    = 10 * time.Minute
+	DEFAULT_SERVER_SAVE_TIME_ZONE = "Europe/Berlin"
+
+	MAXIMUM_BLACKOUTS            = 5
+	MAXIMUM_BLACKOUT_NAME_LENGTH = 50
+)
+
+// Blackout is a daily time window, in which respawns cannot be hunted, e.g. the server save.
+type Blackout struct {
+	Name string
+
+	// Offset from midnight in the blackout time zone
+	Start  time.Duration
+	Length time.Duration
+
+	// IANA name of the time zone start is given in, which may differ from the guild one
+	TimeZone string
+}
+
+// BlackoutWindow is a single occurrence of a blackout.
+type BlackoutWindow struct {
+	Name    string
+	StartAt time.Time
+	EndAt   time.Time
+}
+
+// NewServerSaveBlackout returns blackout every guild starts with.
+func NewServerSaveBlackout() Blackout {
+	return Blackout{
+		Name:     DEFAULT_SERVER_SAVE_NAME,
+		Start:    DEFAULT_SERVER_SAVE_START,
+		Length:   DEFAULT_SERVER_SAVE_LENGTH,
+		TimeZone: DEFAULT_SERVER_SAVE_TIME_ZONE,
+	}
+}
+
+func (b Blackout) Validate() error {
+	if len(strings.TrimSpace(b.Name)) == 0 || utf8.RuneCountInString(b.Name) > MAXIMUM_BLACKOUT_NAME_LENGTH {
+		return fmt.Errorf("blackout name has to be between 1 and %d characters", MAXIMUM_BLACKOUT_NAME_LENGTH)
+	}
+
+	if b.Start < 0 || b.Start >= 24*time.Hour {
+		return errors.New("blackout has to start between 00:00 and 23:59")
+	}
+
+	if b.Length < time.Minute || b.Length > 3*time.Hour {
+		return errors.New("blackout has to take between 1 minute and 3 hours")
+	}
+
+	if _, err := time.LoadLocation(b.TimeZone); err != nil {
+		return fmt.Errorf("unknown time zone %s, use names such as Europe/Warsaw or America/Sao_Paulo", b.TimeZone)
+	}
+
+	return nil
+}
+
+// Location returns the blackout time zone, falling back to the server one.
+func (b Blackout) Location() *time.Location {
+	loc, err := time.LoadLocation(b.TimeZone)
+	if err != nil || len(b.TimeZone) == 0 {
+		return time.Local
+	}
+
+	return loc
+}
+
+// Windows returns occurrences of the blackout overlapping the time between from and to.
+func (b Blackout) Windows(from time.Time, to time.Time) []BlackoutWindow {
+	loc := b.Location()
+	windows := []BlackoutWindow{}
+
+	// Start a day earlier, as the previous occurrence might last past midnight. Days start at midnight,
+	// so that the last one is not dropped when to falls on the next day, but earlier in it than from.
+	from = from.In(loc)
+	day := time.Date(from.Year(), from.Month(), from.Day()-1, 0, 0, 0, 0, loc)
+	for ; !day.After(to.In(loc)); day = day.AddDate(0, 0, 1) {
+		// Hour and minute are set explicitly, so that the blackout keeps its local hour over DST changes
+		startAt := time.Date(day.Year(), day.Month(), day.Day(), int(b.Start/time.Hour), int(b.Start%time.Hour/time.Minute), 0, 0, loc)
+		endAt := startAt.Add(b.Length)
+		if startAt.Before(to) && endAt.After(from) {
+			windows = append(windows, BlackoutWindow{Name: b.Name, StartAt: startAt, EndAt: endAt})
+		}
+	}
+
+	return windows
+}
+
+// BlackoutWindows returns occurrences of all guild blackouts overlapping the time between from and to,
+// ordered by their start.
+func (p *Policy) BlackoutWindows(from time.Time, to time.Time) []BlackoutWindow {
+	windows := []BlackoutWindow{}
+	for _, b := range p.Blackouts {
+		windows = append(windows, b.Windows(from, to)...)
+	}
+
+	slices.SortFunc(windows, func(a BlackoutWindow, b BlackoutWindow) int {
+		return a.StartAt.Compare(b.StartAt)
+	})
+
+	return windows
+}
+
+// InBlackout returns true if t falls within one of the guild blackouts.
+func (p *Policy) InBlackout(t time.Time) bool {
+	return len(p.BlackoutWindows(t, t.Add(time.Nanosecond))) > 0
+}
+
+// ClipToBlackouts shortens a reservation starting or ending within a blackout, so that it stays
+// clear of it. Reservations crossing a blackout, or falling entirely within one, are rejected.
+func (p *Policy) ClipToBlackouts(startAt time.Time, endAt time.Time) (time.Time, time.Time, error) {
+	for _, w := range p.BlackoutWindows(startAt, endAt) {
+		switch {
+		case !w.StartAt.Before(endAt) || !w.EndAt.After(startAt):
+			// Clipped off by one of the previous blackouts
+			continue
+		case !startAt.Before(w.StartAt) && !endAt.After(w.EndAt):
+			return startAt, endAt, fmt.Errorf("reservation falls entirely within %s", w.Name)
+		case startAt.Before(w.StartAt) && endAt.After(w.EndAt):
+			return startAt, endAt, fmt.Errorf("reservation cannot cross %s, end it before it starts or start it after it ends", w.Name)
+		case !startAt.Before(w.StartAt):
+			startAt = w.EndAt
+		default:
+			endAt = w.StartAt
+		}
+	}
+
+	return startAt, endAt, nil
+}
@@ -0,0 +1,53 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlackoutWindows(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	blackout := Blackout{Name: "Maintenance", Start: 30 * time.Minute, Length: 10 * time.Minute, TimeZone: "UTC"}
+	day := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+	inputs := [][2]time.Time{
+		// Within a single day
+		{day, day.Add(35 * time.Minute)},
+		{day.Add(1 * time.Hour), day.Add(24 * time.Hour)},
+		// Crossing midnight, ending earlier in the day than it starts
+		{day.Add(23 * time.Hour), day.Add(25 * time.Hour)},
+		{day.Add(23 * time.Hour), day.Add(49 * time.Hour)},
+	}
+
+	// when
+	res := make([][]time.Time, len(inputs))
+	for i, input := range inputs {
+		res[i] = []time.Time{}
+		for _, w := range blackout.Windows(input[0], input[1]) {
+			res[i] = append(res[i], w.StartAt)
+		}
+	}
+
+	// assert
+	assert.Equal([][]time.Time{
+		{day.Add(30 * time.Minute)},
+		{},
+		{day.Add(24*time.Hour + 30*time.Minute)},
+		{day.Add(24*time.Hour + 30*time.Minute), day.Add(48*time.Hour + 30*time.Minute)},
+	}, res)
+}
+
+func TestBlackoutWindowsLastingPastMidnight(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	blackout := Blackout{Name: "Maintenance", Start: 23*time.Hour + 55*time.Minute, Length: 10 * time.Minute, TimeZone: "UTC"}
+	day := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+
+	// when
+	res := blackout.Windows(day, day.Add(3*time.Minute))
+
+	// assert
+	assert.Equal([]BlackoutWindow{{Name: blackout.Name, StartAt: day.Add(-5 * time.Minute), EndAt: day.Add(5 * time.Minute)}}, res)
+}
@@ -0,0 +1,64 @@
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const MAXIMUM_LOTTERIES = 10
+
+// Lottery hands out reservations of a contested spot by drawing lots among member applications,
+// instead of rewarding whoever books first. Applications for reservations starting on a day are
+// accepted until the draw on the day before, after which remaining free time can be booked as usual.
+// A lottery of a respawn applies to all of its floors and sides, unless they have a lottery of their own.
+type Lottery struct {
+	Spot string
+
+	// Offset from midnight in the guild time zone, at which lots are drawn
+	DrawAt time.Duration
+
+	// Whether members who have won recently get lower chances
+	Weighted bool
+}
+
+func (l Lottery) Validate() error {
+	if len(strings.TrimSpace(l.Spot)) == 0 {
+		return errors.New("lottery spot cannot be empty")
+	}
+
+	if l.DrawAt < 0 || l.DrawAt >= 24*time.Hour {
+		return errors.New("lottery has to be drawn between 00:00 and 23:59")
+	}
+
+	return nil
+}
+
+// DrawTime returns when lots for reservations starting at startAt are drawn, which is on the day
+// before, at the draw time given in loc.
+func (l Lottery) DrawTime(loc *time.Location, startAt time.Time) time.Time {
+	startAt = startAt.In(loc)
+
+	return time.Date(startAt.Year(), startAt.Month(), startAt.Day()-1, 0, 0, 0, 0, loc).Add(l.DrawAt)
+}
+
+func (p *Policy) validateLotteries() error {
+	if len(p.Lotteries) > MAXIMUM_LOTTERIES {
+		return fmt.Errorf("there can be at most %d lotteries", MAXIMUM_LOTTERIES)
+	}
+
+	for i, l := range p.Lotteries {
+		if err := l.Validate(); err != nil {
+			return err
+		}
+
+		for _, other := range p.Lotteries[:i] {
+			if strings.EqualFold(l.Spot, other.Spot) {
+				return fmt.Errorf("there is already a lottery of %s", other.Spot)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,161 @@
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	DEFAULT_MAXIMUM_RESERVATIONS_TIME = 3 * time.Hour
+	DEFAULT_MAXIMUM_RESERVATION_TIME  = 3 * time.Hour
+	DEFAULT_OVERBOOK_ROLE             = "Postman"
+	DEFAULT_SUGGESTION_STEP           = 30 * time.Minute
+	DEFAULT_BOOKING_HORIZON           = 7 * 24 * time.Hour
+	DEFAULT_CHECK_IN_GRACE_PERIOD     = 15 * time.Minute
+	DEFAULT_OVERBOOK_APPROVAL_TIMEOUT = 15 * time.Minute
+)
+
+// Policy holds booking rules of a single guild.
+type Policy struct {
+	GuildID string
+
+	// Maximum time of member reservations within 24 hour window
+	MaximumReservationsTime time.Duration
+
+	// Maximum time of a single reservation
+	MaximumReservationTime time.Duration
+
+	// Name of the role allowed to overbook other members
+	OverbookRole string
+
+	// Interval between suggested hours
+	SuggestionStep time.Duration
+
+	// How far ahead reservations can be made
+	BookingHorizon time.Duration
+
+	// IANA name of the guild time zone, empty means the server time zone
+	TimeZone string
+
+	// Whether time spent in parties of other members counts toward maximum reservations time
+	PartyTimeCounted bool
+
+	// How long after the start of a reservation its author can check in, before it becomes claimable by anyone
+	CheckInGracePeriod time.Duration
+
+	// Daily time windows, in which respawns cannot be hunted
+	Blackouts []Blackout
+
+	// Whether overbooks wait for approval of members they would clip or remove reservations of
+	OverbookApproval bool
+
+	// How long overbooks wait for approval, before they get approved automatically
+	OverbookApprovalTimeout time.Duration
+
+	// Roles granting booking priority and their own maximum reservations time, in place of the overbook role
+	Tiers []Tier
+
+	// Limits of how long each member can hunt given spots within a calendar week
+	WeeklyQuotas []WeeklyQuota
+
+	// Rules of since when given spots can be booked
+	BookingWindows []BookingWindow
+
+	// Spots handed out by drawing lots among member applications
+	Lotteries []Lottery
+}
+
+// NewDefaultPolicy returns policy used by guilds that have not configured their own.
+func NewDefaultPolicy(guildID string) *Policy {
+	return &Policy{
+		GuildID:                 guildID,
+		MaximumReservationsTime: DEFAULT_MAXIMUM_RESERVATIONS_TIME,
+		MaximumReservationTime:  DEFAULT_MAXIMUM_RESERVATION_TIME,
+		OverbookRole:            DEFAULT_OVERBOOK_ROLE,
+		SuggestionStep:          DEFAULT_SUGGESTION_STEP,
+		BookingHorizon:          DEFAULT_BOOKING_HORIZON,
+		CheckInGracePeriod:      DEFAULT_CHECK_IN_GRACE_PERIOD,
+		Blackouts:               []Blackout{NewServerSaveBlackout()},
+		OverbookApprovalTimeout: DEFAULT_OVERBOOK_APPROVAL_TIMEOUT,
+		Tiers:                   []Tier{},
+		WeeklyQuotas:            []WeeklyQuota{},
+		BookingWindows:          []BookingWindow{},
+		Lotteries:               []Lottery{},
+	}
+}
+
+func (p *Policy) Validate() error {
+	if p.MaximumReservationTime < time.Minute || p.MaximumReservationTime > 24*time.Hour {
+		return errors.New("maximum reservation time has to be between 1 minute and 24 hours")
+	}
+
+	if p.MaximumReservationsTime < p.MaximumReservationTime || p.MaximumReservationsTime > 24*time.Hour {
+		return errors.New("maximum reservations time has to be between maximum reservation time and 24 hours")
+	}
+
+	if len(p.OverbookRole) == 0 {
+		return errors.New("overbook role cannot be empty")
+	}
+
+	if p.SuggestionStep < 5*time.Minute || p.SuggestionStep > 3*time.Hour {
+		return errors.New("suggestion step has to be between 5 minutes and 3 hours")
+	}
+
+	if _, err := time.LoadLocation(p.TimeZone); err != nil {
+		return fmt.Errorf("unknown time zone %s, use names such as Europe/Warsaw or America/Sao_Paulo", p.TimeZone)
+	}
+
+	if p.BookingHorizon < 24*time.Hour || p.BookingHorizon > 90*24*time.Hour {
+		return errors.New("booking horizon has to be between 1 and 90 days")
+	}
+
+	if p.CheckInGracePeriod < 5*time.Minute || p.CheckInGracePeriod > 2*time.Hour {
+		return errors.New("check-in grace period has to be between 5 minutes and 2 hours")
+	}
+
+	if p.OverbookApprovalTimeout < 5*time.Minute || p.OverbookApprovalTimeout > 24*time.Hour {
+		return errors.New("overbook approval timeout has to be between 5 minutes and 24 hours")
+	}
+
+	if len(p.Blackouts) > MAXIMUM_BLACKOUTS {
+		return fmt.Errorf("there can be at most %d blackouts", MAXIMUM_BLACKOUTS)
+	}
+
+	for i, b := range p.Blackouts {
+		if err := b.Validate(); err != nil {
+			return err
+		}
+
+		for _, other := range p.Blackouts[:i] {
+			if strings.EqualFold(b.Name, other.Name) {
+				return fmt.Errorf("there is already a blackout called %s", other.Name)
+			}
+		}
+	}
+
+	if err := p.validateTiers(); err != nil {
+		return err
+	}
+
+	if err := p.validateWeeklyQuotas(); err != nil {
+		return err
+	}
+
+	if err := p.validateBookingWindows(); err != nil {
+		return err
+	}
+
+	return p.validateLotteries()
+}
+
+// Location returns the guild time zone, falling back to the server one.
+func (p *Policy) Location() *time.Location {
+	loc, err := time.LoadLocation(p.TimeZone)
+	if err != nil || len(p.TimeZone) == 0 {
+		return time.Local
+	}
+
+	return loc
+}
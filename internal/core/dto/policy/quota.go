@@ -0,0 +1,60 @@
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const MAXIMUM_WEEKLY_QUOTAS = 20
+
+// WeeklyQuota limits how long each member can hunt a spot within a calendar week. A quota
+// of a respawn applies to all of its floors and sides together, while a quota of a single
+// floor or side applies to it alone.
+type WeeklyQuota struct {
+	Spot  string
+	Limit time.Duration
+}
+
+func (q WeeklyQuota) Validate() error {
+	if len(strings.TrimSpace(q.Spot)) == 0 {
+		return errors.New("weekly quota spot cannot be empty")
+	}
+
+	if q.Limit < time.Hour || q.Limit > 7*24*time.Hour {
+		return errors.New("weekly quota has to be between 1 and 168 hours")
+	}
+
+	return nil
+}
+
+// Week returns the start and end of the calendar week t falls within, which begins
+// on Monday midnight in the guild time zone.
+func (p *Policy) Week(t time.Time) (time.Time, time.Time) {
+	t = t.In(p.Location())
+	daysSinceMonday := (int(t.Weekday()) + 6) % 7
+	startAt := time.Date(t.Year(), t.Month(), t.Day()-daysSinceMonday, 0, 0, 0, 0, t.Location())
+
+	return startAt, startAt.AddDate(0, 0, 7)
+}
+
+func (p *Policy) validateWeeklyQuotas() error {
+	if len(p.WeeklyQuotas) > MAXIMUM_WEEKLY_QUOTAS {
+		return fmt.Errorf("there can be at most %d weekly quotas", MAXIMUM_WEEKLY_QUOTAS)
+	}
+
+	for i, q := range p.WeeklyQuotas {
+		if err := q.Validate(); err != nil {
+			return err
+		}
+
+		for _, other := range p.WeeklyQuotas[:i] {
+			if strings.EqualFold(q.Spot, other.Spot) {
+				return fmt.Errorf("there is already a weekly quota of %s", other.Spot)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package policy
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	DEFAULT_START_REMINDER_LEAD_TIME = 15 * time.Minute
+	DEFAULT_END_REMINDER_LEAD_TIME   = 10 * time.Minute
+)
+
+// ReminderPreference holds member choices about reservation reminder DMs.
+type ReminderPreference struct {
+	GuildID  string
+	MemberID string
+
+	// Whether member wants to receive reminders at all
+	Enabled bool
+
+	// How long before the start of a reservation its reminder is sent, zero disables it
+	StartLeadTime time.Duration
+
+	// How long before the end of a reservation its reminder is sent, zero disables it
+	EndLeadTime time.Duration
+}
+
+// NewDefaultReminderPreference returns preference of members that have not configured their own.
+func NewDefaultReminderPreference(guildID string, memberID string) *ReminderPreference {
+	return &ReminderPreference{
+		GuildID:       guildID,
+		MemberID:      memberID,
+		Enabled:       true,
+		StartLeadTime: DEFAULT_START_REMINDER_LEAD_TIME,
+		EndLeadTime:   DEFAULT_END_REMINDER_LEAD_TIME,
+	}
+}
+
+func (p *ReminderPreference) Validate() error {
+	if p.StartLeadTime < 0 || p.StartLeadTime > 24*time.Hour {
+		return errors.New("reminder before the start has to be between 0 minutes and 24 hours")
+	}
+
+	if p.EndLeadTime < 0 || p.EndLeadTime > 24*time.Hour {
+		return errors.New("reminder before the end has to be between 0 minutes and 24 hours")
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package policy
+
+import (
+	"time"
+
+	"spot-assistant/internal/core/dto/discord"
+)
+
+// Request to change guild policy. Nil fields are left unchanged.
+type UpdateRequest struct {
+	Guild *discord.Guild
+
+	MaximumReservationsTime *time.Duration
+	MaximumReservationTime  *time.Duration
+	OverbookRole            *string
+	SuggestionStep          *time.Duration
+	BookingHorizon          *time.Duration
+	TimeZone                *string
+	PartyTimeCounted        *bool
+	CheckInGracePeriod      *time.Duration
+	OverbookApproval        *bool
+	OverbookApprovalTimeout *time.Duration
+
+	// Blackout to be added, or name of the one to be removed
+	AddedBlackout   *Blackout
+	RemovedBlackout *string
+
+	// Tier to be added, or role of the one to be removed
+	AddedTier   *Tier
+	RemovedTier *string
+
+	// Weekly quota to be added, or spot of the one to be removed
+	AddedWeeklyQuota   *WeeklyQuota
+	RemovedWeeklyQuota *string
+
+	// Booking window to be added, or spot of the one to be removed
+	AddedBookingWindow   *BookingWindow
+	RemovedBookingWindow *string
+
+	// Lottery to be added, or spot of the one to be removed
+	AddedLottery   *Lottery
+	RemovedLottery *string
+}
+
+// Request to change member time zone. Empty time zone restores the guild one.
+type TimeZoneRequest struct {
+	Guild  *discord.Guild
+	Member *discord.Member
+
+	TimeZone string
+}
+
+// Request to change member reminder preference. Nil fields are left unchanged.
+type ReminderPreferenceRequest struct {
+	Guild  *discord.Guild
+	Member *discord.Member
+
+	Enabled       *bool
+	StartLeadTime *time.Duration
+	EndLeadTime   *time.Duration
+}
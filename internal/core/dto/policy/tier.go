@@ -0,0 +1,103 @@
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"spot-assistant/internal/core/dto/discord"
+)
+
+const MAXIMUM_TIERS = 10
+
+// RoleChecker tells whether a guild member has a Discord role of a given name. It resolves tiers
+// of members other than the one making a request, such as recipients, party members or queued members.
+type RoleChecker func(member *discord.Member, role string) bool
+
+// Tier grants members of a Discord role a booking priority and their own maximum reservations time.
+// Members can overbook reservations of lower priority only.
+type Tier struct {
+	Role     string
+	Priority int
+
+	// Maximum time of member reservations within 24 hour window
+	MaximumReservationsTime time.Duration
+}
+
+func (t Tier) Validate() error {
+	if len(strings.TrimSpace(t.Role)) == 0 {
+		return errors.New("tier role cannot be empty")
+	}
+
+	if t.Priority < 1 || t.Priority > 100 {
+		return errors.New("tier priority has to be between 1 and 100")
+	}
+
+	if t.MaximumReservationsTime < time.Minute || t.MaximumReservationsTime > 24*time.Hour {
+		return errors.New("tier maximum reservations time has to be between 1 minute and 24 hours")
+	}
+
+	return nil
+}
+
+// MemberTier returns the tier of the highest priority among the ones member has the role of.
+// Members without any of them get priority 0 and the guild maximum reservations time. Guilds
+// without tiers fall back to the overbook role, which is given priority 1.
+func (p *Policy) MemberTier(hasRole func(role string) bool) Tier {
+	base := Tier{MaximumReservationsTime: p.MaximumReservationsTime}
+
+	if len(p.Tiers) == 0 {
+		if hasRole(p.OverbookRole) {
+			return Tier{Role: p.OverbookRole, Priority: 1, MaximumReservationsTime: p.MaximumReservationsTime}
+		}
+
+		return base
+	}
+
+	tier := base
+	for _, t := range p.Tiers {
+		if t.Priority > tier.Priority && hasRole(t.Role) {
+			tier = t
+		}
+	}
+
+	return tier
+}
+
+// TierOf returns the tier of a guild member, based on roles told by hasRole.
+func (p *Policy) TierOf(hasRole RoleChecker, member *discord.Member) Tier {
+	return p.MemberTier(func(role string) bool {
+		return hasRole(member, role)
+	})
+}
+
+// ReservationPriority returns priority reservations made by the tier members are stored with.
+// Guilds without tiers store none, so that holders of the overbook role can overbook each other.
+func (p *Policy) ReservationPriority(tier Tier) int {
+	if len(p.Tiers) == 0 {
+		return 0
+	}
+
+	return tier.Priority
+}
+
+func (p *Policy) validateTiers() error {
+	if len(p.Tiers) > MAXIMUM_TIERS {
+		return fmt.Errorf("there can be at most %d tiers", MAXIMUM_TIERS)
+	}
+
+	for i, t := range p.Tiers {
+		if err := t.Validate(); err != nil {
+			return err
+		}
+
+		for _, other := range p.Tiers[:i] {
+			if strings.EqualFold(t.Role, other.Role) {
+				return fmt.Errorf("there is already a tier of role %s", other.Role)
+			}
+		}
+	}
+
+	return nil
+}
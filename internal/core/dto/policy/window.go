@@ -0,0 +1,99 @@
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const MAXIMUM_BOOKING_WINDOWS = 20
+
+// BookingWindow limits since when reservations of a spot can be booked, so that contested spots
+// do not go to whoever books first. A window of a respawn applies to all of its floors and sides,
+// unless they have a window of their own.
+type BookingWindow struct {
+	Spot string
+
+	// How far ahead reservations can start, zero if there is no such limit
+	Advance time.Duration
+
+	// Offset from midnight in the guild time zone, at which booking of the next day opens,
+	// nil if it does not open daily
+	OpensAt *time.Duration
+}
+
+func (w BookingWindow) Validate() error {
+	if len(strings.TrimSpace(w.Spot)) == 0 {
+		return errors.New("booking window spot cannot be empty")
+	}
+
+	if w.Advance == 0 && w.OpensAt == nil {
+		return errors.New("booking window has to limit how far ahead or since what time reservations can be booked")
+	}
+
+	if w.Advance != 0 && (w.Advance < time.Hour || w.Advance > 90*24*time.Hour) {
+		return errors.New("booking window advance has to be between 1 hour and 90 days")
+	}
+
+	if w.OpensAt != nil && (*w.OpensAt < 0 || *w.OpensAt >= 24*time.Hour) {
+		return errors.New("booking window has to open between 00:00 and 23:59")
+	}
+
+	return nil
+}
+
+// OpenAt returns since when reservations starting at startAt can be booked. Reservations of
+// a day open on the day before, at the opening time given in loc.
+func (w BookingWindow) OpenAt(loc *time.Location, startAt time.Time) time.Time {
+	var openAt time.Time
+	if w.Advance > 0 {
+		openAt = startAt.Add(-w.Advance)
+	}
+
+	if w.OpensAt != nil {
+		startAt = startAt.In(loc)
+		dailyOpenAt := time.Date(startAt.Year(), startAt.Month(), startAt.Day()-1, 0, 0, 0, 0, loc).Add(*w.OpensAt)
+		if dailyOpenAt.After(openAt) {
+			openAt = dailyOpenAt
+		}
+	}
+
+	return openAt
+}
+
+// NextOpening returns when booking of the next day opens after t, or zero time if the window
+// does not open daily.
+func (w BookingWindow) NextOpening(loc *time.Location, t time.Time) time.Time {
+	if w.OpensAt == nil {
+		return time.Time{}
+	}
+
+	t = t.In(loc)
+	openAt := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc).Add(*w.OpensAt)
+	if !openAt.After(t) {
+		openAt = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc).Add(*w.OpensAt)
+	}
+
+	return openAt
+}
+
+func (p *Policy) validateBookingWindows() error {
+	if len(p.BookingWindows) > MAXIMUM_BOOKING_WINDOWS {
+		return fmt.Errorf("there can be at most %d booking windows", MAXIMUM_BOOKING_WINDOWS)
+	}
+
+	for i, w := range p.BookingWindows {
+		if err := w.Validate(); err != nil {
+			return err
+		}
+
+		for _, other := range p.BookingWindows[:i] {
+			if strings.EqualFold(w.Spot, other.Spot) {
+				return fmt.Errorf("there is already a booking window of %s", other.Spot)
+			}
+		}
+	}
+
+	return nil
+}
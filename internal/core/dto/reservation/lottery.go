@@ -0,0 +1,24 @@
+package reservation
+
+import "time"
+
+// LotteryApplication is member request for a reservation of a spot handed out by lottery.
+type LotteryApplication struct {
+	ID              int64
+	Author          string
+	AuthorDiscordID string
+	GuildID         string
+	SpotID          int64
+	StartAt         time.Time
+	EndAt           time.Time
+	DrawAt          time.Time
+	CreatedAt       time.Time
+
+	// Whether the application has won, nil until lots are drawn
+	Won *bool
+}
+
+type LotteryApplicationWithSpot struct {
+	LotteryApplication
+	Spot Spot
+}
@@ -0,0 +1,14 @@
+package reservation
+
+import "time"
+
+// SpotOpening tells since when reservations of a spot can be booked, as seen at a given moment.
+type SpotOpening struct {
+	Spot string
+
+	// How far ahead reservations can start, zero if there is no such limit
+	Advance time.Duration
+
+	// When booking of the next day opens, zero time if it does not open daily
+	NextOpeningAt time.Time
+}
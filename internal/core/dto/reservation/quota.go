@@ -0,0 +1,17 @@
+package reservation
+
+import "time"
+
+// QuotaUsage is the time member has spent or booked on a spot within a calendar week,
+// out of its weekly quota.
+type QuotaUsage struct {
+	Spot     string
+	Limit    time.Duration
+	Used     time.Duration
+	ResetsAt time.Time
+}
+
+// Remaining returns how much of the quota member can still book.
+func (u QuotaUsage) Remaining() time.Duration {
+	return max(u.Limit-u.Used, 0)
+}
@@ -1,6 +1,11 @@
 package reservation
 
-import "time"
+import (
+	"slices"
+	"time"
+
+	"spot-assistant/internal/core/dto/spot"
+)
 
 type Reservation struct {
 	ID              int64
@@ -11,6 +16,40 @@ type Reservation struct {
 	SpotID          int64
 	GuildID         string
 	AuthorDiscordID string
+	// Co-hunters taking part in the reservation besides its author
+	Party []*PartyMember
+	// Time the author confirmed their presence, zero if they have not checked in
+	CheckedInAt time.Time
+	// Time the author has been asked to check in, zero if they have not been, e.g. on reservations
+	// started before check-ins were introduced
+	CheckInRemindedAt time.Time
+	// Time a tentative hold gets released at unless confirmed, zero for regular reservations
+	HeldUntil time.Time
+	// Priority of the author tier at the time of booking, only higher tiers can overbook it
+	Priority int
+}
+
+// CheckedIn returns true if reservation author has confirmed their presence.
+func (r Reservation) CheckedIn() bool {
+	return !r.CheckedInAt.IsZero()
+}
+
+// CheckInRequested returns true if reservation author has been asked to check in. It is unknown whether
+// authors who have not been are present.
+func (r Reservation) CheckInRequested() bool {
+	return !r.CheckInRemindedAt.IsZero()
+}
+
+// Held returns true if reservation is a tentative hold, which has not been confirmed yet.
+func (r Reservation) Held() bool {
+	return !r.HeldUntil.IsZero()
+}
+
+// PartyMember is a member hunting on a reservation made by someone else.
+type PartyMember struct {
+	ReservationID   int64
+	MemberDiscordID string
+	Name            string
 }
 
 // ClippedOrRemovedReservation holds both original reservation and
@@ -24,9 +63,172 @@ type ClippedOrRemovedReservation struct {
 type Spot struct {
 	ID   int64
 	Name string
+	// Respawn the spot is a floor or side of, zero for top-level respawns
+	ParentID int64
+	Details  spot.Details
+}
+
+// SameRespawn returns true if both spots are the same respawn, or its floors or sides.
+func (s Spot) SameRespawn(other Spot) bool {
+	return (&spot.Spot{ID: s.ID, Name: s.Name, ParentID: s.ParentID}).SameRespawn(&spot.Spot{ID: other.ID, Name: other.Name, ParentID: other.ParentID})
 }
 
 type ReservationWithSpot struct {
 	Reservation
 	Spot
 }
+
+// NoShow is a started reservation, which author has not checked in within the grace period.
+type NoShow struct {
+	*ReservationWithSpot
+	// Number of author no-shows in the guild, including this one
+	Count int
+}
+
+// ReminderKind tells which end of a reservation a reminder is about.
+type ReminderKind string
+
+const (
+	ReminderStart ReminderKind = "start"
+	ReminderEnd   ReminderKind = "end"
+)
+
+// Reminder is a reservation its author should be reminded of.
+type Reminder struct {
+	*ReservationWithSpot
+	Kind ReminderKind
+}
+
+// EventAt returns time of the reservation start or end the reminder is about.
+func (r Reminder) EventAt() time.Time {
+	if r.Kind == ReminderEnd {
+		return r.EndAt
+	}
+
+	return r.StartAt
+}
+
+// Series is a weekly recurring reservation, which gets materialized
+// into concrete reservations ahead of time.
+type Series struct {
+	ID              int64
+	Author          string
+	AuthorDiscordID string
+	GuildID         string
+	SpotID          int64
+	Weekdays        []time.Weekday
+	// StartTime and EndTime are offsets from the midnight of an occurrence day.
+	// EndTime lower than StartTime means the reservation ends on the next day.
+	StartTime         time.Duration
+	EndTime           time.Duration
+	CreatedAt         time.Time
+	MaterializedUntil time.Time
+}
+
+// OccursOn returns true if series has an occurrence on a given weekday.
+func (s Series) OccursOn(weekday time.Weekday) bool {
+	for _, w := range s.Weekdays {
+		if w == weekday {
+			return true
+		}
+	}
+
+	return false
+}
+
+type SeriesWithSpot struct {
+	Series
+	Spot
+}
+
+// QueueEntry is a member waiting for a given spot and time range to become free.
+type QueueEntry struct {
+	ID              int64
+	Author          string
+	AuthorDiscordID string
+	GuildID         string
+	SpotID          int64
+	StartAt         time.Time
+	EndAt           time.Time
+	CreatedAt       time.Time
+}
+
+type QueueEntryWithSpot struct {
+	QueueEntry
+	Spot
+}
+
+// OverbookRequest is an overbook waiting for approval of members, whose reservations it would
+// clip or remove. It gets approved automatically once it expires.
+type OverbookRequest struct {
+	ID                    int64
+	Author                string
+	AuthorDiscordID       string
+	GuildID               string
+	SpotID                int64
+	StartAt               time.Time
+	EndAt                 time.Time
+	PartyMemberDiscordIDs []string
+	ExpiresAt             time.Time
+	CreatedAt             time.Time
+	Approvals             []*OverbookApproval
+}
+
+// Declined returns true if any of the affected members declined the overbook.
+func (r OverbookRequest) Declined() bool {
+	for _, a := range r.Approvals {
+		if a.Accepted != nil && !*a.Accepted {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Accepted returns true if every affected member accepted the overbook.
+func (r OverbookRequest) Accepted() bool {
+	for _, a := range r.Approvals {
+		if a.Accepted == nil || !*a.Accepted {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ApproverDiscordIDs returns distinct members, whose answer the overbook waits for.
+func (r OverbookRequest) ApproverDiscordIDs() []string {
+	ids := []string{}
+	for _, a := range r.Approvals {
+		if !slices.Contains(ids, a.MemberDiscordID) {
+			ids = append(ids, a.MemberDiscordID)
+		}
+	}
+
+	return ids
+}
+
+// OverbookApproval is an answer of the author of a single affected reservation, nil until given.
+type OverbookApproval struct {
+	ReservationID   int64
+	MemberDiscordID string
+	Accepted        *bool
+}
+
+type OverbookRequestWithSpot struct {
+	OverbookRequest
+	Spot
+}
+
+// Window is a time range, in which a spot is free and can be booked in a single reservation.
+type Window struct {
+	StartAt time.Time
+	EndAt   time.Time
+}
+
+// Alternative is a free slot proposed instead of a conflicting one, either on the same spot
+// or on another floor or side of the same respawn.
+type Alternative struct {
+	Spot Spot
+	Window
+}
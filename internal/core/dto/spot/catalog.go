@@ -0,0 +1,256 @@
+package spot
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Format of an imported or exported spot catalog.
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatCSV  Format = "csv"
+)
+
+var csvHeader = []string{"name", "parent", "area", "min_level", "max_level", "vocations", "kind", "aliases"}
+
+// Separates vocations and aliases within a single CSV cell.
+const csvListSeparator = ";"
+
+// Returns format of a catalog file judging by its extension.
+func FormatFromFileName(fileName string) (Format, error) {
+	switch format := Format(strings.ToLower(strings.TrimPrefix(filepath.Ext(fileName), "."))); format {
+	case FormatJSON, FormatCSV:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unsupported catalog file %s, use either .%s or .%s", fileName, FormatJSON, FormatCSV)
+	}
+}
+
+// CatalogEntry describes a single spot of an imported or exported catalog.
+type CatalogEntry struct {
+	Name string `json:"name"`
+	// Name of the respawn the spot is a floor or side of
+	Parent    string   `json:"parent,omitempty"`
+	Area      string   `json:"area,omitempty"`
+	MinLevel  int      `json:"minLevel,omitempty"`
+	MaxLevel  int      `json:"maxLevel,omitempty"`
+	Vocations []string `json:"vocations,omitempty"`
+	Kind      Kind     `json:"kind,omitempty"`
+	Aliases   []string `json:"aliases,omitempty"`
+}
+
+func (e *CatalogEntry) Details() Details {
+	return Details{
+		MinLevel:  e.MinLevel,
+		MaxLevel:  e.MaxLevel,
+		Vocations: e.Vocations,
+		Area:      e.Area,
+		Kind:      e.Kind,
+	}
+}
+
+// Reads catalog entries in a given format.
+func ReadCatalog(r io.Reader, format Format) ([]*CatalogEntry, error) {
+	switch format {
+	case FormatJSON:
+		entries := []*CatalogEntry{}
+		err := json.NewDecoder(r).Decode(&entries)
+		if err != nil {
+			return nil, fmt.Errorf("could not read JSON catalog: %w", err)
+		}
+
+		for _, entry := range entries {
+			vocations, err := ParseVocations(strings.Join(entry.Vocations, ","))
+			if err != nil {
+				return nil, fmt.Errorf("respawn %s: %w", entry.Name, err)
+			}
+			entry.Vocations = vocations
+		}
+
+		return entries, nil
+	case FormatCSV:
+		return readCSVCatalog(r)
+	default:
+		return nil, fmt.Errorf("unsupported catalog format %s", format)
+	}
+}
+
+// Writes catalog entries in a given format.
+func WriteCatalog(w io.Writer, format Format, entries []*CatalogEntry) error {
+	switch format {
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(entries)
+	case FormatCSV:
+		return writeCSVCatalog(w, entries)
+	default:
+		return fmt.Errorf("unsupported catalog format %s", format)
+	}
+}
+
+func readCSVCatalog(r io.Reader) ([]*CatalogEntry, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = len(csvHeader)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("could not read CSV catalog header: %w", err)
+	}
+	for i, column := range csvHeader {
+		if strings.ToLower(strings.TrimSpace(header[i])) != column {
+			return nil, fmt.Errorf("CSV catalog columns must be: %s", strings.Join(csvHeader, ","))
+		}
+	}
+
+	entries := []*CatalogEntry{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not read CSV catalog: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		entry := &CatalogEntry{
+			Name:    record[0],
+			Parent:  record[1],
+			Area:    record[2],
+			Kind:    Kind(strings.ToLower(record[6])),
+			Aliases: splitCSVList(record[7]),
+		}
+
+		entry.MinLevel, err = parseCSVLevel(record[3])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		entry.MaxLevel, err = parseCSVLevel(record[4])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		entry.Vocations, err = ParseVocations(strings.ReplaceAll(record[5], csvListSeparator, ","))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func writeCSVCatalog(w io.Writer, entries []*CatalogEntry) error {
+	writer := csv.NewWriter(w)
+	err := writer.Write(csvHeader)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		err = writer.Write([]string{
+			entry.Name,
+			entry.Parent,
+			entry.Area,
+			formatCSVLevel(entry.MinLevel),
+			formatCSVLevel(entry.MaxLevel),
+			strings.Join(entry.Vocations, csvListSeparator),
+			string(entry.Kind),
+			strings.Join(entry.Aliases, csvListSeparator),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
+
+func parseCSVLevel(input string) (int, error) {
+	if len(strings.TrimSpace(input)) == 0 {
+		return 0, nil
+	}
+
+	level, err := strconv.Atoi(strings.TrimSpace(input))
+	if err != nil {
+		return 0, fmt.Errorf("invalid level %s", input)
+	}
+
+	return level, nil
+}
+
+func formatCSVLevel(level int) string {
+	if level == 0 {
+		return ""
+	}
+
+	return strconv.Itoa(level)
+}
+
+func splitCSVList(input string) []string {
+	items := []string{}
+	for _, item := range strings.Split(input, csvListSeparator) {
+		if item = strings.TrimSpace(item); len(item) > 0 {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// ImportResult tells how importing a catalog changes spots of a guild. Nothing is changed on a dry run.
+type ImportResult struct {
+	DryRun    bool
+	Added     []*CatalogEntry
+	Updated   []*CatalogEntry
+	Unchanged []string
+	// Names of shared spots, which can only be changed by importing the shared catalog
+	Skipped []string
+}
+
+// String returns a human readable summary of the import.
+func (r *ImportResult) String() string {
+	var sb strings.Builder
+	if r.DryRun {
+		sb.WriteString("Dry run, nothing has been changed yet.\n")
+	}
+
+	writeNames := func(header string, names []string) {
+		if len(names) == 0 {
+			return
+		}
+
+		sb.WriteString(fmt.Sprintf("%s (%d):\n", header, len(names)))
+		for _, name := range names {
+			sb.WriteString(fmt.Sprintf("* %s\n", name))
+		}
+	}
+	entryNames := func(entries []*CatalogEntry) []string {
+		names := make([]string, len(entries))
+		for i, entry := range entries {
+			names[i] = entry.Name
+		}
+
+		return names
+	}
+
+	writeNames("Added", entryNames(r.Added))
+	writeNames("Updated", entryNames(r.Updated))
+	writeNames("Skipped, as they are shared by all servers", r.Skipped)
+	sb.WriteString(fmt.Sprintf("Unchanged: %d", len(r.Unchanged)))
+
+	return sb.String()
+}
@@ -0,0 +1,156 @@
+package spot
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const MAXIMUM_AREA_LENGTH = 120
+
+// Kind tells whether a spot is meant to be hunted alone or by a team.
+type Kind string
+
+const (
+	KindSolo Kind = "solo"
+	KindTeam Kind = "team"
+)
+
+// Vocation is a character class a spot is recommended for.
+type Vocation struct {
+	Name  string
+	Short string
+}
+
+var Vocations = []Vocation{
+	{Name: "knight", Short: "EK"},
+	{Name: "paladin", Short: "RP"},
+	{Name: "sorcerer", Short: "MS"},
+	{Name: "druid", Short: "ED"},
+	{Name: "monk", Short: "EM"},
+}
+
+// Details hold optional information about a spot members can filter by.
+// Zero values mean the information is unknown.
+type Details struct {
+	MinLevel int
+	MaxLevel int
+	// Names of recommended vocations, e.g. knight
+	Vocations []string
+	// Region of the game world, e.g. Feyrist
+	Area string
+	Kind Kind
+}
+
+// Returns an error if details are inconsistent.
+func (d Details) Validate() error {
+	if d.MinLevel < 0 || d.MaxLevel < 0 {
+		return fmt.Errorf("level cannot be negative")
+	}
+
+	if d.MaxLevel > 0 && d.MinLevel > d.MaxLevel {
+		return fmt.Errorf("minimum level cannot be greater than maximum level")
+	}
+
+	if utf8.RuneCountInString(d.Area) > MAXIMUM_AREA_LENGTH {
+		return fmt.Errorf("area cannot be longer than %d characters", MAXIMUM_AREA_LENGTH)
+	}
+
+	if d.Kind != "" && d.Kind != KindSolo && d.Kind != KindTeam {
+		return fmt.Errorf("respawn can either be %s or %s", KindSolo, KindTeam)
+	}
+
+	for _, name := range d.Vocations {
+		if findVocation(name) == nil {
+			return fmt.Errorf("unknown vocation %s", name)
+		}
+	}
+
+	return nil
+}
+
+// Matches tells whether a single search term describes the spot details, e.g. its area,
+// one of its vocations, its kind or a level within its level range.
+func (d Details) Matches(term string) bool {
+	term = strings.ToLower(term)
+
+	if level, err := strconv.Atoi(term); err == nil {
+		return d.MinLevel+d.MaxLevel > 0 && level >= d.MinLevel && (d.MaxLevel == 0 || level <= d.MaxLevel)
+	}
+
+	if len(d.Area) > 0 && strings.Contains(strings.ToLower(d.Area), term) {
+		return true
+	}
+
+	if len(d.Kind) > 0 && string(d.Kind) == term {
+		return true
+	}
+
+	return slices.ContainsFunc(d.Vocations, func(name string) bool {
+		v := findVocation(name)
+		return v != nil && (strings.HasPrefix(v.Name, term) || strings.ToLower(v.Short) == term)
+	})
+}
+
+// String returns a short, human readable description, e.g. "Feyrist · 200-400 · EK, ED · team".
+func (d Details) String() string {
+	parts := []string{}
+	if len(d.Area) > 0 {
+		parts = append(parts, d.Area)
+	}
+
+	switch {
+	case d.MinLevel > 0 && d.MaxLevel > 0:
+		parts = append(parts, fmt.Sprintf("%d-%d", d.MinLevel, d.MaxLevel))
+	case d.MinLevel > 0:
+		parts = append(parts, fmt.Sprintf("%d+", d.MinLevel))
+	case d.MaxLevel > 0:
+		parts = append(parts, fmt.Sprintf("up to %d", d.MaxLevel))
+	}
+
+	if len(d.Vocations) > 0 {
+		shorts := make([]string, 0, len(d.Vocations))
+		for _, name := range d.Vocations {
+			if v := findVocation(name); v != nil {
+				shorts = append(shorts, v.Short)
+			}
+		}
+		parts = append(parts, strings.Join(shorts, ", "))
+	}
+
+	if len(d.Kind) > 0 {
+		parts = append(parts, string(d.Kind))
+	}
+
+	return strings.Join(parts, " · ")
+}
+
+// Parses a comma or space separated list of vocations, given either by their names or
+// by their short forms (e.g. "EK, ED"), into vocation names.
+func ParseVocations(input string) ([]string, error) {
+	names := []string{}
+	for _, field := range strings.FieldsFunc(input, func(r rune) bool { return r == ',' || r == ' ' }) {
+		v := findVocation(field)
+		if v == nil {
+			return nil, fmt.Errorf("unknown vocation %s", field)
+		}
+
+		if !slices.Contains(names, v.Name) {
+			names = append(names, v.Name)
+		}
+	}
+
+	return names, nil
+}
+
+func findVocation(nameOrShort string) *Vocation {
+	for i, v := range Vocations {
+		if strings.EqualFold(v.Name, nameOrShort) || strings.EqualFold(v.Short, nameOrShort) {
+			return &Vocations[i]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package spot
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"spot-assistant/internal/core/dto/discord"
+)
+
+const MAXIMUM_NAME_LENGTH = 120
+
+// Request to add a new spot to the catalog.
+type AddRequest struct {
+	Guild *discord.Guild
+	Name  string
+	// Respawn the new spot is a floor or side of, empty for top-level respawns
+	Parent string
+}
+
+// Request to change name of an existing spot.
+type RenameRequest struct {
+	Guild   *discord.Guild
+	Name    string
+	NewName string
+}
+
+// Request to replace details of an existing spot.
+type DescribeRequest struct {
+	Guild   *discord.Guild
+	Name    string
+	Details Details
+}
+
+// Request to add or remove an alternative name of a spot.
+type AliasRequest struct {
+	Guild *discord.Guild
+	Name  string
+	Alias string
+}
+
+// Request to import a spot catalog into a guild, or into the shared catalog if guild ID is empty.
+type ImportRequest struct {
+	Guild   *discord.Guild
+	Entries []*CatalogEntry
+	// Whether changes should only be reported, without applying them
+	DryRun bool
+}
+
+// Request to export spots visible to a guild.
+type ExportRequest struct {
+	Guild *discord.Guild
+}
+
+// Request to archive a spot, so that it cannot be booked anymore.
+type ArchiveRequest struct {
+	Guild *discord.Guild
+	Name  string
+}
+
+// Request to hide a shared spot from a guild, or to show it again.
+type VisibilityRequest struct {
+	Guild *discord.Guild
+	Name  string
+}
+
+// Request for autocompletion of spot management commands.
+type AutocompleteRequest struct {
+	Guild *discord.Guild
+	// Whether spots hidden from the guild should be suggested instead of the visible ones
+	Hidden bool
+	Value  string
+}
+
+// Returns an error if name cannot be used as a spot name.
+func ValidateName(name string) error {
+	if len(strings.TrimSpace(name)) == 0 {
+		return errors.New("respawn name cannot be empty")
+	}
+
+	if utf8.RuneCountInString(name) > MAXIMUM_NAME_LENGTH {
+		return fmt.Errorf("respawn name cannot be longer than %d characters", MAXIMUM_NAME_LENGTH)
+	}
+
+	return nil
+}
@@ -1,9 +1,64 @@
 package spot
 
-import "time"
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Matches floor and side suffixes, which respawns added before parent spots were told apart by,
+// e.g. "Library -1" or "Asura Palace (North)".
+var floorOrSideSuffixRegex = regexp.MustCompile(`(?i)\s*(\((north|east|south|west|right|left|side|floor\s*\d+)\)|-\d+)\s*$`)
 
 type Spot struct {
-	Name      string
-	ID        int64
-	CreatedAt time.Time
+	Name       string
+	ID         int64
+	CreatedAt  time.Time
+	ArchivedAt time.Time
+	// Guild the spot has been added by, empty for spots shared by all guilds
+	OwnerGuildID string
+	// Respawn the spot is a floor or side of, zero for top-level respawns
+	ParentID int64
+	Details  Details
+	// Alternative names members can refer to the spot with, e.g. community nicknames
+	Aliases []string
+}
+
+// Archived spots cannot be booked anymore, but remain attached to their past reservations.
+func (s *Spot) Archived() bool {
+	return !s.ArchivedAt.IsZero()
+}
+
+// Shared spots are visible to every guild, unless it hides them.
+func (s *Spot) Shared() bool {
+	return len(s.OwnerGuildID) == 0
+}
+
+// GroupID identifies the respawn the spot belongs to. Floors and sides of the same
+// respawn share it, so that they count as one towards reservation quotas.
+func (s *Spot) GroupID() int64 {
+	if s.ParentID != 0 {
+		return s.ParentID
+	}
+
+	return s.ID
+}
+
+// SameRespawn returns true if both spots are the same respawn, or its floors or sides. Spots without a parent,
+// e.g. ones added before parent spots were introduced, are grouped by their names as well.
+func (s *Spot) SameRespawn(other *Spot) bool {
+	if s.GroupID() == other.GroupID() {
+		return true
+	}
+
+	if s.ParentID != 0 && other.ParentID != 0 {
+		return false
+	}
+
+	return strings.EqualFold(RespawnName(s.Name), RespawnName(other.Name))
+}
+
+// RespawnName returns name of the respawn a floor or side is called after, e.g. Library for Library -1.
+func RespawnName(name string) string {
+	return strings.TrimSpace(floorOrSideSuffixRegex.ReplaceAllString(name, ""))
 }
@@ -0,0 +1,34 @@
+package spot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSameRespawn(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	inputs := [][2]*Spot{
+		// Grouped under the same parent
+		{{ID: 2, Name: "Library -1", ParentID: 1}, {ID: 3, Name: "Library -2", ParentID: 1}},
+		{{ID: 1, Name: "Library"}, {ID: 2, Name: "Library -1", ParentID: 1}},
+		// Added before parent spots, without the respawn itself
+		{{ID: 2, Name: "Library -1"}, {ID: 3, Name: "Library -2"}},
+		{{ID: 4, Name: "Roshamuul -10"}, {ID: 5, Name: "roshamuul -1"}},
+		{{ID: 6, Name: "Asura Palace (North)"}, {ID: 7, Name: "Asura Palace (SOUTH)"}},
+		// Grouped under different parents explicitly
+		{{ID: 2, Name: "Library -1", ParentID: 1}, {ID: 3, Name: "Library -2", ParentID: 8}},
+		// Unrelated respawns
+		{{ID: 2, Name: "Library -1"}, {ID: 5, Name: "Roshamuul -1"}},
+	}
+
+	// when
+	res := make([]bool, len(inputs))
+	for i, input := range inputs {
+		res[i] = input[0].SameRespawn(input[1])
+	}
+
+	// assert
+	assert.Equal([]bool{true, true, true, true, true, false, false}, res)
+}
@@ -12,12 +12,17 @@ type Summary struct {
 	Description  string
 	Ledger       Ledger
 	LegendValues []LegendValue
+
+	// Upcoming guild blackouts, marked alongside the ledger
+	Blackouts []Blackout
 }
 
 type Ledger []LedgerEntry
 
 type LedgerEntry struct {
 	Spot string
+	// Short description of the spot, e.g. its area and level range
+	Details string
 
 	Bookings []*Booking
 }
@@ -27,6 +32,19 @@ type Booking struct {
 	AuthorDiscordID string
 	StartAt         time.Time
 	EndAt           time.Time
+
+	// Names of co-hunters
+	Party []string
+
+	// Time a tentative hold gets released at unless confirmed, zero for regular reservations
+	HeldUntil time.Time
+}
+
+// Blackout is an upcoming time window, in which respawns cannot be hunted.
+type Blackout struct {
+	Name    string
+	StartAt time.Time
+	EndAt   time.Time
 }
 
 // LegendValue is a container for label (Legend) and float64 value (Value)
@@ -7,11 +7,18 @@ import (
 )
 
 func (a *Adapter) MapReservation(reservation *reservation.Reservation) *summary.Booking {
+	party := make([]string, len(reservation.Party))
+	for i, partyMember := range reservation.Party {
+		party[i] = partyMember.Name
+	}
+
 	return &summary.Booking{
 		Author:          reservation.Author,
 		StartAt:         reservation.StartAt,
 		EndAt:           reservation.EndAt,
 		AuthorDiscordID: reservation.AuthorDiscordID,
+		Party:           party,
+		HeldUntil:       reservation.HeldUntil,
 	}
 }
 
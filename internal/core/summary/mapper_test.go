@@ -30,6 +30,47 @@ func TestMapReservation(t *testing.T) {
 	assert.Equal(res.EndAt, input.EndAt)
 }
 
+func TestMapHeldReservation(t *testing.T) {
+	// Given
+	assert := assert.New(t)
+	chartSrvMock := new(mocks.MockChartAdapter)
+	adapter := NewAdapter(chartSrvMock)
+	input := &reservation.Reservation{
+		Author:    "test author",
+		StartAt:   time.Now(),
+		EndAt:     time.Now().Add(2 * time.Hour),
+		HeldUntil: time.Now().Add(15 * time.Minute),
+	}
+
+	// when
+	res := adapter.MapReservation(input)
+
+	// assert
+	assert.Equal(input.HeldUntil, res.HeldUntil)
+}
+
+func TestMapReservationWithParty(t *testing.T) {
+	// Given
+	assert := assert.New(t)
+	chartSrvMock := new(mocks.MockChartAdapter)
+	adapter := NewAdapter(chartSrvMock)
+	input := &reservation.Reservation{
+		Author:  "test author",
+		StartAt: time.Now(),
+		EndAt:   time.Now().Add(2 * time.Hour),
+		Party: []*reservation.PartyMember{
+			{MemberDiscordID: "test-knight-id", Name: "test knight"},
+			{MemberDiscordID: "test-druid-id", Name: "test druid"},
+		},
+	}
+
+	// when
+	res := adapter.MapReservation(input)
+
+	// assert
+	assert.Equal([]string{"test knight", "test druid"}, res.Party)
+}
+
 func TestMapReservations(t *testing.T) {
 	// Given
 	assert := assert.New(t)
@@ -14,7 +14,7 @@ func (a *Adapter) BaseSummary() *dto.Summary {
 	return &dto.Summary{
 		URL:         "https://tibialoot.com",
 		Title:       "TibiaLoot.com - Spot Assistant",
-		Description: "Current and upcoming hunts. Times are in **Europe/Berlin**.",
+		Description: "Current and upcoming hunts. Times are shown in your local time zone.",
 		Footer: fmt.Sprintf(
 			"Version: %s powered by TibiaLoot.com (%s)", version.Version, time.Now().Format("15:04 01.02"),
 		),
@@ -25,6 +25,7 @@ func (a *Adapter) PrepareSummary(reservations []*reservation.ReservationWithSpot
 	sum := a.BaseSummary()
 
 	spotsToReservations := a.mapToSpotsToReservations(reservations)
+	spotsToDetails := a.mapToSpotsToDetails(reservations)
 
 	// Chart generation
 	spotsToCounts := a.mapToSpotsToCounts(spotsToReservations)
@@ -46,6 +47,7 @@ func (a *Adapter) PrepareSummary(reservations []*reservation.ReservationWithSpot
 	for i, spotName := range spotNamesAlphabetically {
 		ledger[i] = dto.LedgerEntry{
 			Spot:     spotName,
+			Details:  spotsToDetails[spotName],
 			Bookings: a.MapReservations(spotsToReservations[spotName]),
 		}
 	}
@@ -77,6 +79,15 @@ func (a *Adapter) mapToSpotsToReservations(reservations []*reservation.Reservati
 	return spotsToReservations
 }
 
+func (a *Adapter) mapToSpotsToDetails(reservations []*reservation.ReservationWithSpot) map[string]string {
+	spotsToDetails := map[string]string{}
+	for _, reserv := range reservations {
+		spotsToDetails[reserv.Spot.Name] = reserv.Spot.Details.String()
+	}
+
+	return spotsToDetails
+}
+
 func (a *Adapter) mapToSpotsToCounts(spotsToReservations map[string][]*reservation.Reservation) map[string]float64 {
 	spotsToCounts := map[string]float64{}
 	for spot, val := range spotsToReservations {
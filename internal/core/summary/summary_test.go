@@ -11,6 +11,7 @@ import (
 	"spot-assistant/internal/common/collections"
 	"spot-assistant/internal/common/test/mocks"
 	"spot-assistant/internal/core/dto/reservation"
+	"spot-assistant/internal/core/dto/spot"
 	dto "spot-assistant/internal/core/dto/summary"
 )
 
@@ -27,7 +28,7 @@ func TestBaseSummary(t *testing.T) {
 	assert.NotNil(summary)
 	assert.Equal(summary.URL, "https://tibialoot.com")
 	assert.Equal(summary.Title, "TibiaLoot.com - Spot Assistant")
-	assert.Equal(summary.Description, "Current and upcoming hunts. Times are in **Europe/Berlin**.")
+	assert.Equal(summary.Description, "Current and upcoming hunts. Times are shown in your local time zone.")
 	assert.Contains(summary.Footer, "powered by TibiaLoot.com")
 }
 
@@ -65,6 +66,13 @@ func TestPrepareSummary(t *testing.T) {
 			},
 			Spot: reservation.Spot{
 				Name: "test-2",
+				Details: spot.Details{
+					MinLevel:  200,
+					MaxLevel:  400,
+					Vocations: []string{"knight", "druid"},
+					Area:      "Feyrist",
+					Kind:      spot.KindTeam,
+				},
 			},
 		},
 	}
@@ -87,7 +95,7 @@ func TestPrepareSummary(t *testing.T) {
 	assert.NotNil(summary)
 	assert.Equal(summary.URL, "https://tibialoot.com")
 	assert.Equal(summary.Title, "TibiaLoot.com - Spot Assistant")
-	assert.Equal(summary.Description, "Current and upcoming hunts. Times are in **Europe/Berlin**.")
+	assert.Equal(summary.Description, "Current and upcoming hunts. Times are shown in your local time zone.")
 	assert.Contains(summary.Footer, "powered by TibiaLoot.com")
 	assert.Len(summary.Ledger, 2)
 
@@ -103,7 +111,9 @@ func TestPrepareSummary(t *testing.T) {
 		assert.NotEmpty(entry.EndAt)
 	}
 
+	assert.Empty(firstEntry.Details)
 	assert.Equal(secondEntry.Spot, "test-2")
+	assert.Equal("Feyrist · 200-400 · EK, ED · team", secondEntry.Details)
 	assert.Len(secondEntry.Bookings, 1)
 	for _, entry := range secondEntry.Bookings {
 		assert.NotNil(entry)
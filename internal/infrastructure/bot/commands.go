@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"github.com/sirupsen/logrus"
 	"spot-assistant/internal/common/strings"
+	"spot-assistant/internal/core/dto/policy"
+	"spot-assistant/internal/core/dto/spot"
 
 	"github.com/bwmarrin/discordgo"
 )
@@ -36,8 +38,87 @@ func (b *Bot) handleCommand(i *discordgo.InteractionCreate) {
 		} else {
 			err = b.Unbook(i)
 		}
+	case "rebook":
+		if isAutocomplete {
+			err = b.RebookAutocomplete(i)
+		} else {
+			err = b.Rebook(i)
+		}
+	case "party":
+		if isAutocomplete {
+			err = b.PartyAutocomplete(i)
+		} else {
+			err = b.Party(i)
+		}
+	case "transfer":
+		if isAutocomplete {
+			// Only reservation option is autocompleted
+			err = b.UnbookAutocomplete(i)
+		} else {
+			err = b.Transfer(i)
+		}
+	case "checkin":
+		if isAutocomplete {
+			err = b.UnbookAutocomplete(i)
+		} else {
+			err = b.CheckIn(i)
+		}
 	case "summary":
 		err = b.PrivateSummary(i)
+	case "queue":
+		if isAutocomplete {
+			// Queue options mirror the book command ones
+			err = b.BookAutocomplete(i)
+		} else {
+			err = b.Queue(i)
+		}
+	case "apply":
+		if isAutocomplete {
+			// Apply options mirror the book command ones
+			err = b.BookAutocomplete(i)
+		} else {
+			err = b.Apply(i)
+		}
+	case "hold":
+		if isAutocomplete {
+			// Hold options mirror the book command ones
+			err = b.BookAutocomplete(i)
+		} else {
+			err = b.Hold(i)
+		}
+	case "confirm":
+		if isAutocomplete {
+			err = b.UnbookAutocomplete(i)
+		} else {
+			err = b.Confirm(i)
+		}
+	case "quota":
+		err = b.Quota(i)
+	case "free":
+		if isAutocomplete {
+			// Free options are a subset of the book command ones
+			err = b.BookAutocomplete(i)
+		} else {
+			err = b.Free(i)
+		}
+	case "timezone":
+		err = b.TimeZone(i)
+	case "reminders":
+		err = b.Reminders(i)
+	case "letter-config":
+		err = b.LetterConfig(i)
+	case "spot":
+		if isAutocomplete {
+			err = b.SpotAutocomplete(i)
+		} else {
+			err = b.Spot(i)
+		}
+	case "recurring":
+		if isAutocomplete {
+			err = b.RecurringAutocomplete(i)
+		} else {
+			err = b.Recurring(i)
+		}
 	default:
 		err = fmt.Errorf("missing handler for command: %s", name)
 	}
@@ -66,6 +147,10 @@ func (b *Bot) handleCommand(i *discordgo.InteractionCreate) {
 	}
 }
 
+var configPermissions int64 = discordgo.PermissionManageServer
+var minimumPolicyValue = 1.0
+var minimumReminderValue = 0.0
+
 var commands = []*discordgo.ApplicationCommand{
 	{
 		Name:        "book",
@@ -96,6 +181,14 @@ var commands = []*discordgo.ApplicationCommand{
 				Autocomplete: true,
 			},
 
+			{
+				Name:         "date",
+				Description:  "A day the hunt shall take place (e.g. 2023-08-19), defaults to the nearest upcoming one",
+				Type:         discordgo.ApplicationCommandOptionString,
+				Required:     false,
+				Autocomplete: true,
+			},
+
 			{
 				Name:         "overbook",
 				Description:  "An hour the hunt shall end (e.g. 17:20)",
@@ -103,6 +196,142 @@ var commands = []*discordgo.ApplicationCommand{
 				Required:     false,
 				Autocomplete: true,
 			},
+
+			{
+				Name:        "party",
+				Description: "Co-hunters joining the hunt, mentioned with @ (e.g. @Knight @Druid)",
+				Type:        discordgo.ApplicationCommandOptionString,
+				Required:    false,
+			},
+		},
+	},
+	{
+		Name:        "rebook",
+		Description: "Change date or hours of your reservation",
+		Type:        discordgo.ChatApplicationCommand,
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:         "reservation",
+				Description:  "Reservation to be changed",
+				Type:         discordgo.ApplicationCommandOptionString,
+				Required:     true,
+				Autocomplete: true,
+			},
+			{
+				Name:         "start-at",
+				Description:  "A new hour the hunt shall start (e.g. 15:20), defaults to the current one",
+				Type:         discordgo.ApplicationCommandOptionString,
+				Required:     false,
+				Autocomplete: true,
+			},
+			{
+				Name:         "end-at",
+				Description:  "A new hour the hunt shall end (e.g. 17:20), defaults to keeping the current length",
+				Type:         discordgo.ApplicationCommandOptionString,
+				Required:     false,
+				Autocomplete: true,
+			},
+			{
+				Name:         "date",
+				Description:  "A new day the hunt shall take place (e.g. 2023-08-19), defaults to the current one",
+				Type:         discordgo.ApplicationCommandOptionString,
+				Required:     false,
+				Autocomplete: true,
+			},
+			{
+				Name:         "overbook",
+				Description:  "Overbook conflicting reservations",
+				Type:         discordgo.ApplicationCommandOptionString,
+				Required:     false,
+				Autocomplete: true,
+			},
+		},
+	},
+	{
+		Name:        "party",
+		Description: "Manage co-hunters of your reservation",
+		Type:        discordgo.ChatApplicationCommand,
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:        "add",
+				Description: "Add a co-hunter to your reservation",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:         "reservation",
+						Description:  "Reservation the member shall join",
+						Type:         discordgo.ApplicationCommandOptionString,
+						Required:     true,
+						Autocomplete: true,
+					},
+					{
+						Name:        "member",
+						Description: "Member joining the hunt",
+						Type:        discordgo.ApplicationCommandOptionUser,
+						Required:    true,
+					},
+				},
+			},
+			{
+				Name:        "remove",
+				Description: "Remove a co-hunter from your reservation",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:         "reservation",
+						Description:  "Reservation the member shall leave",
+						Type:         discordgo.ApplicationCommandOptionString,
+						Required:     true,
+						Autocomplete: true,
+					},
+					{
+						Name:        "member",
+						Description: "Member leaving the hunt",
+						Type:        discordgo.ApplicationCommandOptionUser,
+						Required:    true,
+					},
+				},
+			},
+		},
+	},
+	{
+		Name:        "transfer",
+		Description: "Hand your reservation over to another member",
+		Type:        discordgo.ChatApplicationCommand,
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:         "reservation",
+				Description:  "Reservation to be transferred",
+				Type:         discordgo.ApplicationCommandOptionString,
+				Required:     true,
+				Autocomplete: true,
+			},
+			{
+				Name:        "member",
+				Description: "Member that shall take over the reservation",
+				Type:        discordgo.ApplicationCommandOptionUser,
+				Required:    true,
+			},
+			{
+				Name:        "ask-first",
+				Description: "Let the member accept or decline the reservation before it is transferred",
+				Type:        discordgo.ApplicationCommandOptionBoolean,
+				Required:    false,
+			},
+		},
+	},
+	{
+		Name:        "checkin",
+		Description: "Confirm you are hunting on your reservation, so that nobody can take it over",
+		Type:        discordgo.ChatApplicationCommand,
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:         "reservation",
+				Description:  "Reservation to check in on",
+				Type:         discordgo.ApplicationCommandOptionString,
+				Required:     true,
+				Autocomplete: true,
+			},
 		},
 	},
 	{
@@ -124,4 +353,795 @@ var commands = []*discordgo.ApplicationCommand{
 		Description: "Request a summary snapshot",
 		Type:        discordgo.ChatApplicationCommand,
 	},
+	{
+		Name:        "queue",
+		Description: "Wait for an occupied respawn to become free and book it automatically",
+		Type:        discordgo.ChatApplicationCommand,
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:         "respawn",
+				Description:  "Name of the respawn",
+				Type:         discordgo.ApplicationCommandOptionString,
+				Required:     true,
+				Autocomplete: true,
+			},
+
+			{
+				Name:         "start-at",
+				Description:  "An hour the hunt shall start (e.g. 15:20)",
+				Type:         discordgo.ApplicationCommandOptionString,
+				Required:     true,
+				Autocomplete: true,
+			},
+
+			{
+				Name:         "end-at",
+				Description:  "An hour the hunt shall end (e.g. 17:20)",
+				Type:         discordgo.ApplicationCommandOptionString,
+				Required:     true,
+				Autocomplete: true,
+			},
+
+			{
+				Name:         "date",
+				Description:  "A day the hunt shall take place (e.g. 2023-08-19), defaults to the nearest upcoming one",
+				Type:         discordgo.ApplicationCommandOptionString,
+				Required:     false,
+				Autocomplete: true,
+			},
+		},
+	},
+	{
+		Name:        "apply",
+		Description: "Apply for a respawn handed out by lottery, lots are drawn on the day before the hunt",
+		Type:        discordgo.ChatApplicationCommand,
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:         "respawn",
+				Description:  "Name of the respawn",
+				Type:         discordgo.ApplicationCommandOptionString,
+				Required:     true,
+				Autocomplete: true,
+			},
+
+			{
+				Name:         "start-at",
+				Description:  "An hour the hunt shall start (e.g. 15:20)",
+				Type:         discordgo.ApplicationCommandOptionString,
+				Required:     true,
+				Autocomplete: true,
+			},
+
+			{
+				Name:         "end-at",
+				Description:  "An hour the hunt shall end (e.g. 17:20)",
+				Type:         discordgo.ApplicationCommandOptionString,
+				Required:     true,
+				Autocomplete: true,
+			},
+
+			{
+				Name:         "date",
+				Description:  "A day the hunt shall take place (e.g. 2023-08-19), defaults to the nearest upcoming one",
+				Type:         discordgo.ApplicationCommandOptionString,
+				Required:     false,
+				Autocomplete: true,
+			},
+		},
+	},
+	{
+		Name:        "hold",
+		Description: "Hold a respawn for 15 minutes while you gather your party, confirm it with /confirm",
+		Type:        discordgo.ChatApplicationCommand,
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:         "respawn",
+				Description:  "Name of the respawn",
+				Type:         discordgo.ApplicationCommandOptionString,
+				Required:     true,
+				Autocomplete: true,
+			},
+
+			{
+				Name:         "start-at",
+				Description:  "An hour the hunt shall start (e.g. 15:20)",
+				Type:         discordgo.ApplicationCommandOptionString,
+				Required:     true,
+				Autocomplete: true,
+			},
+
+			{
+				Name:         "end-at",
+				Description:  "An hour the hunt shall end (e.g. 17:20)",
+				Type:         discordgo.ApplicationCommandOptionString,
+				Required:     true,
+				Autocomplete: true,
+			},
+
+			{
+				Name:         "date",
+				Description:  "A day the hunt shall take place (e.g. 2023-08-19), defaults to the nearest upcoming one",
+				Type:         discordgo.ApplicationCommandOptionString,
+				Required:     false,
+				Autocomplete: true,
+			},
+		},
+	},
+	{
+		Name:        "confirm",
+		Description: "Turn your hold into a regular reservation before it expires",
+		Type:        discordgo.ChatApplicationCommand,
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:         "reservation",
+				Description:  "Hold to be confirmed",
+				Type:         discordgo.ApplicationCommandOptionString,
+				Required:     true,
+				Autocomplete: true,
+			},
+		},
+	},
+	{
+		Name:        "free",
+		Description: "List time windows a respawn is free and can be booked in",
+		Type:        discordgo.ChatApplicationCommand,
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:         "respawn",
+				Description:  "Name of the respawn",
+				Type:         discordgo.ApplicationCommandOptionString,
+				Required:     true,
+				Autocomplete: true,
+			},
+
+			{
+				Name:         "date",
+				Description:  "A day to look at (e.g. 2023-08-19), defaults to the next 24 hours",
+				Type:         discordgo.ApplicationCommandOptionString,
+				Required:     false,
+				Autocomplete: true,
+			},
+		},
+	},
+	{
+		Name:        "quota",
+		Description: "Show how much of the weekly respawn quotas has been used this week",
+		Type:        discordgo.ChatApplicationCommand,
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:        "member",
+				Description: "Member to show the usage of, yourself by default",
+				Type:        discordgo.ApplicationCommandOptionUser,
+				Required:    false,
+			},
+		},
+	},
+	{
+		Name:        "recurring",
+		Description: "Manage weekly recurring reservations",
+		Type:        discordgo.ChatApplicationCommand,
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:        "create",
+				Description: "Book a respawn every week on given days",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:         "respawn",
+						Description:  "Name of the respawn",
+						Type:         discordgo.ApplicationCommandOptionString,
+						Required:     true,
+						Autocomplete: true,
+					},
+					{
+						Name:         "days",
+						Description:  "Days of the week the hunt shall take place (e.g. Mon/Wed/Fri)",
+						Type:         discordgo.ApplicationCommandOptionString,
+						Required:     true,
+						Autocomplete: true,
+					},
+					{
+						Name:         "start-at",
+						Description:  "An hour the hunt shall start (e.g. 18:00)",
+						Type:         discordgo.ApplicationCommandOptionString,
+						Required:     true,
+						Autocomplete: true,
+					},
+					{
+						Name:         "end-at",
+						Description:  "An hour the hunt shall end (e.g. 21:00)",
+						Type:         discordgo.ApplicationCommandOptionString,
+						Required:     true,
+						Autocomplete: true,
+					},
+				},
+			},
+			{
+				Name:        "list",
+				Description: "List your recurring reservations",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+			},
+			{
+				Name:        "cancel",
+				Description: "Cancel a recurring reservation along with its upcoming reservations",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:         "series",
+						Description:  "Recurring reservation to be cancelled",
+						Type:         discordgo.ApplicationCommandOptionString,
+						Required:     true,
+						Autocomplete: true,
+					},
+				},
+			},
+		},
+	},
+	{
+		Name:                     "letter-config",
+		Description:              "View or change booking policy of this server",
+		Type:                     discordgo.ChatApplicationCommand,
+		DefaultMemberPermissions: &configPermissions,
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:        "view",
+				Description: "Show current booking policy",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+			},
+			{
+				Name:        "set",
+				Description: "Change booking policy, options that are not provided remain unchanged",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "max-total-minutes",
+						Description: "Maximum minutes of member reservations within 24 hour window",
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						MinValue:    &minimumPolicyValue,
+						MaxValue:    1440,
+					},
+					{
+						Name:        "max-reservation-minutes",
+						Description: "Maximum minutes of a single reservation",
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						MinValue:    &minimumPolicyValue,
+						MaxValue:    1440,
+					},
+					{
+						Name:        "overbook-role",
+						Description: "Name of the role allowed to overbook other members",
+						Type:        discordgo.ApplicationCommandOptionString,
+					},
+					{
+						Name:        "suggestion-step-minutes",
+						Description: "Minutes between suggested hours",
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						MinValue:    &minimumPolicyValue,
+						MaxValue:    180,
+					},
+					{
+						Name:        "booking-horizon-days",
+						Description: "How many days ahead reservations can be made",
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						MinValue:    &minimumPolicyValue,
+						MaxValue:    90,
+					},
+					{
+						Name:        "time-zone",
+						Description: "Time zone of this server (e.g. Europe/Berlin), members can override it with /timezone",
+						Type:        discordgo.ApplicationCommandOptionString,
+					},
+					{
+						Name:        "count-party-time",
+						Description: "Whether hunting in someone else's party counts toward member maximum reservations time",
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+					},
+					{
+						Name:        "check-in-grace-minutes",
+						Description: "Minutes after a reservation starts to check in, before anyone can take it over",
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						MinValue:    &minimumPolicyValue,
+						MaxValue:    120,
+					},
+					{
+						Name:        "overbook-approval",
+						Description: "Whether overbooks wait for approval of members whose reservations they would clip or remove",
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+					},
+					{
+						Name:        "overbook-approval-minutes",
+						Description: "Minutes overbooks wait for approval, before they get approved automatically",
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						MinValue:    &minimumPolicyValue,
+						MaxValue:    1440,
+					},
+				},
+			},
+			{
+				Name:        "blackout-add",
+				Description: "Add a daily time window, in which respawns cannot be hunted",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "name",
+						Description: "Name of the blackout, e.g. Server save",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Required:    true,
+						MaxLength:   policy.MAXIMUM_BLACKOUT_NAME_LENGTH,
+					},
+					{
+						Name:        "start",
+						Description: "Time of day the blackout starts at (e.g. 10:00)",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Required:    true,
+					},
+					{
+						Name:        "minutes",
+						Description: "How many minutes the blackout takes",
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Required:    true,
+						MinValue:    &minimumPolicyValue,
+						MaxValue:    180,
+					},
+					{
+						Name:        "time-zone",
+						Description: "Time zone the start is given in (e.g. Europe/Berlin), the server one by default",
+						Type:        discordgo.ApplicationCommandOptionString,
+					},
+				},
+			},
+			{
+				Name:        "blackout-remove",
+				Description: "Remove a daily blackout",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "name",
+						Description: "Name of the blackout",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Required:    true,
+					},
+				},
+			},
+			{
+				Name:        "tier-add",
+				Description: "Give members of a role a booking priority and their own maximum reservations time",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "role",
+						Description: "Name of the role, e.g. Core",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Required:    true,
+					},
+					{
+						Name:        "priority",
+						Description: "Members can overbook reservations of lower priority only",
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Required:    true,
+						MinValue:    &minimumPolicyValue,
+						MaxValue:    100,
+					},
+					{
+						Name:        "maximum-reservations-minutes",
+						Description: "Maximum minutes of member reservations within 24 hours",
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Required:    true,
+						MinValue:    &minimumPolicyValue,
+						MaxValue:    1440,
+					},
+				},
+			},
+			{
+				Name:        "tier-remove",
+				Description: "Remove a priority tier",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "role",
+						Description: "Name of the role of the tier",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Required:    true,
+					},
+				},
+			},
+			{
+				Name:        "quota-add",
+				Description: "Limit how many hours per week each member can book a respawn, along with its floors and sides",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "respawn",
+						Description: "Name of the respawn, e.g. Soul War",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Required:    true,
+					},
+					{
+						Name:        "hours",
+						Description: "Maximum hours of member reservations on the respawn within a week",
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Required:    true,
+						MinValue:    &minimumPolicyValue,
+						MaxValue:    168,
+					},
+				},
+			},
+			{
+				Name:        "quota-remove",
+				Description: "Remove a weekly respawn quota",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "respawn",
+						Description: "Name of the respawn",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Required:    true,
+					},
+				},
+			},
+			{
+				Name:        "window-add",
+				Description: "Limit since when a respawn, along with its floors and sides, can be booked",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "respawn",
+						Description: "Name of the respawn, e.g. Soul War",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Required:    true,
+					},
+					{
+						Name:        "advance-hours",
+						Description: "How many hours ahead reservations of the respawn can start",
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						MinValue:    &minimumPolicyValue,
+						MaxValue:    90 * 24,
+					},
+					{
+						Name:        "opens-at",
+						Description: "Time of day booking of the next day opens at, in the server time zone (e.g. 18:00)",
+						Type:        discordgo.ApplicationCommandOptionString,
+					},
+				},
+			},
+			{
+				Name:        "window-remove",
+				Description: "Remove a respawn booking window",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "respawn",
+						Description: "Name of the respawn",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Required:    true,
+					},
+				},
+			},
+			{
+				Name:        "lottery-add",
+				Description: "Hand out a respawn, along with its floors and sides, by drawing lots among /apply applications",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "respawn",
+						Description: "Name of the respawn, e.g. Soul War",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Required:    true,
+					},
+					{
+						Name:        "draw-at",
+						Description: "Time of day lots for the next day are drawn at, in the server time zone (e.g. 18:00)",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Required:    true,
+					},
+					{
+						Name:        "weighted",
+						Description: "Whether members who have won within the last 30 days get lower chances",
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+					},
+				},
+			},
+			{
+				Name:        "lottery-remove",
+				Description: "Remove a respawn lottery",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "respawn",
+						Description: "Name of the respawn",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Required:    true,
+					},
+				},
+			},
+		},
+	},
+	{
+		Name:                     "spot",
+		Description:              "Manage respawns that can be booked on this server",
+		Type:                     discordgo.ChatApplicationCommand,
+		DefaultMemberPermissions: &configPermissions,
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:        "add",
+				Description: "Add a new respawn to this server",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "name",
+						Description: "Name of the respawn",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Required:    true,
+						MaxLength:   spot.MAXIMUM_NAME_LENGTH,
+					},
+					{
+						Name:         "parent",
+						Description:  "Respawn this one is a floor or side of, they count as one towards the hunting limit",
+						Type:         discordgo.ApplicationCommandOptionString,
+						Required:     false,
+						Autocomplete: true,
+					},
+				},
+			},
+			{
+				Name:        "rename",
+				Description: "Change name of a respawn, its reservations are kept",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:         "respawn",
+						Description:  "Respawn to be renamed",
+						Type:         discordgo.ApplicationCommandOptionString,
+						Required:     true,
+						Autocomplete: true,
+					},
+					{
+						Name:        "name",
+						Description: "New name of the respawn",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Required:    true,
+						MaxLength:   spot.MAXIMUM_NAME_LENGTH,
+					},
+				},
+			},
+			{
+				Name:        "describe",
+				Description: "Set level range, vocations, area and type of a respawn added by this server, omitted ones are cleared",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:         "respawn",
+						Description:  "Respawn to be described",
+						Type:         discordgo.ApplicationCommandOptionString,
+						Required:     true,
+						Autocomplete: true,
+					},
+					{
+						Name:        "area",
+						Description: "Region the respawn lies in (e.g. Feyrist)",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Required:    false,
+						MaxLength:   spot.MAXIMUM_AREA_LENGTH,
+					},
+					{
+						Name:        "min-level",
+						Description: "Lowest recommended level",
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Required:    false,
+						MinValue:    &minimumPolicyValue,
+					},
+					{
+						Name:        "max-level",
+						Description: "Highest recommended level",
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Required:    false,
+						MinValue:    &minimumPolicyValue,
+					},
+					{
+						Name:        "vocations",
+						Description: "Recommended vocations (e.g. EK, ED)",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Required:    false,
+					},
+					{
+						Name:        "type",
+						Description: "Whether the respawn is meant for solo or team hunts",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Required:    false,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: string(spot.KindSolo), Value: string(spot.KindSolo)},
+							{Name: string(spot.KindTeam), Value: string(spot.KindTeam)},
+						},
+					},
+				},
+			},
+			{
+				Name:        "alias",
+				Description: "Let members refer to a respawn added by this server with another name",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:         "respawn",
+						Description:  "Respawn to be aliased",
+						Type:         discordgo.ApplicationCommandOptionString,
+						Required:     true,
+						Autocomplete: true,
+					},
+					{
+						Name:        "alias",
+						Description: "Another name of the respawn (e.g. libby)",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Required:    true,
+						MaxLength:   spot.MAXIMUM_NAME_LENGTH,
+					},
+				},
+			},
+			{
+				Name:        "unalias",
+				Description: "Remove another name of a respawn added by this server",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:         "respawn",
+						Description:  "Respawn the alias belongs to",
+						Type:         discordgo.ApplicationCommandOptionString,
+						Required:     true,
+						Autocomplete: true,
+					},
+					{
+						Name:        "alias",
+						Description: "Alias to be removed",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Required:    true,
+					},
+				},
+			},
+			{
+				Name:        "import",
+				Description: "Add or update respawns of this server from a JSON or CSV catalog, shows changes first",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "catalog",
+						Description: "Catalog file, e.g. one obtained with /spot export",
+						Type:        discordgo.ApplicationCommandOptionAttachment,
+						Required:    true,
+					},
+					{
+						Name:        "apply",
+						Description: "Apply the changes, instead of only showing them (defaults to false)",
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Required:    false,
+					},
+				},
+			},
+			{
+				Name:        "export",
+				Description: "Download respawns of this server as a catalog file",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "format",
+						Description: "Format of the catalog (defaults to json)",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Required:    false,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: string(spot.FormatJSON), Value: string(spot.FormatJSON)},
+							{Name: string(spot.FormatCSV), Value: string(spot.FormatCSV)},
+						},
+					},
+				},
+			},
+			{
+				Name:        "archive",
+				Description: "Stop a respawn added by this server from being booked, its past reservations are kept",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:         "respawn",
+						Description:  "Respawn to be archived",
+						Type:         discordgo.ApplicationCommandOptionString,
+						Required:     true,
+						Autocomplete: true,
+					},
+				},
+			},
+			{
+				Name:        "hide",
+				Description: "Hide a respawn shared by all servers from this server",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:         "respawn",
+						Description:  "Respawn to be hidden",
+						Type:         discordgo.ApplicationCommandOptionString,
+						Required:     true,
+						Autocomplete: true,
+					},
+				},
+			},
+			{
+				Name:        "unhide",
+				Description: "Show a hidden respawn on this server again",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:         "respawn",
+						Description:  "Respawn to be shown again",
+						Type:         discordgo.ApplicationCommandOptionString,
+						Required:     true,
+						Autocomplete: true,
+					},
+				},
+			},
+		},
+	},
+	{
+		Name:        "timezone",
+		Description: "Manage the time zone your hours are interpreted in",
+		Type:        discordgo.ChatApplicationCommand,
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:        "view",
+				Description: "Show your current time zone",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+			},
+			{
+				Name:        "set",
+				Description: "Set your own time zone",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "zone",
+						Description: "Name of the time zone (e.g. America/Sao_Paulo or Europe/Warsaw)",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Required:    true,
+					},
+				},
+			},
+			{
+				Name:        "reset",
+				Description: "Use time zone of this server again",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+			},
+		},
+	},
+	{
+		Name:        "reminders",
+		Description: "Manage DMs reminding you that your reservations are about to start or end",
+		Type:        discordgo.ChatApplicationCommand,
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Name:        "view",
+				Description: "Show your current reminder settings",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+			},
+			{
+				Name:        "set",
+				Description: "Change your reminder settings, options that are not provided remain unchanged",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "enabled",
+						Description: "Whether you want to receive reminders at all",
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+					},
+					{
+						Name:        "before-start-minutes",
+						Description: "Minutes before the start of your reservation you are reminded, 0 turns it off",
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						MinValue:    &minimumReminderValue,
+						MaxValue:    1440,
+					},
+					{
+						Name:        "before-end-minutes",
+						Description: "Minutes before the end of your reservation you are reminded, 0 turns it off",
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						MinValue:    &minimumReminderValue,
+						MaxValue:    1440,
+					},
+				},
+			},
+		},
+	},
 }
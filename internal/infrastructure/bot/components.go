@@ -0,0 +1,375 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sirupsen/logrus"
+
+	stringsHelper "spot-assistant/internal/common/strings"
+	"spot-assistant/internal/core/dto/book"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/reservation"
+)
+
+// Custom IDs of message components consist of action name and its arguments,
+// separated with a colon, e.g. transfer-accept:1:member-id:recipient-id.
+const componentIDSeparator = ":"
+
+func componentID(action string, args ...string) string {
+	return strings.Join(append([]string{action}, args...), componentIDSeparator)
+}
+
+func (b *Bot) handleComponent(i *discordgo.InteractionCreate) {
+	var err error
+	args := strings.Split(i.MessageComponentData().CustomID, componentIDSeparator)
+	log := b.log.WithFields(logrus.Fields{"customID": i.MessageComponentData().CustomID})
+
+	switch args[0] {
+	case "transfer-accept":
+		err = b.TransferAnswer(i, args[1:], true)
+	case "transfer-decline":
+		err = b.TransferAnswer(i, args[1:], false)
+	case "checkin":
+		err = b.CheckInAnswer(i, args[1:])
+	case "book-alternative":
+		err = b.BookAlternativeAnswer(i, args[1:])
+	case "overbook-accept":
+		err = b.OverbookAnswer(i, args[1:], true)
+	case "overbook-decline":
+		err = b.OverbookAnswer(i, args[1:], false)
+	default:
+		err = fmt.Errorf("missing handler for component: %s", args[0])
+	}
+
+	if err != nil {
+		log.Error(err)
+
+		err = b.interactionRespond(i, &discordgo.InteractionResponseData{
+			Content: b.dcErrorMsg(err),
+			Flags:   discordgo.MessageFlagsEphemeral,
+		}, discordgo.InteractionResponseChannelMessageWithSource)
+		if err != nil {
+			b.log.Errorf("could not respond with an error message: %s", err)
+		}
+	}
+}
+
+func transferOfferComponents(request book.TransferRequest) []discordgo.MessageComponent {
+	args := []string{fmt.Sprint(request.ReservationID), request.Member.ID, request.Recipient.ID}
+
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    "Accept",
+					Style:    discordgo.SuccessButton,
+					CustomID: componentID("transfer-accept", args...),
+				},
+				discordgo.Button{
+					Label:    "Decline",
+					Style:    discordgo.DangerButton,
+					CustomID: componentID("transfer-decline", args...),
+				},
+			},
+		},
+	}
+}
+
+// Handles recipient answer to the transfer offer, args are reservation ID, author ID and recipient ID.
+func (b *Bot) TransferAnswer(i *discordgo.InteractionCreate, args []string, accepted bool) error {
+	if len(args) != 3 {
+		return errors.New("malformed transfer offer")
+	}
+
+	reservationId, err := stringsHelper.StrToInt64(args[0])
+	if err != nil {
+		return fmt.Errorf("could not parse reservation id: %v", args[0])
+	}
+
+	recipient := MapMember(i.Member)
+	if recipient.ID != args[2] {
+		return errors.New("only the member the reservation is offered to can answer it")
+	}
+
+	gID, err := stringsHelper.StrToInt64(i.GuildID)
+	if err != nil {
+		return fmt.Errorf("could not parse guild id: %v", i.GuildID)
+	}
+
+	guild, err := b.GetGuild(gID)
+	if err != nil {
+		return err
+	}
+
+	author, err := b.GetMember(guild, args[1])
+	if err != nil {
+		return fmt.Errorf("could not find the member offering the reservation: %w", err)
+	}
+
+	request := book.TransferRequest{
+		Guild:         guild,
+		Member:        author,
+		Recipient:     recipient,
+		ReservationID: reservationId,
+	}
+
+	content := fmt.Sprintf("<@!%s> declined the reservation offered by <@!%s>.", recipient.ID, author.ID)
+	if accepted {
+		res, err := b.eventHandler.OnTransfer(b, request)
+		if err != nil {
+			return err
+		}
+
+		content = formatTransfer(request, res)
+	}
+
+	return b.interactionRespond(i, &discordgo.InteractionResponseData{
+		Content:    content,
+		Components: []discordgo.MessageComponent{},
+		AllowedMentions: &discordgo.MessageAllowedMentions{
+			Parse: []discordgo.AllowedMentionType{discordgo.AllowedMentionTypeUsers},
+		},
+	}, discordgo.InteractionResponseUpdateMessage)
+}
+
+// Check-in button is sent in DMs, so it carries the guild ID along with reservation ID.
+func checkInComponents(guild *discord.Guild, res *reservation.ReservationWithSpot) []discordgo.MessageComponent {
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    "Check in",
+					Style:    discordgo.SuccessButton,
+					CustomID: componentID("checkin", guild.ID, fmt.Sprint(res.Reservation.ID)),
+				},
+			},
+		},
+	}
+}
+
+// Handles check-in button of a reminder DM, args are guild ID and reservation ID.
+func (b *Bot) CheckInAnswer(i *discordgo.InteractionCreate, args []string) error {
+	if len(args) != 2 {
+		return errors.New("malformed check-in button")
+	}
+
+	gID, err := stringsHelper.StrToInt64(args[0])
+	if err != nil {
+		return fmt.Errorf("could not parse guild id: %v", args[0])
+	}
+
+	reservationId, err := stringsHelper.StrToInt64(args[1])
+	if err != nil {
+		return fmt.Errorf("could not parse reservation id: %v", args[1])
+	}
+
+	guild, err := b.GetGuild(gID)
+	if err != nil {
+		return err
+	}
+
+	// Interactions in DMs come with a user instead of a guild member
+	user := i.User
+	if i.Member != nil {
+		user = i.Member.User
+	}
+
+	member, err := b.GetMember(guild, user.ID)
+	if err != nil {
+		return fmt.Errorf("could not find you on the server: %w", err)
+	}
+
+	res, err := b.eventHandler.OnCheckIn(book.CheckInRequest{
+		Member:        member,
+		Guild:         guild,
+		ReservationID: reservationId,
+	})
+	if err != nil {
+		return err
+	}
+
+	return b.interactionRespond(i, &discordgo.InteractionResponseData{
+		Content:    formatCheckIn(res),
+		Components: []discordgo.MessageComponent{},
+	}, discordgo.InteractionResponseUpdateMessage)
+}
+
+// Discord rejects button labels longer than 80 characters
+const MAXIMUM_BUTTON_LABEL_LENGTH = 80
+
+// Alternative buttons carry ID of the member they are proposed to, spot ID and Unix timestamps of the slot.
+// Labels show hours in member time zone, as buttons cannot hold Discord timestamps.
+func alternativeComponents(member *discord.Member, loc *time.Location, alternatives []*reservation.Alternative) []discordgo.MessageComponent {
+	buttons := make([]discordgo.MessageComponent, len(alternatives))
+	for i, alternative := range alternatives {
+		label := fmt.Sprintf(
+			"%s-%s %s",
+			alternative.StartAt.In(loc).Format("Mon "+stringsHelper.DC_TIME_FORMAT),
+			alternative.EndAt.In(loc).Format(stringsHelper.DC_TIME_FORMAT),
+			alternative.Spot.Name,
+		)
+		if utf8.RuneCountInString(label) > MAXIMUM_BUTTON_LABEL_LENGTH {
+			label = string([]rune(label)[:MAXIMUM_BUTTON_LABEL_LENGTH-1]) + "…"
+		}
+
+		buttons[i] = discordgo.Button{
+			Label: label,
+			Style: discordgo.PrimaryButton,
+			CustomID: componentID(
+				"book-alternative",
+				member.ID,
+				fmt.Sprint(alternative.Spot.ID),
+				fmt.Sprint(alternative.StartAt.Unix()),
+				fmt.Sprint(alternative.EndAt.Unix()),
+			),
+		}
+	}
+
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{Components: buttons},
+	}
+}
+
+// Handles alternative slot button of a conflicting booking, args are member ID, spot ID
+// and Unix timestamps of the slot start and end.
+func (b *Bot) BookAlternativeAnswer(i *discordgo.InteractionCreate, args []string) error {
+	if len(args) != 4 {
+		return errors.New("malformed alternative slot")
+	}
+
+	member := MapMember(i.Member)
+	if member.ID != args[0] {
+		return errors.New("only the member the slot is proposed to can book it")
+	}
+
+	values := make([]int64, 0, len(args)-1)
+	for _, arg := range args[1:] {
+		value, err := stringsHelper.StrToInt64(arg)
+		if err != nil {
+			return fmt.Errorf("could not parse alternative slot: %v", arg)
+		}
+		values = append(values, value)
+	}
+
+	gID, err := stringsHelper.StrToInt64(i.GuildID)
+	if err != nil {
+		return fmt.Errorf("could not parse guild id: %v", i.GuildID)
+	}
+
+	guild, err := b.GetGuild(gID)
+	if err != nil {
+		return err
+	}
+
+	response, err := b.eventHandler.OnBookAlternative(b, book.BookAlternativeRequest{
+		Guild:   guild,
+		Member:  member,
+		SpotID:  values[0],
+		StartAt: time.Unix(values[1], 0),
+		EndAt:   time.Unix(values[2], 0),
+	})
+	if err != nil {
+		return err
+	}
+
+	return b.interactionRespond(i, &discordgo.InteractionResponseData{
+		Content: fmt.Sprintf(
+			"<@!%s> booked **%s** between %s and %s.",
+			member.ID,
+			response.Spot,
+			stringsHelper.FormatDcLongTime(response.StartAt),
+			stringsHelper.FormatDcLongTime(response.EndAt),
+		),
+		Components: []discordgo.MessageComponent{},
+		AllowedMentions: &discordgo.MessageAllowedMentions{
+			Parse: []discordgo.AllowedMentionType{discordgo.AllowedMentionTypeUsers},
+		},
+	}, discordgo.InteractionResponseUpdateMessage)
+}
+
+// Overbook approval buttons are sent in DMs, so they carry the guild ID along with request ID.
+func overbookApprovalComponents(guild *discord.Guild, request *reservation.OverbookRequestWithSpot) []discordgo.MessageComponent {
+	args := []string{guild.ID, fmt.Sprint(request.OverbookRequest.ID)}
+
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    "Accept",
+					Style:    discordgo.SuccessButton,
+					CustomID: componentID("overbook-accept", args...),
+				},
+				discordgo.Button{
+					Label:    "Decline",
+					Style:    discordgo.DangerButton,
+					CustomID: componentID("overbook-decline", args...),
+				},
+			},
+		},
+	}
+}
+
+// Handles affected member answer to an overbook request, args are guild ID and request ID.
+func (b *Bot) OverbookAnswer(i *discordgo.InteractionCreate, args []string, accepted bool) error {
+	if len(args) != 2 {
+		return errors.New("malformed overbook request")
+	}
+
+	gID, err := stringsHelper.StrToInt64(args[0])
+	if err != nil {
+		return fmt.Errorf("could not parse guild id: %v", args[0])
+	}
+
+	requestId, err := stringsHelper.StrToInt64(args[1])
+	if err != nil {
+		return fmt.Errorf("could not parse overbook request id: %v", args[1])
+	}
+
+	guild, err := b.GetGuild(gID)
+	if err != nil {
+		return err
+	}
+
+	// Interactions in DMs come with a user instead of a guild member
+	user := i.User
+	if i.Member != nil {
+		user = i.Member.User
+	}
+
+	member, err := b.GetMember(guild, user.ID)
+	if err != nil {
+		return fmt.Errorf("could not find you on the server: %w", err)
+	}
+
+	request, err := b.eventHandler.OnOverbookAnswer(b, book.OverbookAnswerRequest{
+		Guild:     guild,
+		Member:    member,
+		RequestID: requestId,
+		Accepted:  accepted,
+	})
+	if err != nil {
+		return err
+	}
+
+	answer := "declined"
+	if accepted {
+		answer = "accepted"
+	}
+
+	return b.interactionRespond(i, &discordgo.InteractionResponseData{
+		Content: fmt.Sprintf(
+			"You have %s the overbook of **%s** (%s - %s) requested by <@!%s>. The outcome will be posted in #letter.",
+			answer,
+			request.Spot.Name,
+			stringsHelper.FormatDcLongTime(request.StartAt),
+			stringsHelper.FormatDcLongTime(request.EndAt),
+			request.AuthorDiscordID,
+		),
+		Components: []discordgo.MessageComponent{},
+	}, discordgo.InteractionResponseUpdateMessage)
+}
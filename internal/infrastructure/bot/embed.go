@@ -7,7 +7,7 @@ func (b *Bot) baseEmbed() *discordgo.MessageEmbed {
 		URL:         "https://tibialoot.com",
 		Type:        discordgo.EmbedTypeRich,
 		Title:       "TibiaLoot.com - Spot Assistant",
-		Description: "Current and upcoming hunts. Times are in **Europe/Berlin**.",
+		Description: "Current and upcoming hunts. Times are shown in your local time zone.",
 	}
 }
 
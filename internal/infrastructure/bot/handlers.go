@@ -1,8 +1,12 @@
 package bot
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"regexp"
 	"strings"
 	"time"
 
@@ -12,7 +16,10 @@ import (
 	"spot-assistant/internal/common/collections"
 	stringsHelper "spot-assistant/internal/common/strings"
 	"spot-assistant/internal/core/dto/book"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/policy"
 	"spot-assistant/internal/core/dto/reservation"
+	"spot-assistant/internal/core/dto/spot"
 	"spot-assistant/internal/core/dto/summary"
 )
 
@@ -33,12 +40,16 @@ func (b *Bot) Ready(s *discordgo.Session, r *discordgo.Ready) {
 	defer b.eventHandler.OnReady(b)
 }
 
-// InteractionCreate this is the entry point when a slash command is invoked.
+// InteractionCreate this is the entry point when a slash command is invoked, or a message component is used.
 func (b *Bot) InteractionCreate(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	b.log.Debug("InteractionCreate")
 	tStart := time.Now()
 
-	b.handleCommand(i)
+	if i.Type == discordgo.InteractionMessageComponent {
+		b.handleComponent(i)
+	} else {
+		b.handleCommand(i)
+	}
 
 	b.log.WithFields(logrus.Fields{"time": time.Since(tStart)}).Debug("interaction handled")
 }
@@ -55,60 +66,61 @@ func (b *Bot) Tick() {
 
 func (b *Bot) Book(i *discordgo.InteractionCreate) error {
 	interaction := i.Interaction
-	tNow := time.Now()
 	gID, err := stringsHelper.StrToInt64(i.GuildID)
 	if err != nil {
 		return err
 	}
 	dcSession := b.mgr.SessionForGuild(gID)
 
+	options := MapOptionsByName(i.ApplicationCommandData().Options)
+	spotOption, hasSpot := options["respawn"]
+	startOption, hasStart := options["start-at"]
+	endOption, hasEnd := options["end-at"]
+	if !hasSpot || !hasStart || !hasEnd {
+		return errors.New("book command requires respawn, start-at and end-at arguments")
+	}
+
 	// Flag parsing
 	overbook := false
-	switch len(i.ApplicationCommandData().Options) {
-	case 4:
-		if i.ApplicationCommandData().Options[3].StringValue() == "true" {
-			overbook = true
-		}
-	case 3:
-		break
-	default:
-		return errors.New("book command requires 3 arguments")
+	if option, ok := options["overbook"]; ok && option.StringValue() == "true" {
+		overbook = true
 	}
 
-	startAt, err := time.Parse(stringsHelper.DC_TIME_FORMAT, i.ApplicationCommandData().Options[1].StringValue())
-	if err != nil {
-		return err
+	date := ""
+	if option, ok := options["date"]; ok {
+		date = option.StringValue()
 	}
-	startAt = time.Date(
-		tNow.Year(), tNow.Month(), tNow.Day(), startAt.Hour(), startAt.Minute(), 0, 0, tNow.Location())
 
-	endAt, err := time.Parse(stringsHelper.DC_TIME_FORMAT, i.ApplicationCommandData().Options[2].StringValue())
+	guild, err := b.GetGuild(gID)
 	if err != nil {
 		return err
 	}
-	endAt = time.Date(
-		tNow.Year(), tNow.Month(), tNow.Day(), endAt.Hour(), endAt.Minute(), 0, 0, tNow.Location())
-
-	if startAt.Before(tNow) {
-		b.log.Warning("moving startAt to next day, as it's already past the starting point")
-		startAt = startAt.Add(24 * time.Hour)
-		endAt = endAt.Add(24 * time.Hour)
-	}
 
-	if startAt.After(endAt) {
-		endAt = endAt.Add(24 * time.Hour)
+	// Hours are given in member time zone
+	member := MapMember(i.Member)
+	loc, err := b.eventHandler.OnLocation(guild, member)
+	if err != nil {
+		return err
 	}
 
-	guild, err := b.GetGuild(gID)
+	startAt, endAt, err := b.parseTimeRange(time.Now().In(loc), date, startOption.StringValue(), endOption.StringValue())
 	if err != nil {
 		return err
 	}
 
-	member := MapMember(i.Member)
+	party := []*discord.Member{}
+	if option, ok := options["party"]; ok {
+		party, err = b.mentionedMembers(guild, option.StringValue())
+		if err != nil {
+			return err
+		}
+	}
+
 	request := book.BookRequest{
 		Member:   member,
 		Guild:    guild,
-		Spot:     i.ApplicationCommandData().Options[0].StringValue(),
+		Party:    party,
+		Spot:     spotOption.StringValue(),
 		StartAt:  startAt,
 		EndAt:    endAt,
 		Overbook: overbook,
@@ -120,67 +132,40 @@ func (b *Bot) Book(i *discordgo.InteractionCreate) error {
 		message.WriteString("I'm sorry, but something went wrong. If you require support, join TibiaLoot.com Discord: https://discord.gg/F4YKgsnzmc \n")
 
 		message.WriteString(fmt.Sprintf("Error message:\n```%s```\n", err))
+	} else if response.OverbookRequest != nil {
+		message.WriteString(formatOverbookRequest(response.OverbookRequest))
 	} else {
 		message.WriteString(fmt.Sprintf(
-			"<@!%s> booked **%s** between %s and %s.\n\n",
+			"<@!%s> booked **%s** between %s and %s.",
 			member.ID,
 			response.Spot,
-			response.StartAt.Format("2006-01-02 15:04"),
-			response.EndAt.Format("2006-01-02 15:04"),
+			stringsHelper.FormatDcLongTime(response.StartAt),
+			stringsHelper.FormatDcLongTime(response.EndAt),
 		))
+		if len(party) > 0 {
+			message.WriteString(fmt.Sprintf(" Party: %s.", strings.Join(collections.PoorMansMap(party, func(m *discord.Member) string {
+				return fmt.Sprintf("<@!%s>", m.ID)
+			}), ", ")))
+		}
+		message.WriteString("\n\n")
 	}
 	haveWeOverbooked := err == nil
 
-	if len(response.ConflictingReservations) > 0 {
-		message.WriteString("Following reservations are conflicting")
-		if err == nil {
-			message.WriteString(" **and have been shortened or removed**")
-		}
-		message.WriteString(":\n\n")
-
-		for _, res := range response.ConflictingReservations {
-			var author string
-			switch haveWeOverbooked { // We notify users on overbooks only
-			case true:
-				author = fmt.Sprintf("<@!%s>", res.Original.AuthorDiscordID) // Mention user profile by ID
-			case false:
-				member, err = b.GetMember(guild, res.Original.AuthorDiscordID)
-				if err == nil {
-					author = member.Nick
-					if len(author) == 0 {
-						author = member.Username
-					}
-				} else {
-					author = res.Original.Author
-				}
-				author = fmt.Sprintf("**%s**", author)
-			}
-
-			message.WriteString(fmt.Sprintf(
-				"* %s ", author,
-			))
-
-			if haveWeOverbooked {
-				if len(res.New) > 0 {
-					message.WriteString("had their reservation clipped to: ")
-					newClippedRanges := collections.PoorMansMap(res.New, func(r *reservation.Reservation) string {
-						return fmt.Sprintf("**%s - %s**", r.StartAt.Format(stringsHelper.DC_LONG_TIME_FORMAT), r.EndAt.Format(stringsHelper.DC_LONG_TIME_FORMAT))
-					})
-					message.WriteString(strings.Join(newClippedRanges, ", "))
-				} else {
-					message.WriteString("had their reservation removed ")
-				}
+	if !haveWeOverbooked && len(response.ConflictingReservations) > 0 {
+		message.WriteString("You can use /queue command to get booked automatically once the respawn becomes free.\n\n")
+	}
 
-				message.WriteString(fmt.Sprintf("(originally: %s - %s)\n", res.Original.StartAt.Format(stringsHelper.DC_LONG_TIME_FORMAT), res.Original.EndAt.Format(stringsHelper.DC_LONG_TIME_FORMAT)))
-				continue // Stop here
-			}
+	b.writeConflictingReservations(&message, guild, response.ConflictingReservations, haveWeOverbooked)
 
-			message.WriteString(fmt.Sprintf("%s - %s\n", res.Original.StartAt.Format(stringsHelper.DC_LONG_TIME_FORMAT), res.Original.EndAt.Format(stringsHelper.DC_LONG_TIME_FORMAT)))
-		}
+	components := []discordgo.MessageComponent{}
+	if !haveWeOverbooked && len(response.Alternatives) > 0 {
+		writeAlternatives(&message, response.Alternatives)
+		components = alternativeComponents(member, loc, response.Alternatives)
 	}
 
 	_, err = dcSession.FollowupMessageCreate(interaction, false, &discordgo.WebhookParams{
-		Content: message.String(),
+		Content:    message.String(),
+		Components: components,
 		AllowedMentions: &discordgo.MessageAllowedMentions{
 			Parse: []discordgo.AllowedMentionType{discordgo.AllowedMentionTypeUsers},
 		},
@@ -188,6 +173,171 @@ func (b *Bot) Book(i *discordgo.InteractionCreate) error {
 	return err
 }
 
+func formatOverbookRequest(request *reservation.OverbookRequestWithSpot) string {
+	return fmt.Sprintf(
+		"<@!%s> would like to overbook **%s** between %s and %s. Waiting for approval of %s, the overbook will be approved automatically at %s. The outcome will be posted in #letter.\n\n",
+		request.AuthorDiscordID,
+		request.Spot.Name,
+		stringsHelper.FormatDcLongTime(request.StartAt),
+		stringsHelper.FormatDcLongTime(request.EndAt),
+		strings.Join(collections.PoorMansMap(request.ApproverDiscordIDs(), func(id string) string {
+			return fmt.Sprintf("<@!%s>", id)
+		}), ", "),
+		stringsHelper.FormatDcTime(request.ExpiresAt),
+	)
+}
+
+// Lists free slots proposed instead of the conflicting one.
+func writeAlternatives(message *strings.Builder, alternatives []*reservation.Alternative) {
+	message.WriteString("\nFollowing slots are free, click one of them to book it instead:\n\n")
+	for _, alternative := range alternatives {
+		message.WriteString(fmt.Sprintf(
+			"* **%s** %s - %s\n",
+			alternative.Spot.Name,
+			stringsHelper.FormatDcLongTime(alternative.StartAt),
+			stringsHelper.FormatDcTime(alternative.EndAt),
+		))
+	}
+}
+
+// Discord rejects messages longer than 2000 characters
+const MAXIMUM_MESSAGE_LENGTH = 2000
+
+const MAXIMUM_CATALOG_SIZE = 1024 * 1024
+
+var mentionRegexp = regexp.MustCompile(`<@!?(\d+)>`)
+
+// Returns distinct guild members mentioned in a given text, e.g. "<@123> <@!456>".
+func (b *Bot) mentionedMembers(guild *discord.Guild, text string) ([]*discord.Member, error) {
+	members := []*discord.Member{}
+	for _, match := range mentionRegexp.FindAllStringSubmatch(text, -1) {
+		_, index := collections.PoorMansFind(members, func(m *discord.Member) bool {
+			return m.ID == match[1]
+		})
+		if index != -1 {
+			continue
+		}
+
+		member, err := b.GetMember(guild, match[1])
+		if err != nil {
+			return nil, fmt.Errorf("could not find mentioned member: %w", err)
+		}
+		members = append(members, member)
+	}
+
+	if len(members) == 0 && len(strings.TrimSpace(text)) > 0 {
+		return nil, errors.New("mention party members with @, e.g. @Knight @Druid")
+	}
+
+	return members, nil
+}
+
+// Describes conflicting reservations, which were either overbooked or prevented booking.
+func (b *Bot) writeConflictingReservations(message *strings.Builder, guild *discord.Guild, conflicts []*reservation.ClippedOrRemovedReservation, haveWeOverbooked bool) {
+	if len(conflicts) == 0 {
+		return
+	}
+
+	message.WriteString("Following reservations are conflicting")
+	if haveWeOverbooked {
+		message.WriteString(" **and have been shortened or removed**")
+	}
+	message.WriteString(":\n\n")
+
+	for _, res := range conflicts {
+		var author string
+		switch haveWeOverbooked { // We notify users on overbooks only
+		case true:
+			author = fmt.Sprintf("<@!%s>", res.Original.AuthorDiscordID) // Mention user profile by ID
+		case false:
+			member, err := b.GetMember(guild, res.Original.AuthorDiscordID)
+			if err == nil {
+				author = member.Nick
+				if len(author) == 0 {
+					author = member.Username
+				}
+			} else {
+				author = res.Original.Author
+			}
+			author = fmt.Sprintf("**%s**", author)
+		}
+
+		message.WriteString(fmt.Sprintf(
+			"* %s ", author,
+		))
+
+		if haveWeOverbooked {
+			if len(res.New) > 0 {
+				message.WriteString("had their reservation clipped to: ")
+				newClippedRanges := collections.PoorMansMap(res.New, func(r *reservation.Reservation) string {
+					return fmt.Sprintf("**%s - %s**", stringsHelper.FormatDcLongTime(r.StartAt), stringsHelper.FormatDcLongTime(r.EndAt))
+				})
+				message.WriteString(strings.Join(newClippedRanges, ", "))
+			} else {
+				message.WriteString("had their reservation removed ")
+			}
+
+			message.WriteString(fmt.Sprintf("(originally: %s - %s)\n", stringsHelper.FormatDcLongTime(res.Original.StartAt), stringsHelper.FormatDcLongTime(res.Original.EndAt)))
+			continue // Stop here
+		}
+
+		message.WriteString(fmt.Sprintf("%s - %s\n", stringsHelper.FormatDcLongTime(res.Original.StartAt), stringsHelper.FormatDcLongTime(res.Original.EndAt)))
+	}
+}
+
+// parseTimeRange translates start and end hours into a time range on the given date.
+// Without a date, it picks the nearest upcoming time range.
+func (b *Bot) parseTimeRange(tNow time.Time, date string, startHour string, endHour string) (time.Time, time.Time, error) {
+	day := tNow
+	if len(date) > 0 {
+		parsedDate, err := time.ParseInLocation(stringsHelper.DC_DATE_FORMAT, date, tNow.Location())
+		if err != nil {
+			return parsedDate, parsedDate, fmt.Errorf("could not parse date %s, expected format is YYYY-MM-DD", date)
+		}
+		day = parsedDate
+	}
+
+	startAt, err := time.Parse(stringsHelper.DC_TIME_FORMAT, startHour)
+	if err != nil {
+		return startAt, startAt, err
+	}
+	startAt = time.Date(
+		day.Year(), day.Month(), day.Day(), startAt.Hour(), startAt.Minute(), 0, 0, tNow.Location())
+
+	endAt, err := time.Parse(stringsHelper.DC_TIME_FORMAT, endHour)
+	if err != nil {
+		return startAt, endAt, err
+	}
+	endAt = time.Date(
+		day.Year(), day.Month(), day.Day(), endAt.Hour(), endAt.Minute(), 0, 0, tNow.Location())
+
+	if startAt.Before(tNow) {
+		if len(date) > 0 {
+			return startAt, endAt, errors.New("reservation cannot start in the past")
+		}
+
+		b.log.Warning("moving startAt to next day, as it's already past the starting point")
+		startAt = startAt.AddDate(0, 0, 1)
+		endAt = endAt.AddDate(0, 0, 1)
+	}
+
+	// Days are added on the calendar, as they do not last 24 hours when the clocks change
+	if startAt.After(endAt) {
+		endAt = endAt.AddDate(0, 0, 1)
+	}
+
+	return startAt, endAt, nil
+}
+
+// Maps options of book, queue and free commands to the autocompleted fields
+var bookAutocompleteFields = map[string]book.BookAutocompleteFocus{
+	"respawn":  book.BookAutocompleteSpot,
+	"start-at": book.BookAutocompleteStartAt,
+	"end-at":   book.BookAutocompleteEndAt,
+	"overbook": book.BookAutocompleteOverbook,
+	"date":     book.BookAutocompleteDate,
+}
+
 func (b *Bot) BookAutocomplete(i *discordgo.InteractionCreate) error {
 	selectedOption, index := collections.PoorMansFind(i.ApplicationCommandData().Options,
 		func(o *discordgo.ApplicationCommandInteractionDataOption) bool {
@@ -197,16 +347,42 @@ func (b *Bot) BookAutocomplete(i *discordgo.InteractionCreate) error {
 		return errors.New("none of the options were selected for autocompletion")
 	}
 
-	response, err := b.eventHandler.OnBookAutocomplete(book.BookAutocompleteRequest{
-		Field: book.BookAutocompleteFocus(index),
-		Value: selectedOption.StringValue(),
-	})
+	gID, err := stringsHelper.StrToInt64(i.GuildID)
+	if err != nil {
+		return err
+	}
+
+	guild, err := b.GetGuild(gID)
+	if err != nil {
+		return err
+	}
+
+	field, ok := bookAutocompleteFields[selectedOption.Name]
+	if !ok {
+		return fmt.Errorf("autocomplete not implemented for option: %s", selectedOption.Name)
+	}
+
+	request := book.BookAutocompleteRequest{
+		Guild:  guild,
+		Member: MapMember(i.Member),
+		Field:  field,
+		Value:  selectedOption.StringValue(),
+	}
+	options := MapOptionsByName(i.ApplicationCommandData().Options)
+	if option, ok := options["respawn"]; ok {
+		request.Spot = option.StringValue()
+	}
+	if option, ok := options["date"]; ok {
+		request.Date = option.StringValue()
+	}
+
+	response, err := b.eventHandler.OnBookAutocomplete(request)
 	if err != nil {
 		return err
 	}
 
 	responseData := &discordgo.InteractionResponseData{
-		Choices: MapStringArrToChoice(response),
+		Choices: MapBookAutocompleteResponseToChoice(response),
 	}
 	return b.interactionRespond(i, responseData, discordgo.InteractionApplicationCommandAutocompleteResult)
 }
@@ -241,7 +417,7 @@ func (b *Bot) Unbook(i *discordgo.InteractionCreate) error {
 	}
 
 	_, err = b.mgr.SessionForGuild(gID).FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
-		Content: fmt.Sprintf("%s (%s - %s) reservation has been cancelled.", res.Spot.Name, res.StartAt.Format(stringsHelper.DC_LONG_TIME_FORMAT), res.EndAt.Format(stringsHelper.DC_LONG_TIME_FORMAT)),
+		Content: fmt.Sprintf("%s (%s - %s) reservation has been cancelled.", res.Spot.Name, stringsHelper.FormatDcLongTime(res.StartAt), stringsHelper.FormatDcLongTime(res.EndAt)),
 	})
 	return err
 }
@@ -283,61 +459,1764 @@ func (b *Bot) UnbookAutocomplete(i *discordgo.InteractionCreate) error {
 	return b.interactionRespond(i, responseData, discordgo.InteractionApplicationCommandAutocompleteResult)
 }
 
-func (b *Bot) PrivateSummary(i *discordgo.InteractionCreate) error {
-	b.log.Info("PrivateSummary")
+func (b *Bot) Rebook(i *discordgo.InteractionCreate) error {
+	options := MapOptionsByName(i.ApplicationCommandData().Options)
+	reservationOption, ok := options["reservation"]
+	if !ok {
+		return errors.New("you must select a reservation to change")
+	}
+
+	reservationId, err := stringsHelper.StrToInt64(reservationOption.StringValue())
+	if err != nil {
+		return fmt.Errorf("could not parse reservation id: %v", reservationOption.StringValue())
+	}
 
 	gID, err := stringsHelper.StrToInt64(i.GuildID)
 	if err != nil {
-		return err
+		return fmt.Errorf("could not parse guild id: %v", i.GuildID)
 	}
 
-	uID, err := stringsHelper.StrToInt64(i.Member.User.ID)
+	guild, err := b.GetGuild(gID)
 	if err != nil {
 		return err
 	}
 
-	err = b.eventHandler.OnPrivateSummary(b, summary.PrivateSummaryRequest{
-		GuildID: gID,
-		UserID:  uID,
-	})
+	// Date and hours are given in member time zone
+	member := MapMember(i.Member)
+	loc, err := b.eventHandler.OnLocation(guild, member)
 	if err != nil {
 		return err
 	}
 
-	_, err = b.mgr.SessionForGuild(gID).FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{Content: "Check your DM!"})
+	request := book.RebookRequest{
+		Guild:         guild,
+		Member:        member,
+		ReservationID: reservationId,
+	}
+	if option, ok := options["date"]; ok {
+		date, err := time.ParseInLocation(stringsHelper.DC_DATE_FORMAT, option.StringValue(), loc)
+		if err != nil {
+			return fmt.Errorf("could not parse date %s, expected format is YYYY-MM-DD", option.StringValue())
+		}
+		request.Date = &date
+	}
+	if option, ok := options["start-at"]; ok {
+		startTime, err := stringsHelper.ParseClock(option.StringValue())
+		if err != nil {
+			return err
+		}
+		request.StartTime = &startTime
+	}
+	if option, ok := options["end-at"]; ok {
+		endTime, err := stringsHelper.ParseClock(option.StringValue())
+		if err != nil {
+			return err
+		}
+		request.EndTime = &endTime
+	}
+	if option, ok := options["overbook"]; ok && option.StringValue() == "true" {
+		request.Overbook = true
+	}
+
+	message := strings.Builder{}
+	response, err := b.eventHandler.OnRebook(b, request)
+	if err != nil {
+		if len(response.ConflictingReservations) == 0 {
+			return err
+		}
+
+		message.WriteString(fmt.Sprintf("I could not change the reservation:\n```%s```\n", err))
+	} else {
+		message.WriteString(fmt.Sprintf(
+			"<@!%s> moved **%s** reservation to %s - %s.\n\n",
+			member.ID,
+			response.Reservation.Spot.Name,
+			stringsHelper.FormatDcLongTime(response.Reservation.StartAt),
+			stringsHelper.FormatDcLongTime(response.Reservation.EndAt),
+		))
+	}
+	b.writeConflictingReservations(&message, guild, response.ConflictingReservations, err == nil)
+
+	_, err = b.mgr.SessionForGuild(gID).FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
+		Content: message.String(),
+		AllowedMentions: &discordgo.MessageAllowedMentions{
+			Parse: []discordgo.AllowedMentionType{discordgo.AllowedMentionTypeUsers},
+		},
+	})
 	return err
 }
 
-func (b *Bot) PrivateSummaryWithSpotNamesAutocomplete(i *discordgo.InteractionCreate) error {
-	b.log.Info("PrivateSummaryWithSpotNamesAutocomplete")
+// Rebook command autocompletes reservations like unbook, and the remaining options like book.
+func (b *Bot) RebookAutocomplete(i *discordgo.InteractionCreate) error {
+	selectedOption, index := collections.PoorMansFind(i.ApplicationCommandData().Options,
+		func(o *discordgo.ApplicationCommandInteractionDataOption) bool {
+			return o.Focused
+		})
+	if index == -1 {
+		return errors.New("none of the options were selected for autocompletion")
+	}
+
+	if selectedOption.Name == "reservation" {
+		return b.UnbookAutocomplete(i)
+	}
+
+	return b.BookAutocomplete(i)
+}
+
+func (b *Bot) Transfer(i *discordgo.InteractionCreate) error {
+	options := MapOptionsByName(i.ApplicationCommandData().Options)
+	reservationOption, ok := options["reservation"]
+	if !ok {
+		return errors.New("you must select a reservation to transfer")
+	}
+
+	reservationId, err := stringsHelper.StrToInt64(reservationOption.StringValue())
+	if err != nil {
+		return fmt.Errorf("could not parse reservation id: %v", reservationOption.StringValue())
+	}
+
+	memberOption, ok := options["member"]
+	if !ok {
+		return errors.New("you must select a member to transfer the reservation to")
+	}
 
 	gID, err := stringsHelper.StrToInt64(i.GuildID)
 	if err != nil {
-		return err
+		return fmt.Errorf("could not parse guild id: %v", i.GuildID)
 	}
 
-	uID, err := stringsHelper.StrToInt64(i.Member.User.ID)
+	guild, err := b.GetGuild(gID)
 	if err != nil {
 		return err
 	}
 
-	_, index := collections.PoorMansFind(i.ApplicationCommandData().Options,
-		func(o *discordgo.ApplicationCommandInteractionDataOption) bool {
-			return o.Focused
-		})
-	if index == -1 {
-		return fmt.Errorf("none of the options were selected for autocompletion")
+	recipient, err := b.GetMember(guild, memberOption.UserValue(nil).ID)
+	if err != nil {
+		return fmt.Errorf("could not find the member: %w", err)
 	}
 
-	err = b.eventHandler.OnPrivateSummary(b, summary.PrivateSummaryRequest{
-		GuildID:   gID,
-		UserID:    uID,
-		SpotNames: []string{},
+	request := book.TransferRequest{
+		Guild:         guild,
+		Member:        MapMember(i.Member),
+		Recipient:     recipient,
+		ReservationID: reservationId,
+	}
+
+	webhookParams := &discordgo.WebhookParams{
+		AllowedMentions: &discordgo.MessageAllowedMentions{
+			Parse: []discordgo.AllowedMentionType{discordgo.AllowedMentionTypeUsers},
+		},
+	}
+	if option, ok := options["ask-first"]; ok && option.BoolValue() {
+		res, err := b.eventHandler.OnTransferOffer(b, request)
+		if err != nil {
+			return err
+		}
+
+		webhookParams.Content = fmt.Sprintf(
+			"<@!%s>, <@!%s> would like to hand you over **%s** reservation (%s - %s). Do you accept it?",
+			recipient.ID, request.Member.ID, res.Spot.Name,
+			stringsHelper.FormatDcLongTime(res.StartAt), stringsHelper.FormatDcLongTime(res.EndAt),
+		)
+		webhookParams.Components = transferOfferComponents(request)
+	} else {
+		res, err := b.eventHandler.OnTransfer(b, request)
+		if err != nil {
+			return err
+		}
+
+		webhookParams.Content = formatTransfer(request, res)
+	}
+
+	_, err = b.mgr.SessionForGuild(gID).FollowupMessageCreate(i.Interaction, false, webhookParams)
+	return err
+}
+
+func formatTransfer(request book.TransferRequest, res *reservation.ReservationWithSpot) string {
+	return fmt.Sprintf(
+		"<@!%s> transferred **%s** reservation (%s - %s) to <@!%s>.",
+		request.Member.ID, res.Spot.Name,
+		stringsHelper.FormatDcLongTime(res.StartAt), stringsHelper.FormatDcLongTime(res.EndAt),
+		request.Recipient.ID,
+	)
+}
+
+func (b *Bot) CheckIn(i *discordgo.InteractionCreate) error {
+	options := MapOptionsByName(i.ApplicationCommandData().Options)
+	reservationOption, ok := options["reservation"]
+	if !ok {
+		return errors.New("you must select a reservation to check in on")
+	}
+
+	reservationId, err := stringsHelper.StrToInt64(reservationOption.StringValue())
+	if err != nil {
+		return fmt.Errorf("could not parse reservation id: %v", reservationOption.StringValue())
+	}
+
+	gID, err := stringsHelper.StrToInt64(i.GuildID)
+	if err != nil {
+		return fmt.Errorf("could not parse guild id: %v", i.GuildID)
+	}
+
+	guild, err := b.GetGuild(gID)
+	if err != nil {
+		return err
+	}
+
+	res, err := b.eventHandler.OnCheckIn(book.CheckInRequest{
+		Member:        MapMember(i.Member),
+		Guild:         guild,
+		ReservationID: reservationId,
 	})
 	if err != nil {
 		return err
 	}
 
-	_, err = b.mgr.SessionForGuild(gID).FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{Content: "Check your DM!"})
+	_, err = b.mgr.SessionForGuild(gID).FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
+		Content: formatCheckIn(res),
+	})
 	return err
 }
+
+func formatCheckIn(res *reservation.ReservationWithSpot) string {
+	return fmt.Sprintf(
+		"Checked in on **%s** (%s - %s), happy hunting!",
+		res.Spot.Name, stringsHelper.FormatDcLongTime(res.StartAt), stringsHelper.FormatDcLongTime(res.EndAt),
+	)
+}
+
+func (b *Bot) Party(i *discordgo.InteractionCreate) error {
+	if len(i.ApplicationCommandData().Options) < 1 {
+		return errors.New("party command requires a subcommand")
+	}
+	subcommand := i.ApplicationCommandData().Options[0]
+	options := MapOptionsByName(subcommand.Options)
+
+	reservationOption, ok := options["reservation"]
+	if !ok {
+		return errors.New("you must select a reservation")
+	}
+
+	reservationId, err := stringsHelper.StrToInt64(reservationOption.StringValue())
+	if err != nil {
+		return fmt.Errorf("could not parse reservation id: %v", reservationOption.StringValue())
+	}
+
+	memberOption, ok := options["member"]
+	if !ok {
+		return errors.New("you must select a member")
+	}
+
+	gID, err := stringsHelper.StrToInt64(i.GuildID)
+	if err != nil {
+		return fmt.Errorf("could not parse guild id: %v", i.GuildID)
+	}
+
+	guild, err := b.GetGuild(gID)
+	if err != nil {
+		return err
+	}
+
+	partyMember, err := b.GetMember(guild, memberOption.UserValue(nil).ID)
+	if err != nil {
+		return fmt.Errorf("could not find the member: %w", err)
+	}
+
+	request := book.PartyRequest{
+		Guild:         guild,
+		Member:        MapMember(i.Member),
+		PartyMember:   partyMember,
+		ReservationID: reservationId,
+	}
+
+	var res *reservation.ReservationWithSpot
+	var action string
+	switch subcommand.Name {
+	case "add":
+		res, err = b.eventHandler.OnPartyAdd(b, request)
+		action = "joined"
+	case "remove":
+		res, err = b.eventHandler.OnPartyRemove(b, request)
+		action = "left"
+	default:
+		err = fmt.Errorf("missing handler for party subcommand: %s", subcommand.Name)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = b.mgr.SessionForGuild(gID).FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
+		Content: fmt.Sprintf(
+			"<@!%s> %s the party hunting on **%s** (%s - %s).",
+			partyMember.ID, action, res.Spot.Name,
+			stringsHelper.FormatDcLongTime(res.StartAt), stringsHelper.FormatDcLongTime(res.EndAt),
+		),
+		AllowedMentions: &discordgo.MessageAllowedMentions{
+			Parse: []discordgo.AllowedMentionType{discordgo.AllowedMentionTypeUsers},
+		},
+	})
+	return err
+}
+
+// Party subcommands autocomplete only member reservations.
+func (b *Bot) PartyAutocomplete(i *discordgo.InteractionCreate) error {
+	if len(i.ApplicationCommandData().Options) < 1 {
+		return errors.New("party command requires a subcommand")
+	}
+	subcommand := i.ApplicationCommandData().Options[0]
+
+	selectedOption, index := collections.PoorMansFind(subcommand.Options,
+		func(o *discordgo.ApplicationCommandInteractionDataOption) bool {
+			return o.Focused
+		})
+	if index == -1 {
+		return errors.New("none of the options were selected for autocompletion")
+	}
+
+	gID, err := stringsHelper.StrToInt64(i.GuildID)
+	if err != nil {
+		return err
+	}
+
+	guild, err := b.GetGuild(gID)
+	if err != nil {
+		return err
+	}
+
+	response, err := b.eventHandler.OnUnbookAutocomplete(book.UnbookAutocompleteRequest{
+		Guild:  guild,
+		Member: MapMember(i.Member),
+		Value:  selectedOption.StringValue(),
+	})
+	if err != nil {
+		return err
+	}
+
+	responseData := &discordgo.InteractionResponseData{
+		Choices: MapReservationWithSpotArrToChoice(response.Choices),
+	}
+	return b.interactionRespond(i, responseData, discordgo.InteractionApplicationCommandAutocompleteResult)
+}
+
+func (b *Bot) PrivateSummary(i *discordgo.InteractionCreate) error {
+	b.log.Info("PrivateSummary")
+
+	gID, err := stringsHelper.StrToInt64(i.GuildID)
+	if err != nil {
+		return err
+	}
+
+	uID, err := stringsHelper.StrToInt64(i.Member.User.ID)
+	if err != nil {
+		return err
+	}
+
+	err = b.eventHandler.OnPrivateSummary(b, summary.PrivateSummaryRequest{
+		GuildID: gID,
+		UserID:  uID,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = b.mgr.SessionForGuild(gID).FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{Content: "Check your DM!"})
+	return err
+}
+
+func (b *Bot) PrivateSummaryWithSpotNamesAutocomplete(i *discordgo.InteractionCreate) error {
+	b.log.Info("PrivateSummaryWithSpotNamesAutocomplete")
+
+	gID, err := stringsHelper.StrToInt64(i.GuildID)
+	if err != nil {
+		return err
+	}
+
+	uID, err := stringsHelper.StrToInt64(i.Member.User.ID)
+	if err != nil {
+		return err
+	}
+
+	_, index := collections.PoorMansFind(i.ApplicationCommandData().Options,
+		func(o *discordgo.ApplicationCommandInteractionDataOption) bool {
+			return o.Focused
+		})
+	if index == -1 {
+		return fmt.Errorf("none of the options were selected for autocompletion")
+	}
+
+	err = b.eventHandler.OnPrivateSummary(b, summary.PrivateSummaryRequest{
+		GuildID:   gID,
+		UserID:    uID,
+		SpotNames: []string{},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = b.mgr.SessionForGuild(gID).FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{Content: "Check your DM!"})
+	return err
+}
+
+func (b *Bot) Recurring(i *discordgo.InteractionCreate) error {
+	if len(i.ApplicationCommandData().Options) < 1 {
+		return errors.New("recurring command requires a subcommand")
+	}
+	subcommand := i.ApplicationCommandData().Options[0]
+
+	gID, err := stringsHelper.StrToInt64(i.GuildID)
+	if err != nil {
+		return fmt.Errorf("could not parse guild id: %v", i.GuildID)
+	}
+
+	guild, err := b.GetGuild(gID)
+	if err != nil {
+		return err
+	}
+
+	var content string
+	switch subcommand.Name {
+	case "create":
+		content, err = b.recurringCreate(guild, MapMember(i.Member), MapOptionsByName(subcommand.Options))
+	case "list":
+		content, err = b.recurringList(guild, MapMember(i.Member))
+	case "cancel":
+		content, err = b.recurringCancel(guild, MapMember(i.Member), MapOptionsByName(subcommand.Options))
+	default:
+		err = fmt.Errorf("missing handler for recurring subcommand: %s", subcommand.Name)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = b.mgr.SessionForGuild(gID).FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
+		Content: content,
+	})
+	return err
+}
+
+func (b *Bot) recurringCreate(guild *discord.Guild, member *discord.Member, options map[string]*discordgo.ApplicationCommandInteractionDataOption) (string, error) {
+	for _, name := range []string{"respawn", "days", "start-at", "end-at"} {
+		if _, ok := options[name]; !ok {
+			return "", fmt.Errorf("recurring create command requires %s argument", name)
+		}
+	}
+
+	weekdays, err := stringsHelper.ParseWeekdays(options["days"].StringValue())
+	if err != nil {
+		return "", err
+	}
+
+	startTime, err := stringsHelper.ParseClock(options["start-at"].StringValue())
+	if err != nil {
+		return "", err
+	}
+
+	endTime, err := stringsHelper.ParseClock(options["end-at"].StringValue())
+	if err != nil {
+		return "", err
+	}
+
+	series, err := b.eventHandler.OnSeries(b, book.SeriesRequest{
+		Member:    member,
+		Guild:     guild,
+		Spot:      options["respawn"].StringValue(),
+		Weekdays:  weekdays,
+		StartTime: startTime,
+		EndTime:   endTime,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(
+		"<@!%s> booked **%s** every %s between %s and %s. Reservations are created a week ahead, skipping the ones that conflict with other bookings.",
+		member.ID,
+		series.Spot.Name,
+		stringsHelper.FormatWeekdays(series.Weekdays),
+		stringsHelper.FormatClock(series.StartTime),
+		stringsHelper.FormatClock(series.EndTime),
+	), nil
+}
+
+func (b *Bot) recurringList(guild *discord.Guild, member *discord.Member) (string, error) {
+	response, err := b.eventHandler.OnSeriesList(book.SeriesListRequest{
+		Guild:  guild,
+		Member: member,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if len(response.Series) == 0 {
+		return "You have no recurring reservations.", nil
+	}
+
+	message := strings.Builder{}
+	message.WriteString("Your recurring reservations:\n\n")
+	for _, series := range response.Series {
+		message.WriteString(fmt.Sprintf("* %s\n", MapSeriesWithSpotToLabel(series)))
+	}
+
+	return message.String(), nil
+}
+
+func (b *Bot) recurringCancel(guild *discord.Guild, member *discord.Member, options map[string]*discordgo.ApplicationCommandInteractionDataOption) (string, error) {
+	option, ok := options["series"]
+	if !ok {
+		return "", errors.New("you must select a recurring reservation to cancel")
+	}
+
+	seriesId, err := stringsHelper.StrToInt64(option.StringValue())
+	if err != nil {
+		return "", fmt.Errorf("could not parse series id: %v", option.StringValue())
+	}
+
+	res, err := b.eventHandler.OnSeriesCancel(b, book.SeriesCancelRequest{
+		Member:   member,
+		Guild:    guild,
+		SeriesID: seriesId,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s recurring reservation has been cancelled, along with its upcoming reservations.", MapSeriesWithSpotToLabel(res)), nil
+}
+
+func (b *Bot) RecurringAutocomplete(i *discordgo.InteractionCreate) error {
+	if len(i.ApplicationCommandData().Options) < 1 {
+		return errors.New("recurring command requires a subcommand")
+	}
+	subcommand := i.ApplicationCommandData().Options[0]
+
+	selectedOption, index := collections.PoorMansFind(subcommand.Options,
+		func(o *discordgo.ApplicationCommandInteractionDataOption) bool {
+			return o.Focused
+		})
+	if index == -1 {
+		return errors.New("none of the options were selected for autocompletion")
+	}
+
+	gID, err := stringsHelper.StrToInt64(i.GuildID)
+	if err != nil {
+		return err
+	}
+
+	guild, err := b.GetGuild(gID)
+	if err != nil {
+		return err
+	}
+
+	var choices []*discordgo.ApplicationCommandOptionChoice
+	switch selectedOption.Name {
+	case "series":
+		response, err := b.eventHandler.OnSeriesList(book.SeriesListRequest{
+			Guild:  guild,
+			Member: MapMember(i.Member),
+			Value:  selectedOption.StringValue(),
+		})
+		if err != nil {
+			return err
+		}
+
+		choices = MapSeriesWithSpotArrToChoice(response.Series)
+	default:
+		focus, ok := map[string]book.SeriesAutocompleteFocus{
+			"respawn":  book.SeriesAutocompleteSpot,
+			"days":     book.SeriesAutocompleteWeekdays,
+			"start-at": book.SeriesAutocompleteStartAt,
+			"end-at":   book.SeriesAutocompleteEndAt,
+		}[selectedOption.Name]
+		if !ok {
+			return fmt.Errorf("autocomplete not implemented for %s", selectedOption.Name)
+		}
+
+		response, err := b.eventHandler.OnSeriesAutocomplete(book.SeriesAutocompleteRequest{
+			Guild: guild,
+			Field: focus,
+			Value: selectedOption.StringValue(),
+		})
+		if err != nil {
+			return err
+		}
+
+		choices = MapStringArrToChoice(response)
+	}
+
+	responseData := &discordgo.InteractionResponseData{
+		Choices: choices,
+	}
+	return b.interactionRespond(i, responseData, discordgo.InteractionApplicationCommandAutocompleteResult)
+}
+
+func (b *Bot) Queue(i *discordgo.InteractionCreate) error {
+	options := MapOptionsByName(i.ApplicationCommandData().Options)
+	spotOption, hasSpot := options["respawn"]
+	startOption, hasStart := options["start-at"]
+	endOption, hasEnd := options["end-at"]
+	if !hasSpot || !hasStart || !hasEnd {
+		return errors.New("queue command requires respawn, start-at and end-at arguments")
+	}
+
+	date := ""
+	if option, ok := options["date"]; ok {
+		date = option.StringValue()
+	}
+
+	gID, err := stringsHelper.StrToInt64(i.GuildID)
+	if err != nil {
+		return fmt.Errorf("could not parse guild id: %v", i.GuildID)
+	}
+
+	guild, err := b.GetGuild(gID)
+	if err != nil {
+		return err
+	}
+
+	// Hours are given in member time zone
+	member := MapMember(i.Member)
+	loc, err := b.eventHandler.OnLocation(guild, member)
+	if err != nil {
+		return err
+	}
+
+	startAt, endAt, err := b.parseTimeRange(time.Now().In(loc), date, startOption.StringValue(), endOption.StringValue())
+	if err != nil {
+		return err
+	}
+
+	entry, err := b.eventHandler.OnQueue(b, book.QueueRequest{
+		Member:  member,
+		Guild:   guild,
+		Spot:    spotOption.StringValue(),
+		StartAt: startAt,
+		EndAt:   endAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = b.mgr.SessionForGuild(gID).FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
+		Content: fmt.Sprintf(
+			"<@!%s> has been queued for **%s** between %s and %s. You will be booked automatically and notified via DM once the respawn becomes free.",
+			member.ID,
+			entry.Spot.Name,
+			stringsHelper.FormatDcLongTime(entry.StartAt),
+			stringsHelper.FormatDcLongTime(entry.EndAt),
+		),
+		AllowedMentions: &discordgo.MessageAllowedMentions{
+			Parse: []discordgo.AllowedMentionType{discordgo.AllowedMentionTypeUsers},
+		},
+	})
+	return err
+}
+
+func (b *Bot) Apply(i *discordgo.InteractionCreate) error {
+	options := MapOptionsByName(i.ApplicationCommandData().Options)
+	spotOption, hasSpot := options["respawn"]
+	startOption, hasStart := options["start-at"]
+	endOption, hasEnd := options["end-at"]
+	if !hasSpot || !hasStart || !hasEnd {
+		return errors.New("apply command requires respawn, start-at and end-at arguments")
+	}
+
+	date := ""
+	if option, ok := options["date"]; ok {
+		date = option.StringValue()
+	}
+
+	gID, err := stringsHelper.StrToInt64(i.GuildID)
+	if err != nil {
+		return fmt.Errorf("could not parse guild id: %v", i.GuildID)
+	}
+
+	guild, err := b.GetGuild(gID)
+	if err != nil {
+		return err
+	}
+
+	// Hours are given in member time zone
+	member := MapMember(i.Member)
+	loc, err := b.eventHandler.OnLocation(guild, member)
+	if err != nil {
+		return err
+	}
+
+	startAt, endAt, err := b.parseTimeRange(time.Now().In(loc), date, startOption.StringValue(), endOption.StringValue())
+	if err != nil {
+		return err
+	}
+
+	application, err := b.eventHandler.OnApply(b, book.ApplyRequest{
+		Member:  member,
+		Guild:   guild,
+		Spot:    spotOption.StringValue(),
+		StartAt: startAt,
+		EndAt:   endAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = b.mgr.SessionForGuild(gID).FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
+		Content: fmt.Sprintf(
+			"<@!%s> has applied for **%s** between %s and %s. Lots are drawn %s, and you will be notified via DM whether you have won.",
+			member.ID,
+			application.Spot.Name,
+			stringsHelper.FormatDcLongTime(application.StartAt),
+			stringsHelper.FormatDcLongTime(application.EndAt),
+			stringsHelper.FormatDcLongTime(application.DrawAt),
+		),
+		AllowedMentions: &discordgo.MessageAllowedMentions{
+			Parse: []discordgo.AllowedMentionType{discordgo.AllowedMentionTypeUsers},
+		},
+	})
+	return err
+}
+
+func (b *Bot) Hold(i *discordgo.InteractionCreate) error {
+	options := MapOptionsByName(i.ApplicationCommandData().Options)
+	spotOption, hasSpot := options["respawn"]
+	startOption, hasStart := options["start-at"]
+	endOption, hasEnd := options["end-at"]
+	if !hasSpot || !hasStart || !hasEnd {
+		return errors.New("hold command requires respawn, start-at and end-at arguments")
+	}
+
+	date := ""
+	if option, ok := options["date"]; ok {
+		date = option.StringValue()
+	}
+
+	gID, err := stringsHelper.StrToInt64(i.GuildID)
+	if err != nil {
+		return fmt.Errorf("could not parse guild id: %v", i.GuildID)
+	}
+
+	guild, err := b.GetGuild(gID)
+	if err != nil {
+		return err
+	}
+
+	// Hours are given in member time zone
+	member := MapMember(i.Member)
+	loc, err := b.eventHandler.OnLocation(guild, member)
+	if err != nil {
+		return err
+	}
+
+	startAt, endAt, err := b.parseTimeRange(time.Now().In(loc), date, startOption.StringValue(), endOption.StringValue())
+	if err != nil {
+		return err
+	}
+
+	response, err := b.eventHandler.OnHold(b, book.HoldRequest{
+		Member:  member,
+		Guild:   guild,
+		Spot:    spotOption.StringValue(),
+		StartAt: startAt,
+		EndAt:   endAt,
+	})
+
+	message := strings.Builder{}
+	if err != nil {
+		message.WriteString(fmt.Sprintf("Could not hold the respawn:\n```%s```\n", err))
+		b.writeConflictingReservations(&message, guild, response.ConflictingReservations, false)
+	} else {
+		message.WriteString(fmt.Sprintf(
+			"<@!%s> holds **%s** between %s and %s. Confirm it with /confirm before %s, otherwise it will be released.",
+			member.ID,
+			response.Hold.Spot.Name,
+			stringsHelper.FormatDcLongTime(response.Hold.StartAt),
+			stringsHelper.FormatDcLongTime(response.Hold.EndAt),
+			stringsHelper.FormatDcTime(response.Hold.HeldUntil),
+		))
+	}
+
+	_, err = b.mgr.SessionForGuild(gID).FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
+		Content: message.String(),
+		AllowedMentions: &discordgo.MessageAllowedMentions{
+			Parse: []discordgo.AllowedMentionType{discordgo.AllowedMentionTypeUsers},
+		},
+	})
+	return err
+}
+
+func (b *Bot) Confirm(i *discordgo.InteractionCreate) error {
+	options := MapOptionsByName(i.ApplicationCommandData().Options)
+	reservationOption, ok := options["reservation"]
+	if !ok {
+		return errors.New("you must select a hold to confirm")
+	}
+
+	reservationId, err := stringsHelper.StrToInt64(reservationOption.StringValue())
+	if err != nil {
+		return fmt.Errorf("could not parse reservation id: %v", reservationOption.StringValue())
+	}
+
+	gID, err := stringsHelper.StrToInt64(i.GuildID)
+	if err != nil {
+		return fmt.Errorf("could not parse guild id: %v", i.GuildID)
+	}
+
+	guild, err := b.GetGuild(gID)
+	if err != nil {
+		return err
+	}
+
+	res, err := b.eventHandler.OnConfirm(b, book.ConfirmRequest{
+		Member:        MapMember(i.Member),
+		Guild:         guild,
+		ReservationID: reservationId,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = b.mgr.SessionForGuild(gID).FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
+		Content: fmt.Sprintf(
+			"Your hold on **%s** (%s - %s) is now a regular reservation.",
+			res.Spot.Name, stringsHelper.FormatDcLongTime(res.StartAt), stringsHelper.FormatDcLongTime(res.EndAt),
+		),
+	})
+	return err
+}
+
+func (b *Bot) Free(i *discordgo.InteractionCreate) error {
+	options := MapOptionsByName(i.ApplicationCommandData().Options)
+	spotOption, ok := options["respawn"]
+	if !ok {
+		return errors.New("free command requires respawn argument")
+	}
+
+	gID, err := stringsHelper.StrToInt64(i.GuildID)
+	if err != nil {
+		return fmt.Errorf("could not parse guild id: %v", i.GuildID)
+	}
+
+	guild, err := b.GetGuild(gID)
+	if err != nil {
+		return err
+	}
+
+	// Date is given in member time zone
+	member := MapMember(i.Member)
+	request := book.FreeRequest{
+		Member: member,
+		Guild:  guild,
+		Spot:   spotOption.StringValue(),
+	}
+	if option, ok := options["date"]; ok {
+		loc, err := b.eventHandler.OnLocation(guild, member)
+		if err != nil {
+			return err
+		}
+
+		date, err := time.ParseInLocation(stringsHelper.DC_DATE_FORMAT, option.StringValue(), loc)
+		if err != nil {
+			return fmt.Errorf("could not parse date %s, expected format is YYYY-MM-DD", option.StringValue())
+		}
+		request.Date = &date
+	}
+
+	response, err := b.eventHandler.OnFree(request)
+	if err != nil {
+		return err
+	}
+
+	message := strings.Builder{}
+	if len(response.Windows) == 0 {
+		message.WriteString(fmt.Sprintf(
+			"**%s** is fully booked between %s and %s.",
+			response.Spot,
+			stringsHelper.FormatDcLongTime(response.From),
+			stringsHelper.FormatDcLongTime(response.To),
+		))
+	} else {
+		message.WriteString(fmt.Sprintf(
+			"**%s** can be booked between %s and %s in:\n\n",
+			response.Spot,
+			stringsHelper.FormatDcLongTime(response.From),
+			stringsHelper.FormatDcLongTime(response.To),
+		))
+		for _, window := range response.Windows {
+			message.WriteString(fmt.Sprintf("* %s - %s\n", stringsHelper.FormatDcLongTime(window.StartAt), stringsHelper.FormatDcTime(window.EndAt)))
+		}
+	}
+
+	_, err = b.mgr.SessionForGuild(gID).FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
+		Content: message.String(),
+	})
+	return err
+}
+
+func (b *Bot) Quota(i *discordgo.InteractionCreate) error {
+	gID, err := stringsHelper.StrToInt64(i.GuildID)
+	if err != nil {
+		return fmt.Errorf("could not parse guild id: %v", i.GuildID)
+	}
+
+	guild, err := b.GetGuild(gID)
+	if err != nil {
+		return err
+	}
+
+	member := MapMember(i.Member)
+	if option, ok := MapOptionsByName(i.ApplicationCommandData().Options)["member"]; ok {
+		member, err = b.GetMember(guild, option.UserValue(nil).ID)
+		if err != nil {
+			return fmt.Errorf("could not find the member: %w", err)
+		}
+	}
+
+	usages, err := b.eventHandler.OnQuota(guild, member)
+	if err != nil {
+		return err
+	}
+
+	message := strings.Builder{}
+	if len(usages) == 0 {
+		message.WriteString("This server has no weekly respawn quotas.")
+	} else {
+		message.WriteString(fmt.Sprintf("Weekly quotas of <@!%s>, renewing %s:\n\n", member.ID, stringsHelper.FormatDcLongTime(usages[0].ResetsAt)))
+		for _, usage := range usages {
+			message.WriteString(fmt.Sprintf(
+				"* **%s**: %s of %s used, %s left\n",
+				usage.Spot,
+				stringsHelper.FormatDuration(usage.Used),
+				stringsHelper.FormatDuration(usage.Limit),
+				stringsHelper.FormatDuration(usage.Remaining()),
+			))
+		}
+	}
+
+	_, err = b.mgr.SessionForGuild(gID).FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
+		Content: message.String(),
+	})
+	return err
+}
+
+func (b *Bot) LetterConfig(i *discordgo.InteractionCreate) error {
+	if len(i.ApplicationCommandData().Options) < 1 {
+		return errors.New("letter-config command requires a subcommand")
+	}
+	subcommand := i.ApplicationCommandData().Options[0]
+
+	gID, err := stringsHelper.StrToInt64(i.GuildID)
+	if err != nil {
+		return fmt.Errorf("could not parse guild id: %v", i.GuildID)
+	}
+
+	guild, err := b.GetGuild(gID)
+	if err != nil {
+		return err
+	}
+
+	var p *policy.Policy
+	switch subcommand.Name {
+	case "view":
+		p, err = b.eventHandler.OnPolicy(guild)
+	case "set":
+		if i.Member.Permissions&discordgo.PermissionManageServer == 0 {
+			return errors.New("you need Manage Server permission to change booking policy")
+		}
+
+		request := policy.UpdateRequest{Guild: guild}
+		options := MapOptionsByName(subcommand.Options)
+		if option, ok := options["max-total-minutes"]; ok {
+			d := time.Duration(option.IntValue()) * time.Minute
+			request.MaximumReservationsTime = &d
+		}
+		if option, ok := options["max-reservation-minutes"]; ok {
+			d := time.Duration(option.IntValue()) * time.Minute
+			request.MaximumReservationTime = &d
+		}
+		if option, ok := options["overbook-role"]; ok {
+			role := option.StringValue()
+			request.OverbookRole = &role
+		}
+		if option, ok := options["suggestion-step-minutes"]; ok {
+			d := time.Duration(option.IntValue()) * time.Minute
+			request.SuggestionStep = &d
+		}
+		if option, ok := options["booking-horizon-days"]; ok {
+			d := time.Duration(option.IntValue()) * 24 * time.Hour
+			request.BookingHorizon = &d
+		}
+		if option, ok := options["time-zone"]; ok {
+			timeZone := option.StringValue()
+			request.TimeZone = &timeZone
+		}
+		if option, ok := options["count-party-time"]; ok {
+			counted := option.BoolValue()
+			request.PartyTimeCounted = &counted
+		}
+		if option, ok := options["check-in-grace-minutes"]; ok {
+			d := time.Duration(option.IntValue()) * time.Minute
+			request.CheckInGracePeriod = &d
+		}
+		if option, ok := options["overbook-approval"]; ok {
+			required := option.BoolValue()
+			request.OverbookApproval = &required
+		}
+		if option, ok := options["overbook-approval-minutes"]; ok {
+			d := time.Duration(option.IntValue()) * time.Minute
+			request.OverbookApprovalTimeout = &d
+		}
+
+		p, err = b.eventHandler.OnPolicyUpdate(request)
+	case "blackout-add":
+		if i.Member.Permissions&discordgo.PermissionManageServer == 0 {
+			return errors.New("you need Manage Server permission to change booking policy")
+		}
+
+		options := MapOptionsByName(subcommand.Options)
+		nameOption, hasName := options["name"]
+		startOption, hasStart := options["start"]
+		minutesOption, hasMinutes := options["minutes"]
+		if !hasName || !hasStart || !hasMinutes {
+			return errors.New("letter-config blackout-add command requires name, start and minutes arguments")
+		}
+
+		start, err := stringsHelper.ParseClock(startOption.StringValue())
+		if err != nil {
+			return err
+		}
+
+		blackout := policy.Blackout{
+			Name:   strings.TrimSpace(nameOption.StringValue()),
+			Start:  start,
+			Length: time.Duration(minutesOption.IntValue()) * time.Minute,
+		}
+		if option, ok := options["time-zone"]; ok {
+			blackout.TimeZone = option.StringValue()
+		} else {
+			current, err := b.eventHandler.OnPolicy(guild)
+			if err != nil {
+				return err
+			}
+			blackout.TimeZone = current.Location().String()
+		}
+
+		p, err = b.eventHandler.OnPolicyUpdate(policy.UpdateRequest{Guild: guild, AddedBlackout: &blackout})
+	case "blackout-remove":
+		if i.Member.Permissions&discordgo.PermissionManageServer == 0 {
+			return errors.New("you need Manage Server permission to change booking policy")
+		}
+
+		nameOption, ok := MapOptionsByName(subcommand.Options)["name"]
+		if !ok {
+			return errors.New("you must provide a name of the blackout")
+		}
+
+		name := nameOption.StringValue()
+		p, err = b.eventHandler.OnPolicyUpdate(policy.UpdateRequest{Guild: guild, RemovedBlackout: &name})
+	case "tier-add":
+		if i.Member.Permissions&discordgo.PermissionManageServer == 0 {
+			return errors.New("you need Manage Server permission to change booking policy")
+		}
+
+		options := MapOptionsByName(subcommand.Options)
+		roleOption, hasRole := options["role"]
+		priorityOption, hasPriority := options["priority"]
+		minutesOption, hasMinutes := options["maximum-reservations-minutes"]
+		if !hasRole || !hasPriority || !hasMinutes {
+			return errors.New("letter-config tier-add command requires role, priority and maximum-reservations-minutes arguments")
+		}
+
+		tier := policy.Tier{
+			Role:                    strings.TrimSpace(roleOption.StringValue()),
+			Priority:                int(priorityOption.IntValue()),
+			MaximumReservationsTime: time.Duration(minutesOption.IntValue()) * time.Minute,
+		}
+		p, err = b.eventHandler.OnPolicyUpdate(policy.UpdateRequest{Guild: guild, AddedTier: &tier})
+	case "tier-remove":
+		if i.Member.Permissions&discordgo.PermissionManageServer == 0 {
+			return errors.New("you need Manage Server permission to change booking policy")
+		}
+
+		roleOption, ok := MapOptionsByName(subcommand.Options)["role"]
+		if !ok {
+			return errors.New("you must provide a role of the tier")
+		}
+
+		role := roleOption.StringValue()
+		p, err = b.eventHandler.OnPolicyUpdate(policy.UpdateRequest{Guild: guild, RemovedTier: &role})
+	case "quota-add":
+		if i.Member.Permissions&discordgo.PermissionManageServer == 0 {
+			return errors.New("you need Manage Server permission to change booking policy")
+		}
+
+		options := MapOptionsByName(subcommand.Options)
+		spotOption, hasSpot := options["respawn"]
+		hoursOption, hasHours := options["hours"]
+		if !hasSpot || !hasHours {
+			return errors.New("letter-config quota-add command requires respawn and hours arguments")
+		}
+
+		quota := policy.WeeklyQuota{
+			Spot:  strings.TrimSpace(spotOption.StringValue()),
+			Limit: time.Duration(hoursOption.IntValue()) * time.Hour,
+		}
+		p, err = b.eventHandler.OnPolicyUpdate(policy.UpdateRequest{Guild: guild, AddedWeeklyQuota: &quota})
+	case "quota-remove":
+		if i.Member.Permissions&discordgo.PermissionManageServer == 0 {
+			return errors.New("you need Manage Server permission to change booking policy")
+		}
+
+		spotOption, ok := MapOptionsByName(subcommand.Options)["respawn"]
+		if !ok {
+			return errors.New("you must provide a respawn of the quota")
+		}
+
+		spotName := spotOption.StringValue()
+		p, err = b.eventHandler.OnPolicyUpdate(policy.UpdateRequest{Guild: guild, RemovedWeeklyQuota: &spotName})
+	case "window-add":
+		if i.Member.Permissions&discordgo.PermissionManageServer == 0 {
+			return errors.New("you need Manage Server permission to change booking policy")
+		}
+
+		options := MapOptionsByName(subcommand.Options)
+		spotOption, ok := options["respawn"]
+		if !ok {
+			return errors.New("letter-config window-add command requires respawn argument")
+		}
+
+		window := policy.BookingWindow{
+			Spot: strings.TrimSpace(spotOption.StringValue()),
+		}
+		if option, ok := options["advance-hours"]; ok {
+			window.Advance = time.Duration(option.IntValue()) * time.Hour
+		}
+		if option, ok := options["opens-at"]; ok {
+			opensAt, err := stringsHelper.ParseClock(option.StringValue())
+			if err != nil {
+				return err
+			}
+			window.OpensAt = &opensAt
+		}
+
+		p, err = b.eventHandler.OnPolicyUpdate(policy.UpdateRequest{Guild: guild, AddedBookingWindow: &window})
+	case "window-remove":
+		if i.Member.Permissions&discordgo.PermissionManageServer == 0 {
+			return errors.New("you need Manage Server permission to change booking policy")
+		}
+
+		spotOption, ok := MapOptionsByName(subcommand.Options)["respawn"]
+		if !ok {
+			return errors.New("you must provide a respawn of the booking window")
+		}
+
+		spotName := spotOption.StringValue()
+		p, err = b.eventHandler.OnPolicyUpdate(policy.UpdateRequest{Guild: guild, RemovedBookingWindow: &spotName})
+	case "lottery-add":
+		if i.Member.Permissions&discordgo.PermissionManageServer == 0 {
+			return errors.New("you need Manage Server permission to change booking policy")
+		}
+
+		options := MapOptionsByName(subcommand.Options)
+		spotOption, hasSpot := options["respawn"]
+		drawAtOption, hasDrawAt := options["draw-at"]
+		if !hasSpot || !hasDrawAt {
+			return errors.New("letter-config lottery-add command requires respawn and draw-at arguments")
+		}
+
+		drawAt, err := stringsHelper.ParseClock(drawAtOption.StringValue())
+		if err != nil {
+			return err
+		}
+
+		lottery := policy.Lottery{
+			Spot:   strings.TrimSpace(spotOption.StringValue()),
+			DrawAt: drawAt,
+		}
+		if option, ok := options["weighted"]; ok {
+			lottery.Weighted = option.BoolValue()
+		}
+
+		p, err = b.eventHandler.OnPolicyUpdate(policy.UpdateRequest{Guild: guild, AddedLottery: &lottery})
+	case "lottery-remove":
+		if i.Member.Permissions&discordgo.PermissionManageServer == 0 {
+			return errors.New("you need Manage Server permission to change booking policy")
+		}
+
+		spotOption, ok := MapOptionsByName(subcommand.Options)["respawn"]
+		if !ok {
+			return errors.New("you must provide a respawn of the lottery")
+		}
+
+		spotName := spotOption.StringValue()
+		p, err = b.eventHandler.OnPolicyUpdate(policy.UpdateRequest{Guild: guild, RemovedLottery: &spotName})
+	default:
+		err = fmt.Errorf("missing handler for letter-config subcommand: %s", subcommand.Name)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = b.mgr.SessionForGuild(gID).FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
+		Content: formatPolicy(p),
+	})
+	return err
+}
+
+func (b *Bot) Spot(i *discordgo.InteractionCreate) error {
+	if len(i.ApplicationCommandData().Options) < 1 {
+		return errors.New("spot command requires a subcommand")
+	}
+	subcommand := i.ApplicationCommandData().Options[0]
+
+	if i.Member.Permissions&discordgo.PermissionManageServer == 0 {
+		return errors.New("you need Manage Server permission to manage respawns")
+	}
+
+	gID, err := stringsHelper.StrToInt64(i.GuildID)
+	if err != nil {
+		return fmt.Errorf("could not parse guild id: %v", i.GuildID)
+	}
+
+	guild, err := b.GetGuild(gID)
+	if err != nil {
+		return err
+	}
+
+	options := MapOptionsByName(subcommand.Options)
+	var content string
+	files := []*discordgo.File{}
+	switch subcommand.Name {
+	case "add":
+		nameOption, ok := options["name"]
+		if !ok {
+			return errors.New("you must provide a name of the respawn")
+		}
+
+		var parent string
+		if parentOption, ok := options["parent"]; ok {
+			parent = parentOption.StringValue()
+		}
+
+		s, err := b.eventHandler.OnSpotAdd(spot.AddRequest{
+			Guild:  guild,
+			Name:   nameOption.StringValue(),
+			Parent: parent,
+		})
+		if err != nil {
+			return err
+		}
+
+		content = fmt.Sprintf("**%s** respawn has been added and can be booked now.", s.Name)
+	case "rename":
+		spotOption, hasSpot := options["respawn"]
+		nameOption, hasName := options["name"]
+		if !hasSpot || !hasName {
+			return errors.New("spot rename command requires respawn and name arguments")
+		}
+
+		s, err := b.eventHandler.OnSpotRename(spot.RenameRequest{
+			Guild:   guild,
+			Name:    spotOption.StringValue(),
+			NewName: nameOption.StringValue(),
+		})
+		if err != nil {
+			return err
+		}
+
+		content = fmt.Sprintf("**%s** respawn has been renamed to **%s**.", spotOption.StringValue(), s.Name)
+	case "describe":
+		spotOption, ok := options["respawn"]
+		if !ok {
+			return errors.New("you must select a respawn to describe")
+		}
+
+		details := spot.Details{}
+		if option, ok := options["area"]; ok {
+			details.Area = strings.TrimSpace(option.StringValue())
+		}
+		if option, ok := options["min-level"]; ok {
+			details.MinLevel = int(option.IntValue())
+		}
+		if option, ok := options["max-level"]; ok {
+			details.MaxLevel = int(option.IntValue())
+		}
+		if option, ok := options["vocations"]; ok {
+			vocations, err := spot.ParseVocations(option.StringValue())
+			if err != nil {
+				return err
+			}
+			details.Vocations = vocations
+		}
+		if option, ok := options["type"]; ok {
+			details.Kind = spot.Kind(option.StringValue())
+		}
+
+		s, err := b.eventHandler.OnSpotDescribe(spot.DescribeRequest{
+			Guild:   guild,
+			Name:    spotOption.StringValue(),
+			Details: details,
+		})
+		if err != nil {
+			return err
+		}
+
+		content = fmt.Sprintf("**%s** respawn has been described.", s.Name)
+		if description := s.Details.String(); len(description) > 0 {
+			content = fmt.Sprintf("**%s** respawn has been described: %s", s.Name, description)
+		}
+	case "alias", "unalias":
+		spotOption, hasSpot := options["respawn"]
+		aliasOption, hasAlias := options["alias"]
+		if !hasSpot || !hasAlias {
+			return fmt.Errorf("spot %s command requires respawn and alias arguments", subcommand.Name)
+		}
+
+		request := spot.AliasRequest{
+			Guild: guild,
+			Name:  spotOption.StringValue(),
+			Alias: aliasOption.StringValue(),
+		}
+		if subcommand.Name == "alias" {
+			s, err := b.eventHandler.OnSpotAlias(request)
+			if err != nil {
+				return err
+			}
+
+			content = fmt.Sprintf("**%s** respawn can be referred to as **%s** now.", s.Name, strings.TrimSpace(request.Alias))
+		} else {
+			s, err := b.eventHandler.OnSpotUnalias(request)
+			if err != nil {
+				return err
+			}
+
+			content = fmt.Sprintf("**%s** is no longer an alias of **%s** respawn.", strings.TrimSpace(request.Alias), s.Name)
+		}
+	case "import":
+		catalogOption, ok := options["catalog"]
+		if !ok {
+			return errors.New("you must attach a catalog file")
+		}
+
+		attachment, ok := i.ApplicationCommandData().Resolved.Attachments[catalogOption.Value.(string)]
+		if !ok {
+			return errors.New("could not find the attached catalog file")
+		}
+
+		entries, err := b.readSpotCatalog(attachment)
+		if err != nil {
+			return err
+		}
+
+		apply := false
+		if applyOption, ok := options["apply"]; ok {
+			apply = applyOption.BoolValue()
+		}
+
+		result, err := b.eventHandler.OnSpotImport(spot.ImportRequest{
+			Guild:   guild,
+			Entries: entries,
+			DryRun:  !apply,
+		})
+		if err != nil {
+			return err
+		}
+
+		content = result.String()
+		if result.DryRun {
+			content = fmt.Sprintf("%s\nRepeat the command with 'apply' set to 'true' to apply the changes.", content)
+		}
+		// Long summaries would not fit into a message
+		if len(content) > MAXIMUM_MESSAGE_LENGTH {
+			files = append(files, &discordgo.File{
+				Name:        "import.txt",
+				ContentType: "text/plain",
+				Reader:      strings.NewReader(content),
+			})
+			content = fmt.Sprintf("Added: %d, updated: %d, skipped: %d, unchanged: %d. See the attached file for details.",
+				len(result.Added), len(result.Updated), len(result.Skipped), len(result.Unchanged))
+		}
+	case "export":
+		format := spot.FormatJSON
+		if formatOption, ok := options["format"]; ok {
+			format = spot.Format(formatOption.StringValue())
+		}
+
+		entries, err := b.eventHandler.OnSpotExport(spot.ExportRequest{
+			Guild: guild,
+		})
+		if err != nil {
+			return err
+		}
+
+		var catalog bytes.Buffer
+		err = spot.WriteCatalog(&catalog, format, entries)
+		if err != nil {
+			return fmt.Errorf("could not write the catalog: %w", err)
+		}
+
+		files = append(files, &discordgo.File{
+			Name:        fmt.Sprintf("respawns.%s", format),
+			ContentType: fmt.Sprintf("text/%s", format),
+			Reader:      &catalog,
+		})
+		content = fmt.Sprintf("Catalog of %d respawns.", len(entries))
+	case "archive":
+		spotOption, ok := options["respawn"]
+		if !ok {
+			return errors.New("you must select a respawn to archive")
+		}
+
+		s, err := b.eventHandler.OnSpotArchive(spot.ArchiveRequest{
+			Guild: guild,
+			Name:  spotOption.StringValue(),
+		})
+		if err != nil {
+			return err
+		}
+
+		content = fmt.Sprintf("**%s** respawn has been archived. It cannot be booked anymore, but its past reservations are kept.", s.Name)
+	case "hide", "unhide":
+		spotOption, ok := options["respawn"]
+		if !ok {
+			return fmt.Errorf("you must select a respawn to %s", subcommand.Name)
+		}
+
+		request := spot.VisibilityRequest{
+			Guild: guild,
+			Name:  spotOption.StringValue(),
+		}
+		if subcommand.Name == "hide" {
+			s, err := b.eventHandler.OnSpotHide(request)
+			if err != nil {
+				return err
+			}
+
+			content = fmt.Sprintf("**%s** respawn has been hidden from this server.", s.Name)
+		} else {
+			s, err := b.eventHandler.OnSpotUnhide(request)
+			if err != nil {
+				return err
+			}
+
+			content = fmt.Sprintf("**%s** respawn is visible on this server again.", s.Name)
+		}
+	default:
+		return fmt.Errorf("missing handler for spot subcommand: %s", subcommand.Name)
+	}
+
+	_, err = b.mgr.SessionForGuild(gID).FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
+		Content: content,
+		Files:   files,
+	})
+	return err
+}
+
+// Downloads and parses a spot catalog attached to a command.
+func (b *Bot) readSpotCatalog(attachment *discordgo.MessageAttachment) ([]*spot.CatalogEntry, error) {
+	format, err := spot.FormatFromFileName(attachment.Filename)
+	if err != nil {
+		return nil, err
+	}
+
+	if attachment.Size > MAXIMUM_CATALOG_SIZE {
+		return nil, fmt.Errorf("catalog file cannot be larger than %d kB", MAXIMUM_CATALOG_SIZE/1024)
+	}
+
+	client := http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Get(attachment.URL)
+	if err != nil {
+		return nil, fmt.Errorf("could not download the catalog: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not download the catalog: %s", resp.Status)
+	}
+
+	return spot.ReadCatalog(io.LimitReader(resp.Body, MAXIMUM_CATALOG_SIZE), format)
+}
+
+// Spot subcommands autocomplete only respawns, unhide subcommand suggests hidden ones.
+func (b *Bot) SpotAutocomplete(i *discordgo.InteractionCreate) error {
+	if len(i.ApplicationCommandData().Options) < 1 {
+		return errors.New("spot command requires a subcommand")
+	}
+	subcommand := i.ApplicationCommandData().Options[0]
+
+	selectedOption, index := collections.PoorMansFind(subcommand.Options,
+		func(o *discordgo.ApplicationCommandInteractionDataOption) bool {
+			return o.Focused
+		})
+	if index == -1 {
+		return errors.New("none of the options were selected for autocompletion")
+	}
+
+	gID, err := stringsHelper.StrToInt64(i.GuildID)
+	if err != nil {
+		return err
+	}
+
+	guild, err := b.GetGuild(gID)
+	if err != nil {
+		return err
+	}
+
+	response, err := b.eventHandler.OnSpotAutocomplete(spot.AutocompleteRequest{
+		Guild:  guild,
+		Hidden: subcommand.Name == "unhide",
+		Value:  selectedOption.StringValue(),
+	})
+	if err != nil {
+		return err
+	}
+
+	responseData := &discordgo.InteractionResponseData{
+		Choices: MapStringArrToChoice(response),
+	}
+	return b.interactionRespond(i, responseData, discordgo.InteractionApplicationCommandAutocompleteResult)
+}
+
+func formatPolicy(p *policy.Policy) string {
+	return fmt.Sprintf(
+		"Booking policy of this server:\n"+
+			"* Maximum reservations time within 24 hours: **%s**\n"+
+			"* Maximum time of a single reservation: **%s**\n"+
+			"* Role allowed to overbook: **%s**\n"+
+			"* Priority tiers, which can overbook lower ones only: %s\n"+
+			"* Step between suggested hours: **%s**\n"+
+			"* Reservations can be made up to: **%d days** ahead\n"+
+			"* Time zone: **%s**\n"+
+			"* Time spent in other members' parties counts toward maximum reservations time: **%s**\n"+
+			"* Reservations not checked in within **%s** after they start can be taken over by anyone\n"+
+			"* Overbooks wait for approval of the affected members: **%s**, approved automatically after **%s**\n"+
+			"* Blackouts, in which respawns cannot be hunted: %s\n"+
+			"* Weekly quotas per member: %s\n"+
+			"* Booking windows: %s\n"+
+			"* Lotteries, applied for with /apply: %s\n",
+		stringsHelper.FormatDuration(p.MaximumReservationsTime),
+		stringsHelper.FormatDuration(p.MaximumReservationTime),
+		p.OverbookRole,
+		formatTiers(p.Tiers),
+		stringsHelper.FormatDuration(p.SuggestionStep),
+		int(p.BookingHorizon.Hours()/24),
+		formatLocation(p.Location()),
+		formatYesNo(p.PartyTimeCounted),
+		stringsHelper.FormatDuration(p.CheckInGracePeriod),
+		formatYesNo(p.OverbookApproval),
+		stringsHelper.FormatDuration(p.OverbookApprovalTimeout),
+		formatBlackouts(p.Blackouts),
+		formatWeeklyQuotas(p.WeeklyQuotas),
+		formatBookingWindows(p.BookingWindows),
+		formatLotteries(p.Lotteries),
+	)
+}
+
+func formatBlackouts(blackouts []policy.Blackout) string {
+	if len(blackouts) == 0 {
+		return "**none**"
+	}
+
+	return strings.Join(collections.PoorMansMap(blackouts, func(b policy.Blackout) string {
+		return fmt.Sprintf("**%s** daily at %s for %s (%s)", b.Name, stringsHelper.FormatClock(b.Start), stringsHelper.FormatDuration(b.Length), b.TimeZone)
+	}), ", ")
+}
+
+func formatTiers(tiers []policy.Tier) string {
+	if len(tiers) == 0 {
+		return "**none**, the overbook role is used instead"
+	}
+
+	return strings.Join(collections.PoorMansMap(tiers, func(t policy.Tier) string {
+		return fmt.Sprintf("**%s** with priority %d and up to %s within 24 hours", t.Role, t.Priority, stringsHelper.FormatDuration(t.MaximumReservationsTime))
+	}), ", ")
+}
+
+func formatWeeklyQuotas(quotas []policy.WeeklyQuota) string {
+	if len(quotas) == 0 {
+		return "**none**"
+	}
+
+	return strings.Join(collections.PoorMansMap(quotas, func(q policy.WeeklyQuota) string {
+		return fmt.Sprintf("**%s** up to %s", q.Spot, stringsHelper.FormatDuration(q.Limit))
+	}), ", ")
+}
+
+func formatBookingWindows(windows []policy.BookingWindow) string {
+	if len(windows) == 0 {
+		return "**none**"
+	}
+
+	return strings.Join(collections.PoorMansMap(windows, func(w policy.BookingWindow) string {
+		rules := []string{}
+		if w.Advance > 0 {
+			rules = append(rules, fmt.Sprintf("up to %s ahead", stringsHelper.FormatDuration(w.Advance)))
+		}
+		if w.OpensAt != nil {
+			rules = append(rules, fmt.Sprintf("next day opens at %s", stringsHelper.FormatClock(*w.OpensAt)))
+		}
+
+		return fmt.Sprintf("**%s** %s", w.Spot, strings.Join(rules, ", "))
+	}), ", ")
+}
+
+func formatLotteries(lotteries []policy.Lottery) string {
+	if len(lotteries) == 0 {
+		return "**none**"
+	}
+
+	return strings.Join(collections.PoorMansMap(lotteries, func(l policy.Lottery) string {
+		label := fmt.Sprintf("**%s** drawn daily at %s for the next day", l.Spot, stringsHelper.FormatClock(l.DrawAt))
+		if l.Weighted {
+			label += ", weighted against recent winners"
+		}
+
+		return label
+	}), ", ")
+}
+
+func formatYesNo(value bool) string {
+	if value {
+		return "yes"
+	}
+
+	return "no"
+}
+
+func (b *Bot) TimeZone(i *discordgo.InteractionCreate) error {
+	if len(i.ApplicationCommandData().Options) < 1 {
+		return errors.New("timezone command requires a subcommand")
+	}
+	subcommand := i.ApplicationCommandData().Options[0]
+
+	gID, err := stringsHelper.StrToInt64(i.GuildID)
+	if err != nil {
+		return fmt.Errorf("could not parse guild id: %v", i.GuildID)
+	}
+
+	guild, err := b.GetGuild(gID)
+	if err != nil {
+		return err
+	}
+
+	member := MapMember(i.Member)
+	var loc *time.Location
+	switch subcommand.Name {
+	case "view":
+		loc, err = b.eventHandler.OnLocation(guild, member)
+	case "set":
+		options := MapOptionsByName(subcommand.Options)
+		zone, ok := options["zone"]
+		if !ok {
+			return errors.New("you must provide a time zone")
+		}
+
+		loc, err = b.eventHandler.OnTimeZoneUpdate(policy.TimeZoneRequest{
+			Guild:    guild,
+			Member:   member,
+			TimeZone: zone.StringValue(),
+		})
+	case "reset":
+		loc, err = b.eventHandler.OnTimeZoneUpdate(policy.TimeZoneRequest{
+			Guild:  guild,
+			Member: member,
+		})
+	default:
+		err = fmt.Errorf("missing handler for timezone subcommand: %s", subcommand.Name)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = b.mgr.SessionForGuild(gID).FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
+		Content: fmt.Sprintf("<@!%s> hours you provide are interpreted in **%s**.", member.ID, formatLocation(loc)),
+		AllowedMentions: &discordgo.MessageAllowedMentions{
+			Parse: []discordgo.AllowedMentionType{discordgo.AllowedMentionTypeUsers},
+		},
+	})
+	return err
+}
+
+func (b *Bot) Reminders(i *discordgo.InteractionCreate) error {
+	if len(i.ApplicationCommandData().Options) < 1 {
+		return errors.New("reminders command requires a subcommand")
+	}
+	subcommand := i.ApplicationCommandData().Options[0]
+
+	gID, err := stringsHelper.StrToInt64(i.GuildID)
+	if err != nil {
+		return fmt.Errorf("could not parse guild id: %v", i.GuildID)
+	}
+
+	guild, err := b.GetGuild(gID)
+	if err != nil {
+		return err
+	}
+
+	member := MapMember(i.Member)
+	var p *policy.ReminderPreference
+	switch subcommand.Name {
+	case "view":
+		p, err = b.eventHandler.OnReminderPreference(guild, member)
+	case "set":
+		request := policy.ReminderPreferenceRequest{Guild: guild, Member: member}
+		options := MapOptionsByName(subcommand.Options)
+		if option, ok := options["enabled"]; ok {
+			enabled := option.BoolValue()
+			request.Enabled = &enabled
+		}
+		if option, ok := options["before-start-minutes"]; ok {
+			d := time.Duration(option.IntValue()) * time.Minute
+			request.StartLeadTime = &d
+		}
+		if option, ok := options["before-end-minutes"]; ok {
+			d := time.Duration(option.IntValue()) * time.Minute
+			request.EndLeadTime = &d
+		}
+
+		p, err = b.eventHandler.OnReminderPreferenceUpdate(request)
+	default:
+		err = fmt.Errorf("missing handler for reminders subcommand: %s", subcommand.Name)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = b.mgr.SessionForGuild(gID).FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
+		Content: formatReminderPreference(member, p),
+		AllowedMentions: &discordgo.MessageAllowedMentions{
+			Parse: []discordgo.AllowedMentionType{discordgo.AllowedMentionTypeUsers},
+		},
+	})
+	return err
+}
+
+func formatReminderPreference(member *discord.Member, p *policy.ReminderPreference) string {
+	if !p.Enabled {
+		return fmt.Sprintf("<@!%s> reservation reminders are turned off.", member.ID)
+	}
+
+	return fmt.Sprintf(
+		"<@!%s> reservation reminders:\n"+
+			"* Before the start: **%s**\n"+
+			"* Before the end: **%s**\n",
+		member.ID,
+		formatReminderLeadTime(p.StartLeadTime),
+		formatReminderLeadTime(p.EndLeadTime),
+	)
+}
+
+func formatReminderLeadTime(d time.Duration) string {
+	if d == 0 {
+		return "off"
+	}
+
+	return stringsHelper.FormatDuration(d)
+}
+
+// Returns time zone name along with its current UTC offset.
+func formatLocation(loc *time.Location) string {
+	name := loc.String()
+	if loc == time.Local {
+		name = "server time zone"
+	}
+
+	return fmt.Sprintf("%s (UTC%s)", name, time.Now().In(loc).Format("-07:00"))
+}
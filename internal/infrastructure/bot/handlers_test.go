@@ -0,0 +1,33 @@
+package bot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTimeRangeAcrossClockChange(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	location, err := time.LoadLocation("Europe/Warsaw")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bot := &Bot{log: logrus.WithFields(logrus.Fields{"type": "infra", "name": "bot"})}
+	// Clocks go back an hour during the night from 2026-10-24 to 2026-10-25
+	tNow := time.Date(2026, 10, 24, 22, 0, 0, 0, location)
+
+	// when
+	pastStartAt, pastEndAt, pastErr := bot.parseTimeRange(tNow, "", "21:00", "23:00")
+	overnightStartAt, overnightEndAt, overnightErr := bot.parseTimeRange(tNow, "", "23:00", "04:00")
+
+	// assert
+	assert.Nil(pastErr)
+	assert.Equal(time.Date(2026, 10, 25, 21, 0, 0, 0, location), pastStartAt)
+	assert.Equal(time.Date(2026, 10, 25, 23, 0, 0, 0, location), pastEndAt)
+	assert.Nil(overnightErr)
+	assert.Equal(time.Date(2026, 10, 24, 23, 0, 0, 0, location), overnightStartAt)
+	assert.Equal(time.Date(2026, 10, 25, 4, 0, 0, 0, location), overnightEndAt)
+}
@@ -3,14 +3,18 @@ package bot
 import (
 	"fmt"
 	"strconv"
+	stdStrings "strings"
+	"unicode/utf8"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/sirupsen/logrus"
 
 	"spot-assistant/internal/common/collections"
 	"spot-assistant/internal/common/strings"
+	"spot-assistant/internal/core/dto/book"
 	"spot-assistant/internal/core/dto/discord"
 	"spot-assistant/internal/core/dto/reservation"
+	"spot-assistant/internal/core/dto/summary"
 )
 
 func MapChannel(input *discordgo.Channel) *discord.Channel {
@@ -104,6 +108,13 @@ func MapFooter(text string) *discordgo.MessageEmbedFooter {
 	}
 }
 
+// MapBlackoutsToDescription lists upcoming blackouts, one per line, to be shown above the ledger.
+func MapBlackoutsToDescription(input []summary.Blackout) string {
+	return stdStrings.Join(collections.PoorMansMap(input, func(b summary.Blackout) string {
+		return fmt.Sprintf("⛔ **%s** %s - %s", b.Name, strings.FormatDcTime(b.StartAt), strings.FormatDcTime(b.EndAt))
+	}), "\n")
+}
+
 func MapStringToChoice(text string) *discordgo.ApplicationCommandOptionChoice {
 	return &discordgo.ApplicationCommandOptionChoice{
 		Name:  text,
@@ -117,11 +128,59 @@ func MapStringArrToChoice(texts []string) []*discordgo.ApplicationCommandOptionC
 	})
 }
 
+// Discord rejects choice names longer than 100 characters
+const MAXIMUM_CHOICE_NAME_LENGTH = 100
+
+// MapBookAutocompleteResponseToChoice maps suggestions to choices, labeled unless
+// the label is longer than Discord allows.
+func MapBookAutocompleteResponseToChoice(response book.BookAutocompleteResponse) []*discordgo.ApplicationCommandOptionChoice {
+	return collections.PoorMansMap(response, func(c book.BookAutocompleteChoice) *discordgo.ApplicationCommandOptionChoice {
+		name := c.Label
+		if len(name) == 0 || utf8.RuneCountInString(name) > MAXIMUM_CHOICE_NAME_LENGTH {
+			name = c.Value
+		}
+
+		return &discordgo.ApplicationCommandOptionChoice{
+			Name:  name,
+			Value: c.Value,
+		}
+	})
+}
+
 func MapReservationWithSpotArrToChoice(input []*reservation.ReservationWithSpot) []*discordgo.ApplicationCommandOptionChoice {
 	return collections.PoorMansMap(input, func(i *reservation.ReservationWithSpot) *discordgo.ApplicationCommandOptionChoice {
+		name := fmt.Sprintf("%s - %s %s", i.StartAt.Format(strings.DC_LONG_TIME_FORMAT), i.EndAt.Format(strings.DC_LONG_TIME_FORMAT), i.Spot.Name)
+		if i.Held() {
+			name += " (hold)"
+		}
+
 		return &discordgo.ApplicationCommandOptionChoice{
-			Name:  fmt.Sprintf("%s - %s %s", i.StartAt.Format(strings.DC_LONG_TIME_FORMAT), i.EndAt.Format(strings.DC_LONG_TIME_FORMAT), i.Spot.Name),
+			Name:  name,
 			Value: strconv.FormatInt(i.Reservation.ID, 10),
 		}
 	})
 }
+
+func MapSeriesWithSpotToLabel(input *reservation.SeriesWithSpot) string {
+	return fmt.Sprintf("%s %s - %s %s", strings.FormatWeekdays(input.Weekdays), strings.FormatClock(input.StartTime), strings.FormatClock(input.EndTime), input.Spot.Name)
+}
+
+func MapSeriesWithSpotArrToChoice(input []*reservation.SeriesWithSpot) []*discordgo.ApplicationCommandOptionChoice {
+	return collections.PoorMansMap(input, func(i *reservation.SeriesWithSpot) *discordgo.ApplicationCommandOptionChoice {
+		return &discordgo.ApplicationCommandOptionChoice{
+			Name:  MapSeriesWithSpotToLabel(i),
+			Value: strconv.FormatInt(i.Series.ID, 10),
+		}
+	})
+}
+
+// MapOptionsByName maps command options by their names, so that they can be
+// accessed regardless of the order they were provided in.
+func MapOptionsByName(input []*discordgo.ApplicationCommandInteractionDataOption) map[string]*discordgo.ApplicationCommandInteractionDataOption {
+	options := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(input))
+	for _, option := range input {
+		options[option.Name] = option
+	}
+
+	return options
+}
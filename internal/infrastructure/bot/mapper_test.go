@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"spot-assistant/internal/core/dto/reservation"
+	"spot-assistant/internal/core/dto/summary"
 )
 
 func TestMapChannel(t *testing.T) {
@@ -258,6 +259,22 @@ func TestMapFooter(t *testing.T) {
 	assert.Equal(input, res.Text)
 }
 
+func TestMapBlackoutsToDescription(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	startAt := time.Date(2023, 8, 19, 10, 0, 0, 0, time.UTC)
+	input := []summary.Blackout{
+		{Name: "Server save", StartAt: startAt, EndAt: startAt.Add(10 * time.Minute)},
+		{Name: "Event", StartAt: startAt.Add(time.Hour), EndAt: startAt.Add(2 * time.Hour)},
+	}
+
+	// when
+	res := MapBlackoutsToDescription(input)
+
+	// assert
+	assert.Equal("⛔ **Server save** <t:1692439200:t> - <t:1692439800:t>\n⛔ **Event** <t:1692442800:t> - <t:1692446400:t>", res)
+}
+
 func TestMapStringToChoice(t *testing.T) {
 	// given
 	assert := assert.New(t)
@@ -315,3 +332,54 @@ func TestMapReservationWithSpotArrToChoice(t *testing.T) {
 	assert.Equal("2023-08-10 16:00 - 2023-08-10 18:00 test-spot", result.Name)
 	assert.Equal(strconv.FormatInt(input[0].Reservation.ID, 10), result.Value)
 }
+
+func TestMapSeriesWithSpotArrToChoice(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	input := []*reservation.SeriesWithSpot{
+		{
+			Series: reservation.Series{
+				ID:        7,
+				Weekdays:  []time.Weekday{time.Monday, time.Wednesday, time.Friday},
+				StartTime: 18 * time.Hour,
+				EndTime:   21 * time.Hour,
+			},
+			Spot: reservation.Spot{
+				Name: "Issavi Surface",
+			},
+		},
+	}
+
+	// when
+	res := MapSeriesWithSpotArrToChoice(input)
+
+	// assert
+	assert.Len(res, 1)
+	assert.Equal("Mon/Wed/Fri 18:00 - 21:00 Issavi Surface", res[0].Name)
+	assert.Equal("7", res[0].Value)
+}
+
+func TestMapOptionsByName(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	input := []*discordgo.ApplicationCommandInteractionDataOption{
+		{
+			Name:  "days",
+			Type:  discordgo.ApplicationCommandOptionString,
+			Value: "Mon/Wed",
+		},
+		{
+			Name:  "respawn",
+			Type:  discordgo.ApplicationCommandOptionString,
+			Value: "Issavi Surface",
+		},
+	}
+
+	// when
+	res := MapOptionsByName(input)
+
+	// assert
+	assert.Len(res, 2)
+	assert.Equal("Mon/Wed", res["days"].StringValue())
+	assert.Equal("Issavi Surface", res["respawn"].StringValue())
+}
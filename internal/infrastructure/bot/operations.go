@@ -15,6 +15,7 @@ import (
 	"spot-assistant/internal/common/collections"
 	stringsHelper "spot-assistant/internal/common/strings"
 	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/reservation"
 	"spot-assistant/internal/core/dto/summary"
 )
 
@@ -151,7 +152,8 @@ func (b *Bot) FindChannelByName(g *discord.Guild, channelName string) (*discord.
 	return nil, fmt.Errorf("channel '%s' not found in guild '%s'", channelName, g.Name)
 }
 
-func (b *Bot) EnsureRoles(g *discord.Guild) error {
+// Creates roles of given names, which the guild does not have yet.
+func (b *Bot) EnsureRoles(g *discord.Guild, roleNames ...string) error {
 	guild, err := b.mgr.Gateway.Guild(g.ID)
 	if err != nil {
 		return fmt.Errorf("error when fetching guild: %s", err)
@@ -161,15 +163,19 @@ func (b *Bot) EnsureRoles(g *discord.Guild) error {
 	if err != nil {
 		return err
 	}
-	for _, role := range roles {
-		if role.Name == ROLE {
-			return nil
+
+	for _, roleName := range roleNames {
+		existing, _ := collections.PoorMansFind(roles, func(role *discord.Role) bool {
+			return role.Name == roleName
+		})
+		if existing != nil {
+			continue
 		}
-	}
 
-	_, err = b.mgr.Gateway.GuildRoleCreate(guild.ID, &discordgo.RoleParams{Name: "Postman"})
-	if err != nil {
-		return fmt.Errorf("error when creating a postman role: %s", err)
+		_, err = b.mgr.Gateway.GuildRoleCreate(guild.ID, &discordgo.RoleParams{Name: roleName})
+		if err != nil {
+			return fmt.Errorf("error when creating a %s role: %s", roleName, err)
+		}
 	}
 
 	return nil
@@ -237,14 +243,36 @@ func (b *Bot) SendLetterMessage(guild *discord.Guild, channel *discord.Channel,
 	// Transfrom into lines of text describing reservation
 	fields := collections.PoorMansMap(sum.Ledger, func(el summary.LedgerEntry) *discordgo.MessageEmbedField {
 		writtenReservations := strings.Builder{}
+		if len(el.Details) > 0 {
+			writtenReservations.WriteString(fmt.Sprintf("*%s*\n", el.Details))
+		}
 
 		for _, booking := range el.Bookings {
+			hunters := booking.Author
+			if len(booking.Party) > 0 {
+				hunters = fmt.Sprintf("%s + %s", booking.Author, strings.Join(booking.Party, ", "))
+			}
+
+			// Holds are not confirmed yet, so they are shown without bold and along with their expiry
+			if !booking.HeldUntil.IsZero() {
+				writtenReservations.WriteString(
+					fmt.Sprintf(
+						"⏳ %s - %s %s *(hold until %s)*\n",
+						stringsHelper.FormatDcTime(booking.StartAt),
+						stringsHelper.FormatDcTime(booking.EndAt),
+						hunters,
+						stringsHelper.FormatDcTime(booking.HeldUntil),
+					),
+				)
+				continue
+			}
+
 			writtenReservations.WriteString(
 				fmt.Sprintf(
 					"**%s** - **%s** %s\n",
-					booking.StartAt.Format("15:04"),
-					booking.EndAt.Format("15:04"),
-					booking.Author,
+					stringsHelper.FormatDcTime(booking.StartAt),
+					stringsHelper.FormatDcTime(booking.EndAt),
+					hunters,
 				),
 			)
 		}
@@ -269,6 +297,11 @@ func (b *Bot) SendLetterMessage(guild *discord.Guild, channel *discord.Channel,
 		return b.newEmbed(sum.Title, sum.URL, sum.Description, batch, footer)
 	})
 
+	// Blackouts concern every respawn, so they are marked once, above the ledger
+	if len(sum.Blackouts) > 0 {
+		embeds[0].Description = fmt.Sprintf("%s\n\n%s", embeds[0].Description, MapBlackoutsToDescription(sum.Blackouts))
+	}
+
 	if channel.Type != discord.ChannelTypeDM {
 		err := b.CleanChannel(guild, channel)
 		if err != nil {
@@ -310,6 +343,50 @@ func (b *Bot) SendDM(member *discord.Member, message string) error {
 	return err
 }
 
+func (b *Bot) SendCheckInReminder(guild *discord.Guild, member *discord.Member, res *reservation.ReservationWithSpot, message string) error {
+	channel, err := b.OpenDM(member)
+	if err != nil {
+		return err
+	}
+
+	_, err = b.mgr.SessionForDM().ChannelMessageSendComplex(channel.ID, &discordgo.MessageSend{
+		Content:    message,
+		Components: checkInComponents(guild, res),
+	})
+
+	return err
+}
+
+func (b *Bot) SendOverbookApprovalRequest(guild *discord.Guild, member *discord.Member, request *reservation.OverbookRequestWithSpot, message string) error {
+	channel, err := b.OpenDM(member)
+	if err != nil {
+		return err
+	}
+
+	_, err = b.mgr.SessionForDM().ChannelMessageSendComplex(channel.ID, &discordgo.MessageSend{
+		Content:    message,
+		Components: overbookApprovalComponents(guild, request),
+	})
+
+	return err
+}
+
+func (b *Bot) SendChannelMessage(guild *discord.Guild, channel *discord.Channel, message string) error {
+	gID, err := stringsHelper.StrToInt64(guild.ID)
+	if err != nil {
+		return err
+	}
+
+	_, err = b.mgr.SessionForGuild(gID).ChannelMessageSendComplex(channel.ID, &discordgo.MessageSend{
+		Content: message,
+		AllowedMentions: &discordgo.MessageAllowedMentions{
+			Parse: []discordgo.AllowedMentionType{discordgo.AllowedMentionTypeUsers},
+		},
+	})
+
+	return err
+}
+
 func (b *Bot) GetMember(guild *discord.Guild, memberID string) (*discord.Member, error) {
 	gID, err := stringsHelper.StrToInt64(guild.ID)
 	if err != nil {
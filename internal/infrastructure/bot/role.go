@@ -1,3 +0,0 @@
-package bot
-
-const ROLE = "Postman"
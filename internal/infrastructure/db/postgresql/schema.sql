@@ -5,8 +5,49 @@ CREATE TABLE public.web_spot (
 	id bigserial NOT NULL,
 	"name" varchar(120) NOT NULL,
 	created_at timestamptz NOT NULL,
-	CONSTRAINT web_spot_pkey PRIMARY KEY (id)
+	archived_at timestamptz NULL,
+	owner_guild_id varchar(255) NULL,
+	parent_id int8 NULL,
+	min_level int4 NULL,
+	max_level int4 NULL,
+	vocations varchar(20)[] NOT NULL DEFAULT '{}',
+	area varchar(120) NULL,
+	kind varchar(10) NULL,
+	aliases varchar(120)[] NOT NULL DEFAULT '{}',
+	CONSTRAINT web_spot_pkey PRIMARY KEY (id),
+	CONSTRAINT web_spot_parent_id_fk_web_spot_id FOREIGN KEY (parent_id) REFERENCES public.web_spot(id) DEFERRABLE INITIALLY DEFERRED
 );
+CREATE UNIQUE INDEX web_spot_name_key ON public.web_spot USING btree (COALESCE(owner_guild_id, ''), lower(name));
+CREATE INDEX web_spot_owner_guild_id ON public.web_spot USING btree (owner_guild_id);
+CREATE INDEX web_spot_parent_id ON public.web_spot USING btree (parent_id);
+-- public.web_guild_hidden_spot definition
+-- Drop table
+-- DROP TABLE public.web_guild_hidden_spot;
+CREATE TABLE public.web_guild_hidden_spot (
+	guild_id varchar(255) NOT NULL,
+	spot_id int8 NOT NULL,
+	created_at timestamptz NOT NULL,
+	CONSTRAINT web_guild_hidden_spot_pkey PRIMARY KEY (guild_id, spot_id),
+	CONSTRAINT web_guild_hidden_spot_spot_id_fk_web_spot_id FOREIGN KEY (spot_id) REFERENCES public.web_spot(id) ON DELETE CASCADE
+);
+-- public.web_reservation_series definition
+-- Drop table
+-- DROP TABLE public.web_reservation_series;
+CREATE TABLE public.web_reservation_series (
+	id bigserial NOT NULL,
+	author varchar(200) NOT NULL,
+	author_discord_id varchar(200) NOT NULL,
+	guild_id varchar(255) NOT NULL,
+	spot_id int8 NOT NULL,
+	weekdays int4 NOT NULL,
+	start_time time NOT NULL,
+	end_time time NOT NULL,
+	created_at timestamptz NOT NULL,
+	materialized_until timestamptz NOT NULL,
+	CONSTRAINT web_reservation_series_pkey PRIMARY KEY (id),
+	CONSTRAINT web_reservation_series_spot_id_fk_web_spot_id FOREIGN KEY (spot_id) REFERENCES public.web_spot(id) DEFERRABLE INITIALLY DEFERRED
+);
+CREATE INDEX web_reservation_series_guild_id ON public.web_reservation_series USING btree (guild_id);
 -- public.web_reservation definition
 -- Drop table
 -- DROP TABLE public.web_reservation;
@@ -19,6 +60,12 @@ CREATE TABLE public.web_reservation (
 	spot_id int8 NOT NULL,
 	guild_id varchar(255) NOT NULL,
 	author_discord_id varchar(200) NOT NULL,
+	series_id int8 NULL,
+	checked_in_at timestamptz NULL,
+	check_in_reminded_at timestamptz NULL,
+	no_show_at timestamptz NULL,
+	held_until timestamptz NULL,
+	priority int4 NOT NULL DEFAULT 0,
 	CONSTRAINT unique_reservation_time_and_space_per_guild UNIQUE (start_at, end_at, spot_id, guild_id),
 	CONSTRAINT web_reservation_pkey PRIMARY KEY (id),
 	CONSTRAINT web_reservations_no_overlapping_ranges EXCLUDE USING gist (
@@ -26,7 +73,149 @@ CREATE TABLE public.web_reservation (
 		guild_id WITH =,
 		tstzrange(start_at, end_at) WITH &&
 	),
-	CONSTRAINT web_reservation_spot_id_6b297c19_fk_web_spot_id FOREIGN KEY (spot_id) REFERENCES public.web_spot(id) DEFERRABLE INITIALLY DEFERRED
+	CONSTRAINT web_reservation_spot_id_6b297c19_fk_web_spot_id FOREIGN KEY (spot_id) REFERENCES public.web_spot(id) DEFERRABLE INITIALLY DEFERRED,
+	CONSTRAINT web_reservation_series_id_fk_web_reservation_series_id FOREIGN KEY (series_id) REFERENCES public.web_reservation_series(id) ON DELETE SET NULL
 );
 CREATE INDEX web_reservation_spot_id_6b297c19 ON public.web_reservation USING btree (spot_id);
-CREATE INDEX web_reservations_no_overlapping_ranges ON public.web_reservation USING gist (spot_id, guild_id, tstzrange(start_at, end_at));
\ No newline at end of file
+CREATE INDEX web_reservations_no_overlapping_ranges ON public.web_reservation USING gist (spot_id, guild_id, tstzrange(start_at, end_at));
+-- public.web_reservation_queue definition
+-- Drop table
+-- DROP TABLE public.web_reservation_queue;
+CREATE TABLE public.web_reservation_queue (
+	id bigserial NOT NULL,
+	author varchar(200) NOT NULL,
+	author_discord_id varchar(200) NOT NULL,
+	guild_id varchar(255) NOT NULL,
+	spot_id int8 NOT NULL,
+	start_at timestamptz NOT NULL,
+	end_at timestamptz NOT NULL,
+	created_at timestamptz NOT NULL,
+	CONSTRAINT web_reservation_queue_pkey PRIMARY KEY (id),
+	CONSTRAINT web_reservation_queue_spot_id_fk_web_spot_id FOREIGN KEY (spot_id) REFERENCES public.web_spot(id) DEFERRABLE INITIALLY DEFERRED
+);
+CREATE INDEX web_reservation_queue_guild_id ON public.web_reservation_queue USING btree (guild_id);
+-- public.web_overbook_request definition
+-- Drop table
+-- DROP TABLE public.web_overbook_request;
+CREATE TABLE public.web_overbook_request (
+	id bigserial NOT NULL,
+	author varchar(200) NOT NULL,
+	author_discord_id varchar(200) NOT NULL,
+	guild_id varchar(255) NOT NULL,
+	spot_id int8 NOT NULL,
+	start_at timestamptz NOT NULL,
+	end_at timestamptz NOT NULL,
+	party_member_discord_ids varchar(200)[] NOT NULL DEFAULT '{}',
+	expires_at timestamptz NOT NULL,
+	created_at timestamptz NOT NULL,
+	CONSTRAINT web_overbook_request_pkey PRIMARY KEY (id),
+	CONSTRAINT web_overbook_request_spot_id_fk_web_spot_id FOREIGN KEY (spot_id) REFERENCES public.web_spot(id) DEFERRABLE INITIALLY DEFERRED
+);
+CREATE INDEX web_overbook_request_guild_id ON public.web_overbook_request USING btree (guild_id);
+-- public.web_overbook_approval definition
+-- Drop table
+-- DROP TABLE public.web_overbook_approval;
+CREATE TABLE public.web_overbook_approval (
+	request_id int8 NOT NULL,
+	reservation_id int8 NOT NULL,
+	member_discord_id varchar(200) NOT NULL,
+	accepted bool NULL,
+	CONSTRAINT web_overbook_approval_pkey PRIMARY KEY (request_id, reservation_id),
+	CONSTRAINT web_overbook_approval_request_id_fk FOREIGN KEY (request_id) REFERENCES public.web_overbook_request(id) ON DELETE CASCADE
+);
+-- public.web_lottery_application definition
+-- Drop table
+-- DROP TABLE public.web_lottery_application;
+CREATE TABLE public.web_lottery_application (
+	id bigserial NOT NULL,
+	author varchar(200) NOT NULL,
+	author_discord_id varchar(200) NOT NULL,
+	guild_id varchar(255) NOT NULL,
+	spot_id int8 NOT NULL,
+	start_at timestamptz NOT NULL,
+	end_at timestamptz NOT NULL,
+	draw_at timestamptz NOT NULL,
+	won bool NULL,
+	created_at timestamptz NOT NULL,
+	CONSTRAINT web_lottery_application_pkey PRIMARY KEY (id),
+	CONSTRAINT web_lottery_application_spot_id_fk_web_spot_id FOREIGN KEY (spot_id) REFERENCES public.web_spot(id) DEFERRABLE INITIALLY DEFERRED
+);
+CREATE INDEX web_lottery_application_guild_id ON public.web_lottery_application USING btree (guild_id);
+-- public.web_guild_policy definition
+-- Drop table
+-- DROP TABLE public.web_guild_policy;
+CREATE TABLE public.web_guild_policy (
+	guild_id varchar(255) NOT NULL,
+	maximum_reservations_minutes int4 NOT NULL,
+	maximum_reservation_minutes int4 NOT NULL,
+	overbook_role varchar(100) NOT NULL,
+	suggestion_step_minutes int4 NOT NULL,
+	booking_horizon_days int4 NOT NULL DEFAULT 7,
+	time_zone varchar(64) NOT NULL DEFAULT '',
+	party_time_counted bool NOT NULL DEFAULT false,
+	check_in_grace_minutes int4 NOT NULL DEFAULT 15,
+	blackouts jsonb NOT NULL DEFAULT '[{"name": "Server save", "startMinutes": 600, "lengthMinutes": 10, "timeZone": "Europe/Berlin"}]',
+	overbook_approval bool NOT NULL DEFAULT false,
+	overbook_approval_minutes int4 NOT NULL DEFAULT 15,
+	tiers jsonb NOT NULL DEFAULT '[]',
+	weekly_quotas jsonb NOT NULL DEFAULT '[]',
+	booking_windows jsonb NOT NULL DEFAULT '[]',
+	lotteries jsonb NOT NULL DEFAULT '[]',
+	updated_at timestamptz NOT NULL,
+	CONSTRAINT web_guild_policy_pkey PRIMARY KEY (guild_id)
+);
+-- public.web_member_time_zone definition
+-- Drop table
+-- DROP TABLE public.web_member_time_zone;
+CREATE TABLE public.web_member_time_zone (
+	guild_id varchar(255) NOT NULL,
+	member_id varchar(255) NOT NULL,
+	time_zone varchar(64) NOT NULL,
+	updated_at timestamptz NOT NULL,
+	CONSTRAINT web_member_time_zone_pkey PRIMARY KEY (guild_id, member_id)
+);
+-- public.web_reservation_party_member definition
+-- Drop table
+-- DROP TABLE public.web_reservation_party_member;
+CREATE TABLE public.web_reservation_party_member (
+	reservation_id int8 NOT NULL,
+	member_discord_id varchar(200) NOT NULL,
+	member_name varchar(200) NOT NULL,
+	created_at timestamptz NOT NULL,
+	CONSTRAINT web_reservation_party_member_pkey PRIMARY KEY (reservation_id, member_discord_id),
+	CONSTRAINT web_reservation_party_member_reservation_id_fk_web_reservation_id FOREIGN KEY (reservation_id) REFERENCES public.web_reservation(id) ON DELETE CASCADE
+);
+CREATE INDEX web_reservation_party_member_member_discord_id ON public.web_reservation_party_member USING btree (member_discord_id);
+-- public.web_member_no_show definition
+-- Drop table
+-- DROP TABLE public.web_member_no_show;
+CREATE TABLE public.web_member_no_show (
+	guild_id varchar(255) NOT NULL,
+	member_id varchar(255) NOT NULL,
+	no_show_count int4 NOT NULL,
+	updated_at timestamptz NOT NULL,
+	CONSTRAINT web_member_no_show_pkey PRIMARY KEY (guild_id, member_id)
+);
+-- public.web_member_reminder_preference definition
+-- Drop table
+-- DROP TABLE public.web_member_reminder_preference;
+CREATE TABLE public.web_member_reminder_preference (
+	guild_id varchar(255) NOT NULL,
+	member_id varchar(255) NOT NULL,
+	enabled bool NOT NULL,
+	start_lead_minutes int4 NOT NULL,
+	end_lead_minutes int4 NOT NULL,
+	updated_at timestamptz NOT NULL,
+	CONSTRAINT web_member_reminder_preference_pkey PRIMARY KEY (guild_id, member_id)
+);
+-- public.web_reservation_reminder definition
+-- Drop table
+-- DROP TABLE public.web_reservation_reminder;
+CREATE TABLE public.web_reservation_reminder (
+	reservation_id int8 NOT NULL,
+	kind varchar(20) NOT NULL,
+	event_at timestamptz NOT NULL,
+	sent_at timestamptz NOT NULL,
+	CONSTRAINT web_reservation_reminder_pkey PRIMARY KEY (reservation_id, kind, event_at),
+	CONSTRAINT web_reservation_reminder_reservation_id_fk_web_reservation_id FOREIGN KEY (reservation_id) REFERENCES public.web_reservation(id) ON DELETE CASCADE
+);
\ No newline at end of file
@@ -0,0 +1,82 @@
+-- name: SelectGuildPolicy :one
+SELECT *
+FROM web_guild_policy
+WHERE guild_id = @guild_id
+LIMIT 1;
+-- name: UpsertGuildPolicy :one
+INSERT INTO web_guild_policy (
+    guild_id,
+    maximum_reservations_minutes,
+    maximum_reservation_minutes,
+    overbook_role,
+    suggestion_step_minutes,
+    booking_horizon_days,
+    time_zone,
+    party_time_counted,
+    check_in_grace_minutes,
+    blackouts,
+    overbook_approval,
+    overbook_approval_minutes,
+    tiers,
+    weekly_quotas,
+    booking_windows,
+    lotteries,
+    updated_at
+  )
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, now())
+ON CONFLICT (guild_id) DO UPDATE
+SET maximum_reservations_minutes = EXCLUDED.maximum_reservations_minutes,
+  maximum_reservation_minutes = EXCLUDED.maximum_reservation_minutes,
+  overbook_role = EXCLUDED.overbook_role,
+  suggestion_step_minutes = EXCLUDED.suggestion_step_minutes,
+  booking_horizon_days = EXCLUDED.booking_horizon_days,
+  time_zone = EXCLUDED.time_zone,
+  party_time_counted = EXCLUDED.party_time_counted,
+  check_in_grace_minutes = EXCLUDED.check_in_grace_minutes,
+  blackouts = EXCLUDED.blackouts,
+  overbook_approval = EXCLUDED.overbook_approval,
+  overbook_approval_minutes = EXCLUDED.overbook_approval_minutes,
+  tiers = EXCLUDED.tiers,
+  weekly_quotas = EXCLUDED.weekly_quotas,
+  booking_windows = EXCLUDED.booking_windows,
+  lotteries = EXCLUDED.lotteries,
+  updated_at = EXCLUDED.updated_at
+RETURNING *;
+-- name: SelectMemberTimeZone :one
+SELECT time_zone
+FROM web_member_time_zone
+WHERE guild_id = @guild_id
+  AND member_id = @member_id
+LIMIT 1;
+-- name: UpsertMemberTimeZone :exec
+INSERT INTO web_member_time_zone (guild_id, member_id, time_zone, updated_at)
+VALUES ($1, $2, $3, now())
+ON CONFLICT (guild_id, member_id) DO UPDATE
+SET time_zone = EXCLUDED.time_zone,
+  updated_at = EXCLUDED.updated_at;
+-- name: DeleteMemberTimeZone :exec
+DELETE FROM web_member_time_zone
+WHERE guild_id = @guild_id
+  AND member_id = @member_id;
+-- name: SelectMemberReminderPreference :one
+SELECT *
+FROM web_member_reminder_preference
+WHERE guild_id = @guild_id
+  AND member_id = @member_id
+LIMIT 1;
+-- name: UpsertMemberReminderPreference :one
+INSERT INTO web_member_reminder_preference (
+    guild_id,
+    member_id,
+    enabled,
+    start_lead_minutes,
+    end_lead_minutes,
+    updated_at
+  )
+VALUES ($1, $2, $3, $4, $5, now())
+ON CONFLICT (guild_id, member_id) DO UPDATE
+SET enabled = EXCLUDED.enabled,
+  start_lead_minutes = EXCLUDED.start_lead_minutes,
+  end_lead_minutes = EXCLUDED.end_lead_minutes,
+  updated_at = EXCLUDED.updated_at
+RETURNING *;
\ No newline at end of file
@@ -0,0 +1,161 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+
+package sqlc
+
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type WebGuildHiddenSpot struct {
+	GuildID   string
+	SpotID    int64
+	CreatedAt pgtype.Timestamptz
+}
+
+type WebGuildPolicy struct {
+	GuildID                    string
+	MaximumReservationsMinutes int32
+	MaximumReservationMinutes  int32
+	OverbookRole               string
+	SuggestionStepMinutes      int32
+	BookingHorizonDays         int32
+	TimeZone                   string
+	PartyTimeCounted           bool
+	CheckInGraceMinutes        int32
+	Blackouts                  []byte
+	OverbookApproval           bool
+	OverbookApprovalMinutes    int32
+	Tiers                      []byte
+	WeeklyQuotas               []byte
+	BookingWindows             []byte
+	Lotteries                  []byte
+	UpdatedAt                  pgtype.Timestamptz
+}
+
+type WebLotteryApplication struct {
+	ID              int64
+	Author          string
+	AuthorDiscordID string
+	GuildID         string
+	SpotID          int64
+	StartAt         pgtype.Timestamptz
+	EndAt           pgtype.Timestamptz
+	DrawAt          pgtype.Timestamptz
+	Won             pgtype.Bool
+	CreatedAt       pgtype.Timestamptz
+}
+
+type WebMemberNoShow struct {
+	GuildID     string
+	MemberID    string
+	NoShowCount int32
+	UpdatedAt   pgtype.Timestamptz
+}
+
+type WebMemberReminderPreference struct {
+	GuildID          string
+	MemberID         string
+	Enabled          bool
+	StartLeadMinutes int32
+	EndLeadMinutes   int32
+	UpdatedAt        pgtype.Timestamptz
+}
+
+type WebMemberTimeZone struct {
+	GuildID   string
+	MemberID  string
+	TimeZone  string
+	UpdatedAt pgtype.Timestamptz
+}
+
+type WebOverbookApproval struct {
+	RequestID       int64
+	ReservationID   int64
+	MemberDiscordID string
+	Accepted        pgtype.Bool
+}
+
+type WebOverbookRequest struct {
+	ID                    int64
+	Author                string
+	AuthorDiscordID       string
+	GuildID               string
+	SpotID                int64
+	StartAt               pgtype.Timestamptz
+	EndAt                 pgtype.Timestamptz
+	PartyMemberDiscordIds []string
+	ExpiresAt             pgtype.Timestamptz
+	CreatedAt             pgtype.Timestamptz
+}
+
+type WebReservation struct {
+	ID                int64
+	Author            string
+	CreatedAt         pgtype.Timestamptz
+	StartAt           pgtype.Timestamptz
+	EndAt             pgtype.Timestamptz
+	SpotID            int64
+	GuildID           string
+	AuthorDiscordID   string
+	SeriesID          pgtype.Int8
+	CheckedInAt       pgtype.Timestamptz
+	CheckInRemindedAt pgtype.Timestamptz
+	NoShowAt          pgtype.Timestamptz
+	HeldUntil         pgtype.Timestamptz
+	Priority          int32
+}
+
+type WebReservationPartyMember struct {
+	ReservationID   int64
+	MemberDiscordID string
+	MemberName      string
+	CreatedAt       pgtype.Timestamptz
+}
+
+type WebReservationQueue struct {
+	ID              int64
+	Author          string
+	AuthorDiscordID string
+	GuildID         string
+	SpotID          int64
+	StartAt         pgtype.Timestamptz
+	EndAt           pgtype.Timestamptz
+	CreatedAt       pgtype.Timestamptz
+}
+
+type WebReservationReminder struct {
+	ReservationID int64
+	Kind          string
+	EventAt       pgtype.Timestamptz
+	SentAt        pgtype.Timestamptz
+}
+
+type WebReservationSeries struct {
+	ID                int64
+	Author            string
+	AuthorDiscordID   string
+	GuildID           string
+	SpotID            int64
+	Weekdays          int32
+	StartTime         pgtype.Time
+	EndTime           pgtype.Time
+	CreatedAt         pgtype.Timestamptz
+	MaterializedUntil pgtype.Timestamptz
+}
+
+type WebSpot struct {
+	ID           int64
+	Name         string
+	CreatedAt    pgtype.Timestamptz
+	ArchivedAt   pgtype.Timestamptz
+	OwnerGuildID pgtype.Text
+	ParentID     pgtype.Int8
+	MinLevel     pgtype.Int4
+	MaxLevel     pgtype.Int4
+	Vocations    []string
+	Area         pgtype.Text
+	Kind         pgtype.Text
+	Aliases      []string
+}
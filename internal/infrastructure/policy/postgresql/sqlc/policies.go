@@ -0,0 +1,332 @@
+package sqlc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"spot-assistant/internal/common/collections"
+	"spot-assistant/internal/core/dto/policy"
+)
+
+type PolicyRepository struct {
+	q *Queries
+}
+
+func NewPolicyRepository(db DBTX) *PolicyRepository {
+	return &PolicyRepository{
+		q: New(db),
+	}
+}
+
+func (repo *PolicyRepository) FindGuildPolicy(ctx context.Context, guildId string) (*policy.Policy, error) {
+	res, err := repo.q.SelectGuildPolicy(ctx, guildId)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return policy.NewDefaultPolicy(guildId), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return mapPolicy(res)
+}
+
+func (repo *PolicyRepository) SaveGuildPolicy(ctx context.Context, p *policy.Policy) (*policy.Policy, error) {
+	blackouts, err := json.Marshal(collections.PoorMansMap(p.Blackouts, mapToBlackoutRecord))
+	if err != nil {
+		return nil, fmt.Errorf("could not encode blackouts: %w", err)
+	}
+
+	tiers, err := json.Marshal(collections.PoorMansMap(p.Tiers, mapToTierRecord))
+	if err != nil {
+		return nil, fmt.Errorf("could not encode tiers: %w", err)
+	}
+
+	weeklyQuotas, err := json.Marshal(collections.PoorMansMap(p.WeeklyQuotas, mapToWeeklyQuotaRecord))
+	if err != nil {
+		return nil, fmt.Errorf("could not encode weekly quotas: %w", err)
+	}
+
+	bookingWindows, err := json.Marshal(collections.PoorMansMap(p.BookingWindows, mapToBookingWindowRecord))
+	if err != nil {
+		return nil, fmt.Errorf("could not encode booking windows: %w", err)
+	}
+
+	lotteries, err := json.Marshal(collections.PoorMansMap(p.Lotteries, mapToLotteryRecord))
+	if err != nil {
+		return nil, fmt.Errorf("could not encode lotteries: %w", err)
+	}
+
+	res, err := repo.q.UpsertGuildPolicy(ctx, UpsertGuildPolicyParams{
+		GuildID:                    p.GuildID,
+		MaximumReservationsMinutes: int32(p.MaximumReservationsTime / time.Minute),
+		MaximumReservationMinutes:  int32(p.MaximumReservationTime / time.Minute),
+		OverbookRole:               p.OverbookRole,
+		SuggestionStepMinutes:      int32(p.SuggestionStep / time.Minute),
+		BookingHorizonDays:         int32(p.BookingHorizon / (24 * time.Hour)),
+		TimeZone:                   p.TimeZone,
+		PartyTimeCounted:           p.PartyTimeCounted,
+		CheckInGraceMinutes:        int32(p.CheckInGracePeriod / time.Minute),
+		Blackouts:                  blackouts,
+		OverbookApproval:           p.OverbookApproval,
+		OverbookApprovalMinutes:    int32(p.OverbookApprovalTimeout / time.Minute),
+		Tiers:                      tiers,
+		WeeklyQuotas:               weeklyQuotas,
+		BookingWindows:             bookingWindows,
+		Lotteries:                  lotteries,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mapPolicy(res)
+}
+
+// Returns member time zone, or an empty string if member has not set one.
+func (repo *PolicyRepository) FindMemberTimeZone(ctx context.Context, guildId string, memberId string) (string, error) {
+	res, err := repo.q.SelectMemberTimeZone(ctx, SelectMemberTimeZoneParams{
+		GuildID:  guildId,
+		MemberID: memberId,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+
+	return res, err
+}
+
+func (repo *PolicyRepository) SaveMemberTimeZone(ctx context.Context, guildId string, memberId string, timeZone string) error {
+	return repo.q.UpsertMemberTimeZone(ctx, UpsertMemberTimeZoneParams{
+		GuildID:  guildId,
+		MemberID: memberId,
+		TimeZone: timeZone,
+	})
+}
+
+func (repo *PolicyRepository) DeleteMemberTimeZone(ctx context.Context, guildId string, memberId string) error {
+	return repo.q.DeleteMemberTimeZone(ctx, DeleteMemberTimeZoneParams{
+		GuildID:  guildId,
+		MemberID: memberId,
+	})
+}
+
+// Returns member reminder preference, or the default one if member has not set it.
+func (repo *PolicyRepository) FindMemberReminderPreference(ctx context.Context, guildId string, memberId string) (*policy.ReminderPreference, error) {
+	res, err := repo.q.SelectMemberReminderPreference(ctx, SelectMemberReminderPreferenceParams{
+		GuildID:  guildId,
+		MemberID: memberId,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return policy.NewDefaultReminderPreference(guildId, memberId), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return mapReminderPreference(res), nil
+}
+
+func (repo *PolicyRepository) SaveMemberReminderPreference(ctx context.Context, p *policy.ReminderPreference) (*policy.ReminderPreference, error) {
+	res, err := repo.q.UpsertMemberReminderPreference(ctx, UpsertMemberReminderPreferenceParams{
+		GuildID:          p.GuildID,
+		MemberID:         p.MemberID,
+		Enabled:          p.Enabled,
+		StartLeadMinutes: int32(p.StartLeadTime / time.Minute),
+		EndLeadMinutes:   int32(p.EndLeadTime / time.Minute),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mapReminderPreference(res), nil
+}
+
+// Blackouts are stored as JSON along with the policy, as they are never read on their own
+type blackoutRecord struct {
+	Name          string `json:"name"`
+	StartMinutes  int    `json:"startMinutes"`
+	LengthMinutes int    `json:"lengthMinutes"`
+	TimeZone      string `json:"timeZone"`
+}
+
+func mapToBlackoutRecord(b policy.Blackout) blackoutRecord {
+	return blackoutRecord{
+		Name:          b.Name,
+		StartMinutes:  int(b.Start / time.Minute),
+		LengthMinutes: int(b.Length / time.Minute),
+		TimeZone:      b.TimeZone,
+	}
+}
+
+func mapBlackout(b blackoutRecord) policy.Blackout {
+	return policy.Blackout{
+		Name:     b.Name,
+		Start:    time.Duration(b.StartMinutes) * time.Minute,
+		Length:   time.Duration(b.LengthMinutes) * time.Minute,
+		TimeZone: b.TimeZone,
+	}
+}
+
+// Tiers are stored as JSON along with the policy as well
+type tierRecord struct {
+	Role                       string `json:"role"`
+	Priority                   int    `json:"priority"`
+	MaximumReservationsMinutes int    `json:"maximumReservationsMinutes"`
+}
+
+func mapToTierRecord(t policy.Tier) tierRecord {
+	return tierRecord{
+		Role:                       t.Role,
+		Priority:                   t.Priority,
+		MaximumReservationsMinutes: int(t.MaximumReservationsTime / time.Minute),
+	}
+}
+
+func mapTier(t tierRecord) policy.Tier {
+	return policy.Tier{
+		Role:                    t.Role,
+		Priority:                t.Priority,
+		MaximumReservationsTime: time.Duration(t.MaximumReservationsMinutes) * time.Minute,
+	}
+}
+
+// Weekly quotas are stored as JSON along with the policy as well
+type weeklyQuotaRecord struct {
+	Spot         string `json:"spot"`
+	LimitMinutes int    `json:"limitMinutes"`
+}
+
+func mapToWeeklyQuotaRecord(q policy.WeeklyQuota) weeklyQuotaRecord {
+	return weeklyQuotaRecord{
+		Spot:         q.Spot,
+		LimitMinutes: int(q.Limit / time.Minute),
+	}
+}
+
+func mapWeeklyQuota(q weeklyQuotaRecord) policy.WeeklyQuota {
+	return policy.WeeklyQuota{
+		Spot:  q.Spot,
+		Limit: time.Duration(q.LimitMinutes) * time.Minute,
+	}
+}
+
+// Booking windows are stored as JSON along with the policy as well
+type bookingWindowRecord struct {
+	Spot           string `json:"spot"`
+	AdvanceMinutes int    `json:"advanceMinutes"`
+	OpensAtMinutes *int   `json:"opensAtMinutes"`
+}
+
+func mapToBookingWindowRecord(w policy.BookingWindow) bookingWindowRecord {
+	record := bookingWindowRecord{
+		Spot:           w.Spot,
+		AdvanceMinutes: int(w.Advance / time.Minute),
+	}
+	if w.OpensAt != nil {
+		opensAtMinutes := int(*w.OpensAt / time.Minute)
+		record.OpensAtMinutes = &opensAtMinutes
+	}
+
+	return record
+}
+
+func mapBookingWindow(w bookingWindowRecord) policy.BookingWindow {
+	window := policy.BookingWindow{
+		Spot:    w.Spot,
+		Advance: time.Duration(w.AdvanceMinutes) * time.Minute,
+	}
+	if w.OpensAtMinutes != nil {
+		opensAt := time.Duration(*w.OpensAtMinutes) * time.Minute
+		window.OpensAt = &opensAt
+	}
+
+	return window
+}
+
+// Lotteries are stored as JSON along with the policy as well
+type lotteryRecord struct {
+	Spot          string `json:"spot"`
+	DrawAtMinutes int    `json:"drawAtMinutes"`
+	Weighted      bool   `json:"weighted"`
+}
+
+func mapToLotteryRecord(l policy.Lottery) lotteryRecord {
+	return lotteryRecord{
+		Spot:          l.Spot,
+		DrawAtMinutes: int(l.DrawAt / time.Minute),
+		Weighted:      l.Weighted,
+	}
+}
+
+func mapLottery(l lotteryRecord) policy.Lottery {
+	return policy.Lottery{
+		Spot:     l.Spot,
+		DrawAt:   time.Duration(l.DrawAtMinutes) * time.Minute,
+		Weighted: l.Weighted,
+	}
+}
+
+func mapPolicy(p WebGuildPolicy) (*policy.Policy, error) {
+	blackouts := []blackoutRecord{}
+	err := json.Unmarshal(p.Blackouts, &blackouts)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode blackouts: %w", err)
+	}
+
+	tiers := []tierRecord{}
+	err = json.Unmarshal(p.Tiers, &tiers)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode tiers: %w", err)
+	}
+
+	weeklyQuotas := []weeklyQuotaRecord{}
+	err = json.Unmarshal(p.WeeklyQuotas, &weeklyQuotas)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode weekly quotas: %w", err)
+	}
+
+	bookingWindows := []bookingWindowRecord{}
+	err = json.Unmarshal(p.BookingWindows, &bookingWindows)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode booking windows: %w", err)
+	}
+
+	lotteries := []lotteryRecord{}
+	err = json.Unmarshal(p.Lotteries, &lotteries)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode lotteries: %w", err)
+	}
+
+	return &policy.Policy{
+		GuildID:                 p.GuildID,
+		MaximumReservationsTime: time.Duration(p.MaximumReservationsMinutes) * time.Minute,
+		MaximumReservationTime:  time.Duration(p.MaximumReservationMinutes) * time.Minute,
+		OverbookRole:            p.OverbookRole,
+		SuggestionStep:          time.Duration(p.SuggestionStepMinutes) * time.Minute,
+		BookingHorizon:          time.Duration(p.BookingHorizonDays) * 24 * time.Hour,
+		TimeZone:                p.TimeZone,
+		PartyTimeCounted:        p.PartyTimeCounted,
+		CheckInGracePeriod:      time.Duration(p.CheckInGraceMinutes) * time.Minute,
+		Blackouts:               collections.PoorMansMap(blackouts, mapBlackout),
+		OverbookApproval:        p.OverbookApproval,
+		OverbookApprovalTimeout: time.Duration(p.OverbookApprovalMinutes) * time.Minute,
+		Tiers:                   collections.PoorMansMap(tiers, mapTier),
+		WeeklyQuotas:            collections.PoorMansMap(weeklyQuotas, mapWeeklyQuota),
+		BookingWindows:          collections.PoorMansMap(bookingWindows, mapBookingWindow),
+		Lotteries:               collections.PoorMansMap(lotteries, mapLottery),
+	}, nil
+}
+
+func mapReminderPreference(p WebMemberReminderPreference) *policy.ReminderPreference {
+	return &policy.ReminderPreference{
+		GuildID:       p.GuildID,
+		MemberID:      p.MemberID,
+		Enabled:       p.Enabled,
+		StartLeadTime: time.Duration(p.StartLeadMinutes) * time.Minute,
+		EndLeadTime:   time.Duration(p.EndLeadMinutes) * time.Minute,
+	}
+}
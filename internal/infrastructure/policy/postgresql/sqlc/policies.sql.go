@@ -0,0 +1,272 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: policies.sql
+
+package sqlc
+
+import (
+	"context"
+)
+
+const deleteMemberTimeZone = `-- name: DeleteMemberTimeZone :exec
+DELETE FROM web_member_time_zone
+WHERE guild_id = $1
+  AND member_id = $2
+`
+
+type DeleteMemberTimeZoneParams struct {
+	GuildID  string
+	MemberID string
+}
+
+func (q *Queries) DeleteMemberTimeZone(ctx context.Context, arg DeleteMemberTimeZoneParams) error {
+	_, err := q.db.Exec(ctx, deleteMemberTimeZone, arg.GuildID, arg.MemberID)
+	return err
+}
+
+const selectGuildPolicy = `-- name: SelectGuildPolicy :one
+SELECT guild_id, maximum_reservations_minutes, maximum_reservation_minutes, overbook_role, suggestion_step_minutes, booking_horizon_days, time_zone, party_time_counted, check_in_grace_minutes, blackouts, overbook_approval, overbook_approval_minutes, tiers, weekly_quotas, booking_windows, lotteries, updated_at
+FROM web_guild_policy
+WHERE guild_id = $1
+LIMIT 1
+`
+
+func (q *Queries) SelectGuildPolicy(ctx context.Context, guildID string) (WebGuildPolicy, error) {
+	row := q.db.QueryRow(ctx, selectGuildPolicy, guildID)
+	var i WebGuildPolicy
+	err := row.Scan(
+		&i.GuildID,
+		&i.MaximumReservationsMinutes,
+		&i.MaximumReservationMinutes,
+		&i.OverbookRole,
+		&i.SuggestionStepMinutes,
+		&i.BookingHorizonDays,
+		&i.TimeZone,
+		&i.PartyTimeCounted,
+		&i.CheckInGraceMinutes,
+		&i.Blackouts,
+		&i.OverbookApproval,
+		&i.OverbookApprovalMinutes,
+		&i.Tiers,
+		&i.WeeklyQuotas,
+		&i.BookingWindows,
+		&i.Lotteries,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const selectMemberReminderPreference = `-- name: SelectMemberReminderPreference :one
+SELECT guild_id, member_id, enabled, start_lead_minutes, end_lead_minutes, updated_at
+FROM web_member_reminder_preference
+WHERE guild_id = $1
+  AND member_id = $2
+LIMIT 1
+`
+
+type SelectMemberReminderPreferenceParams struct {
+	GuildID  string
+	MemberID string
+}
+
+func (q *Queries) SelectMemberReminderPreference(ctx context.Context, arg SelectMemberReminderPreferenceParams) (WebMemberReminderPreference, error) {
+	row := q.db.QueryRow(ctx, selectMemberReminderPreference, arg.GuildID, arg.MemberID)
+	var i WebMemberReminderPreference
+	err := row.Scan(
+		&i.GuildID,
+		&i.MemberID,
+		&i.Enabled,
+		&i.StartLeadMinutes,
+		&i.EndLeadMinutes,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const selectMemberTimeZone = `-- name: SelectMemberTimeZone :one
+SELECT time_zone
+FROM web_member_time_zone
+WHERE guild_id = $1
+  AND member_id = $2
+LIMIT 1
+`
+
+type SelectMemberTimeZoneParams struct {
+	GuildID  string
+	MemberID string
+}
+
+func (q *Queries) SelectMemberTimeZone(ctx context.Context, arg SelectMemberTimeZoneParams) (string, error) {
+	row := q.db.QueryRow(ctx, selectMemberTimeZone, arg.GuildID, arg.MemberID)
+	var timeZone string
+	err := row.Scan(&timeZone)
+	return timeZone, err
+}
+
+const upsertGuildPolicy = `-- name: UpsertGuildPolicy :one
+INSERT INTO web_guild_policy (
+    guild_id,
+    maximum_reservations_minutes,
+    maximum_reservation_minutes,
+    overbook_role,
+    suggestion_step_minutes,
+    booking_horizon_days,
+    time_zone,
+    party_time_counted,
+    check_in_grace_minutes,
+    blackouts,
+    overbook_approval,
+    overbook_approval_minutes,
+    tiers,
+    weekly_quotas,
+    booking_windows,
+    lotteries,
+    updated_at
+  )
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, now())
+ON CONFLICT (guild_id) DO UPDATE
+SET maximum_reservations_minutes = EXCLUDED.maximum_reservations_minutes,
+  maximum_reservation_minutes = EXCLUDED.maximum_reservation_minutes,
+  overbook_role = EXCLUDED.overbook_role,
+  suggestion_step_minutes = EXCLUDED.suggestion_step_minutes,
+  booking_horizon_days = EXCLUDED.booking_horizon_days,
+  time_zone = EXCLUDED.time_zone,
+  party_time_counted = EXCLUDED.party_time_counted,
+  check_in_grace_minutes = EXCLUDED.check_in_grace_minutes,
+  blackouts = EXCLUDED.blackouts,
+  overbook_approval = EXCLUDED.overbook_approval,
+  overbook_approval_minutes = EXCLUDED.overbook_approval_minutes,
+  tiers = EXCLUDED.tiers,
+  weekly_quotas = EXCLUDED.weekly_quotas,
+  booking_windows = EXCLUDED.booking_windows,
+  lotteries = EXCLUDED.lotteries,
+  updated_at = EXCLUDED.updated_at
+RETURNING guild_id, maximum_reservations_minutes, maximum_reservation_minutes, overbook_role, suggestion_step_minutes, booking_horizon_days, time_zone, party_time_counted, check_in_grace_minutes, blackouts, overbook_approval, overbook_approval_minutes, tiers, weekly_quotas, booking_windows, lotteries, updated_at
+`
+
+type UpsertGuildPolicyParams struct {
+	GuildID                    string
+	MaximumReservationsMinutes int32
+	MaximumReservationMinutes  int32
+	OverbookRole               string
+	SuggestionStepMinutes      int32
+	BookingHorizonDays         int32
+	TimeZone                   string
+	PartyTimeCounted           bool
+	CheckInGraceMinutes        int32
+	Blackouts                  []byte
+	OverbookApproval           bool
+	OverbookApprovalMinutes    int32
+	Tiers                      []byte
+	WeeklyQuotas               []byte
+	BookingWindows             []byte
+	Lotteries                  []byte
+}
+
+func (q *Queries) UpsertGuildPolicy(ctx context.Context, arg UpsertGuildPolicyParams) (WebGuildPolicy, error) {
+	row := q.db.QueryRow(ctx, upsertGuildPolicy,
+		arg.GuildID,
+		arg.MaximumReservationsMinutes,
+		arg.MaximumReservationMinutes,
+		arg.OverbookRole,
+		arg.SuggestionStepMinutes,
+		arg.BookingHorizonDays,
+		arg.TimeZone,
+		arg.PartyTimeCounted,
+		arg.CheckInGraceMinutes,
+		arg.Blackouts,
+		arg.OverbookApproval,
+		arg.OverbookApprovalMinutes,
+		arg.Tiers,
+		arg.WeeklyQuotas,
+		arg.BookingWindows,
+		arg.Lotteries,
+	)
+	var i WebGuildPolicy
+	err := row.Scan(
+		&i.GuildID,
+		&i.MaximumReservationsMinutes,
+		&i.MaximumReservationMinutes,
+		&i.OverbookRole,
+		&i.SuggestionStepMinutes,
+		&i.BookingHorizonDays,
+		&i.TimeZone,
+		&i.PartyTimeCounted,
+		&i.CheckInGraceMinutes,
+		&i.Blackouts,
+		&i.OverbookApproval,
+		&i.OverbookApprovalMinutes,
+		&i.Tiers,
+		&i.WeeklyQuotas,
+		&i.BookingWindows,
+		&i.Lotteries,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const upsertMemberReminderPreference = `-- name: UpsertMemberReminderPreference :one
+INSERT INTO web_member_reminder_preference (
+    guild_id,
+    member_id,
+    enabled,
+    start_lead_minutes,
+    end_lead_minutes,
+    updated_at
+  )
+VALUES ($1, $2, $3, $4, $5, now())
+ON CONFLICT (guild_id, member_id) DO UPDATE
+SET enabled = EXCLUDED.enabled,
+  start_lead_minutes = EXCLUDED.start_lead_minutes,
+  end_lead_minutes = EXCLUDED.end_lead_minutes,
+  updated_at = EXCLUDED.updated_at
+RETURNING guild_id, member_id, enabled, start_lead_minutes, end_lead_minutes, updated_at
+`
+
+type UpsertMemberReminderPreferenceParams struct {
+	GuildID          string
+	MemberID         string
+	Enabled          bool
+	StartLeadMinutes int32
+	EndLeadMinutes   int32
+}
+
+func (q *Queries) UpsertMemberReminderPreference(ctx context.Context, arg UpsertMemberReminderPreferenceParams) (WebMemberReminderPreference, error) {
+	row := q.db.QueryRow(ctx, upsertMemberReminderPreference,
+		arg.GuildID,
+		arg.MemberID,
+		arg.Enabled,
+		arg.StartLeadMinutes,
+		arg.EndLeadMinutes,
+	)
+	var i WebMemberReminderPreference
+	err := row.Scan(
+		&i.GuildID,
+		&i.MemberID,
+		&i.Enabled,
+		&i.StartLeadMinutes,
+		&i.EndLeadMinutes,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const upsertMemberTimeZone = `-- name: UpsertMemberTimeZone :exec
+INSERT INTO web_member_time_zone (guild_id, member_id, time_zone, updated_at)
+VALUES ($1, $2, $3, now())
+ON CONFLICT (guild_id, member_id) DO UPDATE
+SET time_zone = EXCLUDED.time_zone,
+  updated_at = EXCLUDED.updated_at
+`
+
+type UpsertMemberTimeZoneParams struct {
+	GuildID  string
+	MemberID string
+	TimeZone string
+}
+
+func (q *Queries) UpsertMemberTimeZone(ctx context.Context, arg UpsertMemberTimeZoneParams) error {
+	_, err := q.db.Exec(ctx, upsertMemberTimeZone, arg.GuildID, arg.MemberID, arg.TimeZone)
+	return err
+}
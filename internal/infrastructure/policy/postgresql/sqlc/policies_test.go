@@ -0,0 +1,186 @@
+package sqlc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/stretchr/testify/assert"
+
+	"spot-assistant/internal/core/dto/policy"
+)
+
+func newPolicyRows() *pgxmock.Rows {
+	return pgxmock.NewRows([]string{
+		"guild_id", "maximum_reservations_minutes", "maximum_reservation_minutes",
+		"overbook_role", "suggestion_step_minutes", "booking_horizon_days", "time_zone", "party_time_counted", "check_in_grace_minutes", "blackouts",
+		"overbook_approval", "overbook_approval_minutes", "tiers", "weekly_quotas", "booking_windows", "lotteries", "updated_at",
+	})
+}
+
+func TestFindGuildPolicy(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	mock.ExpectQuery("SelectGuildPolicy").WithArgs("test-guild-id").WillReturnRows(
+		newPolicyRows().AddRow("test-guild-id", int32(240), int32(120), "Admin", int32(15), int32(14), "America/Sao_Paulo", true, int32(10), []byte(`[{"name": "Server save", "startMinutes": 540, "lengthMinutes": 15, "timeZone": "Europe/London"}]`), true, int32(30), []byte(`[{"role": "Core", "priority": 2, "maximumReservationsMinutes": 360}]`), []byte(`[{"spot": "Soul War", "limitMinutes": 360}]`), []byte(`[{"spot": "Soul War", "advanceMinutes": 0, "opensAtMinutes": 1080}, {"spot": "Library", "advanceMinutes": 2880, "opensAtMinutes": null}]`), []byte(`[{"spot": "Soul War", "drawAtMinutes": 1200, "weighted": true}]`), time.Now()),
+	)
+	repository := NewPolicyRepository(mock)
+
+	// when
+	res, err := repository.FindGuildPolicy(context.Background(), "test-guild-id")
+
+	// assert
+	opensAt := 18 * time.Hour
+	assert.Nil(err)
+	assert.Equal(&policy.Policy{
+		GuildID:                 "test-guild-id",
+		MaximumReservationsTime: 4 * time.Hour,
+		MaximumReservationTime:  2 * time.Hour,
+		OverbookRole:            "Admin",
+		SuggestionStep:          15 * time.Minute,
+		BookingHorizon:          14 * 24 * time.Hour,
+		TimeZone:                "America/Sao_Paulo",
+		PartyTimeCounted:        true,
+		CheckInGracePeriod:      10 * time.Minute,
+		Blackouts: []policy.Blackout{
+			{Name: "Server save", Start: 9 * time.Hour, Length: 15 * time.Minute, TimeZone: "Europe/London"},
+		},
+		OverbookApproval:        true,
+		OverbookApprovalTimeout: 30 * time.Minute,
+		Tiers: []policy.Tier{
+			{Role: "Core", Priority: 2, MaximumReservationsTime: 6 * time.Hour},
+		},
+		WeeklyQuotas: []policy.WeeklyQuota{
+			{Spot: "Soul War", Limit: 6 * time.Hour},
+		},
+		BookingWindows: []policy.BookingWindow{
+			{Spot: "Soul War", OpensAt: &opensAt},
+			{Spot: "Library", Advance: 48 * time.Hour},
+		},
+		Lotteries: []policy.Lottery{
+			{Spot: "Soul War", DrawAt: 20 * time.Hour, Weighted: true},
+		},
+	}, res)
+	assert.Nil(mock.ExpectationsWereMet())
+}
+
+func TestFindGuildPolicyWithoutStoredPolicy(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	mock.ExpectQuery("SelectGuildPolicy").WithArgs("test-guild-id").WillReturnError(pgx.ErrNoRows)
+	repository := NewPolicyRepository(mock)
+
+	// when
+	res, err := repository.FindGuildPolicy(context.Background(), "test-guild-id")
+
+	// assert
+	assert.Nil(err)
+	assert.Equal(policy.NewDefaultPolicy("test-guild-id"), res)
+	assert.Nil(mock.ExpectationsWereMet())
+}
+
+func TestSaveGuildPolicy(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guildPolicy := policy.NewDefaultPolicy("test-guild-id")
+	guildPolicy.MaximumReservationsTime = 4 * time.Hour
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	blackouts := []byte(`[{"name":"Server save","startMinutes":600,"lengthMinutes":10,"timeZone":"Europe/Berlin"}]`)
+	tiers := []byte(`[]`)
+	weeklyQuotas := []byte(`[]`)
+	bookingWindows := []byte(`[]`)
+	lotteries := []byte(`[]`)
+	mock.ExpectQuery("UpsertGuildPolicy").WithArgs("test-guild-id", int32(240), int32(180), "Postman", int32(30), int32(7), "", false, int32(15), blackouts, false, int32(15), tiers, weeklyQuotas, bookingWindows, lotteries).WillReturnRows(
+		newPolicyRows().AddRow("test-guild-id", int32(240), int32(180), "Postman", int32(30), int32(7), "", false, int32(15), blackouts, false, int32(15), tiers, weeklyQuotas, bookingWindows, lotteries, time.Now()),
+	)
+	repository := NewPolicyRepository(mock)
+
+	// when
+	res, err := repository.SaveGuildPolicy(context.Background(), guildPolicy)
+
+	// assert
+	assert.Nil(err)
+	assert.Equal(guildPolicy, res)
+	assert.Nil(mock.ExpectationsWereMet())
+}
+
+func TestFindMemberTimeZoneWithoutStoredTimeZone(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	mock.ExpectQuery("SelectMemberTimeZone").WithArgs("test-guild-id", "test-member-id").WillReturnError(pgx.ErrNoRows)
+	repository := NewPolicyRepository(mock)
+
+	// when
+	res, err := repository.FindMemberTimeZone(context.Background(), "test-guild-id", "test-member-id")
+
+	// assert
+	assert.Nil(err)
+	assert.Empty(res)
+	assert.Nil(mock.ExpectationsWereMet())
+}
+
+func TestFindMemberReminderPreferenceWithoutStoredPreference(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	mock.ExpectQuery("SelectMemberReminderPreference").WithArgs("test-guild-id", "test-member-id").WillReturnError(pgx.ErrNoRows)
+	repository := NewPolicyRepository(mock)
+
+	// when
+	res, err := repository.FindMemberReminderPreference(context.Background(), "test-guild-id", "test-member-id")
+
+	// assert
+	assert.Nil(err)
+	assert.Equal(policy.NewDefaultReminderPreference("test-guild-id", "test-member-id"), res)
+	assert.Nil(mock.ExpectationsWereMet())
+}
+
+func TestSaveMemberReminderPreference(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	preference := policy.NewDefaultReminderPreference("test-guild-id", "test-member-id")
+	preference.Enabled = false
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	mock.ExpectQuery("UpsertMemberReminderPreference").WithArgs("test-guild-id", "test-member-id", false, int32(15), int32(10)).WillReturnRows(
+		pgxmock.NewRows([]string{"guild_id", "member_id", "enabled", "start_lead_minutes", "end_lead_minutes", "updated_at"}).
+			AddRow("test-guild-id", "test-member-id", false, int32(15), int32(10), time.Now()),
+	)
+	repository := NewPolicyRepository(mock)
+
+	// when
+	res, err := repository.SaveMemberReminderPreference(context.Background(), preference)
+
+	// assert
+	assert.Nil(err)
+	assert.Equal(preference, res)
+	assert.Nil(mock.ExpectationsWereMet())
+}
@@ -27,23 +27,40 @@ where end_at >= now()
   AND guild_id = @guild_id
   AND author_discord_id = @author_discord_id
 order by start_at asc;
+-- name: SelectMemberReservationsWithSpotsBetween :many
+select sqlc.embed(web_spot),
+  sqlc.embed(web_reservation)
+from web_reservation
+  inner join web_spot on web_reservation.spot_id = web_spot.id
+where guild_id = @guild_id
+  AND author_discord_id = @author_discord_id
+  AND start_at < @to_at
+  AND end_at > @from_at
+order by start_at asc;
 -- name: SelectOverlappingReservations :many
 SELECT web_reservation.id,
   web_reservation.author,
   web_reservation.author_discord_id,
   web_reservation.start_at,
   web_reservation.end_at,
-  web_reservation.guild_id
+  web_reservation.guild_id,
+  web_reservation.checked_in_at,
+  web_reservation.check_in_reminded_at,
+  web_reservation.held_until,
+  web_reservation.priority
 FROM web_reservation
-  INNER JOIN web_spot ON web_reservation.spot_id = web_spot.id
 WHERE web_reservation.end_at >= now()
   AND tstzrange(@start_at, @end_at, '[]') && tstzrange(
     web_reservation.start_at,
     web_reservation.end_at,
     '[]'
   )
-  AND lower(web_spot.name) = lower(@respawn)
-  AND web_reservation.guild_id = @guild_id;
+  AND web_reservation.spot_id = @spot_id
+  AND web_reservation.guild_id = @guild_id
+  AND (
+    web_reservation.held_until IS NULL
+    OR web_reservation.held_until > now()
+  );
 -- name: CreateReservation :one
 INSERT INTO web_reservation (
     author,
@@ -52,9 +69,11 @@ INSERT INTO web_reservation (
     end_at,
     spot_id,
     created_at,
-    guild_id
+    guild_id,
+    held_until,
+    priority
   )
-VALUES ($1, $2, $3, $4, $5, now(), $6)
+VALUES ($1, $2, $3, $4, $5, now(), $6, $7, $8)
 RETURNING *;
 -- name: SelectReservationsWithSpots :many
 select sqlc.embed(web_spot),
@@ -73,4 +92,294 @@ from web_reservation
          inner join web_spot on web_reservation.spot_id = web_spot.id
 where end_at >= now()
   AND guild_id = $1
-  AND web_spot.name = ANY(@spot_names::text[]);
\ No newline at end of file
+  AND web_spot.name = ANY(@spot_names::text[]);
+-- name: CreateSeriesReservation :one
+INSERT INTO web_reservation (
+    author,
+    author_discord_id,
+    start_at,
+    end_at,
+    spot_id,
+    created_at,
+    guild_id,
+    series_id,
+    priority
+  )
+VALUES ($1, $2, $3, $4, $5, now(), $6, $7, $8)
+RETURNING *;
+-- name: CreateReservationSeries :one
+INSERT INTO web_reservation_series (
+    author,
+    author_discord_id,
+    guild_id,
+    spot_id,
+    weekdays,
+    start_time,
+    end_time,
+    created_at,
+    materialized_until
+  )
+VALUES ($1, $2, $3, $4, $5, $6, $7, now(), now())
+RETURNING *;
+-- name: SelectReservationSeriesWithSpots :many
+select sqlc.embed(web_spot),
+  sqlc.embed(web_reservation_series)
+from web_reservation_series
+  inner join web_spot on web_reservation_series.spot_id = web_spot.id
+where web_reservation_series.guild_id = @guild_id
+  AND web_reservation_series.materialized_until < @materialized_until;
+-- name: SelectMemberReservationSeriesWithSpots :many
+select sqlc.embed(web_spot),
+  sqlc.embed(web_reservation_series)
+from web_reservation_series
+  inner join web_spot on web_reservation_series.spot_id = web_spot.id
+where web_reservation_series.guild_id = @guild_id
+  AND web_reservation_series.author_discord_id = @author_discord_id
+order by web_reservation_series.created_at asc;
+-- name: SelectMemberReservationSeriesWithSpot :one
+select sqlc.embed(web_spot),
+  sqlc.embed(web_reservation_series)
+from web_reservation_series
+  inner join web_spot on web_reservation_series.spot_id = web_spot.id
+where web_reservation_series.id = @id
+  AND web_reservation_series.guild_id = @guild_id
+  AND web_reservation_series.author_discord_id = @author_discord_id
+LIMIT 1;
+-- name: UpdateReservationSeriesMaterializedUntil :exec
+UPDATE web_reservation_series
+SET materialized_until = @materialized_until
+WHERE web_reservation_series.id = @id;
+-- name: DeleteUpcomingSeriesReservations :exec
+DELETE FROM web_reservation
+WHERE web_reservation.series_id = @series_id
+  AND web_reservation.start_at > now();
+-- name: DeleteReservationSeries :exec
+DELETE FROM web_reservation_series
+WHERE web_reservation_series.id = $1;
+-- name: CreateQueueEntry :one
+INSERT INTO web_reservation_queue (
+    author,
+    author_discord_id,
+    start_at,
+    end_at,
+    spot_id,
+    created_at,
+    guild_id
+  )
+VALUES ($1, $2, $3, $4, $5, now(), $6)
+RETURNING *;
+-- name: SelectQueueEntriesWithSpots :many
+select sqlc.embed(web_spot),
+  sqlc.embed(web_reservation_queue)
+from web_reservation_queue
+  inner join web_spot on web_reservation_queue.spot_id = web_spot.id
+where web_reservation_queue.end_at > now()
+  AND web_reservation_queue.guild_id = @guild_id
+order by web_reservation_queue.created_at asc;
+-- name: DeleteQueueEntry :exec
+DELETE FROM web_reservation_queue
+WHERE web_reservation_queue.id = $1;
+-- name: DeleteExpiredQueueEntries :exec
+DELETE FROM web_reservation_queue
+WHERE web_reservation_queue.guild_id = @guild_id
+  AND web_reservation_queue.end_at <= now();
+-- name: UpdatePresentMemberReservation :execrows
+UPDATE web_reservation
+SET start_at = @start_at,
+  end_at = @end_at
+WHERE web_reservation.id = @id
+  AND web_reservation.guild_id = @guild_id
+  AND web_reservation.author_discord_id = @author_discord_id
+  AND web_reservation.end_at > now();
+-- name: TransferPresentMemberReservation :execrows
+UPDATE web_reservation
+SET author = @new_author,
+  author_discord_id = @new_author_discord_id,
+  priority = @priority
+WHERE web_reservation.id = @id
+  AND web_reservation.guild_id = @guild_id
+  AND web_reservation.author_discord_id = @author_discord_id
+  AND web_reservation.end_at > now();
+-- name: InsertReservationPartyMember :exec
+INSERT INTO web_reservation_party_member (
+    reservation_id,
+    member_discord_id,
+    member_name,
+    created_at
+  )
+VALUES ($1, $2, $3, now()) ON CONFLICT (reservation_id, member_discord_id) DO NOTHING;
+-- name: DeleteReservationPartyMember :execrows
+DELETE FROM web_reservation_party_member
+WHERE reservation_id = @reservation_id
+  AND member_discord_id = @member_discord_id;
+-- name: SelectReservationsPartyMembers :many
+SELECT *
+FROM web_reservation_party_member
+WHERE reservation_id = ANY(@reservation_ids::int8[]);
+-- name: SelectUpcomingMemberPartyReservationsWithSpots :many
+select sqlc.embed(web_spot),
+  sqlc.embed(web_reservation)
+from web_reservation
+  inner join web_spot on web_reservation.spot_id = web_spot.id
+  inner join web_reservation_party_member on web_reservation_party_member.reservation_id = web_reservation.id
+where end_at >= now()
+  AND guild_id = @guild_id
+  AND web_reservation_party_member.member_discord_id = @member_discord_id
+order by start_at asc;
+-- name: CheckInPresentMemberReservation :execrows
+UPDATE web_reservation
+SET checked_in_at = COALESCE(checked_in_at, now())
+WHERE web_reservation.id = @id
+  AND web_reservation.guild_id = @guild_id
+  AND web_reservation.author_discord_id = @author_discord_id
+  AND web_reservation.end_at > now();
+-- name: SelectCheckInPendingReservationsWithSpots :many
+select sqlc.embed(web_spot),
+  sqlc.embed(web_reservation)
+from web_reservation
+  inner join web_spot on web_reservation.spot_id = web_spot.id
+where web_reservation.guild_id = @guild_id
+  AND web_reservation.start_at <= @started_before
+  AND web_reservation.end_at > now()
+  AND web_reservation.checked_in_at IS NULL
+  AND web_reservation.no_show_at IS NULL
+  AND web_reservation.held_until IS NULL
+order by web_reservation.start_at asc;
+-- name: MarkReservationCheckInReminded :execrows
+UPDATE web_reservation
+SET check_in_reminded_at = now()
+WHERE web_reservation.id = @id
+  AND web_reservation.checked_in_at IS NULL
+  AND web_reservation.check_in_reminded_at IS NULL;
+-- name: MarkReservationNoShow :execrows
+UPDATE web_reservation
+SET no_show_at = now()
+WHERE web_reservation.id = @id
+  AND web_reservation.checked_in_at IS NULL
+  AND web_reservation.no_show_at IS NULL;
+-- name: IncrementMemberNoShowCount :one
+INSERT INTO web_member_no_show (guild_id, member_id, no_show_count, updated_at)
+VALUES ($1, $2, 1, now())
+ON CONFLICT (guild_id, member_id) DO UPDATE
+SET no_show_count = web_member_no_show.no_show_count + 1,
+  updated_at = EXCLUDED.updated_at
+RETURNING *;
+-- name: InsertReservationReminder :execrows
+INSERT INTO web_reservation_reminder (reservation_id, kind, event_at, sent_at)
+VALUES ($1, $2, $3, now()) ON CONFLICT (reservation_id, kind, event_at) DO NOTHING;
+-- name: ConfirmPresentMemberReservationHold :execrows
+UPDATE web_reservation
+SET held_until = NULL
+WHERE web_reservation.id = @id
+  AND web_reservation.guild_id = @guild_id
+  AND web_reservation.author_discord_id = @author_discord_id
+  AND web_reservation.held_until > now();
+-- name: SelectExpiredReservationHoldsWithSpots :many
+select sqlc.embed(web_spot),
+  sqlc.embed(web_reservation)
+from web_reservation
+  inner join web_spot on web_reservation.spot_id = web_spot.id
+where web_reservation.guild_id = @guild_id
+  AND web_reservation.held_until <= now()
+order by web_reservation.start_at asc;
+-- name: DeleteExpiredReservationHold :execrows
+DELETE FROM web_reservation
+WHERE web_reservation.id = @id
+  AND web_reservation.held_until <= now();
+-- name: DeleteOverlappingExpiredReservationHolds :exec
+DELETE FROM web_reservation
+WHERE web_reservation.spot_id = @spot_id
+  AND web_reservation.guild_id = @guild_id
+  AND web_reservation.held_until <= now()
+  AND tstzrange(web_reservation.start_at, web_reservation.end_at) && tstzrange(@start_at::timestamptz, @end_at::timestamptz);
+-- name: CreateOverbookRequest :one
+INSERT INTO web_overbook_request (
+    author,
+    author_discord_id,
+    start_at,
+    end_at,
+    spot_id,
+    guild_id,
+    party_member_discord_ids,
+    expires_at,
+    created_at
+  )
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, now())
+RETURNING *;
+-- name: CreateOverbookApproval :exec
+INSERT INTO web_overbook_approval (request_id, reservation_id, member_discord_id)
+VALUES ($1, $2, $3);
+-- name: SelectOverbookRequestWithSpot :one
+select sqlc.embed(web_spot),
+  sqlc.embed(web_overbook_request)
+from web_overbook_request
+  inner join web_spot on web_overbook_request.spot_id = web_spot.id
+where web_overbook_request.id = @id
+  AND web_overbook_request.guild_id = @guild_id
+LIMIT 1;
+-- name: SelectOverbookApprovals :many
+SELECT *
+FROM web_overbook_approval
+WHERE request_id = ANY(@request_ids::bigint [])
+ORDER BY request_id,
+  reservation_id;
+-- name: AnswerOverbookApproval :execrows
+UPDATE web_overbook_approval
+SET accepted = @accepted
+FROM web_overbook_request
+WHERE web_overbook_approval.request_id = web_overbook_request.id
+  AND web_overbook_request.id = @request_id
+  AND web_overbook_request.guild_id = @guild_id
+  AND web_overbook_request.expires_at > now()
+  AND web_overbook_approval.member_discord_id = @member_discord_id
+  AND web_overbook_approval.accepted IS NULL;
+-- name: SelectExpiredOverbookRequestsWithSpots :many
+select sqlc.embed(web_spot),
+  sqlc.embed(web_overbook_request)
+from web_overbook_request
+  inner join web_spot on web_overbook_request.spot_id = web_spot.id
+where web_overbook_request.guild_id = @guild_id
+  AND web_overbook_request.expires_at <= now()
+order by web_overbook_request.created_at asc;
+-- name: DeleteOverbookRequest :execrows
+DELETE FROM web_overbook_request
+WHERE web_overbook_request.id = $1;
+-- name: CreateLotteryApplication :one
+INSERT INTO web_lottery_application (
+    author,
+    author_discord_id,
+    start_at,
+    end_at,
+    spot_id,
+    guild_id,
+    draw_at,
+    created_at
+  )
+VALUES ($1, $2, $3, $4, $5, $6, $7, now())
+RETURNING *;
+-- name: SelectPendingLotteryApplicationsWithSpots :many
+select sqlc.embed(web_spot),
+  sqlc.embed(web_lottery_application)
+from web_lottery_application
+  inner join web_spot on web_lottery_application.spot_id = web_spot.id
+where web_lottery_application.guild_id = @guild_id
+  AND web_lottery_application.won IS NULL
+order by web_lottery_application.created_at asc;
+-- name: SelectLotteryWinCounts :many
+SELECT author_discord_id,
+  count(*) AS wins
+FROM web_lottery_application
+WHERE guild_id = @guild_id
+  AND won
+  AND draw_at > @since
+GROUP BY author_discord_id;
+-- name: UpdateLotteryApplicationResult :execrows
+UPDATE web_lottery_application
+SET won = @won
+WHERE web_lottery_application.id = @id
+  AND web_lottery_application.won IS NULL;
+-- name: DeleteLotteryApplicationsDrawnBefore :exec
+DELETE FROM web_lottery_application
+WHERE web_lottery_application.guild_id = @guild_id
+  AND web_lottery_application.won IS NOT NULL
+  AND web_lottery_application.draw_at <= @before;
@@ -0,0 +1,143 @@
+package sqlc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"spot-assistant/internal/common/errors"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/reservation"
+)
+
+// Creates a tentative reservation, which gets released at heldUntil unless confirmed.
+func (t *ReservationRepository) CreateHold(ctx context.Context, member *discord.Member, guild *discord.Guild, spotId int64, startAt time.Time, endAt time.Time, heldUntil time.Time, priority int) (*reservation.Reservation, error) {
+	tx, err := t.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer errors.ExecuteAndIgnoreErrorF(tx.Rollback, ctx)
+	qtx := t.q.WithTx(tx)
+
+	startAtInput := pgtype.Timestamptz{}
+	err = startAtInput.Scan(startAt)
+	if err != nil {
+		return nil, err
+	}
+
+	endAtInput := pgtype.Timestamptz{}
+	err = endAtInput.Scan(endAt)
+	if err != nil {
+		return nil, err
+	}
+
+	// Expired holds no longer take the spot up, but stay in the table until they get released
+	err = qtx.DeleteOverlappingExpiredReservationHolds(ctx, DeleteOverlappingExpiredReservationHoldsParams{
+		SpotID:  spotId,
+		GuildID: guild.ID,
+		StartAt: startAtInput,
+		EndAt:   endAtInput,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := qtx.CreateReservation(ctx, CreateReservationParams{
+		Author:          member.DisplayName(),
+		AuthorDiscordID: member.ID,
+		StartAt:         startAtInput,
+		EndAt:           endAtInput,
+		SpotID:          spotId,
+		GuildID:         guild.ID,
+		HeldUntil:       heldUntilInput(heldUntil),
+		Priority:        int32(priority),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &reservation.Reservation{
+		ID:              res.ID,
+		Author:          res.Author,
+		CreatedAt:       res.CreatedAt.Time,
+		StartAt:         res.StartAt.Time,
+		EndAt:           res.EndAt.Time,
+		SpotID:          res.SpotID,
+		GuildID:         res.GuildID,
+		AuthorDiscordID: res.AuthorDiscordID,
+		HeldUntil:       res.HeldUntil.Time,
+		Priority:        int(res.Priority),
+	}, nil
+}
+
+// Turns member hold into a regular reservation, as long as it has not expired yet.
+func (t *ReservationRepository) ConfirmPresentMemberHold(ctx context.Context, g *discord.Guild, m *discord.Member, reservationId int64) error {
+	updated, err := t.q.ConfirmPresentMemberReservationHold(ctx, ConfirmPresentMemberReservationHoldParams{
+		ID:              reservationId,
+		GuildID:         g.ID,
+		AuthorDiscordID: m.ID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if updated == 0 {
+		return fmt.Errorf("hold %d does not exist or has already expired", reservationId)
+	}
+
+	return nil
+}
+
+func (t *ReservationRepository) SelectExpiredHoldsWithSpots(ctx context.Context, guildId string) ([]*reservation.ReservationWithSpot, error) {
+	res, err := t.q.SelectExpiredReservationHoldsWithSpots(ctx, guildId)
+	if err != nil {
+		return []*reservation.ReservationWithSpot{}, err
+	}
+
+	holds := make([]*reservation.ReservationWithSpot, len(res))
+	for i, row := range res {
+		holds[i] = &reservation.ReservationWithSpot{
+			Spot: mapSpot(row.WebSpot),
+			Reservation: reservation.Reservation{
+				ID:              row.WebReservation.ID,
+				Author:          row.WebReservation.Author,
+				AuthorDiscordID: row.WebReservation.AuthorDiscordID,
+				CreatedAt:       row.WebReservation.CreatedAt.Time,
+				StartAt:         row.WebReservation.StartAt.Time,
+				EndAt:           row.WebReservation.EndAt.Time,
+				SpotID:          row.WebReservation.SpotID,
+				GuildID:         row.WebReservation.GuildID,
+				HeldUntil:       row.WebReservation.HeldUntil.Time,
+				Priority:        int(row.WebReservation.Priority),
+			},
+		}
+	}
+
+	return holds, nil
+}
+
+// Deletes an expired hold. Returns false if it has been confirmed or released meanwhile.
+func (t *ReservationRepository) ReleaseExpiredHold(ctx context.Context, reservationId int64) (bool, error) {
+	deleted, err := t.q.DeleteExpiredReservationHold(ctx, reservationId)
+	if err != nil {
+		return false, err
+	}
+
+	return deleted > 0, nil
+}
+
+// Zero time stands for a confirmed reservation, which is stored as NULL.
+func heldUntilInput(heldUntil time.Time) pgtype.Timestamptz {
+	if heldUntil.IsZero() {
+		return pgtype.Timestamptz{}
+	}
+
+	return pgtype.Timestamptz{Time: heldUntil, Valid: true}
+}
@@ -0,0 +1,164 @@
+package sqlc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"spot-assistant/internal/common/errors"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/reservation"
+)
+
+func (t *ReservationRepository) CreateLotteryApplication(ctx context.Context, member *discord.Member, guild *discord.Guild, spotId int64, startAt time.Time, endAt time.Time, drawAt time.Time) (*reservation.LotteryApplication, error) {
+	res, err := t.q.CreateLotteryApplication(ctx, CreateLotteryApplicationParams{
+		Author:          member.DisplayName(),
+		AuthorDiscordID: member.ID,
+		StartAt:         pgtype.Timestamptz{Time: startAt, Valid: true},
+		EndAt:           pgtype.Timestamptz{Time: endAt, Valid: true},
+		SpotID:          spotId,
+		GuildID:         guild.ID,
+		DrawAt:          pgtype.Timestamptz{Time: drawAt, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	application := mapLotteryApplication(res)
+	return &application, nil
+}
+
+func (t *ReservationRepository) SelectPendingLotteryApplicationsWithSpots(ctx context.Context, guildId string) ([]*reservation.LotteryApplicationWithSpot, error) {
+	res, err := t.q.SelectPendingLotteryApplicationsWithSpots(ctx, guildId)
+	if err != nil {
+		return []*reservation.LotteryApplicationWithSpot{}, err
+	}
+
+	applications := make([]*reservation.LotteryApplicationWithSpot, len(res))
+	for i, row := range res {
+		applications[i] = &reservation.LotteryApplicationWithSpot{
+			LotteryApplication: mapLotteryApplication(row.WebLotteryApplication),
+			Spot:               mapSpot(row.WebSpot),
+		}
+	}
+
+	return applications, nil
+}
+
+// Returns how many lotteries each member of the guild has won since a given time, by member ID.
+func (t *ReservationRepository) CountLotteryWins(ctx context.Context, guildId string, since time.Time) (map[string]int, error) {
+	res, err := t.q.SelectLotteryWinCounts(ctx, SelectLotteryWinCountsParams{
+		GuildID: guildId,
+		Since:   pgtype.Timestamptz{Time: since, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	wins := make(map[string]int, len(res))
+	for _, row := range res {
+		wins[row.AuthorDiscordID] = int(row.Wins)
+	}
+
+	return wins, nil
+}
+
+// Records the draw result of an application, and books it if it has won.
+func (t *ReservationRepository) ResolveLotteryApplication(ctx context.Context, application *reservation.LotteryApplication, won bool, priority int) (*reservation.Reservation, error) {
+	tx, err := t.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer errors.ExecuteAndIgnoreErrorF(tx.Rollback, ctx)
+	qtx := t.q.WithTx(tx)
+
+	updated, err := qtx.UpdateLotteryApplicationResult(ctx, UpdateLotteryApplicationResultParams{
+		Won: pgtype.Bool{Bool: won, Valid: true},
+		ID:  application.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if updated == 0 {
+		return nil, fmt.Errorf("lottery application %d has already been drawn", application.ID)
+	}
+
+	var booked *reservation.Reservation
+	if won {
+		startAtInput := pgtype.Timestamptz{Time: application.StartAt, Valid: true}
+		endAtInput := pgtype.Timestamptz{Time: application.EndAt, Valid: true}
+		// Expired holds no longer take the spot up, but stay in the table until they get released
+		err = qtx.DeleteOverlappingExpiredReservationHolds(ctx, DeleteOverlappingExpiredReservationHoldsParams{
+			SpotID:  application.SpotID,
+			GuildID: application.GuildID,
+			StartAt: startAtInput,
+			EndAt:   endAtInput,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := qtx.CreateReservation(ctx, CreateReservationParams{
+			Author:          application.Author,
+			AuthorDiscordID: application.AuthorDiscordID,
+			StartAt:         startAtInput,
+			EndAt:           endAtInput,
+			SpotID:          application.SpotID,
+			GuildID:         application.GuildID,
+			Priority:        int32(priority),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		booked = &reservation.Reservation{
+			ID:              res.ID,
+			Author:          res.Author,
+			CreatedAt:       res.CreatedAt.Time,
+			StartAt:         res.StartAt.Time,
+			EndAt:           res.EndAt.Time,
+			SpotID:          res.SpotID,
+			GuildID:         res.GuildID,
+			AuthorDiscordID: res.AuthorDiscordID,
+			Priority:        int(res.Priority),
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return booked, nil
+}
+
+// Deletes guild applications drawn at or before a given time, which no longer count as recent wins.
+func (t *ReservationRepository) DeleteLotteryApplicationsDrawnBefore(ctx context.Context, guildId string, before time.Time) error {
+	return t.q.DeleteLotteryApplicationsDrawnBefore(ctx, DeleteLotteryApplicationsDrawnBeforeParams{
+		GuildID: guildId,
+		Before:  pgtype.Timestamptz{Time: before, Valid: true},
+	})
+}
+
+func mapLotteryApplication(application WebLotteryApplication) reservation.LotteryApplication {
+	mapped := reservation.LotteryApplication{
+		ID:              application.ID,
+		Author:          application.Author,
+		AuthorDiscordID: application.AuthorDiscordID,
+		GuildID:         application.GuildID,
+		SpotID:          application.SpotID,
+		StartAt:         application.StartAt.Time,
+		EndAt:           application.EndAt.Time,
+		DrawAt:          application.DrawAt.Time,
+		CreatedAt:       application.CreatedAt.Time,
+	}
+	if application.Won.Valid {
+		won := application.Won.Bool
+		mapped.Won = &won
+	}
+
+	return mapped
+}
@@ -0,0 +1,78 @@
+package sqlc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/stretchr/testify/assert"
+
+	"spot-assistant/internal/common/test/mocks"
+	"spot-assistant/internal/core/dto/reservation"
+)
+
+func TestResolveWinningLotteryApplication(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	tNow := time.Now()
+	application := &reservation.LotteryApplication{
+		ID:              1,
+		Author:          "test-member-nick",
+		AuthorDiscordID: "test-member-id",
+		GuildID:         "test-guild-id",
+		SpotID:          1,
+		StartAt:         time.Date(tNow.Year(), tNow.Month(), tNow.Day(), 21, 1, 0, 0, time.UTC),
+		EndAt:           time.Date(tNow.Year(), tNow.Month(), tNow.Day(), 23, 1, 0, 0, time.UTC),
+	}
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE web_lottery_application").WithArgs(pgtype.Bool{Bool: true, Valid: true}, application.ID).WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	expectOverlappingExpiredHoldsDeletion(mock, application.SpotID, application.GuildID, application.StartAt, application.EndAt)
+	mock.ExpectQuery("INSERT INTO web_reservation").WithArgs(
+		application.Author, application.AuthorDiscordID, mocks.NewPgTimestamptzTime(application.StartAt),
+		mocks.NewPgTimestamptzTime(application.EndAt), application.SpotID, application.GuildID, pgtype.Timestamptz{}, int32(2),
+	).WillReturnRows(newReservationRows().AddRow(
+		int64(2), application.Author, time.Now(), application.StartAt, application.EndAt, application.SpotID, application.GuildID, application.AuthorDiscordID, nil, nil, nil, nil, nil, int32(2),
+	))
+	mock.ExpectCommit()
+	repository := NewReservationRepository(mock)
+
+	// when
+	res, err := repository.ResolveLotteryApplication(context.Background(), application, true, 2)
+
+	// assert
+	assert.Nil(err)
+	assert.Equal(int64(2), res.ID)
+	assert.Equal(application.StartAt, res.StartAt)
+	assert.Equal(2, res.Priority)
+	assert.Nil(mock.ExpectationsWereMet())
+}
+
+func TestResolveLotteryApplicationDrawnMeanwhile(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	application := &reservation.LotteryApplication{ID: 1}
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE web_lottery_application").WithArgs(pgtype.Bool{Bool: false, Valid: true}, application.ID).WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+	mock.ExpectRollback()
+	repository := NewReservationRepository(mock)
+
+	// when
+	res, err := repository.ResolveLotteryApplication(context.Background(), application, false, 0)
+
+	// assert
+	assert.Nil(res)
+	assert.ErrorContains(err, "has already been drawn")
+	assert.Nil(mock.ExpectationsWereMet())
+}
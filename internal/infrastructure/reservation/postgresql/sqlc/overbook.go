@@ -0,0 +1,182 @@
+package sqlc
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"spot-assistant/internal/common/collections"
+	"spot-assistant/internal/common/errors"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/reservation"
+)
+
+// Creates an overbook request along with approvals of the affected reservations authors.
+func (t *ReservationRepository) CreateOverbookRequest(ctx context.Context, member *discord.Member, guild *discord.Guild, party []*discord.Member, spotId int64, startAt time.Time, endAt time.Time, expiresAt time.Time, affected []*reservation.Reservation) (*reservation.OverbookRequest, error) {
+	tx, err := t.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer errors.ExecuteAndIgnoreErrorF(tx.Rollback, ctx)
+	qtx := t.q.WithTx(tx)
+
+	res, err := qtx.CreateOverbookRequest(ctx, CreateOverbookRequestParams{
+		Author:          member.DisplayName(),
+		AuthorDiscordID: member.ID,
+		StartAt:         pgtype.Timestamptz{Time: startAt, Valid: true},
+		EndAt:           pgtype.Timestamptz{Time: endAt, Valid: true},
+		SpotID:          spotId,
+		GuildID:         guild.ID,
+		PartyMemberDiscordIds: collections.PoorMansMap(party, func(m *discord.Member) string {
+			return m.ID
+		}),
+		ExpiresAt: pgtype.Timestamptz{Time: expiresAt, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	request := mapOverbookRequest(res)
+	for _, r := range affected {
+		err = qtx.CreateOverbookApproval(ctx, CreateOverbookApprovalParams{
+			RequestID:       res.ID,
+			ReservationID:   r.ID,
+			MemberDiscordID: r.AuthorDiscordID,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		request.Approvals = append(request.Approvals, &reservation.OverbookApproval{
+			ReservationID:   r.ID,
+			MemberDiscordID: r.AuthorDiscordID,
+		})
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &request, nil
+}
+
+func (t *ReservationRepository) FindOverbookRequestWithSpot(ctx context.Context, guildId string, requestId int64) (*reservation.OverbookRequestWithSpot, error) {
+	res, err := t.q.SelectOverbookRequestWithSpot(ctx, SelectOverbookRequestWithSpotParams{
+		ID:      requestId,
+		GuildID: guildId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	requests := []*reservation.OverbookRequestWithSpot{{
+		OverbookRequest: mapOverbookRequest(res.WebOverbookRequest),
+		Spot:            mapSpot(res.WebSpot),
+	}}
+	err = t.fillOverbookApprovals(ctx, requests)
+	if err != nil {
+		return nil, err
+	}
+
+	return requests[0], nil
+}
+
+// Records member answer to every affected reservation of theirs. Returns false if the request
+// does not exist, has expired or does not wait for member answer anymore.
+func (t *ReservationRepository) AnswerOverbookRequest(ctx context.Context, guildId string, requestId int64, memberId string, accepted bool) (bool, error) {
+	updated, err := t.q.AnswerOverbookApproval(ctx, AnswerOverbookApprovalParams{
+		Accepted:        pgtype.Bool{Bool: accepted, Valid: true},
+		RequestID:       requestId,
+		GuildID:         guildId,
+		MemberDiscordID: memberId,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return updated > 0, nil
+}
+
+func (t *ReservationRepository) SelectExpiredOverbookRequestsWithSpots(ctx context.Context, guildId string) ([]*reservation.OverbookRequestWithSpot, error) {
+	res, err := t.q.SelectExpiredOverbookRequestsWithSpots(ctx, guildId)
+	if err != nil {
+		return []*reservation.OverbookRequestWithSpot{}, err
+	}
+
+	requests := make([]*reservation.OverbookRequestWithSpot, len(res))
+	for i, row := range res {
+		requests[i] = &reservation.OverbookRequestWithSpot{
+			OverbookRequest: mapOverbookRequest(row.WebOverbookRequest),
+			Spot:            mapSpot(row.WebSpot),
+		}
+	}
+
+	err = t.fillOverbookApprovals(ctx, requests)
+	if err != nil {
+		return []*reservation.OverbookRequestWithSpot{}, err
+	}
+
+	return requests, nil
+}
+
+// Deletes an overbook request along with its approvals. Returns false if it has been resolved meanwhile.
+func (t *ReservationRepository) DeleteOverbookRequest(ctx context.Context, requestId int64) (bool, error) {
+	deleted, err := t.q.DeleteOverbookRequest(ctx, requestId)
+	if err != nil {
+		return false, err
+	}
+
+	return deleted > 0, nil
+}
+
+func (t *ReservationRepository) fillOverbookApprovals(ctx context.Context, requests []*reservation.OverbookRequestWithSpot) error {
+	if len(requests) == 0 {
+		return nil
+	}
+
+	approvals, err := t.q.SelectOverbookApprovals(ctx, collections.PoorMansMap(requests, func(r *reservation.OverbookRequestWithSpot) int64 {
+		return r.OverbookRequest.ID
+	}))
+	if err != nil {
+		return err
+	}
+
+	for _, r := range requests {
+		for _, a := range approvals {
+			if a.RequestID != r.OverbookRequest.ID {
+				continue
+			}
+
+			approval := &reservation.OverbookApproval{
+				ReservationID:   a.ReservationID,
+				MemberDiscordID: a.MemberDiscordID,
+			}
+			if a.Accepted.Valid {
+				accepted := a.Accepted.Bool
+				approval.Accepted = &accepted
+			}
+
+			r.Approvals = append(r.Approvals, approval)
+		}
+	}
+
+	return nil
+}
+
+func mapOverbookRequest(r WebOverbookRequest) reservation.OverbookRequest {
+	return reservation.OverbookRequest{
+		ID:                    r.ID,
+		Author:                r.Author,
+		AuthorDiscordID:       r.AuthorDiscordID,
+		GuildID:               r.GuildID,
+		SpotID:                r.SpotID,
+		StartAt:               r.StartAt.Time,
+		EndAt:                 r.EndAt.Time,
+		PartyMemberDiscordIDs: r.PartyMemberDiscordIds,
+		ExpiresAt:             r.ExpiresAt.Time,
+		CreatedAt:             r.CreatedAt.Time,
+		Approvals:             []*reservation.OverbookApproval{},
+	}
+}
@@ -0,0 +1,76 @@
+package sqlc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/stretchr/testify/assert"
+
+	"spot-assistant/internal/common/test/mocks"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/reservation"
+)
+
+func TestCreateOverbookRequest(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	guild := &discord.Guild{ID: "test-guild-id"}
+	member := &discord.Member{ID: "test-member-id", Nick: "test-member-nick"}
+	party := []*discord.Member{{ID: "party-member-id"}}
+	startAt := time.Now().Add(1 * time.Hour)
+	endAt := startAt.Add(2 * time.Hour)
+	expiresAt := time.Now().Add(15 * time.Minute)
+	affected := []*reservation.Reservation{{ID: 7, AuthorDiscordID: "affected-member-id"}}
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO web_overbook_request").WithArgs(
+		member.Nick, member.ID, mocks.NewPgTimestamptzTime(startAt), mocks.NewPgTimestamptzTime(endAt),
+		int64(1), guild.ID, []string{"party-member-id"}, mocks.NewPgTimestamptzTime(expiresAt),
+	).WillReturnRows(pgxmock.NewRows([]string{
+		"id", "author", "author_discord_id", "guild_id", "spot_id", "start_at", "end_at", "party_member_discord_ids", "expires_at", "created_at",
+	}).AddRow(
+		int64(3), member.Nick, member.ID, guild.ID, int64(1), startAt, endAt, []string{"party-member-id"}, expiresAt, time.Now(),
+	))
+	mock.ExpectExec("INSERT INTO web_overbook_approval").WithArgs(int64(3), int64(7), "affected-member-id").WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectCommit()
+	repository := NewReservationRepository(mock)
+
+	// when
+	res, err := repository.CreateOverbookRequest(context.Background(), member, guild, party, 1, startAt, endAt, expiresAt, affected)
+
+	// assert
+	assert.Nil(err)
+	assert.Equal(int64(3), res.ID)
+	assert.Equal([]string{"party-member-id"}, res.PartyMemberDiscordIDs)
+	assert.Equal([]*reservation.OverbookApproval{{ReservationID: 7, MemberDiscordID: "affected-member-id"}}, res.Approvals)
+	assert.Nil(mock.ExpectationsWereMet())
+}
+
+func TestAnswerOverbookRequestWhenNotAwaited(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	mock.ExpectExec("UPDATE web_overbook_approval").WithArgs(
+		pgtype.Bool{Bool: true, Valid: true}, int64(3), "test-guild-id", "test-member-id",
+	).WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+	repository := NewReservationRepository(mock)
+
+	// when
+	ok, err := repository.AnswerOverbookRequest(context.Background(), "test-guild-id", 3, "test-member-id", true)
+
+	// assert
+	assert.Nil(err)
+	assert.False(ok)
+	assert.Nil(mock.ExpectationsWereMet())
+}
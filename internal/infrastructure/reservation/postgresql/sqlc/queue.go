@@ -0,0 +1,149 @@
+package sqlc
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"spot-assistant/internal/common/errors"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/reservation"
+)
+
+func (t *ReservationRepository) CreateQueueEntry(ctx context.Context, member *discord.Member, guild *discord.Guild, spotId int64, startAt time.Time, endAt time.Time) (*reservation.QueueEntry, error) {
+	startAtInput := pgtype.Timestamptz{}
+	err := startAtInput.Scan(startAt)
+	if err != nil {
+		return nil, err
+	}
+
+	endAtInput := pgtype.Timestamptz{}
+	err = endAtInput.Scan(endAt)
+	if err != nil {
+		return nil, err
+	}
+
+	var author string
+	if len(member.Nick) > 0 {
+		author = member.Nick
+	} else {
+		author = member.Username
+	}
+
+	res, err := t.q.CreateQueueEntry(ctx, CreateQueueEntryParams{
+		Author:          author,
+		AuthorDiscordID: member.ID,
+		StartAt:         startAtInput,
+		EndAt:           endAtInput,
+		SpotID:          spotId,
+		GuildID:         guild.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entry := mapQueueEntry(res)
+	return &entry, nil
+}
+
+func (t *ReservationRepository) SelectQueueEntriesWithSpots(ctx context.Context, guildId string) ([]*reservation.QueueEntryWithSpot, error) {
+	res, err := t.q.SelectQueueEntriesWithSpots(ctx, guildId)
+	if err != nil {
+		return []*reservation.QueueEntryWithSpot{}, err
+	}
+
+	entries := make([]*reservation.QueueEntryWithSpot, len(res))
+	for i, row := range res {
+		entries[i] = &reservation.QueueEntryWithSpot{
+			QueueEntry: mapQueueEntry(row.WebReservationQueue),
+			Spot:       mapSpot(row.WebSpot),
+		}
+	}
+
+	return entries, nil
+}
+
+func (t *ReservationRepository) DeleteExpiredQueueEntries(ctx context.Context, guildId string) error {
+	return t.q.DeleteExpiredQueueEntries(ctx, guildId)
+}
+
+func (t *ReservationRepository) CreateReservationFromQueueEntry(ctx context.Context, entry *reservation.QueueEntry, priority int) (*reservation.Reservation, error) {
+	tx, err := t.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer errors.ExecuteAndIgnoreErrorF(tx.Rollback, ctx)
+	qtx := t.q.WithTx(tx)
+
+	startAtInput := pgtype.Timestamptz{}
+	err = startAtInput.Scan(entry.StartAt)
+	if err != nil {
+		return nil, err
+	}
+
+	endAtInput := pgtype.Timestamptz{}
+	err = endAtInput.Scan(entry.EndAt)
+	if err != nil {
+		return nil, err
+	}
+
+	// Expired holds no longer take the spot up, but stay in the table until they get released
+	err = qtx.DeleteOverlappingExpiredReservationHolds(ctx, DeleteOverlappingExpiredReservationHoldsParams{
+		SpotID:  entry.SpotID,
+		GuildID: entry.GuildID,
+		StartAt: startAtInput,
+		EndAt:   endAtInput,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := qtx.CreateReservation(ctx, CreateReservationParams{
+		Author:          entry.Author,
+		AuthorDiscordID: entry.AuthorDiscordID,
+		StartAt:         startAtInput,
+		EndAt:           endAtInput,
+		SpotID:          entry.SpotID,
+		GuildID:         entry.GuildID,
+		Priority:        int32(priority),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = qtx.DeleteQueueEntry(ctx, entry.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &reservation.Reservation{
+		ID:              res.ID,
+		Author:          res.Author,
+		CreatedAt:       res.CreatedAt.Time,
+		StartAt:         res.StartAt.Time,
+		EndAt:           res.EndAt.Time,
+		SpotID:          res.SpotID,
+		GuildID:         res.GuildID,
+		AuthorDiscordID: res.AuthorDiscordID,
+		Priority:        int(res.Priority),
+	}, nil
+}
+
+func mapQueueEntry(entry WebReservationQueue) reservation.QueueEntry {
+	return reservation.QueueEntry{
+		ID:              entry.ID,
+		Author:          entry.Author,
+		AuthorDiscordID: entry.AuthorDiscordID,
+		GuildID:         entry.GuildID,
+		SpotID:          entry.SpotID,
+		StartAt:         entry.StartAt.Time,
+		EndAt:           entry.EndAt.Time,
+		CreatedAt:       entry.CreatedAt.Time,
+	}
+}
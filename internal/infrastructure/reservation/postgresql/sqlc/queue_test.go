@@ -0,0 +1,54 @@
+package sqlc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/stretchr/testify/assert"
+
+	"spot-assistant/internal/common/test/mocks"
+	"spot-assistant/internal/core/dto/reservation"
+)
+
+func TestCreateReservationFromQueueEntry(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	tNow := time.Now()
+	entry := &reservation.QueueEntry{
+		ID:              1,
+		Author:          "test-member-nick",
+		AuthorDiscordID: "test-member-id",
+		GuildID:         "test-guild-id",
+		SpotID:          1,
+		StartAt:         time.Date(tNow.Year(), tNow.Month(), tNow.Day(), 21, 1, 0, 0, time.UTC),
+		EndAt:           time.Date(tNow.Year(), tNow.Month(), tNow.Day(), 23, 1, 0, 0, time.UTC),
+	}
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	mock.ExpectBegin()
+	expectOverlappingExpiredHoldsDeletion(mock, entry.SpotID, entry.GuildID, entry.StartAt, entry.EndAt)
+	mock.ExpectQuery("INSERT INTO web_reservation").WithArgs(
+		entry.Author, entry.AuthorDiscordID, mocks.NewPgTimestamptzTime(entry.StartAt),
+		mocks.NewPgTimestamptzTime(entry.EndAt), entry.SpotID, entry.GuildID, pgtype.Timestamptz{}, int32(1),
+	).WillReturnRows(newReservationRows().AddRow(
+		int64(2), entry.Author, time.Now(), entry.StartAt, entry.EndAt, entry.SpotID, entry.GuildID, entry.AuthorDiscordID, nil, nil, nil, nil, nil, int32(1),
+	))
+	mock.ExpectExec("DELETE FROM web_reservation_queue").WithArgs(entry.ID).WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	mock.ExpectCommit()
+	repository := NewReservationRepository(mock)
+
+	// when
+	res, err := repository.CreateReservationFromQueueEntry(context.Background(), entry, 1)
+
+	// assert
+	assert.Nil(err)
+	assert.Equal(int64(2), res.ID)
+	assert.Equal(entry.StartAt, res.StartAt)
+	assert.Nil(mock.ExpectationsWereMet())
+}
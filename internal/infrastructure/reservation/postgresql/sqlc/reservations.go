@@ -2,15 +2,18 @@ package sqlc
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/sirupsen/logrus"
 
+	"spot-assistant/internal/common/collections"
 	"spot-assistant/internal/common/errors"
 	"spot-assistant/internal/core/dto/discord"
 	"spot-assistant/internal/core/dto/reservation"
+	dto "spot-assistant/internal/core/dto/spot"
 )
 
 type DBTXWrapper interface {
@@ -47,6 +50,8 @@ func (t *ReservationRepository) Find(ctx context.Context, id int64) (*reservatio
 		EndAt:           res.EndAt.Time,
 		SpotID:          res.SpotID,
 		GuildID:         res.GuildID,
+		HeldUntil:       res.HeldUntil.Time,
+		Priority:        int(res.Priority),
 		AuthorDiscordID: res.AuthorDiscordID,
 	}, nil
 }
@@ -63,19 +68,20 @@ func (t *ReservationRepository) FindReservationWithSpot(ctx context.Context, id
 	}
 
 	return &reservation.ReservationWithSpot{
-		Spot: reservation.Spot{
-			ID:   res.WebSpot.ID,
-			Name: res.WebSpot.Name,
-		},
+		Spot: mapSpot(res.WebSpot),
 		Reservation: reservation.Reservation{
-			ID:              res.WebReservation.ID,
-			Author:          res.WebReservation.Author,
-			AuthorDiscordID: res.WebReservation.AuthorDiscordID,
-			CreatedAt:       res.WebReservation.CreatedAt.Time,
-			StartAt:         res.WebReservation.StartAt.Time,
-			EndAt:           res.WebReservation.EndAt.Time,
-			SpotID:          res.WebReservation.SpotID,
-			GuildID:         res.WebReservation.GuildID,
+			ID:                res.WebReservation.ID,
+			Author:            res.WebReservation.Author,
+			AuthorDiscordID:   res.WebReservation.AuthorDiscordID,
+			CreatedAt:         res.WebReservation.CreatedAt.Time,
+			StartAt:           res.WebReservation.StartAt.Time,
+			EndAt:             res.WebReservation.EndAt.Time,
+			SpotID:            res.WebReservation.SpotID,
+			GuildID:           res.WebReservation.GuildID,
+			HeldUntil:         res.WebReservation.HeldUntil.Time,
+			Priority:          int(res.WebReservation.Priority),
+			CheckedInAt:       res.WebReservation.CheckedInAt.Time,
+			CheckInRemindedAt: res.WebReservation.CheckInRemindedAt.Time,
 		},
 	}, nil
 }
@@ -97,25 +103,72 @@ func (t *ReservationRepository) SelectUpcomingReservationsWithSpot(ctx context.C
 				EndAt:           reservationWithSpotRow.WebReservation.EndAt.Time,
 				SpotID:          reservationWithSpotRow.WebReservation.SpotID,
 				GuildID:         reservationWithSpotRow.WebReservation.GuildID,
+				HeldUntil:       reservationWithSpotRow.WebReservation.HeldUntil.Time,
+				Priority:        int(reservationWithSpotRow.WebReservation.Priority),
 				AuthorDiscordID: reservationWithSpotRow.WebReservation.AuthorDiscordID,
 			},
-			Spot: reservation.Spot{
-				ID:   reservationWithSpotRow.WebSpot.ID,
-				Name: reservationWithSpotRow.WebSpot.Name,
-			},
+			Spot: mapSpot(reservationWithSpotRow.WebSpot),
 		}
 
 		reservationsWithSpots[i] = mappedRes
 	}
 
-	return reservationsWithSpots, nil
+	return reservationsWithSpots, t.selectParties(ctx, collections.PoorMansMap(reservationsWithSpots, func(r *reservation.ReservationWithSpot) *reservation.Reservation {
+		return &r.Reservation
+	}))
+}
+
+// Returns member reservations overlapping the time between from and to, including the past ones.
+func (t *ReservationRepository) SelectMemberReservationsWithSpotsBetween(ctx context.Context, guild *discord.Guild, member *discord.Member, from time.Time, to time.Time) ([]*reservation.ReservationWithSpot, error) {
+	fromInput := pgtype.Timestamptz{}
+	err := fromInput.Scan(from)
+	if err != nil {
+		return nil, err
+	}
+
+	toInput := pgtype.Timestamptz{}
+	err = toInput.Scan(to)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := t.q.SelectMemberReservationsWithSpotsBetween(ctx, SelectMemberReservationsWithSpotsBetweenParams{
+		GuildID:         guild.ID,
+		AuthorDiscordID: member.ID,
+		FromAt:          fromInput,
+		ToAt:            toInput,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	reservations := make([]*reservation.ReservationWithSpot, len(res))
+	for i, row := range res {
+		reservations[i] = &reservation.ReservationWithSpot{
+			Spot: mapSpot(row.WebSpot),
+			Reservation: reservation.Reservation{
+				ID:              row.WebReservation.ID,
+				Author:          row.WebReservation.Author,
+				AuthorDiscordID: row.WebReservation.AuthorDiscordID,
+				CreatedAt:       row.WebReservation.CreatedAt.Time,
+				StartAt:         row.WebReservation.StartAt.Time,
+				EndAt:           row.WebReservation.EndAt.Time,
+				SpotID:          row.WebReservation.SpotID,
+				GuildID:         row.WebReservation.GuildID,
+				HeldUntil:       row.WebReservation.HeldUntil.Time,
+				Priority:        int(row.WebReservation.Priority),
+			},
+		}
+	}
+
+	return reservations, nil
 }
 
-func (t *ReservationRepository) SelectOverlappingReservations(ctx context.Context, spot string, startAt time.Time, endAt time.Time, guildId string) ([]*reservation.Reservation, error) {
+func (t *ReservationRepository) SelectOverlappingReservations(ctx context.Context, spotId int64, startAt time.Time, endAt time.Time, guildId string) ([]*reservation.Reservation, error) {
 	res, err := t.q.SelectOverlappingReservations(ctx, SelectOverlappingReservationsParams{
 		StartAt: startAt,
 		EndAt:   endAt,
-		Respawn: spot,
+		SpotID:  spotId,
 		GuildID: guildId,
 	})
 	if err != nil {
@@ -125,33 +178,95 @@ func (t *ReservationRepository) SelectOverlappingReservations(ctx context.Contex
 	reservations := make([]*reservation.Reservation, len(res))
 	for i, row := range res {
 		reservations[i] = &reservation.Reservation{
-			ID:              row.ID,
-			Author:          row.Author,
-			AuthorDiscordID: row.AuthorDiscordID,
-			StartAt:         row.StartAt.Time,
-			EndAt:           row.EndAt.Time,
-			GuildID:         row.GuildID,
+			ID:                row.ID,
+			Author:            row.Author,
+			AuthorDiscordID:   row.AuthorDiscordID,
+			StartAt:           row.StartAt.Time,
+			EndAt:             row.EndAt.Time,
+			GuildID:           row.GuildID,
+			HeldUntil:         row.HeldUntil.Time,
+			Priority:          int(row.Priority),
+			CheckedInAt:       row.CheckedInAt.Time,
+			CheckInRemindedAt: row.CheckInRemindedAt.Time,
 		}
 	}
 
-	return reservations, nil
+	return reservations, t.selectParties(ctx, reservations)
 }
 
-func (t *ReservationRepository) CreateAndDeleteConflicting(ctx context.Context, member *discord.Member, guild *discord.Guild, conflicts []*reservation.Reservation, spotId int64, startAt time.Time, endAt time.Time) ([]*reservation.ClippedOrRemovedReservation, error) {
-	modifiedConflicts := make([]*reservation.ClippedOrRemovedReservation, len(conflicts))
+func (t *ReservationRepository) CreateAndDeleteConflicting(ctx context.Context, member *discord.Member, guild *discord.Guild, party []*discord.Member, conflicts []*reservation.Reservation, spotId int64, startAt time.Time, endAt time.Time, priority int) ([]*reservation.ClippedOrRemovedReservation, error) {
 	tx, err := t.db.Begin(ctx)
 	if err != nil {
-		return modifiedConflicts, err
+		return []*reservation.ClippedOrRemovedReservation{}, err
 	}
 	defer errors.ExecuteAndIgnoreErrorF(tx.Rollback, ctx)
 	qtx := t.q.WithTx(tx)
 
+	modifiedConflicts, err := t.deleteConflicting(ctx, qtx, member, conflicts, spotId, startAt, endAt)
+	if err != nil {
+		return modifiedConflicts, err
+	}
+
+	startAtInput := pgtype.Timestamptz{}
+	err = startAtInput.Scan(startAt)
+	if err != nil {
+		return modifiedConflicts, err
+	}
+
+	endAtInput := pgtype.Timestamptz{}
+	err = endAtInput.Scan(endAt)
+	if err != nil {
+		return modifiedConflicts, err
+	}
+
+	// Expired holds no longer take the spot up, but stay in the table until they get released
+	err = qtx.DeleteOverlappingExpiredReservationHolds(ctx, DeleteOverlappingExpiredReservationHoldsParams{
+		SpotID:  spotId,
+		GuildID: guild.ID,
+		StartAt: startAtInput,
+		EndAt:   endAtInput,
+	})
+	if err != nil {
+		return modifiedConflicts, err
+	}
+
+	created, err := qtx.CreateReservation(ctx, CreateReservationParams{
+		Author:          member.DisplayName(),
+		AuthorDiscordID: member.ID,
+		StartAt:         startAtInput,
+		EndAt:           endAtInput,
+		SpotID:          spotId,
+		GuildID:         guild.ID,
+		Priority:        int32(priority),
+	})
+	if err != nil {
+		return modifiedConflicts, err
+	}
+
+	for _, partyMember := range party {
+		err = qtx.InsertReservationPartyMember(ctx, InsertReservationPartyMemberParams{
+			ReservationID:   created.ID,
+			MemberDiscordID: partyMember.ID,
+			MemberName:      partyMember.DisplayName(),
+		})
+		if err != nil {
+			return modifiedConflicts, err
+		}
+	}
+
+	return modifiedConflicts, tx.Commit(ctx)
+}
+
+// Removes conflicting reservations, and recreates parts of other members reservations
+// that do not overlap with the given time range.
+func (t *ReservationRepository) deleteConflicting(ctx context.Context, qtx *Queries, member *discord.Member, conflicts []*reservation.Reservation, spotId int64, startAt time.Time, endAt time.Time) ([]*reservation.ClippedOrRemovedReservation, error) {
+	modifiedConflicts := make([]*reservation.ClippedOrRemovedReservation, len(conflicts))
 	for index, conflictingReservation := range conflicts {
 		modifiedConflicts[index] = &reservation.ClippedOrRemovedReservation{
 			Original: conflictingReservation,
 			New:      []*reservation.Reservation{},
 		}
-		err = qtx.DeleteReservation(ctx, conflictingReservation.ID)
+		err := qtx.DeleteReservation(ctx, conflictingReservation.ID)
 		if err != nil {
 			return modifiedConflicts, err
 		}
@@ -172,6 +287,8 @@ func (t *ReservationRepository) CreateAndDeleteConflicting(ctx context.Context,
 						EndAt:           leftover.EndAt.Time,
 						SpotID:          leftover.SpotID,
 						GuildID:         leftover.GuildID,
+						HeldUntil:       leftover.HeldUntil.Time,
+						Priority:        int(leftover.Priority),
 						AuthorDiscordID: leftover.AuthorDiscordID,
 					},
 				)
@@ -179,6 +296,24 @@ func (t *ReservationRepository) CreateAndDeleteConflicting(ctx context.Context,
 		}
 	}
 
+	return modifiedConflicts, nil
+}
+
+// Moves member reservation to a new time range, removing conflicting reservations
+// within the same transaction.
+func (t *ReservationRepository) UpdateAndDeleteConflicting(ctx context.Context, member *discord.Member, guild *discord.Guild, reservationId int64, conflicts []*reservation.Reservation, spotId int64, startAt time.Time, endAt time.Time) ([]*reservation.ClippedOrRemovedReservation, error) {
+	tx, err := t.db.Begin(ctx)
+	if err != nil {
+		return []*reservation.ClippedOrRemovedReservation{}, err
+	}
+	defer errors.ExecuteAndIgnoreErrorF(tx.Rollback, ctx)
+	qtx := t.q.WithTx(tx)
+
+	modifiedConflicts, err := t.deleteConflicting(ctx, qtx, member, conflicts, spotId, startAt, endAt)
+	if err != nil {
+		return modifiedConflicts, err
+	}
+
 	startAtInput := pgtype.Timestamptz{}
 	err = startAtInput.Scan(startAt)
 	if err != nil {
@@ -191,25 +326,32 @@ func (t *ReservationRepository) CreateAndDeleteConflicting(ctx context.Context,
 		return modifiedConflicts, err
 	}
 
-	var author string
-	if len(member.Nick) > 0 {
-		author = member.Nick
-	} else {
-		author = member.Username
+	// Expired holds no longer take the spot up, but stay in the table until they get released
+	err = qtx.DeleteOverlappingExpiredReservationHolds(ctx, DeleteOverlappingExpiredReservationHoldsParams{
+		SpotID:  spotId,
+		GuildID: guild.ID,
+		StartAt: startAtInput,
+		EndAt:   endAtInput,
+	})
+	if err != nil {
+		return modifiedConflicts, err
 	}
 
-	_, err = qtx.CreateReservation(ctx, CreateReservationParams{
-		Author:          author,
-		AuthorDiscordID: member.ID,
+	updated, err := qtx.UpdatePresentMemberReservation(ctx, UpdatePresentMemberReservationParams{
 		StartAt:         startAtInput,
 		EndAt:           endAtInput,
-		SpotID:          spotId,
+		ID:              reservationId,
 		GuildID:         guild.ID,
+		AuthorDiscordID: member.ID,
 	})
 	if err != nil {
 		return modifiedConflicts, err
 	}
 
+	if updated == 0 {
+		return modifiedConflicts, fmt.Errorf("reservation %d does not exist or has already ended", reservationId)
+	}
+
 	return modifiedConflicts, tx.Commit(ctx)
 }
 
@@ -225,10 +367,7 @@ func (t *ReservationRepository) SelectUpcomingMemberReservationsWithSpots(ctx co
 	reservations := make([]*reservation.ReservationWithSpot, len(res))
 	for i, row := range res {
 		reservations[i] = &reservation.ReservationWithSpot{
-			Spot: reservation.Spot{
-				ID:   row.WebSpot.ID,
-				Name: row.WebSpot.Name,
-			},
+			Spot: mapSpot(row.WebSpot),
 			Reservation: reservation.Reservation{
 				ID:              row.WebReservation.ID,
 				Author:          row.WebReservation.Author,
@@ -238,6 +377,8 @@ func (t *ReservationRepository) SelectUpcomingMemberReservationsWithSpots(ctx co
 				EndAt:           row.WebReservation.EndAt.Time,
 				SpotID:          row.WebReservation.SpotID,
 				GuildID:         row.WebReservation.GuildID,
+				HeldUntil:       row.WebReservation.HeldUntil.Time,
+				Priority:        int(row.WebReservation.Priority),
 			},
 		}
 	}
@@ -258,6 +399,228 @@ func (t *ReservationRepository) DeletePresentMemberReservation(ctx context.Conte
 	return nil
 }
 
+// Hands member reservation over to another member, as long as it has not ended yet.
+func (t *ReservationRepository) TransferPresentMemberReservation(ctx context.Context, g *discord.Guild, from *discord.Member, to *discord.Member, reservationId int64, priority int) error {
+	updated, err := t.q.TransferPresentMemberReservation(ctx, TransferPresentMemberReservationParams{
+		NewAuthor:          to.DisplayName(),
+		NewAuthorDiscordID: to.ID,
+		ID:                 reservationId,
+		GuildID:            g.ID,
+		AuthorDiscordID:    from.ID,
+		Priority:           int32(priority),
+	})
+	if err != nil {
+		return err
+	}
+
+	if updated == 0 {
+		return fmt.Errorf("reservation %d does not exist or has already ended", reservationId)
+	}
+
+	return nil
+}
+
+// Marks member presence on one of their reservations, as long as it has not ended yet.
+// Checking in again keeps the original check-in time.
+func (t *ReservationRepository) CheckInPresentMemberReservation(ctx context.Context, g *discord.Guild, m *discord.Member, reservationId int64) error {
+	updated, err := t.q.CheckInPresentMemberReservation(ctx, CheckInPresentMemberReservationParams{
+		ID:              reservationId,
+		GuildID:         g.ID,
+		AuthorDiscordID: m.ID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if updated == 0 {
+		return fmt.Errorf("reservation %d does not exist or has already ended", reservationId)
+	}
+
+	return nil
+}
+
+func (t *ReservationRepository) SelectCheckInPendingReservationsWithSpots(ctx context.Context, guildId string, startedBefore time.Time) ([]*reservation.ReservationWithSpot, error) {
+	startedBeforeInput := pgtype.Timestamptz{}
+	err := startedBeforeInput.Scan(startedBefore)
+	if err != nil {
+		return []*reservation.ReservationWithSpot{}, err
+	}
+
+	res, err := t.q.SelectCheckInPendingReservationsWithSpots(ctx, SelectCheckInPendingReservationsWithSpotsParams{
+		GuildID:       guildId,
+		StartedBefore: startedBeforeInput,
+	})
+	if err != nil {
+		return []*reservation.ReservationWithSpot{}, err
+	}
+
+	reservations := make([]*reservation.ReservationWithSpot, len(res))
+	for i, row := range res {
+		reservations[i] = &reservation.ReservationWithSpot{
+			Spot: mapSpot(row.WebSpot),
+			Reservation: reservation.Reservation{
+				ID:                row.WebReservation.ID,
+				Author:            row.WebReservation.Author,
+				AuthorDiscordID:   row.WebReservation.AuthorDiscordID,
+				CreatedAt:         row.WebReservation.CreatedAt.Time,
+				StartAt:           row.WebReservation.StartAt.Time,
+				EndAt:             row.WebReservation.EndAt.Time,
+				SpotID:            row.WebReservation.SpotID,
+				GuildID:           row.WebReservation.GuildID,
+				CheckInRemindedAt: row.WebReservation.CheckInRemindedAt.Time,
+			},
+		}
+	}
+
+	return reservations, nil
+}
+
+// Marks reservation author as reminded to check in. Returns false if they have already been reminded,
+// or checked in meanwhile.
+func (t *ReservationRepository) MarkCheckInReminded(ctx context.Context, reservationId int64) (bool, error) {
+	updated, err := t.q.MarkReservationCheckInReminded(ctx, reservationId)
+	if err != nil {
+		return false, err
+	}
+
+	return updated > 0, nil
+}
+
+// Flags reservation as a no-show and increments no-show count of its author within the guild.
+// Returns the new count, or 0 if reservation has been checked in or flagged meanwhile.
+func (t *ReservationRepository) MarkNoShow(ctx context.Context, r *reservation.Reservation) (int, error) {
+	tx, err := t.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer errors.ExecuteAndIgnoreErrorF(tx.Rollback, ctx)
+	qtx := t.q.WithTx(tx)
+
+	updated, err := qtx.MarkReservationNoShow(ctx, r.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	if updated == 0 {
+		return 0, nil
+	}
+
+	noShow, err := qtx.IncrementMemberNoShowCount(ctx, IncrementMemberNoShowCountParams{
+		GuildID:  r.GuildID,
+		MemberID: r.AuthorDiscordID,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int(noShow.NoShowCount), tx.Commit(ctx)
+}
+
+// Records a reminder about reservation start or end as sent. Returns false if it already was,
+// so that the reminder is sent at most once per reservation time.
+func (t *ReservationRepository) ClaimReminder(ctx context.Context, reservationId int64, kind reservation.ReminderKind, eventAt time.Time) (bool, error) {
+	eventAtInput := pgtype.Timestamptz{}
+	err := eventAtInput.Scan(eventAt)
+	if err != nil {
+		return false, err
+	}
+
+	inserted, err := t.q.InsertReservationReminder(ctx, InsertReservationReminderParams{
+		ReservationID: reservationId,
+		Kind:          string(kind),
+		EventAt:       eventAtInput,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return inserted > 0, nil
+}
+
+func (t *ReservationRepository) SelectUpcomingMemberPartyReservationsWithSpots(ctx context.Context, guild *discord.Guild, member *discord.Member) ([]*reservation.ReservationWithSpot, error) {
+	res, err := t.q.SelectUpcomingMemberPartyReservationsWithSpots(ctx, SelectUpcomingMemberPartyReservationsWithSpotsParams{
+		GuildID:         guild.ID,
+		MemberDiscordID: member.ID,
+	})
+	if err != nil {
+		return []*reservation.ReservationWithSpot{}, err
+	}
+
+	reservations := make([]*reservation.ReservationWithSpot, len(res))
+	for i, row := range res {
+		reservations[i] = &reservation.ReservationWithSpot{
+			Spot: mapSpot(row.WebSpot),
+			Reservation: reservation.Reservation{
+				ID:              row.WebReservation.ID,
+				Author:          row.WebReservation.Author,
+				AuthorDiscordID: row.WebReservation.AuthorDiscordID,
+				CreatedAt:       row.WebReservation.CreatedAt.Time,
+				StartAt:         row.WebReservation.StartAt.Time,
+				EndAt:           row.WebReservation.EndAt.Time,
+				SpotID:          row.WebReservation.SpotID,
+				GuildID:         row.WebReservation.GuildID,
+				HeldUntil:       row.WebReservation.HeldUntil.Time,
+				Priority:        int(row.WebReservation.Priority),
+			},
+		}
+	}
+
+	return reservations, nil
+}
+
+func (t *ReservationRepository) AddReservationPartyMember(ctx context.Context, reservationId int64, member *discord.Member) error {
+	return t.q.InsertReservationPartyMember(ctx, InsertReservationPartyMemberParams{
+		ReservationID:   reservationId,
+		MemberDiscordID: member.ID,
+		MemberName:      member.DisplayName(),
+	})
+}
+
+func (t *ReservationRepository) RemoveReservationPartyMember(ctx context.Context, reservationId int64, memberDiscordID string) error {
+	removed, err := t.q.DeleteReservationPartyMember(ctx, DeleteReservationPartyMemberParams{
+		ReservationID:   reservationId,
+		MemberDiscordID: memberDiscordID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if removed == 0 {
+		return fmt.Errorf("member is not a part of reservation %d party", reservationId)
+	}
+
+	return nil
+}
+
+// Fills in party members of given reservations.
+func (t *ReservationRepository) selectParties(ctx context.Context, reservations []*reservation.Reservation) error {
+	if len(reservations) == 0 {
+		return nil
+	}
+
+	rows, err := t.q.SelectReservationsPartyMembers(ctx, collections.PoorMansMap(reservations, func(r *reservation.Reservation) int64 {
+		return r.ID
+	}))
+	if err != nil {
+		return err
+	}
+
+	for _, r := range reservations {
+		r.Party = []*reservation.PartyMember{}
+		for _, row := range rows {
+			if row.ReservationID == r.ID {
+				r.Party = append(r.Party, &reservation.PartyMember{
+					ReservationID:   row.ReservationID,
+					MemberDiscordID: row.MemberDiscordID,
+					Name:            row.MemberName,
+				})
+			}
+		}
+	}
+
+	return nil
+}
+
 func (t *ReservationRepository) SelectAllReservationsWithSpotsBySpotNames(ctx context.Context, guildId string, spotNames []string) ([]*reservation.ReservationWithSpot, error) {
 	res, err := t.q.SelectAllReservationsWithSpotsBySpotNames(ctx, SelectAllReservationsWithSpotsBySpotNamesParams{
 		GuildID:   guildId,
@@ -270,10 +633,7 @@ func (t *ReservationRepository) SelectAllReservationsWithSpotsBySpotNames(ctx co
 	reservations := make([]*reservation.ReservationWithSpot, len(res))
 	for i, row := range res {
 		reservations[i] = &reservation.ReservationWithSpot{
-			Spot: reservation.Spot{
-				ID:   row.WebSpot.ID,
-				Name: row.WebSpot.Name,
-			},
+			Spot: mapSpot(row.WebSpot),
 			Reservation: reservation.Reservation{
 				ID:              row.WebReservation.ID,
 				Author:          row.WebReservation.Author,
@@ -283,11 +643,15 @@ func (t *ReservationRepository) SelectAllReservationsWithSpotsBySpotNames(ctx co
 				EndAt:           row.WebReservation.EndAt.Time,
 				SpotID:          row.WebReservation.SpotID,
 				GuildID:         row.WebReservation.GuildID,
+				HeldUntil:       row.WebReservation.HeldUntil.Time,
+				Priority:        int(row.WebReservation.Priority),
 			},
 		}
 	}
 
-	return reservations, nil
+	return reservations, t.selectParties(ctx, collections.PoorMansMap(reservations, func(r *reservation.ReservationWithSpot) *reservation.Reservation {
+		return &r.Reservation
+	}))
 }
 
 // createOverbookedLeftovers creates up to two reservations from overbooked reservation leftovers.
@@ -319,6 +683,8 @@ func (t *ReservationRepository) createOverbookedLeftovers(
 			EndAt:           endAtInput,
 			SpotID:          spotId,
 			GuildID:         overbookedReservation.GuildID,
+			HeldUntil:       heldUntilInput(overbookedReservation.HeldUntil),
+			Priority:        int32(overbookedReservation.Priority),
 		})
 		if err != nil {
 			return leftoverReservations, err
@@ -348,6 +714,8 @@ func (t *ReservationRepository) createOverbookedLeftovers(
 			EndAt:           endAtInput,
 			SpotID:          spotId,
 			GuildID:         overbookedReservation.GuildID,
+			HeldUntil:       heldUntilInput(overbookedReservation.HeldUntil),
+			Priority:        int32(overbookedReservation.Priority),
 		})
 		if err != nil {
 			return leftoverReservations, err
@@ -356,5 +724,34 @@ func (t *ReservationRepository) createOverbookedLeftovers(
 		leftoverReservations = append(leftoverReservations, newReservation)
 	}
 
+	// Leftovers are still hunted by the same party
+	for _, leftover := range leftoverReservations {
+		for _, partyMember := range overbookedReservation.Party {
+			err := qtx.InsertReservationPartyMember(ctx, InsertReservationPartyMemberParams{
+				ReservationID:   leftover.ID,
+				MemberDiscordID: partyMember.MemberDiscordID,
+				MemberName:      partyMember.Name,
+			})
+			if err != nil {
+				return leftoverReservations, err
+			}
+		}
+	}
+
 	return leftoverReservations, nil
 }
+
+func mapSpot(spot WebSpot) reservation.Spot {
+	return reservation.Spot{
+		ID:       spot.ID,
+		Name:     spot.Name,
+		ParentID: spot.ParentID.Int64,
+		Details: dto.Details{
+			MinLevel:  int(spot.MinLevel.Int32),
+			MaxLevel:  int(spot.MaxLevel.Int32),
+			Vocations: spot.Vocations,
+			Area:      spot.Area.String,
+			Kind:      dto.Kind(spot.Kind.String),
+		},
+	}
+}
@@ -11,8 +11,207 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-const createReservation = `-- name: CreateReservation :one
-INSERT INTO web_reservation (
+const answerOverbookApproval = `-- name: AnswerOverbookApproval :execrows
+UPDATE web_overbook_approval
+SET accepted = $1
+FROM web_overbook_request
+WHERE web_overbook_approval.request_id = web_overbook_request.id
+  AND web_overbook_request.id = $2
+  AND web_overbook_request.guild_id = $3
+  AND web_overbook_request.expires_at > now()
+  AND web_overbook_approval.member_discord_id = $4
+  AND web_overbook_approval.accepted IS NULL
+`
+
+type AnswerOverbookApprovalParams struct {
+	Accepted        pgtype.Bool
+	RequestID       int64
+	GuildID         string
+	MemberDiscordID string
+}
+
+func (q *Queries) AnswerOverbookApproval(ctx context.Context, arg AnswerOverbookApprovalParams) (int64, error) {
+	result, err := q.db.Exec(ctx, answerOverbookApproval,
+		arg.Accepted,
+		arg.RequestID,
+		arg.GuildID,
+		arg.MemberDiscordID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const checkInPresentMemberReservation = `-- name: CheckInPresentMemberReservation :execrows
+UPDATE web_reservation
+SET checked_in_at = COALESCE(checked_in_at, now())
+WHERE web_reservation.id = $1
+  AND web_reservation.guild_id = $2
+  AND web_reservation.author_discord_id = $3
+  AND web_reservation.end_at > now()
+`
+
+type CheckInPresentMemberReservationParams struct {
+	ID              int64
+	GuildID         string
+	AuthorDiscordID string
+}
+
+func (q *Queries) CheckInPresentMemberReservation(ctx context.Context, arg CheckInPresentMemberReservationParams) (int64, error) {
+	result, err := q.db.Exec(ctx, checkInPresentMemberReservation, arg.ID, arg.GuildID, arg.AuthorDiscordID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const confirmPresentMemberReservationHold = `-- name: ConfirmPresentMemberReservationHold :execrows
+UPDATE web_reservation
+SET held_until = NULL
+WHERE web_reservation.id = $1
+  AND web_reservation.guild_id = $2
+  AND web_reservation.author_discord_id = $3
+  AND web_reservation.held_until > now()
+`
+
+type ConfirmPresentMemberReservationHoldParams struct {
+	ID              int64
+	GuildID         string
+	AuthorDiscordID string
+}
+
+func (q *Queries) ConfirmPresentMemberReservationHold(ctx context.Context, arg ConfirmPresentMemberReservationHoldParams) (int64, error) {
+	result, err := q.db.Exec(ctx, confirmPresentMemberReservationHold, arg.ID, arg.GuildID, arg.AuthorDiscordID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const createLotteryApplication = `-- name: CreateLotteryApplication :one
+INSERT INTO web_lottery_application (
+    author,
+    author_discord_id,
+    start_at,
+    end_at,
+    spot_id,
+    guild_id,
+    draw_at,
+    created_at
+  )
+VALUES ($1, $2, $3, $4, $5, $6, $7, now())
+RETURNING id, author, author_discord_id, guild_id, spot_id, start_at, end_at, draw_at, won, created_at
+`
+
+type CreateLotteryApplicationParams struct {
+	Author          string
+	AuthorDiscordID string
+	StartAt         pgtype.Timestamptz
+	EndAt           pgtype.Timestamptz
+	SpotID          int64
+	GuildID         string
+	DrawAt          pgtype.Timestamptz
+}
+
+func (q *Queries) CreateLotteryApplication(ctx context.Context, arg CreateLotteryApplicationParams) (WebLotteryApplication, error) {
+	row := q.db.QueryRow(ctx, createLotteryApplication,
+		arg.Author,
+		arg.AuthorDiscordID,
+		arg.StartAt,
+		arg.EndAt,
+		arg.SpotID,
+		arg.GuildID,
+		arg.DrawAt,
+	)
+	var i WebLotteryApplication
+	err := row.Scan(
+		&i.ID,
+		&i.Author,
+		&i.AuthorDiscordID,
+		&i.GuildID,
+		&i.SpotID,
+		&i.StartAt,
+		&i.EndAt,
+		&i.DrawAt,
+		&i.Won,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createOverbookApproval = `-- name: CreateOverbookApproval :exec
+INSERT INTO web_overbook_approval (request_id, reservation_id, member_discord_id)
+VALUES ($1, $2, $3)
+`
+
+type CreateOverbookApprovalParams struct {
+	RequestID       int64
+	ReservationID   int64
+	MemberDiscordID string
+}
+
+func (q *Queries) CreateOverbookApproval(ctx context.Context, arg CreateOverbookApprovalParams) error {
+	_, err := q.db.Exec(ctx, createOverbookApproval, arg.RequestID, arg.ReservationID, arg.MemberDiscordID)
+	return err
+}
+
+const createOverbookRequest = `-- name: CreateOverbookRequest :one
+INSERT INTO web_overbook_request (
+    author,
+    author_discord_id,
+    start_at,
+    end_at,
+    spot_id,
+    guild_id,
+    party_member_discord_ids,
+    expires_at,
+    created_at
+  )
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, now())
+RETURNING id, author, author_discord_id, guild_id, spot_id, start_at, end_at, party_member_discord_ids, expires_at, created_at
+`
+
+type CreateOverbookRequestParams struct {
+	Author                string
+	AuthorDiscordID       string
+	StartAt               pgtype.Timestamptz
+	EndAt                 pgtype.Timestamptz
+	SpotID                int64
+	GuildID               string
+	PartyMemberDiscordIds []string
+	ExpiresAt             pgtype.Timestamptz
+}
+
+func (q *Queries) CreateOverbookRequest(ctx context.Context, arg CreateOverbookRequestParams) (WebOverbookRequest, error) {
+	row := q.db.QueryRow(ctx, createOverbookRequest,
+		arg.Author,
+		arg.AuthorDiscordID,
+		arg.StartAt,
+		arg.EndAt,
+		arg.SpotID,
+		arg.GuildID,
+		arg.PartyMemberDiscordIds,
+		arg.ExpiresAt,
+	)
+	var i WebOverbookRequest
+	err := row.Scan(
+		&i.ID,
+		&i.Author,
+		&i.AuthorDiscordID,
+		&i.GuildID,
+		&i.SpotID,
+		&i.StartAt,
+		&i.EndAt,
+		&i.PartyMemberDiscordIds,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createQueueEntry = `-- name: CreateQueueEntry :one
+INSERT INTO web_reservation_queue (
     author,
     author_discord_id,
     start_at,
@@ -22,7 +221,55 @@ INSERT INTO web_reservation (
     guild_id
   )
 VALUES ($1, $2, $3, $4, $5, now(), $6)
-RETURNING id, author, created_at, start_at, end_at, spot_id, guild_id, author_discord_id
+RETURNING id, author, author_discord_id, guild_id, spot_id, start_at, end_at, created_at
+`
+
+type CreateQueueEntryParams struct {
+	Author          string
+	AuthorDiscordID string
+	StartAt         pgtype.Timestamptz
+	EndAt           pgtype.Timestamptz
+	SpotID          int64
+	GuildID         string
+}
+
+func (q *Queries) CreateQueueEntry(ctx context.Context, arg CreateQueueEntryParams) (WebReservationQueue, error) {
+	row := q.db.QueryRow(ctx, createQueueEntry,
+		arg.Author,
+		arg.AuthorDiscordID,
+		arg.StartAt,
+		arg.EndAt,
+		arg.SpotID,
+		arg.GuildID,
+	)
+	var i WebReservationQueue
+	err := row.Scan(
+		&i.ID,
+		&i.Author,
+		&i.AuthorDiscordID,
+		&i.GuildID,
+		&i.SpotID,
+		&i.StartAt,
+		&i.EndAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createReservation = `-- name: CreateReservation :one
+INSERT INTO web_reservation (
+    author,
+    author_discord_id,
+    start_at,
+    end_at,
+    spot_id,
+    created_at,
+    guild_id,
+    held_until,
+    priority
+  )
+VALUES ($1, $2, $3, $4, $5, now(), $6, $7, $8)
+RETURNING id, author, created_at, start_at, end_at, spot_id, guild_id, author_discord_id, series_id, checked_in_at, check_in_reminded_at, no_show_at, held_until, priority
 `
 
 type CreateReservationParams struct {
@@ -32,6 +279,8 @@ type CreateReservationParams struct {
 	EndAt           pgtype.Timestamptz
 	SpotID          int64
 	GuildID         string
+	HeldUntil       pgtype.Timestamptz
+	Priority        int32
 }
 
 func (q *Queries) CreateReservation(ctx context.Context, arg CreateReservationParams) (WebReservation, error) {
@@ -42,6 +291,8 @@ func (q *Queries) CreateReservation(ctx context.Context, arg CreateReservationPa
 		arg.EndAt,
 		arg.SpotID,
 		arg.GuildID,
+		arg.HeldUntil,
+		arg.Priority,
 	)
 	var i WebReservation
 	err := row.Scan(
@@ -53,72 +304,847 @@ func (q *Queries) CreateReservation(ctx context.Context, arg CreateReservationPa
 		&i.SpotID,
 		&i.GuildID,
 		&i.AuthorDiscordID,
+		&i.SeriesID,
+		&i.CheckedInAt,
+		&i.CheckInRemindedAt,
+		&i.NoShowAt,
+		&i.HeldUntil,
+		&i.Priority,
 	)
 	return i, err
 }
 
-const deletePresentMemberReservation = `-- name: DeletePresentMemberReservation :exec
-DELETE FROM web_reservation
-where web_reservation.guild_id = $1
-  AND web_reservation.author_discord_id = $2
-  AND web_reservation.id = $3
-  AND web_reservation.end_at > now()
+const createReservationSeries = `-- name: CreateReservationSeries :one
+INSERT INTO web_reservation_series (
+    author,
+    author_discord_id,
+    guild_id,
+    spot_id,
+    weekdays,
+    start_time,
+    end_time,
+    created_at,
+    materialized_until
+  )
+VALUES ($1, $2, $3, $4, $5, $6, $7, now(), now())
+RETURNING id, author, author_discord_id, guild_id, spot_id, weekdays, start_time, end_time, created_at, materialized_until
 `
 
-type DeletePresentMemberReservationParams struct {
+type CreateReservationSeriesParams struct {
+	Author          string
+	AuthorDiscordID string
 	GuildID         string
+	SpotID          int64
+	Weekdays        int32
+	StartTime       pgtype.Time
+	EndTime         pgtype.Time
+}
+
+func (q *Queries) CreateReservationSeries(ctx context.Context, arg CreateReservationSeriesParams) (WebReservationSeries, error) {
+	row := q.db.QueryRow(ctx, createReservationSeries,
+		arg.Author,
+		arg.AuthorDiscordID,
+		arg.GuildID,
+		arg.SpotID,
+		arg.Weekdays,
+		arg.StartTime,
+		arg.EndTime,
+	)
+	var i WebReservationSeries
+	err := row.Scan(
+		&i.ID,
+		&i.Author,
+		&i.AuthorDiscordID,
+		&i.GuildID,
+		&i.SpotID,
+		&i.Weekdays,
+		&i.StartTime,
+		&i.EndTime,
+		&i.CreatedAt,
+		&i.MaterializedUntil,
+	)
+	return i, err
+}
+
+const createSeriesReservation = `-- name: CreateSeriesReservation :one
+INSERT INTO web_reservation (
+    author,
+    author_discord_id,
+    start_at,
+    end_at,
+    spot_id,
+    created_at,
+    guild_id,
+    series_id,
+    priority
+  )
+VALUES ($1, $2, $3, $4, $5, now(), $6, $7, $8)
+RETURNING id, author, created_at, start_at, end_at, spot_id, guild_id, author_discord_id, series_id, checked_in_at, check_in_reminded_at, no_show_at, held_until, priority
+`
+
+type CreateSeriesReservationParams struct {
+	Author          string
 	AuthorDiscordID string
-	ID              int64
+	StartAt         pgtype.Timestamptz
+	EndAt           pgtype.Timestamptz
+	SpotID          int64
+	GuildID         string
+	SeriesID        pgtype.Int8
+	Priority        int32
 }
 
-func (q *Queries) DeletePresentMemberReservation(ctx context.Context, arg DeletePresentMemberReservationParams) error {
-	_, err := q.db.Exec(ctx, deletePresentMemberReservation, arg.GuildID, arg.AuthorDiscordID, arg.ID)
+func (q *Queries) CreateSeriesReservation(ctx context.Context, arg CreateSeriesReservationParams) (WebReservation, error) {
+	row := q.db.QueryRow(ctx, createSeriesReservation,
+		arg.Author,
+		arg.AuthorDiscordID,
+		arg.StartAt,
+		arg.EndAt,
+		arg.SpotID,
+		arg.GuildID,
+		arg.SeriesID,
+		arg.Priority,
+	)
+	var i WebReservation
+	err := row.Scan(
+		&i.ID,
+		&i.Author,
+		&i.CreatedAt,
+		&i.StartAt,
+		&i.EndAt,
+		&i.SpotID,
+		&i.GuildID,
+		&i.AuthorDiscordID,
+		&i.SeriesID,
+		&i.CheckedInAt,
+		&i.CheckInRemindedAt,
+		&i.NoShowAt,
+		&i.HeldUntil,
+		&i.Priority,
+	)
+	return i, err
+}
+
+const deleteExpiredQueueEntries = `-- name: DeleteExpiredQueueEntries :exec
+DELETE FROM web_reservation_queue
+WHERE web_reservation_queue.guild_id = $1
+  AND web_reservation_queue.end_at <= now()
+`
+
+func (q *Queries) DeleteExpiredQueueEntries(ctx context.Context, guildID string) error {
+	_, err := q.db.Exec(ctx, deleteExpiredQueueEntries, guildID)
 	return err
 }
 
-const deleteReservation = `-- name: DeleteReservation :exec
+const deleteExpiredReservationHold = `-- name: DeleteExpiredReservationHold :execrows
 DELETE FROM web_reservation
 WHERE web_reservation.id = $1
+  AND web_reservation.held_until <= now()
 `
 
-func (q *Queries) DeleteReservation(ctx context.Context, id int64) error {
-	_, err := q.db.Exec(ctx, deleteReservation, id)
+func (q *Queries) DeleteExpiredReservationHold(ctx context.Context, id int64) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteExpiredReservationHold, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteLotteryApplicationsDrawnBefore = `-- name: DeleteLotteryApplicationsDrawnBefore :exec
+DELETE FROM web_lottery_application
+WHERE web_lottery_application.guild_id = $1
+  AND web_lottery_application.won IS NOT NULL
+  AND web_lottery_application.draw_at <= $2
+`
+
+type DeleteLotteryApplicationsDrawnBeforeParams struct {
+	GuildID string
+	Before  pgtype.Timestamptz
+}
+
+func (q *Queries) DeleteLotteryApplicationsDrawnBefore(ctx context.Context, arg DeleteLotteryApplicationsDrawnBeforeParams) error {
+	_, err := q.db.Exec(ctx, deleteLotteryApplicationsDrawnBefore, arg.GuildID, arg.Before)
+	return err
+}
+
+const deleteOverbookRequest = `-- name: DeleteOverbookRequest :execrows
+DELETE FROM web_overbook_request
+WHERE web_overbook_request.id = $1
+`
+
+func (q *Queries) DeleteOverbookRequest(ctx context.Context, id int64) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteOverbookRequest, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteOverlappingExpiredReservationHolds = `-- name: DeleteOverlappingExpiredReservationHolds :exec
+DELETE FROM web_reservation
+WHERE web_reservation.spot_id = $1
+  AND web_reservation.guild_id = $2
+  AND web_reservation.held_until <= now()
+  AND tstzrange(web_reservation.start_at, web_reservation.end_at) && tstzrange($3::timestamptz, $4::timestamptz)
+`
+
+type DeleteOverlappingExpiredReservationHoldsParams struct {
+	SpotID  int64
+	GuildID string
+	StartAt pgtype.Timestamptz
+	EndAt   pgtype.Timestamptz
+}
+
+func (q *Queries) DeleteOverlappingExpiredReservationHolds(ctx context.Context, arg DeleteOverlappingExpiredReservationHoldsParams) error {
+	_, err := q.db.Exec(ctx, deleteOverlappingExpiredReservationHolds,
+		arg.SpotID,
+		arg.GuildID,
+		arg.StartAt,
+		arg.EndAt,
+	)
 	return err
 }
 
-const selectAllReservationsWithSpotsBySpotNames = `-- name: SelectAllReservationsWithSpotsBySpotNames :many
-select web_spot.id, web_spot.name, web_spot.created_at,
-       web_reservation.id, web_reservation.author, web_reservation.created_at, web_reservation.start_at, web_reservation.end_at, web_reservation.spot_id, web_reservation.guild_id, web_reservation.author_discord_id
+const deletePresentMemberReservation = `-- name: DeletePresentMemberReservation :exec
+DELETE FROM web_reservation
+where web_reservation.guild_id = $1
+  AND web_reservation.author_discord_id = $2
+  AND web_reservation.id = $3
+  AND web_reservation.end_at > now()
+`
+
+type DeletePresentMemberReservationParams struct {
+	GuildID         string
+	AuthorDiscordID string
+	ID              int64
+}
+
+func (q *Queries) DeletePresentMemberReservation(ctx context.Context, arg DeletePresentMemberReservationParams) error {
+	_, err := q.db.Exec(ctx, deletePresentMemberReservation, arg.GuildID, arg.AuthorDiscordID, arg.ID)
+	return err
+}
+
+const deleteQueueEntry = `-- name: DeleteQueueEntry :exec
+DELETE FROM web_reservation_queue
+WHERE web_reservation_queue.id = $1
+`
+
+func (q *Queries) DeleteQueueEntry(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, deleteQueueEntry, id)
+	return err
+}
+
+const deleteReservation = `-- name: DeleteReservation :exec
+DELETE FROM web_reservation
+WHERE web_reservation.id = $1
+`
+
+func (q *Queries) DeleteReservation(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, deleteReservation, id)
+	return err
+}
+
+const deleteReservationPartyMember = `-- name: DeleteReservationPartyMember :execrows
+DELETE FROM web_reservation_party_member
+WHERE reservation_id = $1
+  AND member_discord_id = $2
+`
+
+type DeleteReservationPartyMemberParams struct {
+	ReservationID   int64
+	MemberDiscordID string
+}
+
+func (q *Queries) DeleteReservationPartyMember(ctx context.Context, arg DeleteReservationPartyMemberParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteReservationPartyMember, arg.ReservationID, arg.MemberDiscordID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteReservationSeries = `-- name: DeleteReservationSeries :exec
+DELETE FROM web_reservation_series
+WHERE web_reservation_series.id = $1
+`
+
+func (q *Queries) DeleteReservationSeries(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, deleteReservationSeries, id)
+	return err
+}
+
+const deleteUpcomingSeriesReservations = `-- name: DeleteUpcomingSeriesReservations :exec
+DELETE FROM web_reservation
+WHERE web_reservation.series_id = $1
+  AND web_reservation.start_at > now()
+`
+
+func (q *Queries) DeleteUpcomingSeriesReservations(ctx context.Context, seriesID pgtype.Int8) error {
+	_, err := q.db.Exec(ctx, deleteUpcomingSeriesReservations, seriesID)
+	return err
+}
+
+const incrementMemberNoShowCount = `-- name: IncrementMemberNoShowCount :one
+INSERT INTO web_member_no_show (guild_id, member_id, no_show_count, updated_at)
+VALUES ($1, $2, 1, now())
+ON CONFLICT (guild_id, member_id) DO UPDATE
+SET no_show_count = web_member_no_show.no_show_count + 1,
+  updated_at = EXCLUDED.updated_at
+RETURNING guild_id, member_id, no_show_count, updated_at
+`
+
+type IncrementMemberNoShowCountParams struct {
+	GuildID  string
+	MemberID string
+}
+
+func (q *Queries) IncrementMemberNoShowCount(ctx context.Context, arg IncrementMemberNoShowCountParams) (WebMemberNoShow, error) {
+	row := q.db.QueryRow(ctx, incrementMemberNoShowCount, arg.GuildID, arg.MemberID)
+	var i WebMemberNoShow
+	err := row.Scan(
+		&i.GuildID,
+		&i.MemberID,
+		&i.NoShowCount,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const insertReservationPartyMember = `-- name: InsertReservationPartyMember :exec
+INSERT INTO web_reservation_party_member (
+    reservation_id,
+    member_discord_id,
+    member_name,
+    created_at
+  )
+VALUES ($1, $2, $3, now()) ON CONFLICT (reservation_id, member_discord_id) DO NOTHING
+`
+
+type InsertReservationPartyMemberParams struct {
+	ReservationID   int64
+	MemberDiscordID string
+	MemberName      string
+}
+
+func (q *Queries) InsertReservationPartyMember(ctx context.Context, arg InsertReservationPartyMemberParams) error {
+	_, err := q.db.Exec(ctx, insertReservationPartyMember, arg.ReservationID, arg.MemberDiscordID, arg.MemberName)
+	return err
+}
+
+const insertReservationReminder = `-- name: InsertReservationReminder :execrows
+INSERT INTO web_reservation_reminder (reservation_id, kind, event_at, sent_at)
+VALUES ($1, $2, $3, now()) ON CONFLICT (reservation_id, kind, event_at) DO NOTHING
+`
+
+type InsertReservationReminderParams struct {
+	ReservationID int64
+	Kind          string
+	EventAt       pgtype.Timestamptz
+}
+
+func (q *Queries) InsertReservationReminder(ctx context.Context, arg InsertReservationReminderParams) (int64, error) {
+	result, err := q.db.Exec(ctx, insertReservationReminder, arg.ReservationID, arg.Kind, arg.EventAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const markReservationCheckInReminded = `-- name: MarkReservationCheckInReminded :execrows
+UPDATE web_reservation
+SET check_in_reminded_at = now()
+WHERE web_reservation.id = $1
+  AND web_reservation.checked_in_at IS NULL
+  AND web_reservation.check_in_reminded_at IS NULL
+`
+
+func (q *Queries) MarkReservationCheckInReminded(ctx context.Context, id int64) (int64, error) {
+	result, err := q.db.Exec(ctx, markReservationCheckInReminded, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const markReservationNoShow = `-- name: MarkReservationNoShow :execrows
+UPDATE web_reservation
+SET no_show_at = now()
+WHERE web_reservation.id = $1
+  AND web_reservation.checked_in_at IS NULL
+  AND web_reservation.no_show_at IS NULL
+`
+
+func (q *Queries) MarkReservationNoShow(ctx context.Context, id int64) (int64, error) {
+	result, err := q.db.Exec(ctx, markReservationNoShow, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const selectAllReservationsWithSpotsBySpotNames = `-- name: SelectAllReservationsWithSpotsBySpotNames :many
+select web_spot.id, web_spot.name, web_spot.created_at, web_spot.archived_at, web_spot.owner_guild_id, web_spot.parent_id, web_spot.min_level, web_spot.max_level, web_spot.vocations, web_spot.area, web_spot.kind, web_spot.aliases,
+       web_reservation.id, web_reservation.author, web_reservation.created_at, web_reservation.start_at, web_reservation.end_at, web_reservation.spot_id, web_reservation.guild_id, web_reservation.author_discord_id, web_reservation.series_id, web_reservation.checked_in_at, web_reservation.check_in_reminded_at, web_reservation.no_show_at, web_reservation.held_until, web_reservation.priority
+from web_reservation
+         inner join web_spot on web_reservation.spot_id = web_spot.id
+where end_at >= now()
+  AND guild_id = $1
+  AND web_spot.name = ANY($2::text[])
+`
+
+type SelectAllReservationsWithSpotsBySpotNamesParams struct {
+	GuildID   string
+	SpotNames []string
+}
+
+type SelectAllReservationsWithSpotsBySpotNamesRow struct {
+	WebSpot        WebSpot
+	WebReservation WebReservation
+}
+
+func (q *Queries) SelectAllReservationsWithSpotsBySpotNames(ctx context.Context, arg SelectAllReservationsWithSpotsBySpotNamesParams) ([]SelectAllReservationsWithSpotsBySpotNamesRow, error) {
+	rows, err := q.db.Query(ctx, selectAllReservationsWithSpotsBySpotNames, arg.GuildID, arg.SpotNames)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SelectAllReservationsWithSpotsBySpotNamesRow
+	for rows.Next() {
+		var i SelectAllReservationsWithSpotsBySpotNamesRow
+		if err := rows.Scan(
+			&i.WebSpot.ID,
+			&i.WebSpot.Name,
+			&i.WebSpot.CreatedAt,
+			&i.WebSpot.ArchivedAt,
+			&i.WebSpot.OwnerGuildID,
+			&i.WebSpot.ParentID,
+			&i.WebSpot.MinLevel,
+			&i.WebSpot.MaxLevel,
+			&i.WebSpot.Vocations,
+			&i.WebSpot.Area,
+			&i.WebSpot.Kind,
+			&i.WebSpot.Aliases,
+			&i.WebReservation.ID,
+			&i.WebReservation.Author,
+			&i.WebReservation.CreatedAt,
+			&i.WebReservation.StartAt,
+			&i.WebReservation.EndAt,
+			&i.WebReservation.SpotID,
+			&i.WebReservation.GuildID,
+			&i.WebReservation.AuthorDiscordID,
+			&i.WebReservation.SeriesID,
+			&i.WebReservation.CheckedInAt,
+			&i.WebReservation.CheckInRemindedAt,
+			&i.WebReservation.NoShowAt,
+			&i.WebReservation.HeldUntil,
+			&i.WebReservation.Priority,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectCheckInPendingReservationsWithSpots = `-- name: SelectCheckInPendingReservationsWithSpots :many
+select web_spot.id, web_spot.name, web_spot.created_at, web_spot.archived_at, web_spot.owner_guild_id, web_spot.parent_id, web_spot.min_level, web_spot.max_level, web_spot.vocations, web_spot.area, web_spot.kind, web_spot.aliases,
+  web_reservation.id, web_reservation.author, web_reservation.created_at, web_reservation.start_at, web_reservation.end_at, web_reservation.spot_id, web_reservation.guild_id, web_reservation.author_discord_id, web_reservation.series_id, web_reservation.checked_in_at, web_reservation.check_in_reminded_at, web_reservation.no_show_at, web_reservation.held_until, web_reservation.priority
+from web_reservation
+  inner join web_spot on web_reservation.spot_id = web_spot.id
+where web_reservation.guild_id = $1
+  AND web_reservation.start_at <= $2
+  AND web_reservation.end_at > now()
+  AND web_reservation.checked_in_at IS NULL
+  AND web_reservation.no_show_at IS NULL
+  AND web_reservation.held_until IS NULL
+order by web_reservation.start_at asc
+`
+
+type SelectCheckInPendingReservationsWithSpotsParams struct {
+	GuildID       string
+	StartedBefore pgtype.Timestamptz
+}
+
+type SelectCheckInPendingReservationsWithSpotsRow struct {
+	WebSpot        WebSpot
+	WebReservation WebReservation
+}
+
+func (q *Queries) SelectCheckInPendingReservationsWithSpots(ctx context.Context, arg SelectCheckInPendingReservationsWithSpotsParams) ([]SelectCheckInPendingReservationsWithSpotsRow, error) {
+	rows, err := q.db.Query(ctx, selectCheckInPendingReservationsWithSpots, arg.GuildID, arg.StartedBefore)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SelectCheckInPendingReservationsWithSpotsRow
+	for rows.Next() {
+		var i SelectCheckInPendingReservationsWithSpotsRow
+		if err := rows.Scan(
+			&i.WebSpot.ID,
+			&i.WebSpot.Name,
+			&i.WebSpot.CreatedAt,
+			&i.WebSpot.ArchivedAt,
+			&i.WebSpot.OwnerGuildID,
+			&i.WebSpot.ParentID,
+			&i.WebSpot.MinLevel,
+			&i.WebSpot.MaxLevel,
+			&i.WebSpot.Vocations,
+			&i.WebSpot.Area,
+			&i.WebSpot.Kind,
+			&i.WebSpot.Aliases,
+			&i.WebReservation.ID,
+			&i.WebReservation.Author,
+			&i.WebReservation.CreatedAt,
+			&i.WebReservation.StartAt,
+			&i.WebReservation.EndAt,
+			&i.WebReservation.SpotID,
+			&i.WebReservation.GuildID,
+			&i.WebReservation.AuthorDiscordID,
+			&i.WebReservation.SeriesID,
+			&i.WebReservation.CheckedInAt,
+			&i.WebReservation.CheckInRemindedAt,
+			&i.WebReservation.NoShowAt,
+			&i.WebReservation.HeldUntil,
+			&i.WebReservation.Priority,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectExpiredOverbookRequestsWithSpots = `-- name: SelectExpiredOverbookRequestsWithSpots :many
+select web_spot.id, web_spot.name, web_spot.created_at, web_spot.archived_at, web_spot.owner_guild_id, web_spot.parent_id, web_spot.min_level, web_spot.max_level, web_spot.vocations, web_spot.area, web_spot.kind, web_spot.aliases,
+  web_overbook_request.id, web_overbook_request.author, web_overbook_request.author_discord_id, web_overbook_request.guild_id, web_overbook_request.spot_id, web_overbook_request.start_at, web_overbook_request.end_at, web_overbook_request.party_member_discord_ids, web_overbook_request.expires_at, web_overbook_request.created_at
+from web_overbook_request
+  inner join web_spot on web_overbook_request.spot_id = web_spot.id
+where web_overbook_request.guild_id = $1
+  AND web_overbook_request.expires_at <= now()
+order by web_overbook_request.created_at asc
+`
+
+type SelectExpiredOverbookRequestsWithSpotsRow struct {
+	WebSpot            WebSpot
+	WebOverbookRequest WebOverbookRequest
+}
+
+func (q *Queries) SelectExpiredOverbookRequestsWithSpots(ctx context.Context, guildID string) ([]SelectExpiredOverbookRequestsWithSpotsRow, error) {
+	rows, err := q.db.Query(ctx, selectExpiredOverbookRequestsWithSpots, guildID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SelectExpiredOverbookRequestsWithSpotsRow
+	for rows.Next() {
+		var i SelectExpiredOverbookRequestsWithSpotsRow
+		if err := rows.Scan(
+			&i.WebSpot.ID,
+			&i.WebSpot.Name,
+			&i.WebSpot.CreatedAt,
+			&i.WebSpot.ArchivedAt,
+			&i.WebSpot.OwnerGuildID,
+			&i.WebSpot.ParentID,
+			&i.WebSpot.MinLevel,
+			&i.WebSpot.MaxLevel,
+			&i.WebSpot.Vocations,
+			&i.WebSpot.Area,
+			&i.WebSpot.Kind,
+			&i.WebSpot.Aliases,
+			&i.WebOverbookRequest.ID,
+			&i.WebOverbookRequest.Author,
+			&i.WebOverbookRequest.AuthorDiscordID,
+			&i.WebOverbookRequest.GuildID,
+			&i.WebOverbookRequest.SpotID,
+			&i.WebOverbookRequest.StartAt,
+			&i.WebOverbookRequest.EndAt,
+			&i.WebOverbookRequest.PartyMemberDiscordIds,
+			&i.WebOverbookRequest.ExpiresAt,
+			&i.WebOverbookRequest.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectExpiredReservationHoldsWithSpots = `-- name: SelectExpiredReservationHoldsWithSpots :many
+select web_spot.id, web_spot.name, web_spot.created_at, web_spot.archived_at, web_spot.owner_guild_id, web_spot.parent_id, web_spot.min_level, web_spot.max_level, web_spot.vocations, web_spot.area, web_spot.kind, web_spot.aliases,
+  web_reservation.id, web_reservation.author, web_reservation.created_at, web_reservation.start_at, web_reservation.end_at, web_reservation.spot_id, web_reservation.guild_id, web_reservation.author_discord_id, web_reservation.series_id, web_reservation.checked_in_at, web_reservation.check_in_reminded_at, web_reservation.no_show_at, web_reservation.held_until, web_reservation.priority
+from web_reservation
+  inner join web_spot on web_reservation.spot_id = web_spot.id
+where web_reservation.guild_id = $1
+  AND web_reservation.held_until <= now()
+order by web_reservation.start_at asc
+`
+
+type SelectExpiredReservationHoldsWithSpotsRow struct {
+	WebSpot        WebSpot
+	WebReservation WebReservation
+}
+
+func (q *Queries) SelectExpiredReservationHoldsWithSpots(ctx context.Context, guildID string) ([]SelectExpiredReservationHoldsWithSpotsRow, error) {
+	rows, err := q.db.Query(ctx, selectExpiredReservationHoldsWithSpots, guildID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SelectExpiredReservationHoldsWithSpotsRow
+	for rows.Next() {
+		var i SelectExpiredReservationHoldsWithSpotsRow
+		if err := rows.Scan(
+			&i.WebSpot.ID,
+			&i.WebSpot.Name,
+			&i.WebSpot.CreatedAt,
+			&i.WebSpot.ArchivedAt,
+			&i.WebSpot.OwnerGuildID,
+			&i.WebSpot.ParentID,
+			&i.WebSpot.MinLevel,
+			&i.WebSpot.MaxLevel,
+			&i.WebSpot.Vocations,
+			&i.WebSpot.Area,
+			&i.WebSpot.Kind,
+			&i.WebSpot.Aliases,
+			&i.WebReservation.ID,
+			&i.WebReservation.Author,
+			&i.WebReservation.CreatedAt,
+			&i.WebReservation.StartAt,
+			&i.WebReservation.EndAt,
+			&i.WebReservation.SpotID,
+			&i.WebReservation.GuildID,
+			&i.WebReservation.AuthorDiscordID,
+			&i.WebReservation.SeriesID,
+			&i.WebReservation.CheckedInAt,
+			&i.WebReservation.CheckInRemindedAt,
+			&i.WebReservation.NoShowAt,
+			&i.WebReservation.HeldUntil,
+			&i.WebReservation.Priority,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectLotteryWinCounts = `-- name: SelectLotteryWinCounts :many
+SELECT author_discord_id,
+  count(*) AS wins
+FROM web_lottery_application
+WHERE guild_id = $1
+  AND won
+  AND draw_at > $2
+GROUP BY author_discord_id
+`
+
+type SelectLotteryWinCountsParams struct {
+	GuildID string
+	Since   pgtype.Timestamptz
+}
+
+type SelectLotteryWinCountsRow struct {
+	AuthorDiscordID string
+	Wins            int64
+}
+
+func (q *Queries) SelectLotteryWinCounts(ctx context.Context, arg SelectLotteryWinCountsParams) ([]SelectLotteryWinCountsRow, error) {
+	rows, err := q.db.Query(ctx, selectLotteryWinCounts, arg.GuildID, arg.Since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SelectLotteryWinCountsRow
+	for rows.Next() {
+		var i SelectLotteryWinCountsRow
+		if err := rows.Scan(&i.AuthorDiscordID, &i.Wins); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectMemberReservationSeriesWithSpot = `-- name: SelectMemberReservationSeriesWithSpot :one
+select web_spot.id, web_spot.name, web_spot.created_at, web_spot.archived_at, web_spot.owner_guild_id, web_spot.parent_id, web_spot.min_level, web_spot.max_level, web_spot.vocations, web_spot.area, web_spot.kind, web_spot.aliases,
+  web_reservation_series.id, web_reservation_series.author, web_reservation_series.author_discord_id, web_reservation_series.guild_id, web_reservation_series.spot_id, web_reservation_series.weekdays, web_reservation_series.start_time, web_reservation_series.end_time, web_reservation_series.created_at, web_reservation_series.materialized_until
+from web_reservation_series
+  inner join web_spot on web_reservation_series.spot_id = web_spot.id
+where web_reservation_series.id = $1
+  AND web_reservation_series.guild_id = $2
+  AND web_reservation_series.author_discord_id = $3
+LIMIT 1
+`
+
+type SelectMemberReservationSeriesWithSpotParams struct {
+	ID              int64
+	GuildID         string
+	AuthorDiscordID string
+}
+
+type SelectMemberReservationSeriesWithSpotRow struct {
+	WebSpot              WebSpot
+	WebReservationSeries WebReservationSeries
+}
+
+func (q *Queries) SelectMemberReservationSeriesWithSpot(ctx context.Context, arg SelectMemberReservationSeriesWithSpotParams) (SelectMemberReservationSeriesWithSpotRow, error) {
+	row := q.db.QueryRow(ctx, selectMemberReservationSeriesWithSpot, arg.ID, arg.GuildID, arg.AuthorDiscordID)
+	var i SelectMemberReservationSeriesWithSpotRow
+	err := row.Scan(
+		&i.WebSpot.ID,
+		&i.WebSpot.Name,
+		&i.WebSpot.CreatedAt,
+		&i.WebSpot.ArchivedAt,
+		&i.WebSpot.OwnerGuildID,
+		&i.WebSpot.ParentID,
+		&i.WebSpot.MinLevel,
+		&i.WebSpot.MaxLevel,
+		&i.WebSpot.Vocations,
+		&i.WebSpot.Area,
+		&i.WebSpot.Kind,
+		&i.WebSpot.Aliases,
+		&i.WebReservationSeries.ID,
+		&i.WebReservationSeries.Author,
+		&i.WebReservationSeries.AuthorDiscordID,
+		&i.WebReservationSeries.GuildID,
+		&i.WebReservationSeries.SpotID,
+		&i.WebReservationSeries.Weekdays,
+		&i.WebReservationSeries.StartTime,
+		&i.WebReservationSeries.EndTime,
+		&i.WebReservationSeries.CreatedAt,
+		&i.WebReservationSeries.MaterializedUntil,
+	)
+	return i, err
+}
+
+const selectMemberReservationSeriesWithSpots = `-- name: SelectMemberReservationSeriesWithSpots :many
+select web_spot.id, web_spot.name, web_spot.created_at, web_spot.archived_at, web_spot.owner_guild_id, web_spot.parent_id, web_spot.min_level, web_spot.max_level, web_spot.vocations, web_spot.area, web_spot.kind, web_spot.aliases,
+  web_reservation_series.id, web_reservation_series.author, web_reservation_series.author_discord_id, web_reservation_series.guild_id, web_reservation_series.spot_id, web_reservation_series.weekdays, web_reservation_series.start_time, web_reservation_series.end_time, web_reservation_series.created_at, web_reservation_series.materialized_until
+from web_reservation_series
+  inner join web_spot on web_reservation_series.spot_id = web_spot.id
+where web_reservation_series.guild_id = $1
+  AND web_reservation_series.author_discord_id = $2
+order by web_reservation_series.created_at asc
+`
+
+type SelectMemberReservationSeriesWithSpotsParams struct {
+	GuildID         string
+	AuthorDiscordID string
+}
+
+type SelectMemberReservationSeriesWithSpotsRow struct {
+	WebSpot              WebSpot
+	WebReservationSeries WebReservationSeries
+}
+
+func (q *Queries) SelectMemberReservationSeriesWithSpots(ctx context.Context, arg SelectMemberReservationSeriesWithSpotsParams) ([]SelectMemberReservationSeriesWithSpotsRow, error) {
+	rows, err := q.db.Query(ctx, selectMemberReservationSeriesWithSpots, arg.GuildID, arg.AuthorDiscordID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SelectMemberReservationSeriesWithSpotsRow
+	for rows.Next() {
+		var i SelectMemberReservationSeriesWithSpotsRow
+		if err := rows.Scan(
+			&i.WebSpot.ID,
+			&i.WebSpot.Name,
+			&i.WebSpot.CreatedAt,
+			&i.WebSpot.ArchivedAt,
+			&i.WebSpot.OwnerGuildID,
+			&i.WebSpot.ParentID,
+			&i.WebSpot.MinLevel,
+			&i.WebSpot.MaxLevel,
+			&i.WebSpot.Vocations,
+			&i.WebSpot.Area,
+			&i.WebSpot.Kind,
+			&i.WebSpot.Aliases,
+			&i.WebReservationSeries.ID,
+			&i.WebReservationSeries.Author,
+			&i.WebReservationSeries.AuthorDiscordID,
+			&i.WebReservationSeries.GuildID,
+			&i.WebReservationSeries.SpotID,
+			&i.WebReservationSeries.Weekdays,
+			&i.WebReservationSeries.StartTime,
+			&i.WebReservationSeries.EndTime,
+			&i.WebReservationSeries.CreatedAt,
+			&i.WebReservationSeries.MaterializedUntil,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectMemberReservationsWithSpotsBetween = `-- name: SelectMemberReservationsWithSpotsBetween :many
+select web_spot.id, web_spot.name, web_spot.created_at, web_spot.archived_at, web_spot.owner_guild_id, web_spot.parent_id, web_spot.min_level, web_spot.max_level, web_spot.vocations, web_spot.area, web_spot.kind, web_spot.aliases,
+  web_reservation.id, web_reservation.author, web_reservation.created_at, web_reservation.start_at, web_reservation.end_at, web_reservation.spot_id, web_reservation.guild_id, web_reservation.author_discord_id, web_reservation.series_id, web_reservation.checked_in_at, web_reservation.check_in_reminded_at, web_reservation.no_show_at, web_reservation.held_until, web_reservation.priority
 from web_reservation
-         inner join web_spot on web_reservation.spot_id = web_spot.id
-where end_at >= now()
-  AND guild_id = $1
-  AND web_spot.name = ANY($2::text[])
+  inner join web_spot on web_reservation.spot_id = web_spot.id
+where guild_id = $1
+  AND author_discord_id = $2
+  AND start_at < $3
+  AND end_at > $4
+order by start_at asc
 `
 
-type SelectAllReservationsWithSpotsBySpotNamesParams struct {
-	GuildID   string
-	SpotNames []string
+type SelectMemberReservationsWithSpotsBetweenParams struct {
+	GuildID         string
+	AuthorDiscordID string
+	ToAt            pgtype.Timestamptz
+	FromAt          pgtype.Timestamptz
 }
 
-type SelectAllReservationsWithSpotsBySpotNamesRow struct {
+type SelectMemberReservationsWithSpotsBetweenRow struct {
 	WebSpot        WebSpot
 	WebReservation WebReservation
 }
 
-func (q *Queries) SelectAllReservationsWithSpotsBySpotNames(ctx context.Context, arg SelectAllReservationsWithSpotsBySpotNamesParams) ([]SelectAllReservationsWithSpotsBySpotNamesRow, error) {
-	rows, err := q.db.Query(ctx, selectAllReservationsWithSpotsBySpotNames, arg.GuildID, arg.SpotNames)
+func (q *Queries) SelectMemberReservationsWithSpotsBetween(ctx context.Context, arg SelectMemberReservationsWithSpotsBetweenParams) ([]SelectMemberReservationsWithSpotsBetweenRow, error) {
+	rows, err := q.db.Query(ctx, selectMemberReservationsWithSpotsBetween,
+		arg.GuildID,
+		arg.AuthorDiscordID,
+		arg.ToAt,
+		arg.FromAt,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []SelectAllReservationsWithSpotsBySpotNamesRow
+	var items []SelectMemberReservationsWithSpotsBetweenRow
 	for rows.Next() {
-		var i SelectAllReservationsWithSpotsBySpotNamesRow
+		var i SelectMemberReservationsWithSpotsBetweenRow
 		if err := rows.Scan(
 			&i.WebSpot.ID,
 			&i.WebSpot.Name,
 			&i.WebSpot.CreatedAt,
+			&i.WebSpot.ArchivedAt,
+			&i.WebSpot.OwnerGuildID,
+			&i.WebSpot.ParentID,
+			&i.WebSpot.MinLevel,
+			&i.WebSpot.MaxLevel,
+			&i.WebSpot.Vocations,
+			&i.WebSpot.Area,
+			&i.WebSpot.Kind,
+			&i.WebSpot.Aliases,
 			&i.WebReservation.ID,
 			&i.WebReservation.Author,
 			&i.WebReservation.CreatedAt,
@@ -127,6 +1153,45 @@ func (q *Queries) SelectAllReservationsWithSpotsBySpotNames(ctx context.Context,
 			&i.WebReservation.SpotID,
 			&i.WebReservation.GuildID,
 			&i.WebReservation.AuthorDiscordID,
+			&i.WebReservation.SeriesID,
+			&i.WebReservation.CheckedInAt,
+			&i.WebReservation.CheckInRemindedAt,
+			&i.WebReservation.NoShowAt,
+			&i.WebReservation.HeldUntil,
+			&i.WebReservation.Priority,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectOverbookApprovals = `-- name: SelectOverbookApprovals :many
+SELECT request_id, reservation_id, member_discord_id, accepted
+FROM web_overbook_approval
+WHERE request_id = ANY($1::bigint [])
+ORDER BY request_id,
+  reservation_id
+`
+
+func (q *Queries) SelectOverbookApprovals(ctx context.Context, requestIds []int64) ([]WebOverbookApproval, error) {
+	rows, err := q.db.Query(ctx, selectOverbookApprovals, requestIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WebOverbookApproval
+	for rows.Next() {
+		var i WebOverbookApproval
+		if err := rows.Scan(
+			&i.RequestID,
+			&i.ReservationID,
+			&i.MemberDiscordID,
+			&i.Accepted,
 		); err != nil {
 			return nil, err
 		}
@@ -138,46 +1203,107 @@ func (q *Queries) SelectAllReservationsWithSpotsBySpotNames(ctx context.Context,
 	return items, nil
 }
 
+const selectOverbookRequestWithSpot = `-- name: SelectOverbookRequestWithSpot :one
+select web_spot.id, web_spot.name, web_spot.created_at, web_spot.archived_at, web_spot.owner_guild_id, web_spot.parent_id, web_spot.min_level, web_spot.max_level, web_spot.vocations, web_spot.area, web_spot.kind, web_spot.aliases,
+  web_overbook_request.id, web_overbook_request.author, web_overbook_request.author_discord_id, web_overbook_request.guild_id, web_overbook_request.spot_id, web_overbook_request.start_at, web_overbook_request.end_at, web_overbook_request.party_member_discord_ids, web_overbook_request.expires_at, web_overbook_request.created_at
+from web_overbook_request
+  inner join web_spot on web_overbook_request.spot_id = web_spot.id
+where web_overbook_request.id = $1
+  AND web_overbook_request.guild_id = $2
+LIMIT 1
+`
+
+type SelectOverbookRequestWithSpotParams struct {
+	ID      int64
+	GuildID string
+}
+
+type SelectOverbookRequestWithSpotRow struct {
+	WebSpot            WebSpot
+	WebOverbookRequest WebOverbookRequest
+}
+
+func (q *Queries) SelectOverbookRequestWithSpot(ctx context.Context, arg SelectOverbookRequestWithSpotParams) (SelectOverbookRequestWithSpotRow, error) {
+	row := q.db.QueryRow(ctx, selectOverbookRequestWithSpot, arg.ID, arg.GuildID)
+	var i SelectOverbookRequestWithSpotRow
+	err := row.Scan(
+		&i.WebSpot.ID,
+		&i.WebSpot.Name,
+		&i.WebSpot.CreatedAt,
+		&i.WebSpot.ArchivedAt,
+		&i.WebSpot.OwnerGuildID,
+		&i.WebSpot.ParentID,
+		&i.WebSpot.MinLevel,
+		&i.WebSpot.MaxLevel,
+		&i.WebSpot.Vocations,
+		&i.WebSpot.Area,
+		&i.WebSpot.Kind,
+		&i.WebSpot.Aliases,
+		&i.WebOverbookRequest.ID,
+		&i.WebOverbookRequest.Author,
+		&i.WebOverbookRequest.AuthorDiscordID,
+		&i.WebOverbookRequest.GuildID,
+		&i.WebOverbookRequest.SpotID,
+		&i.WebOverbookRequest.StartAt,
+		&i.WebOverbookRequest.EndAt,
+		&i.WebOverbookRequest.PartyMemberDiscordIds,
+		&i.WebOverbookRequest.ExpiresAt,
+		&i.WebOverbookRequest.CreatedAt,
+	)
+	return i, err
+}
+
 const selectOverlappingReservations = `-- name: SelectOverlappingReservations :many
 SELECT web_reservation.id,
   web_reservation.author,
   web_reservation.author_discord_id,
   web_reservation.start_at,
   web_reservation.end_at,
-  web_reservation.guild_id
+  web_reservation.guild_id,
+  web_reservation.checked_in_at,
+  web_reservation.check_in_reminded_at,
+  web_reservation.held_until,
+  web_reservation.priority
 FROM web_reservation
-  INNER JOIN web_spot ON web_reservation.spot_id = web_spot.id
 WHERE web_reservation.end_at >= now()
   AND tstzrange($1, $2, '[]') && tstzrange(
     web_reservation.start_at,
     web_reservation.end_at,
     '[]'
   )
-  AND lower(web_spot.name) = lower($3)
+  AND web_reservation.spot_id = $3
   AND web_reservation.guild_id = $4
+  AND (
+    web_reservation.held_until IS NULL
+    OR web_reservation.held_until > now()
+  )
 `
 
 type SelectOverlappingReservationsParams struct {
 	StartAt interface{}
 	EndAt   interface{}
-	Respawn string
+	SpotID  int64
 	GuildID string
 }
 
 type SelectOverlappingReservationsRow struct {
-	ID              int64
-	Author          string
-	AuthorDiscordID string
-	StartAt         pgtype.Timestamptz
-	EndAt           pgtype.Timestamptz
-	GuildID         string
+	ID                int64
+	Author            string
+	AuthorDiscordID   string
+	StartAt           pgtype.Timestamptz
+	EndAt             pgtype.Timestamptz
+	GuildID           string
+	CheckedInAt       pgtype.Timestamptz
+	CheckInRemindedAt pgtype.Timestamptz
+	HeldUntil         pgtype.Timestamptz
+	Priority          int32
 }
 
 func (q *Queries) SelectOverlappingReservations(ctx context.Context, arg SelectOverlappingReservationsParams) ([]SelectOverlappingReservationsRow, error) {
 	rows, err := q.db.Query(ctx, selectOverlappingReservations,
 		arg.StartAt,
 		arg.EndAt,
-		arg.Respawn,
+		arg.SpotID,
 		arg.GuildID,
 	)
 	if err != nil {
@@ -194,6 +1320,124 @@ func (q *Queries) SelectOverlappingReservations(ctx context.Context, arg SelectO
 			&i.StartAt,
 			&i.EndAt,
 			&i.GuildID,
+			&i.CheckedInAt,
+			&i.CheckInRemindedAt,
+			&i.HeldUntil,
+			&i.Priority,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectPendingLotteryApplicationsWithSpots = `-- name: SelectPendingLotteryApplicationsWithSpots :many
+select web_spot.id, web_spot.name, web_spot.created_at, web_spot.archived_at, web_spot.owner_guild_id, web_spot.parent_id, web_spot.min_level, web_spot.max_level, web_spot.vocations, web_spot.area, web_spot.kind, web_spot.aliases,
+  web_lottery_application.id, web_lottery_application.author, web_lottery_application.author_discord_id, web_lottery_application.guild_id, web_lottery_application.spot_id, web_lottery_application.start_at, web_lottery_application.end_at, web_lottery_application.draw_at, web_lottery_application.won, web_lottery_application.created_at
+from web_lottery_application
+  inner join web_spot on web_lottery_application.spot_id = web_spot.id
+where web_lottery_application.guild_id = $1
+  AND web_lottery_application.won IS NULL
+order by web_lottery_application.created_at asc
+`
+
+type SelectPendingLotteryApplicationsWithSpotsRow struct {
+	WebSpot               WebSpot
+	WebLotteryApplication WebLotteryApplication
+}
+
+func (q *Queries) SelectPendingLotteryApplicationsWithSpots(ctx context.Context, guildID string) ([]SelectPendingLotteryApplicationsWithSpotsRow, error) {
+	rows, err := q.db.Query(ctx, selectPendingLotteryApplicationsWithSpots, guildID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SelectPendingLotteryApplicationsWithSpotsRow
+	for rows.Next() {
+		var i SelectPendingLotteryApplicationsWithSpotsRow
+		if err := rows.Scan(
+			&i.WebSpot.ID,
+			&i.WebSpot.Name,
+			&i.WebSpot.CreatedAt,
+			&i.WebSpot.ArchivedAt,
+			&i.WebSpot.OwnerGuildID,
+			&i.WebSpot.ParentID,
+			&i.WebSpot.MinLevel,
+			&i.WebSpot.MaxLevel,
+			&i.WebSpot.Vocations,
+			&i.WebSpot.Area,
+			&i.WebSpot.Kind,
+			&i.WebSpot.Aliases,
+			&i.WebLotteryApplication.ID,
+			&i.WebLotteryApplication.Author,
+			&i.WebLotteryApplication.AuthorDiscordID,
+			&i.WebLotteryApplication.GuildID,
+			&i.WebLotteryApplication.SpotID,
+			&i.WebLotteryApplication.StartAt,
+			&i.WebLotteryApplication.EndAt,
+			&i.WebLotteryApplication.DrawAt,
+			&i.WebLotteryApplication.Won,
+			&i.WebLotteryApplication.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectQueueEntriesWithSpots = `-- name: SelectQueueEntriesWithSpots :many
+select web_spot.id, web_spot.name, web_spot.created_at, web_spot.archived_at, web_spot.owner_guild_id, web_spot.parent_id, web_spot.min_level, web_spot.max_level, web_spot.vocations, web_spot.area, web_spot.kind, web_spot.aliases,
+  web_reservation_queue.id, web_reservation_queue.author, web_reservation_queue.author_discord_id, web_reservation_queue.guild_id, web_reservation_queue.spot_id, web_reservation_queue.start_at, web_reservation_queue.end_at, web_reservation_queue.created_at
+from web_reservation_queue
+  inner join web_spot on web_reservation_queue.spot_id = web_spot.id
+where web_reservation_queue.end_at > now()
+  AND web_reservation_queue.guild_id = $1
+order by web_reservation_queue.created_at asc
+`
+
+type SelectQueueEntriesWithSpotsRow struct {
+	WebSpot             WebSpot
+	WebReservationQueue WebReservationQueue
+}
+
+func (q *Queries) SelectQueueEntriesWithSpots(ctx context.Context, guildID string) ([]SelectQueueEntriesWithSpotsRow, error) {
+	rows, err := q.db.Query(ctx, selectQueueEntriesWithSpots, guildID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SelectQueueEntriesWithSpotsRow
+	for rows.Next() {
+		var i SelectQueueEntriesWithSpotsRow
+		if err := rows.Scan(
+			&i.WebSpot.ID,
+			&i.WebSpot.Name,
+			&i.WebSpot.CreatedAt,
+			&i.WebSpot.ArchivedAt,
+			&i.WebSpot.OwnerGuildID,
+			&i.WebSpot.ParentID,
+			&i.WebSpot.MinLevel,
+			&i.WebSpot.MaxLevel,
+			&i.WebSpot.Vocations,
+			&i.WebSpot.Area,
+			&i.WebSpot.Kind,
+			&i.WebSpot.Aliases,
+			&i.WebReservationQueue.ID,
+			&i.WebReservationQueue.Author,
+			&i.WebReservationQueue.AuthorDiscordID,
+			&i.WebReservationQueue.GuildID,
+			&i.WebReservationQueue.SpotID,
+			&i.WebReservationQueue.StartAt,
+			&i.WebReservationQueue.EndAt,
+			&i.WebReservationQueue.CreatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -206,7 +1450,7 @@ func (q *Queries) SelectOverlappingReservations(ctx context.Context, arg SelectO
 }
 
 const selectReservation = `-- name: SelectReservation :one
-SELECT id, author, created_at, start_at, end_at, spot_id, guild_id, author_discord_id
+SELECT id, author, created_at, start_at, end_at, spot_id, guild_id, author_discord_id, series_id, checked_in_at, check_in_reminded_at, no_show_at, held_until, priority
 FROM web_reservation
 WHERE id = $1
 LIMIT 1
@@ -224,13 +1468,81 @@ func (q *Queries) SelectReservation(ctx context.Context, id int64) (WebReservati
 		&i.SpotID,
 		&i.GuildID,
 		&i.AuthorDiscordID,
+		&i.SeriesID,
+		&i.CheckedInAt,
+		&i.CheckInRemindedAt,
+		&i.NoShowAt,
+		&i.HeldUntil,
+		&i.Priority,
 	)
 	return i, err
 }
 
+const selectReservationSeriesWithSpots = `-- name: SelectReservationSeriesWithSpots :many
+select web_spot.id, web_spot.name, web_spot.created_at, web_spot.archived_at, web_spot.owner_guild_id, web_spot.parent_id, web_spot.min_level, web_spot.max_level, web_spot.vocations, web_spot.area, web_spot.kind, web_spot.aliases,
+  web_reservation_series.id, web_reservation_series.author, web_reservation_series.author_discord_id, web_reservation_series.guild_id, web_reservation_series.spot_id, web_reservation_series.weekdays, web_reservation_series.start_time, web_reservation_series.end_time, web_reservation_series.created_at, web_reservation_series.materialized_until
+from web_reservation_series
+  inner join web_spot on web_reservation_series.spot_id = web_spot.id
+where web_reservation_series.guild_id = $1
+  AND web_reservation_series.materialized_until < $2
+`
+
+type SelectReservationSeriesWithSpotsParams struct {
+	GuildID           string
+	MaterializedUntil pgtype.Timestamptz
+}
+
+type SelectReservationSeriesWithSpotsRow struct {
+	WebSpot              WebSpot
+	WebReservationSeries WebReservationSeries
+}
+
+func (q *Queries) SelectReservationSeriesWithSpots(ctx context.Context, arg SelectReservationSeriesWithSpotsParams) ([]SelectReservationSeriesWithSpotsRow, error) {
+	rows, err := q.db.Query(ctx, selectReservationSeriesWithSpots, arg.GuildID, arg.MaterializedUntil)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SelectReservationSeriesWithSpotsRow
+	for rows.Next() {
+		var i SelectReservationSeriesWithSpotsRow
+		if err := rows.Scan(
+			&i.WebSpot.ID,
+			&i.WebSpot.Name,
+			&i.WebSpot.CreatedAt,
+			&i.WebSpot.ArchivedAt,
+			&i.WebSpot.OwnerGuildID,
+			&i.WebSpot.ParentID,
+			&i.WebSpot.MinLevel,
+			&i.WebSpot.MaxLevel,
+			&i.WebSpot.Vocations,
+			&i.WebSpot.Area,
+			&i.WebSpot.Kind,
+			&i.WebSpot.Aliases,
+			&i.WebReservationSeries.ID,
+			&i.WebReservationSeries.Author,
+			&i.WebReservationSeries.AuthorDiscordID,
+			&i.WebReservationSeries.GuildID,
+			&i.WebReservationSeries.SpotID,
+			&i.WebReservationSeries.Weekdays,
+			&i.WebReservationSeries.StartTime,
+			&i.WebReservationSeries.EndTime,
+			&i.WebReservationSeries.CreatedAt,
+			&i.WebReservationSeries.MaterializedUntil,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const selectReservationWithSpot = `-- name: SelectReservationWithSpot :one
-SELECT reservations.id, reservations.author, reservations.created_at, reservations.start_at, reservations.end_at, reservations.spot_id, reservations.guild_id, reservations.author_discord_id,
-  spots.id, spots.name, spots.created_at
+SELECT reservations.id, reservations.author, reservations.created_at, reservations.start_at, reservations.end_at, reservations.spot_id, reservations.guild_id, reservations.author_discord_id, reservations.series_id, reservations.checked_in_at, reservations.check_in_reminded_at, reservations.no_show_at, reservations.held_until, reservations.priority,
+  spots.id, spots.name, spots.created_at, spots.archived_at, spots.owner_guild_id, spots.parent_id, spots.min_level, spots.max_level, spots.vocations, spots.area, spots.kind, spots.aliases
 FROM web_reservation reservations
   JOIN web_spot spots ON spots.id = reservations.spot_id
 WHERE reservations.id = $1
@@ -262,16 +1574,62 @@ func (q *Queries) SelectReservationWithSpot(ctx context.Context, arg SelectReser
 		&i.WebReservation.SpotID,
 		&i.WebReservation.GuildID,
 		&i.WebReservation.AuthorDiscordID,
+		&i.WebReservation.SeriesID,
+		&i.WebReservation.CheckedInAt,
+		&i.WebReservation.CheckInRemindedAt,
+		&i.WebReservation.NoShowAt,
+		&i.WebReservation.HeldUntil,
+		&i.WebReservation.Priority,
 		&i.WebSpot.ID,
 		&i.WebSpot.Name,
 		&i.WebSpot.CreatedAt,
+		&i.WebSpot.ArchivedAt,
+		&i.WebSpot.OwnerGuildID,
+		&i.WebSpot.ParentID,
+		&i.WebSpot.MinLevel,
+		&i.WebSpot.MaxLevel,
+		&i.WebSpot.Vocations,
+		&i.WebSpot.Area,
+		&i.WebSpot.Kind,
+		&i.WebSpot.Aliases,
 	)
 	return i, err
 }
 
+const selectReservationsPartyMembers = `-- name: SelectReservationsPartyMembers :many
+SELECT reservation_id, member_discord_id, member_name, created_at
+FROM web_reservation_party_member
+WHERE reservation_id = ANY($1::int8[])
+`
+
+func (q *Queries) SelectReservationsPartyMembers(ctx context.Context, reservationIds []int64) ([]WebReservationPartyMember, error) {
+	rows, err := q.db.Query(ctx, selectReservationsPartyMembers, reservationIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WebReservationPartyMember
+	for rows.Next() {
+		var i WebReservationPartyMember
+		if err := rows.Scan(
+			&i.ReservationID,
+			&i.MemberDiscordID,
+			&i.MemberName,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const selectReservationsWithSpots = `-- name: SelectReservationsWithSpots :many
-select web_spot.id, web_spot.name, web_spot.created_at,
-  web_reservation.id, web_reservation.author, web_reservation.created_at, web_reservation.start_at, web_reservation.end_at, web_reservation.spot_id, web_reservation.guild_id, web_reservation.author_discord_id
+select web_spot.id, web_spot.name, web_spot.created_at, web_spot.archived_at, web_spot.owner_guild_id, web_spot.parent_id, web_spot.min_level, web_spot.max_level, web_spot.vocations, web_spot.area, web_spot.kind, web_spot.aliases,
+  web_reservation.id, web_reservation.author, web_reservation.created_at, web_reservation.start_at, web_reservation.end_at, web_reservation.spot_id, web_reservation.guild_id, web_reservation.author_discord_id, web_reservation.series_id, web_reservation.checked_in_at, web_reservation.check_in_reminded_at, web_reservation.no_show_at, web_reservation.held_until, web_reservation.priority
 from web_reservation
   inner join web_spot on web_reservation.spot_id = web_spot.id
 where end_at >= now()
@@ -296,6 +1654,84 @@ func (q *Queries) SelectReservationsWithSpots(ctx context.Context, guildID strin
 			&i.WebSpot.ID,
 			&i.WebSpot.Name,
 			&i.WebSpot.CreatedAt,
+			&i.WebSpot.ArchivedAt,
+			&i.WebSpot.OwnerGuildID,
+			&i.WebSpot.ParentID,
+			&i.WebSpot.MinLevel,
+			&i.WebSpot.MaxLevel,
+			&i.WebSpot.Vocations,
+			&i.WebSpot.Area,
+			&i.WebSpot.Kind,
+			&i.WebSpot.Aliases,
+			&i.WebReservation.ID,
+			&i.WebReservation.Author,
+			&i.WebReservation.CreatedAt,
+			&i.WebReservation.StartAt,
+			&i.WebReservation.EndAt,
+			&i.WebReservation.SpotID,
+			&i.WebReservation.GuildID,
+			&i.WebReservation.AuthorDiscordID,
+			&i.WebReservation.SeriesID,
+			&i.WebReservation.CheckedInAt,
+			&i.WebReservation.CheckInRemindedAt,
+			&i.WebReservation.NoShowAt,
+			&i.WebReservation.HeldUntil,
+			&i.WebReservation.Priority,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectUpcomingMemberPartyReservationsWithSpots = `-- name: SelectUpcomingMemberPartyReservationsWithSpots :many
+select web_spot.id, web_spot.name, web_spot.created_at, web_spot.archived_at, web_spot.owner_guild_id, web_spot.parent_id, web_spot.min_level, web_spot.max_level, web_spot.vocations, web_spot.area, web_spot.kind, web_spot.aliases,
+  web_reservation.id, web_reservation.author, web_reservation.created_at, web_reservation.start_at, web_reservation.end_at, web_reservation.spot_id, web_reservation.guild_id, web_reservation.author_discord_id, web_reservation.series_id, web_reservation.checked_in_at, web_reservation.check_in_reminded_at, web_reservation.no_show_at, web_reservation.held_until, web_reservation.priority
+from web_reservation
+  inner join web_spot on web_reservation.spot_id = web_spot.id
+  inner join web_reservation_party_member on web_reservation_party_member.reservation_id = web_reservation.id
+where end_at >= now()
+  AND guild_id = $1
+  AND web_reservation_party_member.member_discord_id = $2
+order by start_at asc
+`
+
+type SelectUpcomingMemberPartyReservationsWithSpotsParams struct {
+	GuildID         string
+	MemberDiscordID string
+}
+
+type SelectUpcomingMemberPartyReservationsWithSpotsRow struct {
+	WebSpot        WebSpot
+	WebReservation WebReservation
+}
+
+func (q *Queries) SelectUpcomingMemberPartyReservationsWithSpots(ctx context.Context, arg SelectUpcomingMemberPartyReservationsWithSpotsParams) ([]SelectUpcomingMemberPartyReservationsWithSpotsRow, error) {
+	rows, err := q.db.Query(ctx, selectUpcomingMemberPartyReservationsWithSpots, arg.GuildID, arg.MemberDiscordID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SelectUpcomingMemberPartyReservationsWithSpotsRow
+	for rows.Next() {
+		var i SelectUpcomingMemberPartyReservationsWithSpotsRow
+		if err := rows.Scan(
+			&i.WebSpot.ID,
+			&i.WebSpot.Name,
+			&i.WebSpot.CreatedAt,
+			&i.WebSpot.ArchivedAt,
+			&i.WebSpot.OwnerGuildID,
+			&i.WebSpot.ParentID,
+			&i.WebSpot.MinLevel,
+			&i.WebSpot.MaxLevel,
+			&i.WebSpot.Vocations,
+			&i.WebSpot.Area,
+			&i.WebSpot.Kind,
+			&i.WebSpot.Aliases,
 			&i.WebReservation.ID,
 			&i.WebReservation.Author,
 			&i.WebReservation.CreatedAt,
@@ -304,6 +1740,12 @@ func (q *Queries) SelectReservationsWithSpots(ctx context.Context, guildID strin
 			&i.WebReservation.SpotID,
 			&i.WebReservation.GuildID,
 			&i.WebReservation.AuthorDiscordID,
+			&i.WebReservation.SeriesID,
+			&i.WebReservation.CheckedInAt,
+			&i.WebReservation.CheckInRemindedAt,
+			&i.WebReservation.NoShowAt,
+			&i.WebReservation.HeldUntil,
+			&i.WebReservation.Priority,
 		); err != nil {
 			return nil, err
 		}
@@ -316,8 +1758,8 @@ func (q *Queries) SelectReservationsWithSpots(ctx context.Context, guildID strin
 }
 
 const selectUpcomingMemberReservationsWithSpots = `-- name: SelectUpcomingMemberReservationsWithSpots :many
-select web_spot.id, web_spot.name, web_spot.created_at,
-  web_reservation.id, web_reservation.author, web_reservation.created_at, web_reservation.start_at, web_reservation.end_at, web_reservation.spot_id, web_reservation.guild_id, web_reservation.author_discord_id
+select web_spot.id, web_spot.name, web_spot.created_at, web_spot.archived_at, web_spot.owner_guild_id, web_spot.parent_id, web_spot.min_level, web_spot.max_level, web_spot.vocations, web_spot.area, web_spot.kind, web_spot.aliases,
+  web_reservation.id, web_reservation.author, web_reservation.created_at, web_reservation.start_at, web_reservation.end_at, web_reservation.spot_id, web_reservation.guild_id, web_reservation.author_discord_id, web_reservation.series_id, web_reservation.checked_in_at, web_reservation.check_in_reminded_at, web_reservation.no_show_at, web_reservation.held_until, web_reservation.priority
 from web_reservation
   inner join web_spot on web_reservation.spot_id = web_spot.id
 where end_at >= now()
@@ -349,6 +1791,15 @@ func (q *Queries) SelectUpcomingMemberReservationsWithSpots(ctx context.Context,
 			&i.WebSpot.ID,
 			&i.WebSpot.Name,
 			&i.WebSpot.CreatedAt,
+			&i.WebSpot.ArchivedAt,
+			&i.WebSpot.OwnerGuildID,
+			&i.WebSpot.ParentID,
+			&i.WebSpot.MinLevel,
+			&i.WebSpot.MaxLevel,
+			&i.WebSpot.Vocations,
+			&i.WebSpot.Area,
+			&i.WebSpot.Kind,
+			&i.WebSpot.Aliases,
 			&i.WebReservation.ID,
 			&i.WebReservation.Author,
 			&i.WebReservation.CreatedAt,
@@ -357,6 +1808,12 @@ func (q *Queries) SelectUpcomingMemberReservationsWithSpots(ctx context.Context,
 			&i.WebReservation.SpotID,
 			&i.WebReservation.GuildID,
 			&i.WebReservation.AuthorDiscordID,
+			&i.WebReservation.SeriesID,
+			&i.WebReservation.CheckedInAt,
+			&i.WebReservation.CheckInRemindedAt,
+			&i.WebReservation.NoShowAt,
+			&i.WebReservation.HeldUntil,
+			&i.WebReservation.Priority,
 		); err != nil {
 			return nil, err
 		}
@@ -367,3 +1824,106 @@ func (q *Queries) SelectUpcomingMemberReservationsWithSpots(ctx context.Context,
 	}
 	return items, nil
 }
+
+const transferPresentMemberReservation = `-- name: TransferPresentMemberReservation :execrows
+UPDATE web_reservation
+SET author = $1,
+  author_discord_id = $2,
+  priority = $3
+WHERE web_reservation.id = $4
+  AND web_reservation.guild_id = $5
+  AND web_reservation.author_discord_id = $6
+  AND web_reservation.end_at > now()
+`
+
+type TransferPresentMemberReservationParams struct {
+	NewAuthor          string
+	NewAuthorDiscordID string
+	Priority           int32
+	ID                 int64
+	GuildID            string
+	AuthorDiscordID    string
+}
+
+func (q *Queries) TransferPresentMemberReservation(ctx context.Context, arg TransferPresentMemberReservationParams) (int64, error) {
+	result, err := q.db.Exec(ctx, transferPresentMemberReservation,
+		arg.NewAuthor,
+		arg.NewAuthorDiscordID,
+		arg.Priority,
+		arg.ID,
+		arg.GuildID,
+		arg.AuthorDiscordID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateLotteryApplicationResult = `-- name: UpdateLotteryApplicationResult :execrows
+UPDATE web_lottery_application
+SET won = $1
+WHERE web_lottery_application.id = $2
+  AND web_lottery_application.won IS NULL
+`
+
+type UpdateLotteryApplicationResultParams struct {
+	Won pgtype.Bool
+	ID  int64
+}
+
+func (q *Queries) UpdateLotteryApplicationResult(ctx context.Context, arg UpdateLotteryApplicationResultParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateLotteryApplicationResult, arg.Won, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updatePresentMemberReservation = `-- name: UpdatePresentMemberReservation :execrows
+UPDATE web_reservation
+SET start_at = $1,
+  end_at = $2
+WHERE web_reservation.id = $3
+  AND web_reservation.guild_id = $4
+  AND web_reservation.author_discord_id = $5
+  AND web_reservation.end_at > now()
+`
+
+type UpdatePresentMemberReservationParams struct {
+	StartAt         pgtype.Timestamptz
+	EndAt           pgtype.Timestamptz
+	ID              int64
+	GuildID         string
+	AuthorDiscordID string
+}
+
+func (q *Queries) UpdatePresentMemberReservation(ctx context.Context, arg UpdatePresentMemberReservationParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updatePresentMemberReservation,
+		arg.StartAt,
+		arg.EndAt,
+		arg.ID,
+		arg.GuildID,
+		arg.AuthorDiscordID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateReservationSeriesMaterializedUntil = `-- name: UpdateReservationSeriesMaterializedUntil :exec
+UPDATE web_reservation_series
+SET materialized_until = $1
+WHERE web_reservation_series.id = $2
+`
+
+type UpdateReservationSeriesMaterializedUntilParams struct {
+	MaterializedUntil pgtype.Timestamptz
+	ID                int64
+}
+
+func (q *Queries) UpdateReservationSeriesMaterializedUntil(ctx context.Context, arg UpdateReservationSeriesMaterializedUntilParams) error {
+	_, err := q.db.Exec(ctx, updateReservationSeriesMaterializedUntil, arg.MaterializedUntil, arg.ID)
+	return err
+}
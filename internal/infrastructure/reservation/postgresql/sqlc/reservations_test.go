@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/pashagolub/pgxmock/v3"
 	"github.com/stretchr/testify/assert"
 
@@ -17,10 +18,18 @@ import (
 func newReservationRows() *pgxmock.Rows {
 	return pgxmock.NewRows([]string{
 		"id", "author", "created_at", "start_at", "end_at",
-		"spot_id", "guild_id", "author_discord_id",
+		"spot_id", "guild_id", "author_discord_id", "series_id",
+		"checked_in_at", "check_in_reminded_at", "no_show_at", "held_until", "priority",
 	})
 }
 
+// Expects expired holds overlapping the given time range to be deleted, none of which exist.
+func expectOverlappingExpiredHoldsDeletion(mock pgxmock.PgxPoolIface, spotId int64, guildId string, startAt time.Time, endAt time.Time) {
+	mock.ExpectExec("DELETE FROM web_reservation").WithArgs(
+		spotId, guildId, mocks.NewPgTimestamptzTime(startAt), mocks.NewPgTimestamptzTime(endAt),
+	).WillReturnResult(pgxmock.NewResult("DELETE", 0))
+}
+
 func TestCreateAndDeleteConflictingWithNoConflicting(t *testing.T) {
 	// given
 	assert := assert.New(t)
@@ -43,18 +52,111 @@ func TestCreateAndDeleteConflictingWithNoConflicting(t *testing.T) {
 	}
 	defer mock.Close()
 	mock.ExpectBegin()
+	expectOverlappingExpiredHoldsDeletion(mock, spotId, testGuild.ID, startAt, endAt)
 	mock.ExpectQuery("INSERT INTO web_reservation").WithArgs(
 		testMember.Nick, testMember.ID, mocks.NewPgTimestamptzTime(startAt),
-		mocks.NewPgTimestamptzTime(endAt), spotId, testGuild.ID,
+		mocks.NewPgTimestamptzTime(endAt), spotId, testGuild.ID, pgtype.Timestamptz{}, int32(0),
 	).WillReturnRows(newReservationRows().AddRow(
-		int64(1), testMember.Nick, time.Now(), startAt, endAt, spotId, testGuild.ID, testMember.ID,
+		int64(1), testMember.Nick, time.Now(), startAt, endAt, spotId, testGuild.ID, testMember.ID, nil, nil, nil, nil, nil, int32(0),
 	))
 
 	mock.ExpectCommit()
 	repository := NewReservationRepository(mock)
 
 	// when
-	removed, err := repository.CreateAndDeleteConflicting(context.Background(), testMember, testGuild, make([]*reservation.Reservation, 0), spotId, startAt, endAt)
+	removed, err := repository.CreateAndDeleteConflicting(context.Background(), testMember, testGuild, []*discord.Member{}, make([]*reservation.Reservation, 0), spotId, startAt, endAt, 0)
+
+	// assert
+	assert.Nil(err)
+	assert.Empty(removed)
+	assert.Nil(mock.ExpectationsWereMet())
+}
+
+func TestCreateAndDeleteConflictingOverExpiredHold(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	testMember := &discord.Member{
+		ID:       "test-member-id",
+		Username: "test-member-username",
+		Nick:     "test-member-nick",
+	}
+	testGuild := &discord.Guild{
+		ID:   "test-guild-id",
+		Name: "test-guild-name",
+	}
+	spotId := int64(1)
+	tNow := time.Now()
+	startAt := time.Date(tNow.Year(), tNow.Month(), tNow.Day(), 21, 1, 0, 0, time.UTC)
+	endAt := time.Date(tNow.Year(), tNow.Month(), tNow.Day(), 23, 1, 0, 0, time.UTC)
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	mock.ExpectBegin()
+	// Expired hold has not been released yet, and would violate the no overlapping ranges constraint
+	mock.ExpectExec("DELETE FROM web_reservation").WithArgs(
+		spotId, testGuild.ID, mocks.NewPgTimestamptzTime(startAt), mocks.NewPgTimestamptzTime(endAt),
+	).WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	mock.ExpectQuery("INSERT INTO web_reservation").WithArgs(
+		testMember.Nick, testMember.ID, mocks.NewPgTimestamptzTime(startAt),
+		mocks.NewPgTimestamptzTime(endAt), spotId, testGuild.ID, pgtype.Timestamptz{}, int32(0),
+	).WillReturnRows(newReservationRows().AddRow(
+		int64(2), testMember.Nick, time.Now(), startAt, endAt, spotId, testGuild.ID, testMember.ID, nil, nil, nil, nil, nil, int32(0),
+	))
+	mock.ExpectCommit()
+	repository := NewReservationRepository(mock)
+
+	// when
+	removed, err := repository.CreateAndDeleteConflicting(context.Background(), testMember, testGuild, []*discord.Member{}, make([]*reservation.Reservation, 0), spotId, startAt, endAt, 0)
+
+	// assert
+	assert.Nil(err)
+	assert.Empty(removed)
+	assert.Nil(mock.ExpectationsWereMet())
+}
+
+func TestCreateAndDeleteConflictingWithParty(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	testMember := &discord.Member{
+		ID:       "test-member-id",
+		Username: "test-member-username",
+		Nick:     "test-member-nick",
+	}
+	partyMember := &discord.Member{
+		ID:       "test-party-member-id",
+		Username: "test-party-member-username",
+	}
+	testGuild := &discord.Guild{
+		ID:   "test-guild-id",
+		Name: "test-guild-name",
+	}
+	spotId := int64(1)
+	tNow := time.Now()
+	startAt := time.Date(tNow.Year(), tNow.Month(), tNow.Day(), 21, 1, 0, 0, time.UTC)
+	endAt := time.Date(tNow.Year(), tNow.Month(), tNow.Day(), 23, 1, 0, 0, time.UTC)
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	mock.ExpectBegin()
+	expectOverlappingExpiredHoldsDeletion(mock, spotId, testGuild.ID, startAt, endAt)
+	mock.ExpectQuery("INSERT INTO web_reservation").WithArgs(
+		testMember.Nick, testMember.ID, mocks.NewPgTimestamptzTime(startAt),
+		mocks.NewPgTimestamptzTime(endAt), spotId, testGuild.ID, pgtype.Timestamptz{}, int32(0),
+	).WillReturnRows(newReservationRows().AddRow(
+		int64(7), testMember.Nick, time.Now(), startAt, endAt, spotId, testGuild.ID, testMember.ID, nil, nil, nil, nil, nil, int32(0),
+	))
+	mock.ExpectExec("INSERT INTO web_reservation_party_member").WithArgs(
+		int64(7), partyMember.ID, partyMember.Username,
+	).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectCommit()
+	repository := NewReservationRepository(mock)
+
+	// when
+	removed, err := repository.CreateAndDeleteConflicting(context.Background(), testMember, testGuild, []*discord.Member{partyMember}, make([]*reservation.Reservation, 0), spotId, startAt, endAt, 0)
 
 	// assert
 	assert.Nil(err)
@@ -111,26 +213,27 @@ func TestCreateAndDeleteConflictingWithOneConflicting(t *testing.T) {
 		conflictingReservations[0].Author, conflictingReservations[0].AuthorDiscordID,
 		mocks.NewPgTimestamptzTime(reservationInput.EndAt.Add(1*time.Minute)),
 		mocks.NewPgTimestamptzTime(conflictingReservations[0].EndAt),
-		conflictingReservations[0].SpotID, conflictingReservations[0].GuildID,
+		conflictingReservations[0].SpotID, conflictingReservations[0].GuildID, pgtype.Timestamptz{}, int32(0),
 	).WillReturnRows(newReservationRows().AddRow(
 		int64(1), testMember.Nick, time.Now(),
 		reservationInput.EndAt.Add(1*time.Minute), conflictingReservations[0].EndAt,
-		spotId, testGuild.ID, testMember.ID,
+		spotId, testGuild.ID, testMember.ID, nil, nil, nil, nil, nil, int32(0),
 	))
+	expectOverlappingExpiredHoldsDeletion(mock, reservationInput.SpotID, reservationInput.GuildID, reservationInput.StartAt, reservationInput.EndAt)
 	mock.ExpectQuery("INSERT INTO web_reservation").WithArgs(
 		reservationInput.Author, reservationInput.AuthorDiscordID,
 		mocks.NewPgTimestamptzTime(reservationInput.StartAt), mocks.NewPgTimestamptzTime(reservationInput.EndAt),
-		reservationInput.SpotID, reservationInput.GuildID,
+		reservationInput.SpotID, reservationInput.GuildID, pgtype.Timestamptz{}, int32(0),
 	).WillReturnRows(newReservationRows().AddRow(
 		int64(2), testMember.Nick, time.Now(),
 		reservationInput.StartAt, reservationInput.EndAt,
-		spotId, testGuild.ID, testMember.ID,
+		spotId, testGuild.ID, testMember.ID, nil, nil, nil, nil, nil, int32(0),
 	))
 	mock.ExpectCommit()
 	repository := NewReservationRepository(mock)
 
 	// when
-	removed, err := repository.CreateAndDeleteConflicting(context.Background(), testMember, testGuild, conflictingReservations, spotId, reservationInput.StartAt, reservationInput.EndAt)
+	removed, err := repository.CreateAndDeleteConflicting(context.Background(), testMember, testGuild, []*discord.Member{}, conflictingReservations, spotId, reservationInput.StartAt, reservationInput.EndAt, 0)
 
 	// assert
 	assert.Nil(err)
@@ -203,26 +306,27 @@ func TestCreateAndDeleteConflictingWithTwoConflicting(t *testing.T) {
 	mock.ExpectQuery("INSERT INTO web_reservation").WithArgs(
 		conflictingReservations[0].Author, conflictingReservations[0].AuthorDiscordID,
 		mocks.NewPgTimestamptzTime(conflictingReservations[0].StartAt), mocks.NewPgTimestamptzTime(reservationInput.StartAt.Add(-1*time.Minute)),
-		conflictingReservations[0].SpotID, conflictingReservations[0].GuildID,
+		conflictingReservations[0].SpotID, conflictingReservations[0].GuildID, pgtype.Timestamptz{}, int32(0),
 	).WillReturnRows(newReservationRows().AddRow(
-		int64(3), testMember2.Nick, time.Now(), conflictingReservations[0].StartAt, reservationInput.StartAt.Add(-1*time.Minute), spotId, testGuild.ID, testMember.ID,
+		int64(3), testMember2.Nick, time.Now(), conflictingReservations[0].StartAt, reservationInput.StartAt.Add(-1*time.Minute), spotId, testGuild.ID, testMember.ID, nil, nil, nil, nil, nil, int32(0),
 	))
 	mock.ExpectExec("DELETE FROM web_reservation").WithArgs(conflictingReservations[1].ID).WillReturnResult(pgxmock.NewResult("DELETE", 1))
 	mock.ExpectQuery("INSERT INTO web_reservation").WithArgs(
 		conflictingReservations[1].Author, conflictingReservations[1].AuthorDiscordID,
 		mocks.NewPgTimestamptzTime(reservationInput.EndAt.Add(1*time.Minute)), mocks.NewPgTimestamptzTime(conflictingReservations[1].EndAt),
-		conflictingReservations[1].SpotID, conflictingReservations[1].GuildID,
-	).WillReturnRows(newReservationRows().AddRow(int64(4), testMember3.Nick, time.Now(), reservationInput.EndAt.Add(1*time.Minute), conflictingReservations[1].EndAt, spotId, testGuild.ID, testMember.ID))
+		conflictingReservations[1].SpotID, conflictingReservations[1].GuildID, pgtype.Timestamptz{}, int32(0),
+	).WillReturnRows(newReservationRows().AddRow(int64(4), testMember3.Nick, time.Now(), reservationInput.EndAt.Add(1*time.Minute), conflictingReservations[1].EndAt, spotId, testGuild.ID, testMember.ID, nil, nil, nil, nil, nil, int32(0)))
+	expectOverlappingExpiredHoldsDeletion(mock, reservationInput.SpotID, reservationInput.GuildID, reservationInput.StartAt, reservationInput.EndAt)
 	mock.ExpectQuery("INSERT INTO web_reservation").WithArgs(
 		reservationInput.Author, reservationInput.AuthorDiscordID,
 		mocks.NewPgTimestamptzTime(reservationInput.StartAt), mocks.NewPgTimestamptzTime(reservationInput.EndAt),
-		reservationInput.SpotID, reservationInput.GuildID,
-	).WillReturnRows(newReservationRows().AddRow(int64(5), testMember.Nick, time.Now(), reservationInput.EndAt.Add(1*time.Minute), conflictingReservations[1].EndAt, spotId, testGuild.ID, testMember.ID))
+		reservationInput.SpotID, reservationInput.GuildID, pgtype.Timestamptz{}, int32(0),
+	).WillReturnRows(newReservationRows().AddRow(int64(5), testMember.Nick, time.Now(), reservationInput.EndAt.Add(1*time.Minute), conflictingReservations[1].EndAt, spotId, testGuild.ID, testMember.ID, nil, nil, nil, nil, nil, int32(0)))
 	mock.ExpectCommit()
 	repository := NewReservationRepository(mock)
 
 	// when
-	removed, err := repository.CreateAndDeleteConflicting(context.Background(), testMember, testGuild, conflictingReservations, spotId, reservationInput.StartAt, reservationInput.EndAt)
+	removed, err := repository.CreateAndDeleteConflicting(context.Background(), testMember, testGuild, []*discord.Member{}, conflictingReservations, spotId, reservationInput.StartAt, reservationInput.EndAt, 0)
 
 	// assert
 	assert.Nil(err)
@@ -290,22 +394,23 @@ func TestCreateAndDeleteConflictingWithTwoConflictingButSecondOneFromTheSameAuth
 	mock.ExpectQuery("INSERT INTO web_reservation").WithArgs(
 		conflictingReservations[0].Author, conflictingReservations[0].AuthorDiscordID,
 		mocks.NewPgTimestamptzTime(conflictingReservations[0].StartAt), mocks.NewPgTimestamptzTime(reservationInput.StartAt.Add(-1*time.Minute)),
-		conflictingReservations[0].SpotID, conflictingReservations[0].GuildID,
+		conflictingReservations[0].SpotID, conflictingReservations[0].GuildID, pgtype.Timestamptz{}, int32(0),
 	).WillReturnRows(newReservationRows().AddRow(
-		int64(3), testMember2.Nick, time.Now(), conflictingReservations[0].StartAt, reservationInput.StartAt.Add(-1*time.Minute), spotId, testGuild.ID, testMember.ID))
+		int64(3), testMember2.Nick, time.Now(), conflictingReservations[0].StartAt, reservationInput.StartAt.Add(-1*time.Minute), spotId, testGuild.ID, testMember.ID, nil, nil, nil, nil, nil, int32(0)))
 	mock.ExpectExec("DELETE FROM web_reservation").WithArgs(conflictingReservations[1].ID).WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	expectOverlappingExpiredHoldsDeletion(mock, reservationInput.SpotID, reservationInput.GuildID, reservationInput.StartAt, reservationInput.EndAt)
 	mock.ExpectQuery("INSERT INTO web_reservation").WithArgs(
 		reservationInput.Author, reservationInput.AuthorDiscordID,
 		mocks.NewPgTimestamptzTime(reservationInput.StartAt), mocks.NewPgTimestamptzTime(reservationInput.EndAt),
-		reservationInput.SpotID, reservationInput.GuildID,
+		reservationInput.SpotID, reservationInput.GuildID, pgtype.Timestamptz{}, int32(0),
 	).WillReturnRows(newReservationRows().AddRow(
-		int64(4), testMember.Nick, time.Now(), reservationInput.StartAt, reservationInput.EndAt, spotId, testGuild.ID, testMember.ID,
+		int64(4), testMember.Nick, time.Now(), reservationInput.StartAt, reservationInput.EndAt, spotId, testGuild.ID, testMember.ID, nil, nil, nil, nil, nil, int32(0),
 	))
 	mock.ExpectCommit()
 	repository := NewReservationRepository(mock)
 
 	// when
-	removed, err := repository.CreateAndDeleteConflicting(context.Background(), testMember, testGuild, conflictingReservations, spotId, reservationInput.StartAt, reservationInput.EndAt)
+	removed, err := repository.CreateAndDeleteConflicting(context.Background(), testMember, testGuild, []*discord.Member{}, conflictingReservations, spotId, reservationInput.StartAt, reservationInput.EndAt, 0)
 
 	// assert
 	assert.Nil(err)
@@ -315,3 +420,254 @@ func TestCreateAndDeleteConflictingWithTwoConflictingButSecondOneFromTheSameAuth
 		return r.Original
 	}))
 }
+
+func TestUpdateAndDeleteConflictingWithNoConflicting(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	testMember := &discord.Member{
+		ID:       "test-member-id",
+		Username: "test-member-username",
+		Nick:     "test-member-nick",
+	}
+	testGuild := &discord.Guild{
+		ID:   "test-guild-id",
+		Name: "test-guild-name",
+	}
+	spotId := int64(1)
+	reservationId := int64(5)
+	tNow := time.Now()
+	startAt := time.Date(tNow.Year(), tNow.Month(), tNow.Day(), 21, 1, 0, 0, time.UTC)
+	endAt := time.Date(tNow.Year(), tNow.Month(), tNow.Day(), 23, 31, 0, 0, time.UTC)
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	mock.ExpectBegin()
+	expectOverlappingExpiredHoldsDeletion(mock, spotId, testGuild.ID, startAt, endAt)
+	mock.ExpectExec("UPDATE web_reservation").WithArgs(
+		mocks.NewPgTimestamptzTime(startAt), mocks.NewPgTimestamptzTime(endAt),
+		reservationId, testGuild.ID, testMember.ID,
+	).WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectCommit()
+	repository := NewReservationRepository(mock)
+
+	// when
+	removed, err := repository.UpdateAndDeleteConflicting(context.Background(), testMember, testGuild, reservationId, make([]*reservation.Reservation, 0), spotId, startAt, endAt)
+
+	// assert
+	assert.Nil(err)
+	assert.Empty(removed)
+	assert.Nil(mock.ExpectationsWereMet())
+}
+
+func TestUpdateAndDeleteConflictingWithEndedReservation(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	testMember := &discord.Member{
+		ID:       "test-member-id",
+		Username: "test-member-username",
+		Nick:     "test-member-nick",
+	}
+	testGuild := &discord.Guild{
+		ID:   "test-guild-id",
+		Name: "test-guild-name",
+	}
+	spotId := int64(1)
+	reservationId := int64(5)
+	tNow := time.Now()
+	startAt := time.Date(tNow.Year(), tNow.Month(), tNow.Day(), 21, 1, 0, 0, time.UTC)
+	endAt := time.Date(tNow.Year(), tNow.Month(), tNow.Day(), 23, 31, 0, 0, time.UTC)
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	mock.ExpectBegin()
+	expectOverlappingExpiredHoldsDeletion(mock, spotId, testGuild.ID, startAt, endAt)
+	mock.ExpectExec("UPDATE web_reservation").WithArgs(
+		mocks.NewPgTimestamptzTime(startAt), mocks.NewPgTimestamptzTime(endAt),
+		reservationId, testGuild.ID, testMember.ID,
+	).WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+	mock.ExpectRollback()
+	repository := NewReservationRepository(mock)
+
+	// when
+	_, err = repository.UpdateAndDeleteConflicting(context.Background(), testMember, testGuild, reservationId, make([]*reservation.Reservation, 0), spotId, startAt, endAt)
+
+	// assert
+	assert.NotNil(err)
+	assert.Nil(mock.ExpectationsWereMet())
+}
+
+func TestTransferPresentMemberReservation(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	testGuild := &discord.Guild{
+		ID:   "test-guild-id",
+		Name: "test-guild-name",
+	}
+	from := &discord.Member{
+		ID:       "test-member-id",
+		Username: "test-member-username",
+	}
+	to := &discord.Member{
+		ID:       "test-recipient-id",
+		Username: "test-recipient-username",
+	}
+	reservationId := int64(5)
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	mock.ExpectExec("UPDATE web_reservation").WithArgs(
+		to.Username, to.ID, int32(2), reservationId, testGuild.ID, from.ID,
+	).WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	repository := NewReservationRepository(mock)
+
+	// when
+	err = repository.TransferPresentMemberReservation(context.Background(), testGuild, from, to, reservationId, 2)
+
+	// assert
+	assert.Nil(err)
+	assert.Nil(mock.ExpectationsWereMet())
+}
+
+func TestTransferPresentMemberReservationWhenNothingWasTransferred(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	testGuild := &discord.Guild{
+		ID: "test-guild-id",
+	}
+	from := &discord.Member{
+		ID: "test-member-id",
+	}
+	to := &discord.Member{
+		ID:   "test-recipient-id",
+		Nick: "test-recipient-nick",
+	}
+	reservationId := int64(5)
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	mock.ExpectExec("UPDATE web_reservation").WithArgs(
+		to.Nick, to.ID, int32(0), reservationId, testGuild.ID, from.ID,
+	).WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+	repository := NewReservationRepository(mock)
+
+	// when
+	err = repository.TransferPresentMemberReservation(context.Background(), testGuild, from, to, reservationId, 0)
+
+	// assert
+	assert.NotNil(err)
+	assert.Nil(mock.ExpectationsWereMet())
+}
+
+func TestSelectOverlappingReservationsOfSpot(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	startAt := time.Now().Add(1 * time.Hour)
+	endAt := startAt.Add(2 * time.Hour)
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	// Spot names are unique per owner guild only, so reservations are matched by spot ID
+	mock.ExpectQuery("web_reservation.spot_id = \\$3").WithArgs(startAt, endAt, int64(5), "test-guild-id").WillReturnRows(
+		pgxmock.NewRows([]string{"id", "author", "author_discord_id", "start_at", "end_at", "guild_id", "checked_in_at", "check_in_reminded_at", "held_until", "priority"}),
+	)
+	repository := NewReservationRepository(mock)
+
+	// when
+	res, err := repository.SelectOverlappingReservations(context.Background(), 5, startAt, endAt, "test-guild-id")
+
+	// assert
+	assert.Nil(err)
+	assert.Empty(res)
+	assert.Nil(mock.ExpectationsWereMet())
+}
+
+func TestMarkNoShow(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	r := &reservation.Reservation{
+		ID:              5,
+		GuildID:         "test-guild-id",
+		AuthorDiscordID: "test-member-id",
+	}
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE web_reservation").WithArgs(r.ID).WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectQuery("INSERT INTO web_member_no_show").WithArgs(r.GuildID, r.AuthorDiscordID).WillReturnRows(
+		pgxmock.NewRows([]string{"guild_id", "member_id", "no_show_count", "updated_at"}).
+			AddRow(r.GuildID, r.AuthorDiscordID, int32(3), time.Now()),
+	)
+	mock.ExpectCommit()
+	repository := NewReservationRepository(mock)
+
+	// when
+	count, err := repository.MarkNoShow(context.Background(), r)
+
+	// assert
+	assert.Nil(err)
+	assert.Equal(3, count)
+	assert.Nil(mock.ExpectationsWereMet())
+}
+
+func TestMarkNoShowWhenAlreadyCheckedIn(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	r := &reservation.Reservation{
+		ID:              5,
+		GuildID:         "test-guild-id",
+		AuthorDiscordID: "test-member-id",
+	}
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE web_reservation").WithArgs(r.ID).WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+	mock.ExpectRollback()
+	repository := NewReservationRepository(mock)
+
+	// when
+	count, err := repository.MarkNoShow(context.Background(), r)
+
+	// assert
+	assert.Nil(err)
+	assert.Equal(0, count)
+	assert.Nil(mock.ExpectationsWereMet())
+}
+
+func TestClaimReminderWhenAlreadySent(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	eventAt := time.Now().Add(10 * time.Minute)
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	mock.ExpectExec("INSERT INTO web_reservation_reminder").WithArgs(
+		int64(5), "start", mocks.NewPgTimestamptzTime(eventAt),
+	).WillReturnResult(pgxmock.NewResult("INSERT", 0))
+	repository := NewReservationRepository(mock)
+
+	// when
+	claimed, err := repository.ClaimReminder(context.Background(), 5, reservation.ReminderStart, eventAt)
+
+	// assert
+	assert.Nil(err)
+	assert.False(claimed)
+	assert.Nil(mock.ExpectationsWereMet())
+}
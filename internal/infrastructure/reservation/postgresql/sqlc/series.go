@@ -0,0 +1,248 @@
+package sqlc
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"spot-assistant/internal/common/errors"
+	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/reservation"
+)
+
+func (t *ReservationRepository) CreateSeries(ctx context.Context, member *discord.Member, guild *discord.Guild, spotId int64, weekdays []time.Weekday, startTime time.Duration, endTime time.Duration) (*reservation.Series, error) {
+	var author string
+	if len(member.Nick) > 0 {
+		author = member.Nick
+	} else {
+		author = member.Username
+	}
+
+	res, err := t.q.CreateReservationSeries(ctx, CreateReservationSeriesParams{
+		Author:          author,
+		AuthorDiscordID: member.ID,
+		GuildID:         guild.ID,
+		SpotID:          spotId,
+		Weekdays:        mapWeekdaysToBitmask(weekdays),
+		StartTime:       mapDurationToTime(startTime),
+		EndTime:         mapDurationToTime(endTime),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	series := mapSeries(res)
+	return &series, nil
+}
+
+func (t *ReservationRepository) SelectMemberSeriesWithSpots(ctx context.Context, guild *discord.Guild, member *discord.Member) ([]*reservation.SeriesWithSpot, error) {
+	res, err := t.q.SelectMemberReservationSeriesWithSpots(ctx, SelectMemberReservationSeriesWithSpotsParams{
+		GuildID:         guild.ID,
+		AuthorDiscordID: member.ID,
+	})
+	if err != nil {
+		return []*reservation.SeriesWithSpot{}, err
+	}
+
+	series := make([]*reservation.SeriesWithSpot, len(res))
+	for i, row := range res {
+		series[i] = mapSeriesWithSpot(row.WebReservationSeries, row.WebSpot)
+	}
+
+	return series, nil
+}
+
+func (t *ReservationRepository) FindMemberSeriesWithSpot(ctx context.Context, id int64, guildID, authorDiscordID string) (*reservation.SeriesWithSpot, error) {
+	res, err := t.q.SelectMemberReservationSeriesWithSpot(ctx, SelectMemberReservationSeriesWithSpotParams{
+		ID:              id,
+		GuildID:         guildID,
+		AuthorDiscordID: authorDiscordID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mapSeriesWithSpot(res.WebReservationSeries, res.WebSpot), nil
+}
+
+func (t *ReservationRepository) SelectSeriesToMaterialize(ctx context.Context, guildId string, until time.Time) ([]*reservation.SeriesWithSpot, error) {
+	untilInput := pgtype.Timestamptz{}
+	err := untilInput.Scan(until)
+	if err != nil {
+		return []*reservation.SeriesWithSpot{}, err
+	}
+
+	res, err := t.q.SelectReservationSeriesWithSpots(ctx, SelectReservationSeriesWithSpotsParams{
+		GuildID:           guildId,
+		MaterializedUntil: untilInput,
+	})
+	if err != nil {
+		return []*reservation.SeriesWithSpot{}, err
+	}
+
+	series := make([]*reservation.SeriesWithSpot, len(res))
+	for i, row := range res {
+		series[i] = mapSeriesWithSpot(row.WebReservationSeries, row.WebSpot)
+	}
+
+	return series, nil
+}
+
+func (t *ReservationRepository) CreateSeriesReservation(ctx context.Context, series *reservation.Series, startAt time.Time, endAt time.Time, priority int) (*reservation.Reservation, error) {
+	tx, err := t.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer errors.ExecuteAndIgnoreErrorF(tx.Rollback, ctx)
+	qtx := t.q.WithTx(tx)
+
+	startAtInput := pgtype.Timestamptz{}
+	err = startAtInput.Scan(startAt)
+	if err != nil {
+		return nil, err
+	}
+
+	endAtInput := pgtype.Timestamptz{}
+	err = endAtInput.Scan(endAt)
+	if err != nil {
+		return nil, err
+	}
+
+	// Expired holds no longer take the spot up, but stay in the table until they get released
+	err = qtx.DeleteOverlappingExpiredReservationHolds(ctx, DeleteOverlappingExpiredReservationHoldsParams{
+		SpotID:  series.SpotID,
+		GuildID: series.GuildID,
+		StartAt: startAtInput,
+		EndAt:   endAtInput,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := qtx.CreateSeriesReservation(ctx, CreateSeriesReservationParams{
+		Author:          series.Author,
+		AuthorDiscordID: series.AuthorDiscordID,
+		StartAt:         startAtInput,
+		EndAt:           endAtInput,
+		SpotID:          series.SpotID,
+		GuildID:         series.GuildID,
+		SeriesID:        pgtype.Int8{Int64: series.ID, Valid: true},
+		Priority:        int32(priority),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &reservation.Reservation{
+		ID:              res.ID,
+		Author:          res.Author,
+		CreatedAt:       res.CreatedAt.Time,
+		StartAt:         res.StartAt.Time,
+		EndAt:           res.EndAt.Time,
+		SpotID:          res.SpotID,
+		GuildID:         res.GuildID,
+		AuthorDiscordID: res.AuthorDiscordID,
+		Priority:        int(res.Priority),
+	}, nil
+}
+
+func (t *ReservationRepository) UpdateSeriesMaterializedUntil(ctx context.Context, seriesId int64, until time.Time) error {
+	untilInput := pgtype.Timestamptz{}
+	err := untilInput.Scan(until)
+	if err != nil {
+		return err
+	}
+
+	return t.q.UpdateReservationSeriesMaterializedUntil(ctx, UpdateReservationSeriesMaterializedUntilParams{
+		MaterializedUntil: untilInput,
+		ID:                seriesId,
+	})
+}
+
+func (t *ReservationRepository) DeleteMemberSeries(ctx context.Context, g *discord.Guild, m *discord.Member, seriesId int64) error {
+	tx, err := t.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer errors.ExecuteAndIgnoreErrorF(tx.Rollback, ctx)
+	qtx := t.q.WithTx(tx)
+
+	// Make sure the series belongs to the member before removing anything
+	_, err = qtx.SelectMemberReservationSeriesWithSpot(ctx, SelectMemberReservationSeriesWithSpotParams{
+		ID:              seriesId,
+		GuildID:         g.ID,
+		AuthorDiscordID: m.ID,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = qtx.DeleteUpcomingSeriesReservations(ctx, pgtype.Int8{Int64: seriesId, Valid: true})
+	if err != nil {
+		return err
+	}
+
+	err = qtx.DeleteReservationSeries(ctx, seriesId)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func mapSeriesWithSpot(series WebReservationSeries, spot WebSpot) *reservation.SeriesWithSpot {
+	return &reservation.SeriesWithSpot{
+		Series: mapSeries(series),
+		Spot:   mapSpot(spot),
+	}
+}
+
+func mapSeries(series WebReservationSeries) reservation.Series {
+	return reservation.Series{
+		ID:                series.ID,
+		Author:            series.Author,
+		AuthorDiscordID:   series.AuthorDiscordID,
+		GuildID:           series.GuildID,
+		SpotID:            series.SpotID,
+		Weekdays:          mapBitmaskToWeekdays(series.Weekdays),
+		StartTime:         mapTimeToDuration(series.StartTime),
+		EndTime:           mapTimeToDuration(series.EndTime),
+		CreatedAt:         series.CreatedAt.Time,
+		MaterializedUntil: series.MaterializedUntil.Time,
+	}
+}
+
+// Weekdays are stored as a bitmask, where n-th bit represents time.Weekday(n).
+func mapWeekdaysToBitmask(weekdays []time.Weekday) int32 {
+	var bitmask int32
+	for _, weekday := range weekdays {
+		bitmask |= 1 << weekday
+	}
+
+	return bitmask
+}
+
+func mapBitmaskToWeekdays(bitmask int32) []time.Weekday {
+	weekdays := make([]time.Weekday, 0, 7)
+	for _, weekday := range []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday} {
+		if bitmask&(1<<weekday) != 0 {
+			weekdays = append(weekdays, weekday)
+		}
+	}
+
+	return weekdays
+}
+
+func mapDurationToTime(d time.Duration) pgtype.Time {
+	return pgtype.Time{Microseconds: d.Microseconds(), Valid: true}
+}
+
+func mapTimeToDuration(t pgtype.Time) time.Duration {
+	return time.Duration(t.Microseconds) * time.Microsecond
+}
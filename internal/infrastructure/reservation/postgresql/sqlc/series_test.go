@@ -0,0 +1,87 @@
+package sqlc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/stretchr/testify/assert"
+
+	"spot-assistant/internal/core/dto/discord"
+)
+
+func TestDeleteMemberSeries(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	testMember := &discord.Member{ID: "test-member-id"}
+	testGuild := &discord.Guild{ID: "test-guild-id"}
+	seriesId := int64(1)
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SelectMemberReservationSeriesWithSpot").WithArgs(seriesId, testGuild.ID, testMember.ID).WillReturnRows(
+		pgxmock.NewRows([]string{
+			"id", "name", "created_at", "archived_at", "owner_guild_id", "parent_id",
+			"min_level", "max_level", "vocations", "area", "kind", "aliases",
+			"id", "author", "author_discord_id", "guild_id", "spot_id", "weekdays", "start_time", "end_time", "created_at", "materialized_until",
+		}).AddRow(
+			int64(1), "test-spot", time.Now(), pgtype.Timestamptz{}, pgtype.Text{}, pgtype.Int8{},
+			pgtype.Int4{}, pgtype.Int4{}, []string{}, pgtype.Text{}, pgtype.Text{}, []string{},
+			seriesId, "test-author", testMember.ID, testGuild.ID, int64(1), int32(2), pgtype.Time{}, pgtype.Time{}, time.Now(), time.Now(),
+		))
+	mock.ExpectExec("DELETE FROM web_reservation").WithArgs(pgtype.Int8{Int64: seriesId, Valid: true}).WillReturnResult(pgxmock.NewResult("DELETE", 2))
+	mock.ExpectExec("DELETE FROM web_reservation_series").WithArgs(seriesId).WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	mock.ExpectCommit()
+	repository := NewReservationRepository(mock)
+
+	// when
+	err = repository.DeleteMemberSeries(context.Background(), testGuild, testMember, seriesId)
+
+	// assert
+	assert.Nil(err)
+	assert.Nil(mock.ExpectationsWereMet())
+}
+
+func TestDeleteMemberSeriesOfOtherMember(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	testMember := &discord.Member{ID: "test-member-id"}
+	testGuild := &discord.Guild{ID: "test-guild-id"}
+	seriesId := int64(1)
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SelectMemberReservationSeriesWithSpot").WithArgs(seriesId, testGuild.ID, testMember.ID).WillReturnError(errors.New("no rows in result set"))
+	mock.ExpectRollback()
+	repository := NewReservationRepository(mock)
+
+	// when
+	err = repository.DeleteMemberSeries(context.Background(), testGuild, testMember, seriesId)
+
+	// assert
+	assert.NotNil(err)
+	assert.Nil(mock.ExpectationsWereMet())
+}
+
+func TestMapWeekdaysBitmask(t *testing.T) {
+	// given
+	assert := assert.New(t)
+	weekdays := []time.Weekday{time.Monday, time.Friday, time.Sunday}
+
+	// when
+	bitmask := mapWeekdaysToBitmask(weekdays)
+	res := mapBitmaskToWeekdays(bitmask)
+
+	// assert
+	assert.Equal(int32(1<<time.Sunday|1<<time.Monday|1<<time.Friday), bitmask)
+	assert.Equal(weekdays, res)
+}
@@ -2,6 +2,81 @@
 SELECT
     id,
     name,
-    created_at
+    created_at,
+    archived_at,
+    owner_guild_id,
+    parent_id,
+    min_level,
+    max_level,
+    vocations,
+    area,
+    kind,
+    aliases
 FROM
-    web_spot;
\ No newline at end of file
+    web_spot
+WHERE
+    (web_spot.owner_guild_id IS NULL OR web_spot.owner_guild_id = @guild_id::text)
+    AND NOT EXISTS (
+        SELECT 1
+        FROM web_guild_hidden_spot
+        WHERE web_guild_hidden_spot.spot_id = web_spot.id
+            AND web_guild_hidden_spot.guild_id = @guild_id::text
+    );
+-- name: SelectHiddenSpots :many
+SELECT
+    web_spot.id,
+    web_spot.name,
+    web_spot.created_at,
+    web_spot.archived_at,
+    web_spot.owner_guild_id,
+    web_spot.parent_id,
+    web_spot.min_level,
+    web_spot.max_level,
+    web_spot.vocations,
+    web_spot.area,
+    web_spot.kind,
+    web_spot.aliases
+FROM web_spot
+    INNER JOIN web_guild_hidden_spot ON web_guild_hidden_spot.spot_id = web_spot.id
+WHERE web_guild_hidden_spot.guild_id = @guild_id;
+-- name: InsertSpot :one
+INSERT INTO web_spot (name, owner_guild_id, parent_id, created_at)
+VALUES (@name, @owner_guild_id, @parent_id, NOW())
+RETURNING *;
+-- name: UpdateSpotName :one
+UPDATE web_spot
+SET name = @name
+WHERE id = @id
+RETURNING *;
+-- name: UpdateSpotDetails :one
+UPDATE web_spot
+SET min_level = @min_level,
+    max_level = @max_level,
+    vocations = @vocations,
+    area = @area,
+    kind = @kind
+WHERE id = @id
+RETURNING *;
+-- name: UpdateSpotAliases :one
+UPDATE web_spot
+SET aliases = @aliases
+WHERE id = @id
+RETURNING *;
+-- name: UpdateSpotParent :one
+UPDATE web_spot
+SET parent_id = @parent_id
+WHERE id = @id
+RETURNING *;
+-- name: ArchiveSpot :one
+UPDATE web_spot
+SET archived_at = COALESCE(archived_at, NOW())
+WHERE id = @id
+RETURNING *;
+-- name: InsertHiddenSpot :exec
+INSERT INTO web_guild_hidden_spot (guild_id, spot_id, created_at)
+VALUES (@guild_id, @spot_id, NOW())
+ON CONFLICT (guild_id, spot_id) DO NOTHING;
+-- name: DeleteHiddenSpot :execrows
+DELETE FROM web_guild_hidden_spot
+WHERE guild_id = @guild_id
+    AND spot_id = @spot_id;
\ No newline at end of file
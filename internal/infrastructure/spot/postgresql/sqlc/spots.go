@@ -2,6 +2,9 @@ package sqlc
 
 import (
 	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgtype"
 
 	"spot-assistant/internal/common/collections"
 	"spot-assistant/internal/core/dto/spot"
@@ -17,17 +20,154 @@ func NewSpotRepository(db DBTX) *SpotRepository {
 	}
 }
 
-func (repo *SpotRepository) SelectAllSpots(ctx context.Context) ([]*spot.Spot, error) {
-	res, err := repo.q.SelectAllSpots(ctx)
+// Returns spots visible to a guild, including archived ones.
+func (repo *SpotRepository) SelectAllSpots(ctx context.Context, guildId string) ([]*spot.Spot, error) {
+	res, err := repo.q.SelectAllSpots(ctx, guildId)
+	if err != nil {
+		return []*spot.Spot{}, err
+	}
+
+	return collections.PoorMansMap(res, mapSpot), nil
+}
+
+// Returns shared spots hidden by a guild.
+func (repo *SpotRepository) SelectHiddenSpots(ctx context.Context, guildId string) ([]*spot.Spot, error) {
+	res, err := repo.q.SelectHiddenSpots(ctx, guildId)
 	if err != nil {
 		return []*spot.Spot{}, err
 	}
 
-	return collections.PoorMansMap(res, func(s WebSpot) *spot.Spot {
-		return &spot.Spot{
-			ID:        s.ID,
-			Name:      s.Name,
-			CreatedAt: s.CreatedAt.Time,
-		}
-	}), nil
+	return collections.PoorMansMap(res, mapSpot), nil
+}
+
+// Creates a spot owned by a guild, or a shared one if guildId is empty. Non-zero parentId
+// makes the spot a floor or side of another respawn.
+func (repo *SpotRepository) CreateSpot(ctx context.Context, guildId string, name string, parentId int64) (*spot.Spot, error) {
+	res, err := repo.q.InsertSpot(ctx, InsertSpotParams{
+		Name:         name,
+		OwnerGuildID: pgtype.Text{String: guildId, Valid: len(guildId) > 0},
+		ParentID:     pgtype.Int8{Int64: parentId, Valid: parentId > 0},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mapSpot(res), nil
+}
+
+func (repo *SpotRepository) RenameSpot(ctx context.Context, id int64, name string) (*spot.Spot, error) {
+	res, err := repo.q.UpdateSpotName(ctx, UpdateSpotNameParams{
+		ID:   id,
+		Name: name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mapSpot(res), nil
+}
+
+// Makes a spot a floor or side of another respawn, or a top-level respawn if parentId is zero.
+func (repo *SpotRepository) UpdateSpotParent(ctx context.Context, id int64, parentId int64) (*spot.Spot, error) {
+	res, err := repo.q.UpdateSpotParent(ctx, UpdateSpotParentParams{
+		ID:       id,
+		ParentID: pgtype.Int8{Int64: parentId, Valid: parentId > 0},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mapSpot(res), nil
+}
+
+// Replaces details of a spot, zero values are stored as unknown.
+func (repo *SpotRepository) UpdateSpotDetails(ctx context.Context, id int64, details spot.Details) (*spot.Spot, error) {
+	vocations := details.Vocations
+	if vocations == nil {
+		vocations = []string{}
+	}
+
+	res, err := repo.q.UpdateSpotDetails(ctx, UpdateSpotDetailsParams{
+		ID:        id,
+		MinLevel:  pgtype.Int4{Int32: int32(details.MinLevel), Valid: details.MinLevel > 0},
+		MaxLevel:  pgtype.Int4{Int32: int32(details.MaxLevel), Valid: details.MaxLevel > 0},
+		Vocations: vocations,
+		Area:      pgtype.Text{String: details.Area, Valid: len(details.Area) > 0},
+		Kind:      pgtype.Text{String: string(details.Kind), Valid: len(details.Kind) > 0},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mapSpot(res), nil
+}
+
+// Replaces alternative names members can refer to a spot with.
+func (repo *SpotRepository) UpdateSpotAliases(ctx context.Context, id int64, aliases []string) (*spot.Spot, error) {
+	if aliases == nil {
+		aliases = []string{}
+	}
+
+	res, err := repo.q.UpdateSpotAliases(ctx, UpdateSpotAliasesParams{
+		ID:      id,
+		Aliases: aliases,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mapSpot(res), nil
+}
+
+// Archives a spot, which keeps it attached to its reservations. Archiving
+// an already archived spot keeps its original archival time.
+func (repo *SpotRepository) ArchiveSpot(ctx context.Context, id int64) (*spot.Spot, error) {
+	res, err := repo.q.ArchiveSpot(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapSpot(res), nil
+}
+
+func (repo *SpotRepository) HideSpot(ctx context.Context, guildId string, spotId int64) error {
+	return repo.q.InsertHiddenSpot(ctx, InsertHiddenSpotParams{
+		GuildID: guildId,
+		SpotID:  spotId,
+	})
+}
+
+func (repo *SpotRepository) UnhideSpot(ctx context.Context, guildId string, spotId int64) error {
+	count, err := repo.q.DeleteHiddenSpot(ctx, DeleteHiddenSpotParams{
+		GuildID: guildId,
+		SpotID:  spotId,
+	})
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return errors.New("the respawn is not hidden")
+	}
+
+	return nil
+}
+
+func mapSpot(s WebSpot) *spot.Spot {
+	return &spot.Spot{
+		ID:           s.ID,
+		Name:         s.Name,
+		CreatedAt:    s.CreatedAt.Time,
+		ArchivedAt:   s.ArchivedAt.Time,
+		OwnerGuildID: s.OwnerGuildID.String,
+		ParentID:     s.ParentID.Int64,
+		Details: spot.Details{
+			MinLevel:  int(s.MinLevel.Int32),
+			MaxLevel:  int(s.MaxLevel.Int32),
+			Vocations: s.Vocations,
+			Area:      s.Area.String,
+			Kind:      spot.Kind(s.Kind.String),
+		},
+		Aliases: s.Aliases,
+	}
 }
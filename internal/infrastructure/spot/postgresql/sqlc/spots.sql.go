@@ -7,19 +7,132 @@ package sqlc
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const archiveSpot = `-- name: ArchiveSpot :one
+UPDATE web_spot
+SET archived_at = COALESCE(archived_at, NOW())
+WHERE id = $1
+RETURNING id, name, created_at, archived_at, owner_guild_id, parent_id, min_level, max_level, vocations, area, kind, aliases
+`
+
+func (q *Queries) ArchiveSpot(ctx context.Context, id int64) (WebSpot, error) {
+	row := q.db.QueryRow(ctx, archiveSpot, id)
+	var i WebSpot
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.CreatedAt,
+		&i.ArchivedAt,
+		&i.OwnerGuildID,
+		&i.ParentID,
+		&i.MinLevel,
+		&i.MaxLevel,
+		&i.Vocations,
+		&i.Area,
+		&i.Kind,
+		&i.Aliases,
+	)
+	return i, err
+}
+
+const deleteHiddenSpot = `-- name: DeleteHiddenSpot :execrows
+DELETE FROM web_guild_hidden_spot
+WHERE guild_id = $1
+    AND spot_id = $2
+`
+
+type DeleteHiddenSpotParams struct {
+	GuildID string
+	SpotID  int64
+}
+
+func (q *Queries) DeleteHiddenSpot(ctx context.Context, arg DeleteHiddenSpotParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteHiddenSpot, arg.GuildID, arg.SpotID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const insertHiddenSpot = `-- name: InsertHiddenSpot :exec
+INSERT INTO web_guild_hidden_spot (guild_id, spot_id, created_at)
+VALUES ($1, $2, NOW())
+ON CONFLICT (guild_id, spot_id) DO NOTHING
+`
+
+type InsertHiddenSpotParams struct {
+	GuildID string
+	SpotID  int64
+}
+
+func (q *Queries) InsertHiddenSpot(ctx context.Context, arg InsertHiddenSpotParams) error {
+	_, err := q.db.Exec(ctx, insertHiddenSpot, arg.GuildID, arg.SpotID)
+	return err
+}
+
+const insertSpot = `-- name: InsertSpot :one
+INSERT INTO web_spot (name, owner_guild_id, parent_id, created_at)
+VALUES ($1, $2, $3, NOW())
+RETURNING id, name, created_at, archived_at, owner_guild_id, parent_id, min_level, max_level, vocations, area, kind, aliases
+`
+
+type InsertSpotParams struct {
+	Name         string
+	OwnerGuildID pgtype.Text
+	ParentID     pgtype.Int8
+}
+
+func (q *Queries) InsertSpot(ctx context.Context, arg InsertSpotParams) (WebSpot, error) {
+	row := q.db.QueryRow(ctx, insertSpot, arg.Name, arg.OwnerGuildID, arg.ParentID)
+	var i WebSpot
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.CreatedAt,
+		&i.ArchivedAt,
+		&i.OwnerGuildID,
+		&i.ParentID,
+		&i.MinLevel,
+		&i.MaxLevel,
+		&i.Vocations,
+		&i.Area,
+		&i.Kind,
+		&i.Aliases,
+	)
+	return i, err
+}
+
 const selectAllSpots = `-- name: SelectAllSpots :many
 SELECT
     id,
     name,
-    created_at
+    created_at,
+    archived_at,
+    owner_guild_id,
+    parent_id,
+    min_level,
+    max_level,
+    vocations,
+    area,
+    kind,
+    aliases
 FROM
     web_spot
+WHERE
+    (web_spot.owner_guild_id IS NULL OR web_spot.owner_guild_id = $1::text)
+    AND NOT EXISTS (
+        SELECT 1
+        FROM web_guild_hidden_spot
+        WHERE web_guild_hidden_spot.spot_id = web_spot.id
+            AND web_guild_hidden_spot.guild_id = $1::text
+    )
 `
 
-func (q *Queries) SelectAllSpots(ctx context.Context) ([]WebSpot, error) {
-	rows, err := q.db.Query(ctx, selectAllSpots)
+func (q *Queries) SelectAllSpots(ctx context.Context, guildID string) ([]WebSpot, error) {
+	rows, err := q.db.Query(ctx, selectAllSpots, guildID)
 	if err != nil {
 		return nil, err
 	}
@@ -27,7 +140,20 @@ func (q *Queries) SelectAllSpots(ctx context.Context) ([]WebSpot, error) {
 	var items []WebSpot
 	for rows.Next() {
 		var i WebSpot
-		if err := rows.Scan(&i.ID, &i.Name, &i.CreatedAt); err != nil {
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.CreatedAt,
+			&i.ArchivedAt,
+			&i.OwnerGuildID,
+			&i.ParentID,
+			&i.MinLevel,
+			&i.MaxLevel,
+			&i.Vocations,
+			&i.Area,
+			&i.Kind,
+			&i.Aliases,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -37,3 +163,198 @@ func (q *Queries) SelectAllSpots(ctx context.Context) ([]WebSpot, error) {
 	}
 	return items, nil
 }
+
+const selectHiddenSpots = `-- name: SelectHiddenSpots :many
+SELECT
+    web_spot.id,
+    web_spot.name,
+    web_spot.created_at,
+    web_spot.archived_at,
+    web_spot.owner_guild_id,
+    web_spot.parent_id,
+    web_spot.min_level,
+    web_spot.max_level,
+    web_spot.vocations,
+    web_spot.area,
+    web_spot.kind,
+    web_spot.aliases
+FROM web_spot
+    INNER JOIN web_guild_hidden_spot ON web_guild_hidden_spot.spot_id = web_spot.id
+WHERE web_guild_hidden_spot.guild_id = $1
+`
+
+func (q *Queries) SelectHiddenSpots(ctx context.Context, guildID string) ([]WebSpot, error) {
+	rows, err := q.db.Query(ctx, selectHiddenSpots, guildID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WebSpot
+	for rows.Next() {
+		var i WebSpot
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.CreatedAt,
+			&i.ArchivedAt,
+			&i.OwnerGuildID,
+			&i.ParentID,
+			&i.MinLevel,
+			&i.MaxLevel,
+			&i.Vocations,
+			&i.Area,
+			&i.Kind,
+			&i.Aliases,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateSpotAliases = `-- name: UpdateSpotAliases :one
+UPDATE web_spot
+SET aliases = $1
+WHERE id = $2
+RETURNING id, name, created_at, archived_at, owner_guild_id, parent_id, min_level, max_level, vocations, area, kind, aliases
+`
+
+type UpdateSpotAliasesParams struct {
+	Aliases []string
+	ID      int64
+}
+
+func (q *Queries) UpdateSpotAliases(ctx context.Context, arg UpdateSpotAliasesParams) (WebSpot, error) {
+	row := q.db.QueryRow(ctx, updateSpotAliases, arg.Aliases, arg.ID)
+	var i WebSpot
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.CreatedAt,
+		&i.ArchivedAt,
+		&i.OwnerGuildID,
+		&i.ParentID,
+		&i.MinLevel,
+		&i.MaxLevel,
+		&i.Vocations,
+		&i.Area,
+		&i.Kind,
+		&i.Aliases,
+	)
+	return i, err
+}
+
+const updateSpotDetails = `-- name: UpdateSpotDetails :one
+UPDATE web_spot
+SET min_level = $1,
+    max_level = $2,
+    vocations = $3,
+    area = $4,
+    kind = $5
+WHERE id = $6
+RETURNING id, name, created_at, archived_at, owner_guild_id, parent_id, min_level, max_level, vocations, area, kind, aliases
+`
+
+type UpdateSpotDetailsParams struct {
+	MinLevel  pgtype.Int4
+	MaxLevel  pgtype.Int4
+	Vocations []string
+	Area      pgtype.Text
+	Kind      pgtype.Text
+	ID        int64
+}
+
+func (q *Queries) UpdateSpotDetails(ctx context.Context, arg UpdateSpotDetailsParams) (WebSpot, error) {
+	row := q.db.QueryRow(ctx, updateSpotDetails,
+		arg.MinLevel,
+		arg.MaxLevel,
+		arg.Vocations,
+		arg.Area,
+		arg.Kind,
+		arg.ID,
+	)
+	var i WebSpot
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.CreatedAt,
+		&i.ArchivedAt,
+		&i.OwnerGuildID,
+		&i.ParentID,
+		&i.MinLevel,
+		&i.MaxLevel,
+		&i.Vocations,
+		&i.Area,
+		&i.Kind,
+		&i.Aliases,
+	)
+	return i, err
+}
+
+const updateSpotName = `-- name: UpdateSpotName :one
+UPDATE web_spot
+SET name = $1
+WHERE id = $2
+RETURNING id, name, created_at, archived_at, owner_guild_id, parent_id, min_level, max_level, vocations, area, kind, aliases
+`
+
+type UpdateSpotNameParams struct {
+	Name string
+	ID   int64
+}
+
+func (q *Queries) UpdateSpotName(ctx context.Context, arg UpdateSpotNameParams) (WebSpot, error) {
+	row := q.db.QueryRow(ctx, updateSpotName, arg.Name, arg.ID)
+	var i WebSpot
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.CreatedAt,
+		&i.ArchivedAt,
+		&i.OwnerGuildID,
+		&i.ParentID,
+		&i.MinLevel,
+		&i.MaxLevel,
+		&i.Vocations,
+		&i.Area,
+		&i.Kind,
+		&i.Aliases,
+	)
+	return i, err
+}
+
+const updateSpotParent = `-- name: UpdateSpotParent :one
+UPDATE web_spot
+SET parent_id = $1
+WHERE id = $2
+RETURNING id, name, created_at, archived_at, owner_guild_id, parent_id, min_level, max_level, vocations, area, kind, aliases
+`
+
+type UpdateSpotParentParams struct {
+	ParentID pgtype.Int8
+	ID       int64
+}
+
+func (q *Queries) UpdateSpotParent(ctx context.Context, arg UpdateSpotParentParams) (WebSpot, error) {
+	row := q.db.QueryRow(ctx, updateSpotParent, arg.ParentID, arg.ID)
+	var i WebSpot
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.CreatedAt,
+		&i.ArchivedAt,
+		&i.OwnerGuildID,
+		&i.ParentID,
+		&i.MinLevel,
+		&i.MaxLevel,
+		&i.Vocations,
+		&i.Area,
+		&i.Kind,
+		&i.Aliases,
+	)
+	return i, err
+}
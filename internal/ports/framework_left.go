@@ -1,9 +1,13 @@
 package ports
 
 import (
+	"time"
+
 	"spot-assistant/internal/core/dto/book"
 	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/policy"
 	"spot-assistant/internal/core/dto/reservation"
+	"spot-assistant/internal/core/dto/spot"
 	"spot-assistant/internal/core/dto/summary"
 )
 
@@ -13,7 +17,42 @@ type APIPort interface {
 	OnTick(BotPort)
 	OnBook(BotPort, book.BookRequest) (book.BookResponse, error)
 	OnBookAutocomplete(book.BookAutocompleteRequest) (book.BookAutocompleteResponse, error)
+	OnBookAlternative(BotPort, book.BookAlternativeRequest) (book.BookResponse, error)
+	OnOverbookAnswer(BotPort, book.OverbookAnswerRequest) (*reservation.OverbookRequestWithSpot, error)
 	OnUnbook(bot BotPort, request book.UnbookRequest) (*reservation.ReservationWithSpot, error)
 	OnUnbookAutocomplete(request book.UnbookAutocompleteRequest) (book.UnbookAutocompleteResponse, error)
+	OnRebook(BotPort, book.RebookRequest) (book.RebookResponse, error)
+	OnPartyAdd(BotPort, book.PartyRequest) (*reservation.ReservationWithSpot, error)
+	OnPartyRemove(BotPort, book.PartyRequest) (*reservation.ReservationWithSpot, error)
+	OnTransferOffer(BotPort, book.TransferRequest) (*reservation.ReservationWithSpot, error)
+	OnTransfer(BotPort, book.TransferRequest) (*reservation.ReservationWithSpot, error)
+	OnCheckIn(book.CheckInRequest) (*reservation.ReservationWithSpot, error)
+	OnHold(BotPort, book.HoldRequest) (book.HoldResponse, error)
+	OnConfirm(BotPort, book.ConfirmRequest) (*reservation.ReservationWithSpot, error)
 	OnPrivateSummary(BotPort, summary.PrivateSummaryRequest) error
+	OnSeries(BotPort, book.SeriesRequest) (*reservation.SeriesWithSpot, error)
+	OnSeriesAutocomplete(book.SeriesAutocompleteRequest) (book.SeriesAutocompleteResponse, error)
+	OnSeriesList(book.SeriesListRequest) (book.SeriesListResponse, error)
+	OnSeriesCancel(BotPort, book.SeriesCancelRequest) (*reservation.SeriesWithSpot, error)
+	OnQueue(BotPort, book.QueueRequest) (*reservation.QueueEntryWithSpot, error)
+	OnApply(BotPort, book.ApplyRequest) (*reservation.LotteryApplicationWithSpot, error)
+	OnFree(book.FreeRequest) (book.FreeResponse, error)
+	OnQuota(*discord.Guild, *discord.Member) ([]*reservation.QuotaUsage, error)
+	OnSpotAdd(spot.AddRequest) (*spot.Spot, error)
+	OnSpotRename(spot.RenameRequest) (*spot.Spot, error)
+	OnSpotDescribe(spot.DescribeRequest) (*spot.Spot, error)
+	OnSpotAlias(spot.AliasRequest) (*spot.Spot, error)
+	OnSpotUnalias(spot.AliasRequest) (*spot.Spot, error)
+	OnSpotImport(spot.ImportRequest) (*spot.ImportResult, error)
+	OnSpotExport(spot.ExportRequest) ([]*spot.CatalogEntry, error)
+	OnSpotArchive(spot.ArchiveRequest) (*spot.Spot, error)
+	OnSpotHide(spot.VisibilityRequest) (*spot.Spot, error)
+	OnSpotUnhide(spot.VisibilityRequest) (*spot.Spot, error)
+	OnSpotAutocomplete(spot.AutocompleteRequest) ([]string, error)
+	OnPolicy(*discord.Guild) (*policy.Policy, error)
+	OnPolicyUpdate(policy.UpdateRequest) (*policy.Policy, error)
+	OnLocation(*discord.Guild, *discord.Member) (*time.Location, error)
+	OnTimeZoneUpdate(policy.TimeZoneRequest) (*time.Location, error)
+	OnReminderPreference(*discord.Guild, *discord.Member) (*policy.ReminderPreference, error)
+	OnReminderPreferenceUpdate(policy.ReminderPreferenceRequest) (*policy.ReminderPreference, error)
 }
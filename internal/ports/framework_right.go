@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"spot-assistant/internal/core/dto/discord"
+	"spot-assistant/internal/core/dto/policy"
 	"spot-assistant/internal/core/dto/reservation"
 	"spot-assistant/internal/core/dto/spot"
 	"spot-assistant/internal/core/dto/summary"
@@ -15,20 +16,166 @@ type ReservationRepository interface {
 	FindReservationWithSpot(ctx context.Context, id int64, guildID, authorDiscordID string) (*reservation.ReservationWithSpot, error)
 	SelectAllReservationsWithSpotsBySpotNames(ctx context.Context, guildId string, spotNames []string) ([]*reservation.ReservationWithSpot, error)
 	SelectUpcomingReservationsWithSpot(ctx context.Context, guildId string) ([]*reservation.ReservationWithSpot, error)
-	SelectOverlappingReservations(ctx context.Context, spot string, startAt time.Time, endAt time.Time, guildId string) ([]*reservation.Reservation, error)
+	SelectOverlappingReservations(ctx context.Context, spotId int64, startAt time.Time, endAt time.Time, guildId string) ([]*reservation.Reservation, error)
 	SelectUpcomingMemberReservationsWithSpots(ctx context.Context, guild *discord.Guild, member *discord.Member) ([]*reservation.ReservationWithSpot, error)
 
-	// Creates a new reservation, and removes or shorten any existing conflicting reservations.
+	// Returns member reservations overlapping the time between from and to, including the past ones.
+	SelectMemberReservationsWithSpotsBetween(ctx context.Context, guild *discord.Guild, member *discord.Member, from time.Time, to time.Time) ([]*reservation.ReservationWithSpot, error)
+
+	// Creates a new reservation of the given priority along with its party, and removes or shorten any existing conflicting reservations.
 	// Returns removed or shortened conflicting reservations.
-	CreateAndDeleteConflicting(ctx context.Context, member *discord.Member, guild *discord.Guild, conflicts []*reservation.Reservation, spotId int64, startAt time.Time, endAt time.Time) ([]*reservation.ClippedOrRemovedReservation, error)
+	CreateAndDeleteConflicting(ctx context.Context, member *discord.Member, guild *discord.Guild, party []*discord.Member, conflicts []*reservation.Reservation, spotId int64, startAt time.Time, endAt time.Time, priority int) ([]*reservation.ClippedOrRemovedReservation, error)
+
+	// Returns upcoming reservations made by other members, which member is a party member of.
+	SelectUpcomingMemberPartyReservationsWithSpots(ctx context.Context, guild *discord.Guild, member *discord.Member) ([]*reservation.ReservationWithSpot, error)
+	AddReservationPartyMember(ctx context.Context, reservationId int64, member *discord.Member) error
+	RemoveReservationPartyMember(ctx context.Context, reservationId int64, memberDiscordID string) error
+
+	// Moves one of the upcoming member reservations to a new time range, and removes or shortens
+	// any existing conflicting reservations. Returns removed or shortened conflicting reservations.
+	UpdateAndDeleteConflicting(ctx context.Context, member *discord.Member, guild *discord.Guild, reservationId int64, conflicts []*reservation.Reservation, spotId int64, startAt time.Time, endAt time.Time) ([]*reservation.ClippedOrRemovedReservation, error)
 
 	// Deletes one of the upcoming member reservations in a given guild. Returns error if operation
 	// did not succeed.
 	DeletePresentMemberReservation(ctx context.Context, g *discord.Guild, m *discord.Member, reservationId int64) error
+
+	// Hands one of the upcoming member reservations over to another member, storing it with the given priority.
+	// Returns error if operation did not succeed.
+	TransferPresentMemberReservation(ctx context.Context, g *discord.Guild, from *discord.Member, to *discord.Member, reservationId int64, priority int) error
+
+	// Marks member presence on one of their reservations, which has not ended yet. Returns error if operation
+	// did not succeed.
+	CheckInPresentMemberReservation(ctx context.Context, g *discord.Guild, m *discord.Member, reservationId int64) error
+
+	// Returns guild reservations started before a given time, which have not ended, been checked in nor flagged as no-shows yet.
+	SelectCheckInPendingReservationsWithSpots(ctx context.Context, guildId string, startedBefore time.Time) ([]*reservation.ReservationWithSpot, error)
+
+	// Marks reservation author as reminded to check in, returns false if they already were.
+	MarkCheckInReminded(ctx context.Context, reservationId int64) (bool, error)
+
+	// Flags reservation as a no-show, returns no-show count of its author or 0 if it cannot be flagged anymore.
+	MarkNoShow(ctx context.Context, r *reservation.Reservation) (int, error)
+
+	// Creates a tentative reservation, which gets released at heldUntil unless confirmed.
+	CreateHold(ctx context.Context, member *discord.Member, guild *discord.Guild, spotId int64, startAt time.Time, endAt time.Time, heldUntil time.Time, priority int) (*reservation.Reservation, error)
+
+	// Turns one of the member holds into a regular reservation. Returns error if the hold
+	// does not exist or has already expired.
+	ConfirmPresentMemberHold(ctx context.Context, g *discord.Guild, m *discord.Member, reservationId int64) error
+
+	// Returns guild holds, which have expired without being confirmed.
+	SelectExpiredHoldsWithSpots(ctx context.Context, guildId string) ([]*reservation.ReservationWithSpot, error)
+
+	// Deletes an expired hold, returns false if it has been confirmed or released meanwhile.
+	ReleaseExpiredHold(ctx context.Context, reservationId int64) (bool, error)
+
+	// Creates an overbook request, which waits for approval of authors of the affected reservations.
+	CreateOverbookRequest(ctx context.Context, member *discord.Member, guild *discord.Guild, party []*discord.Member, spotId int64, startAt time.Time, endAt time.Time, expiresAt time.Time, affected []*reservation.Reservation) (*reservation.OverbookRequest, error)
+	FindOverbookRequestWithSpot(ctx context.Context, guildId string, requestId int64) (*reservation.OverbookRequestWithSpot, error)
+
+	// Records member answer to an overbook request, returns false if the request does not wait
+	// for member answer, e.g. because it has expired or member has already answered.
+	AnswerOverbookRequest(ctx context.Context, guildId string, requestId int64, memberId string, accepted bool) (bool, error)
+
+	// Returns guild overbook requests, which have not been answered in time.
+	SelectExpiredOverbookRequestsWithSpots(ctx context.Context, guildId string) ([]*reservation.OverbookRequestWithSpot, error)
+
+	// Deletes a resolved overbook request, returns false if it has been resolved meanwhile.
+	DeleteOverbookRequest(ctx context.Context, requestId int64) (bool, error)
+
+	// Records a reminder about reservation start or end as sent, returns false if it already was.
+	ClaimReminder(ctx context.Context, reservationId int64, kind reservation.ReminderKind, eventAt time.Time) (bool, error)
+
+	CreateSeries(ctx context.Context, member *discord.Member, guild *discord.Guild, spotId int64, weekdays []time.Weekday, startTime time.Duration, endTime time.Duration) (*reservation.Series, error)
+	SelectMemberSeriesWithSpots(ctx context.Context, guild *discord.Guild, member *discord.Member) ([]*reservation.SeriesWithSpot, error)
+	FindMemberSeriesWithSpot(ctx context.Context, id int64, guildID, authorDiscordID string) (*reservation.SeriesWithSpot, error)
+
+	// Returns guild series, which have not been materialized up to a given time yet.
+	SelectSeriesToMaterialize(ctx context.Context, guildId string, until time.Time) ([]*reservation.SeriesWithSpot, error)
+	CreateSeriesReservation(ctx context.Context, series *reservation.Series, startAt time.Time, endAt time.Time, priority int) (*reservation.Reservation, error)
+	UpdateSeriesMaterializedUntil(ctx context.Context, seriesId int64, until time.Time) error
+
+	// Deletes member series along with its upcoming reservations. Returns error if operation
+	// did not succeed.
+	DeleteMemberSeries(ctx context.Context, g *discord.Guild, m *discord.Member, seriesId int64) error
+
+	CreateQueueEntry(ctx context.Context, member *discord.Member, guild *discord.Guild, spotId int64, startAt time.Time, endAt time.Time) (*reservation.QueueEntry, error)
+
+	// Returns guild queue entries that have not ended yet, ordered by their creation time.
+	SelectQueueEntriesWithSpots(ctx context.Context, guildId string) ([]*reservation.QueueEntryWithSpot, error)
+	DeleteExpiredQueueEntries(ctx context.Context, guildId string) error
+
+	// Creates a reservation of the given priority out of queue entry, and removes the entry.
+	CreateReservationFromQueueEntry(ctx context.Context, entry *reservation.QueueEntry, priority int) (*reservation.Reservation, error)
+
+	// Applies for a reservation of a spot handed out by lottery, which gets drawn at drawAt.
+	CreateLotteryApplication(ctx context.Context, member *discord.Member, guild *discord.Guild, spotId int64, startAt time.Time, endAt time.Time, drawAt time.Time) (*reservation.LotteryApplication, error)
+
+	// Returns guild lottery applications, which have not been drawn yet.
+	SelectPendingLotteryApplicationsWithSpots(ctx context.Context, guildId string) ([]*reservation.LotteryApplicationWithSpot, error)
+
+	// Returns how many lotteries each member of the guild has won since a given time, by member ID.
+	CountLotteryWins(ctx context.Context, guildId string, since time.Time) (map[string]int, error)
+
+	// Records the draw result of an application, and books it with the given priority if it has won. Returns
+	// the reservation of a winning application, or an error if the application has already been drawn.
+	ResolveLotteryApplication(ctx context.Context, application *reservation.LotteryApplication, won bool, priority int) (*reservation.Reservation, error)
+
+	// Deletes guild applications drawn at or before a given time.
+	DeleteLotteryApplicationsDrawnBefore(ctx context.Context, guildId string, before time.Time) error
+}
+
+type PolicyRepository interface {
+	// Returns guild policy, or the default one if guild has not configured it yet.
+	FindGuildPolicy(ctx context.Context, guildId string) (*policy.Policy, error)
+
+	// Creates or replaces guild policy.
+	SaveGuildPolicy(ctx context.Context, p *policy.Policy) (*policy.Policy, error)
+
+	// Returns member time zone, or an empty string if member has not set one.
+	FindMemberTimeZone(ctx context.Context, guildId string, memberId string) (string, error)
+
+	// Creates or replaces member time zone.
+	SaveMemberTimeZone(ctx context.Context, guildId string, memberId string, timeZone string) error
+
+	// Removes member time zone, so that the guild one applies.
+	DeleteMemberTimeZone(ctx context.Context, guildId string, memberId string) error
+
+	// Returns member reminder preference, or the default one if member has not set it.
+	FindMemberReminderPreference(ctx context.Context, guildId string, memberId string) (*policy.ReminderPreference, error)
+
+	// Creates or replaces member reminder preference.
+	SaveMemberReminderPreference(ctx context.Context, p *policy.ReminderPreference) (*policy.ReminderPreference, error)
 }
 
 type SpotRepository interface {
-	SelectAllSpots(ctx context.Context) ([]*spot.Spot, error)
+	// Returns shared spots, which guild has not hidden, along with spots owned by the guild.
+	// Archived spots are included, so that past reservations can be resolved.
+	SelectAllSpots(ctx context.Context, guildId string) ([]*spot.Spot, error)
+
+	// Returns shared spots hidden by a guild.
+	SelectHiddenSpots(ctx context.Context, guildId string) ([]*spot.Spot, error)
+
+	// Creates a spot owned by a guild, or a shared one if guildId is empty. Non-zero parentId
+	// makes the spot a floor or side of another respawn.
+	CreateSpot(ctx context.Context, guildId string, name string, parentId int64) (*spot.Spot, error)
+	RenameSpot(ctx context.Context, id int64, name string) (*spot.Spot, error)
+
+	// Makes a spot a floor or side of another respawn, or a top-level respawn if parentId is zero.
+	UpdateSpotParent(ctx context.Context, id int64, parentId int64) (*spot.Spot, error)
+
+	// Replaces details of a spot, zero values are stored as unknown.
+	UpdateSpotDetails(ctx context.Context, id int64, details spot.Details) (*spot.Spot, error)
+
+	// Replaces alternative names members can refer to a spot with.
+	UpdateSpotAliases(ctx context.Context, id int64, aliases []string) (*spot.Spot, error)
+
+	// Archives a spot, so that it cannot be booked anymore. Returns the archived spot.
+	ArchiveSpot(ctx context.Context, id int64) (*spot.Spot, error)
+
+	// Hides a shared spot from a guild, or shows it again.
+	HideSpot(ctx context.Context, guildId string, spotId int64) error
+	UnhideSpot(ctx context.Context, guildId string, spotId int64) error
 }
 
 type BotPort interface {
@@ -36,10 +183,16 @@ type BotPort interface {
 	CleanChannel(g *discord.Guild, channel *discord.Channel) error
 	EnsureChannel(g *discord.Guild) error
 	FindChannelByName(g *discord.Guild, channelName string) (*discord.Channel, error)
-	EnsureRoles(g *discord.Guild) error
+	// Creates roles of given names, which the guild does not have yet.
+	EnsureRoles(g *discord.Guild, roleNames ...string) error
 	GetGuilds() []*discord.Guild
 	SendLetterMessage(g *discord.Guild, ch *discord.Channel, sum *summary.Summary) error
 	SendDM(m *discord.Member, message string) error
+	// Sends a DM along with a button, which checks the member in on a given reservation.
+	SendCheckInReminder(g *discord.Guild, m *discord.Member, r *reservation.ReservationWithSpot, message string) error
+	// Sends a DM along with buttons, which accept or decline a given overbook request.
+	SendOverbookApprovalRequest(g *discord.Guild, m *discord.Member, r *reservation.OverbookRequestWithSpot, message string) error
+	SendChannelMessage(g *discord.Guild, ch *discord.Channel, message string) error
 	RegisterCommands(g *discord.Guild) error
 	MemberHasRole(g *discord.Guild, m *discord.Member, roleName string) bool
 	OpenDM(m *discord.Member) (*discord.Channel, error)